@@ -12,10 +12,58 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/evrintobing17/expense-management-backend/config"
+	advanceHandler "github.com/evrintobing17/expense-management-backend/internal/advance/handler"
+	advanceRepository "github.com/evrintobing17/expense-management-backend/internal/advance/repository"
+	advanceUsecase "github.com/evrintobing17/expense-management-backend/internal/advance/usecase"
+	allocationChecker "github.com/evrintobing17/expense-management-backend/internal/allocation/checker"
+	allocationHandler "github.com/evrintobing17/expense-management-backend/internal/allocation/handler"
+	allocationRepository "github.com/evrintobing17/expense-management-backend/internal/allocation/repository"
+	allocationUsecase "github.com/evrintobing17/expense-management-backend/internal/allocation/usecase"
+	"github.com/evrintobing17/expense-management-backend/internal/approval/policy"
 	approvalRepository "github.com/evrintobing17/expense-management-backend/internal/approval/repository"
+	approvalScope "github.com/evrintobing17/expense-management-backend/internal/approval/scope"
+	budgetChecker "github.com/evrintobing17/expense-management-backend/internal/budget/checker"
+	budgetHandler "github.com/evrintobing17/expense-management-backend/internal/budget/handler"
+	budgetRepository "github.com/evrintobing17/expense-management-backend/internal/budget/repository"
+	budgetUsecase "github.com/evrintobing17/expense-management-backend/internal/budget/usecase"
+	categoryHandler "github.com/evrintobing17/expense-management-backend/internal/category/handler"
+	categoryRepository "github.com/evrintobing17/expense-management-backend/internal/category/repository"
+	categoryUsecase "github.com/evrintobing17/expense-management-backend/internal/category/usecase"
+	delegationHandler "github.com/evrintobing17/expense-management-backend/internal/delegation/handler"
+	delegationRepository "github.com/evrintobing17/expense-management-backend/internal/delegation/repository"
+	delegationUsecase "github.com/evrintobing17/expense-management-backend/internal/delegation/usecase"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	duplicateDetector "github.com/evrintobing17/expense-management-backend/internal/duplicate/detector"
+	duplicateRepository "github.com/evrintobing17/expense-management-backend/internal/duplicate/repository"
+	escalationRepository "github.com/evrintobing17/expense-management-backend/internal/escalation/repository"
 	"github.com/evrintobing17/expense-management-backend/internal/expense/handler"
+	fraudDetector "github.com/evrintobing17/expense-management-backend/internal/fraud/detector"
+	fraudRepository "github.com/evrintobing17/expense-management-backend/internal/fraud/repository"
+	fxConverter "github.com/evrintobing17/expense-management-backend/internal/fx/converter"
+	fxRepository "github.com/evrintobing17/expense-management-backend/internal/fx/repository"
+	historyRepository "github.com/evrintobing17/expense-management-backend/internal/history/repository"
+	paymentHandler "github.com/evrintobing17/expense-management-backend/internal/payment/handler"
+	paymentRepository "github.com/evrintobing17/expense-management-backend/internal/payment/repository"
+	paymentUsecase "github.com/evrintobing17/expense-management-backend/internal/payment/usecase"
+	"github.com/evrintobing17/expense-management-backend/internal/receipt"
+	receiptHandler "github.com/evrintobing17/expense-management-backend/internal/receipt/handler"
+	receiptRepository "github.com/evrintobing17/expense-management-backend/internal/receipt/repository"
+	receiptStore "github.com/evrintobing17/expense-management-backend/internal/receipt/store"
+	receiptUsecase "github.com/evrintobing17/expense-management-backend/internal/receipt/usecase"
+	reportHandler "github.com/evrintobing17/expense-management-backend/internal/report/handler"
+	reportRepository "github.com/evrintobing17/expense-management-backend/internal/report/repository"
+	reportUsecase "github.com/evrintobing17/expense-management-backend/internal/report/usecase"
+	ruleEngine "github.com/evrintobing17/expense-management-backend/internal/rule/engine"
+	ruleHandler "github.com/evrintobing17/expense-management-backend/internal/rule/handler"
+	ruleRepository "github.com/evrintobing17/expense-management-backend/internal/rule/repository"
+	ruleUsecase "github.com/evrintobing17/expense-management-backend/internal/rule/usecase"
+	taxHandler "github.com/evrintobing17/expense-management-backend/internal/tax/handler"
+	taxRepository "github.com/evrintobing17/expense-management-backend/internal/tax/repository"
+	taxUsecase "github.com/evrintobing17/expense-management-backend/internal/tax/usecase"
 
+	userHandler "github.com/evrintobing17/expense-management-backend/internal/user/handler"
 	userRepository "github.com/evrintobing17/expense-management-backend/internal/user/repository"
+	userUsecase "github.com/evrintobing17/expense-management-backend/internal/user/usecase"
 	"github.com/evrintobing17/expense-management-backend/pkg/database"
 
 	authService "github.com/evrintobing17/expense-management-backend/internal/auth/service"
@@ -39,22 +87,138 @@ func main() {
 	}
 	defer db.Close()
 
+	approvalPolicy := domain.ApprovalPolicy{
+		Tiers:                     domain.DefaultApprovalTiers,
+		IncludeIndirectReports:    cfg.ApprovalIncludeIndirectReports,
+		FinanceApprovalOverBudget: cfg.BudgetOverrunFinanceApproval,
+	}
+	if cfg.ApprovalTiers != "" {
+		approvalPolicy.Tiers, err = domain.ParseApprovalTiers(cfg.ApprovalTiers)
+		if err != nil {
+			log.Fatalf("Invalid APPROVAL_TIERS: %v", err)
+		}
+	}
+
 	// Initialize repositories
 	userRepo := userRepository.NewUserRepository(db)
 	expenseRepo := expenseRepository.NewExpenseRepository(db)
 	approvalRepo := approvalRepository.NewApprovalRepository(db)
+	historyRepo := historyRepository.NewHistoryRepository(db)
+	delegationRepo := delegationRepository.NewDelegationRepository(db)
+	escalationRepo := escalationRepository.NewEscalationRepository(db)
+	categoryRepo := categoryRepository.NewCategoryRepository(db)
+	ruleRepo := ruleRepository.NewRuleRepository(db)
+	violationRepo := ruleRepository.NewViolationRepository(db)
+	receiptRepo := receiptRepository.NewReceiptRepository(db)
+	duplicateRepo := duplicateRepository.NewDuplicateRepository(db)
+	fraudRepo := fraudRepository.NewFraudRepository(db)
+	fxRateRepo := fxRepository.NewFXRateRepository(db)
+	taxRepo := taxRepository.NewTaxRepository(db)
+	reportRepo := reportRepository.NewReportRepository(db)
+	costCenterRepo := allocationRepository.NewCostCenterRepository(db)
+	projectRepo := allocationRepository.NewProjectRepository(db)
+	allocationRepo := allocationRepository.NewAllocationRepository(db)
+	budgetRepo := budgetRepository.NewBudgetRepository(db)
+	advanceRepo := advanceRepository.NewAdvanceRepository(db)
+	paymentRepo := paymentRepository.NewPaymentRepository(db)
 
 	// Initialize services
 	authService := authService.NewAuthService(userRepo, cfg.JWTSecret)
 
+	// Initialize receipt storage
+	var receiptFiles http.Handler
+	var store receipt.ReceiptStore
+	switch cfg.ReceiptStorage {
+	case "local":
+		store = receiptStore.NewLocalStore(cfg.ReceiptLocalDir, cfg.ReceiptPublicBaseURL, cfg.ReceiptURLSecret)
+		receiptFiles = receiptStore.NewLocalFileServer(cfg.ReceiptLocalDir, cfg.ReceiptURLSecret)
+	case "s3":
+		store = receiptStore.NewS3Store(receiptStore.S3Config{
+			Endpoint:       cfg.S3Endpoint,
+			PublicEndpoint: cfg.S3PublicEndpoint,
+			Region:         cfg.S3Region,
+			Bucket:         cfg.S3Bucket,
+			AccessKey:      cfg.S3AccessKey,
+			SecretKey:      cfg.S3SecretKey,
+			PathStyle:      cfg.S3PathStyle,
+		}, &http.Client{Timeout: 30 * time.Second})
+	default:
+		log.Fatalf("Invalid RECEIPT_STORAGE %q: use local or s3", cfg.ReceiptStorage)
+	}
+
+	// Initialize policies
+	approverScope := approvalScope.NewApproverScope(userRepo, delegationRepo, approvalPolicy.IncludeIndirectReports)
+	segregationPolicy := policy.NewSegregationPolicy(approvalRepo, userRepo, approverScope, cfg.SoDMaxConsecutiveApprovals)
+	ruleEngine := ruleEngine.NewRuleEngine(ruleRepo, expenseRepo)
+	duplicateDetector := duplicateDetector.NewDuplicateDetector(expenseRepo, receiptRepo, domain.DuplicatePolicy{
+		WindowDays:               cfg.DuplicateWindowDays,
+		AmountTolerancePercent:   cfg.DuplicateAmountTolerancePercent,
+		MinDescriptionSimilarity: cfg.DuplicateDescriptionSimilarity,
+	})
+	splitDetector := fraudDetector.NewSplitDetector(expenseRepo, domain.SplitPolicy{
+		WindowDays:               cfg.SplitWindowDays,
+		MinDescriptionSimilarity: cfg.SplitDescriptionSimilarity,
+		Threshold:                approvalPolicy.Threshold(),
+	})
+	allocationChecker := allocationChecker.NewAllocationChecker(costCenterRepo, projectRepo)
+	budgetChecker := budgetChecker.NewBudgetChecker(budgetRepo, domain.BudgetPolicy{
+		WarningPercent: cfg.BudgetWarningPercent,
+	})
+
 	// Initialize use cases
+	transactor := database.NewTransactor(db)
 	authUseCase := authUsecase.NewAuthUseCase(authService)
-	expenseUseCase := expenseUsecase.NewExpenseUseCase(expenseRepo, approvalRepo)
+	currencyConverter := fxConverter.NewCurrencyConverter(fxRateRepo, cfg.FXMaxRateAgeDays)
+	expenseUseCase := expenseUsecase.NewExpenseUseCase(expenseUsecase.Deps{
+		ExpenseRepo:    expenseRepo,
+		ApprovalRepo:   approvalRepo,
+		HistoryRepo:    historyRepo,
+		UserRepo:       userRepo,
+		EscalationRepo: escalationRepo,
+		CategoryRepo:   categoryRepo,
+		ViolationRepo:  violationRepo,
+		DuplicateRepo:  duplicateRepo,
+		FraudRepo:      fraudRepo,
+		AllocationRepo: allocationRepo,
+		AdvanceRepo:    advanceRepo,
+		ApprovalPolicy: approvalPolicy,
+		Scope:          approverScope,
+		Segregation:    segregationPolicy,
+		RuleEngine:     ruleEngine,
+		Duplicates:     duplicateDetector,
+		Splits:         splitDetector,
+		Converter:      currencyConverter,
+		Allocations:    allocationChecker,
+		Budgets:        budgetChecker,
+		Transactor:     transactor,
+	})
+	delegationUseCase := delegationUsecase.NewDelegationUseCase(delegationRepo, userRepo)
+	userUseCase := userUsecase.NewUserUseCase(userRepo)
+	categoryUseCase := categoryUsecase.NewCategoryUseCase(categoryRepo)
+	ruleUseCase := ruleUsecase.NewRuleUseCase(ruleRepo, categoryRepo)
+	taxUseCase := taxUsecase.NewTaxUseCase(taxRepo)
+	allocationUseCase := allocationUsecase.NewAllocationUseCase(costCenterRepo, projectRepo)
+	budgetUseCase := budgetUsecase.NewBudgetUseCase(budgetRepo)
+	advanceUseCase := advanceUsecase.NewAdvanceUseCase(advanceRepo, expenseRepo, historyRepo, userRepo, approverScope, transactor)
+	paymentUseCase := paymentUsecase.NewPaymentUseCase(expenseRepo, reportRepo, advanceRepo, paymentRepo, historyRepo)
+	reportUseCase := reportUsecase.NewReportUseCase(reportRepo, expenseRepo, historyRepo, userRepo, approverScope, segregationPolicy, expenseUseCase, approvalPolicy, allocationRepo, escalationRepo, transactor)
+	receiptUseCase := receiptUsecase.NewReceiptUseCase(receiptRepo, store, expenseRepo, expenseUseCase, int64(cfg.ReceiptMaxBytes), time.Duration(cfg.ReceiptURLTTL)*time.Second)
 
 	// Initialize handlers
 	authHandler := authHandler.NewAuthHandler(authUseCase)
 	expenseHandler := handler.NewExpenseHandler(expenseUseCase)
 	healthHandler := healthHandler.NewHealthHandler(db)
+	delegationHandler := delegationHandler.NewDelegationHandler(delegationUseCase)
+	userHandler := userHandler.NewUserHandler(userUseCase)
+	categoryHandler := categoryHandler.NewCategoryHandler(categoryUseCase)
+	ruleHandler := ruleHandler.NewRuleHandler(ruleUseCase)
+	taxHandler := taxHandler.NewTaxHandler(taxUseCase)
+	allocationHandler := allocationHandler.NewAllocationHandler(allocationUseCase)
+	budgetHandler := budgetHandler.NewBudgetHandler(budgetUseCase)
+	advanceHandler := advanceHandler.NewAdvanceHandler(advanceUseCase)
+	reportHandler := reportHandler.NewReportHandler(reportUseCase)
+	paymentHandler := paymentHandler.NewPaymentHandler(paymentUseCase)
+	receiptHandler := receiptHandler.NewReceiptHandler(receiptUseCase, int64(cfg.ReceiptMaxBytes))
 
 	// Initialize router
 	router := mux.NewRouter()
@@ -63,6 +227,11 @@ func main() {
 	router.HandleFunc("/api/auth/login", authHandler.Login).Methods("POST")
 	router.HandleFunc("/api/health", healthHandler.Check).Methods("GET")
 
+	// Locally stored receipts are served to holders of a signed URL
+	if receiptFiles != nil {
+		router.PathPrefix(receiptStore.LocalFilesPath).Handler(http.StripPrefix(receiptStore.LocalFilesPath, receiptFiles)).Methods("GET")
+	}
+
 	// Protected routes
 	apiRouter := router.PathPrefix("/api").Subrouter()
 	apiRouter.Use(middleware.AuthMiddleware(authService))
@@ -70,14 +239,90 @@ func main() {
 	apiRouter.HandleFunc("/expenses", expenseHandler.CreateExpense).Methods("POST")
 	apiRouter.HandleFunc("/expenses", expenseHandler.GetExpenses).Methods("GET")
 	apiRouter.HandleFunc("/expenses/{id}", expenseHandler.GetExpense).Methods("GET")
+	apiRouter.HandleFunc("/expenses/{id}", expenseHandler.UpdateExpense).Methods("PATCH")
+	apiRouter.HandleFunc("/expenses/{id}/submit", expenseHandler.SubmitExpense).Methods("POST")
+	apiRouter.HandleFunc("/expenses/{id}/cancel", expenseHandler.CancelExpense).Methods("POST")
+	apiRouter.HandleFunc("/expenses/{id}/history", expenseHandler.GetExpenseHistory).Methods("GET")
+	apiRouter.HandleFunc("/expenses/{id}/resubmit", expenseHandler.ResubmitExpense).Methods("POST")
+	apiRouter.HandleFunc("/expenses/{id}/receipts", receiptHandler.UploadReceipt).Methods("POST")
+	apiRouter.HandleFunc("/expenses/{id}/receipts", receiptHandler.ListReceipts).Methods("GET")
+	apiRouter.HandleFunc("/receipts/{id}", receiptHandler.GetReceipt).Methods("GET")
+	apiRouter.HandleFunc("/expense-reports", reportHandler.CreateReport).Methods("POST")
+	apiRouter.HandleFunc("/expense-reports", reportHandler.GetReports).Methods("GET")
+	apiRouter.HandleFunc("/expense-reports/{id}", reportHandler.GetReport).Methods("GET")
+	apiRouter.HandleFunc("/expense-reports/{id}", reportHandler.UpdateReport).Methods("PUT")
+	apiRouter.HandleFunc("/expense-reports/{id}/lines", reportHandler.AddLine).Methods("POST")
+	apiRouter.HandleFunc("/expense-reports/{id}/lines/{expenseId}", reportHandler.RemoveLine).Methods("DELETE")
+	apiRouter.HandleFunc("/expense-reports/{id}/submit", reportHandler.SubmitReport).Methods("POST")
+	apiRouter.HandleFunc("/expense-reports/{id}/cancel", reportHandler.CancelReport).Methods("POST")
+	apiRouter.HandleFunc("/advances", advanceHandler.RequestAdvance).Methods("POST")
+	apiRouter.HandleFunc("/advances", advanceHandler.GetAdvances).Methods("GET")
+	apiRouter.HandleFunc("/advances/balance", advanceHandler.GetBalance).Methods("GET")
+	apiRouter.HandleFunc("/advances/{id}", advanceHandler.GetAdvance).Methods("GET")
+	apiRouter.HandleFunc("/advances/{id}/cancel", advanceHandler.CancelAdvance).Methods("POST")
+	apiRouter.HandleFunc("/advances/{id}/settle", advanceHandler.SettleAdvance).Methods("POST")
+	apiRouter.HandleFunc("/categories", categoryHandler.ListCategories).Methods("GET")
+	apiRouter.HandleFunc("/categories/{id}", categoryHandler.GetCategory).Methods("GET")
+	apiRouter.HandleFunc("/cost-centers", allocationHandler.ListCostCenters).Methods("GET")
+	apiRouter.HandleFunc("/projects", allocationHandler.ListProjects).Methods("GET")
+	apiRouter.HandleFunc("/budgets", budgetHandler.ListBudgets).Methods("GET")
+	apiRouter.HandleFunc("/budgets/{id}", budgetHandler.GetBudget).Methods("GET")
+
+	// Approver-only routes (managers, finance directors and CFOs)
+	approverRouter := apiRouter.PathPrefix("").Subrouter()
+	approverRouter.Use(middleware.ApproverOnlyMiddleware)
+
+	approverRouter.HandleFunc("/expenses/{id}/approve", expenseHandler.ApproveExpense).Methods("PUT")
+	approverRouter.HandleFunc("/expenses/{id}/reject", expenseHandler.RejectExpense).Methods("PUT")
+	approverRouter.HandleFunc("/expenses/{id}/request-changes", expenseHandler.RequestChanges).Methods("PUT")
+	approverRouter.HandleFunc("/expenses-pending", expenseHandler.GetPendingApproval).Methods("GET")
+	approverRouter.HandleFunc("/expense-reports/{id}/approve", reportHandler.ApproveReport).Methods("PUT")
+	approverRouter.HandleFunc("/expense-reports/{id}/reject", reportHandler.RejectReport).Methods("PUT")
+	approverRouter.HandleFunc("/expense-reports/{id}/lines/{expenseId}/reject", reportHandler.RejectLine).Methods("PUT")
+	approverRouter.HandleFunc("/expense-reports-pending", reportHandler.GetPendingApproval).Methods("GET")
+	approverRouter.HandleFunc("/advances/{id}/approve", advanceHandler.ApproveAdvance).Methods("PUT")
+	approverRouter.HandleFunc("/advances/{id}/reject", advanceHandler.RejectAdvance).Methods("PUT")
+	approverRouter.HandleFunc("/advances-pending", advanceHandler.GetPendingApproval).Methods("GET")
 
 	// Manager-only routes
 	managerRouter := apiRouter.PathPrefix("").Subrouter()
 	managerRouter.Use(middleware.ManagerOnlyMiddleware)
 
-	managerRouter.HandleFunc("/expenses/{id}/approve", expenseHandler.ApproveExpense).Methods("PUT")
-	managerRouter.HandleFunc("/expenses/{id}/reject", expenseHandler.RejectExpense).Methods("PUT")
-	managerRouter.HandleFunc("/expenses-pending", expenseHandler.GetPendingApproval).Methods("GET")
+	managerRouter.HandleFunc("/delegations", delegationHandler.ListDelegations).Methods("GET")
+	managerRouter.HandleFunc("/delegations", delegationHandler.CreateDelegation).Methods("POST")
+	managerRouter.HandleFunc("/delegations/{id}", delegationHandler.RevokeDelegation).Methods("DELETE")
+
+	// Finance-only routes
+	financeRouter := apiRouter.PathPrefix("").Subrouter()
+	financeRouter.Use(middleware.FinanceOnlyMiddleware)
+
+	financeRouter.HandleFunc("/reports/tax-summary", taxHandler.GetTaxSummary).Methods("GET")
+	financeRouter.HandleFunc("/advances/{id}/repayment", advanceHandler.RecordRepayment).Methods("POST")
+	financeRouter.HandleFunc("/payments/dead-letter", paymentHandler.GetDeadLetters).Methods("GET")
+	financeRouter.HandleFunc("/payments/dead-letter/{id}/requeue", paymentHandler.RequeueDeadLetter).Methods("POST")
+	financeRouter.HandleFunc("/payments/dead-letter/{id}/abandon", paymentHandler.AbandonDeadLetter).Methods("POST")
+
+	// Admin-only routes
+	adminRouter := apiRouter.PathPrefix("").Subrouter()
+	adminRouter.Use(middleware.AdminOnlyMiddleware)
+
+	adminRouter.HandleFunc("/users/{id}/manager", userHandler.AssignManager).Methods("PUT")
+	adminRouter.HandleFunc("/categories", categoryHandler.CreateCategory).Methods("POST")
+	adminRouter.HandleFunc("/categories/{id}", categoryHandler.UpdateCategory).Methods("PUT")
+	adminRouter.HandleFunc("/categories/{id}", categoryHandler.DeleteCategory).Methods("DELETE")
+	adminRouter.HandleFunc("/cost-centers", allocationHandler.CreateCostCenter).Methods("POST")
+	adminRouter.HandleFunc("/cost-centers/{id}", allocationHandler.UpdateCostCenter).Methods("PUT")
+	adminRouter.HandleFunc("/cost-centers/{id}", allocationHandler.DeleteCostCenter).Methods("DELETE")
+	adminRouter.HandleFunc("/projects", allocationHandler.CreateProject).Methods("POST")
+	adminRouter.HandleFunc("/projects/{id}", allocationHandler.UpdateProject).Methods("PUT")
+	adminRouter.HandleFunc("/projects/{id}", allocationHandler.DeleteProject).Methods("DELETE")
+	adminRouter.HandleFunc("/budgets", budgetHandler.CreateBudget).Methods("POST")
+	adminRouter.HandleFunc("/budgets/{id}", budgetHandler.UpdateBudget).Methods("PUT")
+	adminRouter.HandleFunc("/policy-rules", ruleHandler.ListRules).Methods("GET")
+	adminRouter.HandleFunc("/policy-rules", ruleHandler.CreateRule).Methods("POST")
+	adminRouter.HandleFunc("/policy-rules/{id}", ruleHandler.GetRule).Methods("GET")
+	adminRouter.HandleFunc("/policy-rules/{id}", ruleHandler.UpdateRule).Methods("PUT")
+	adminRouter.HandleFunc("/policy-rules/{id}", ruleHandler.DeleteRule).Methods("DELETE")
 
 	handler := middleware.CORS(router)
 
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/evrintobing17/expense-management-backend/config"
+	"github.com/evrintobing17/expense-management-backend/internal/fx/importer"
+	fxRepository "github.com/evrintobing17/expense-management-backend/internal/fx/repository"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+)
+
+func main() {
+	file := flag.String("file", "", "CSV file with date,currency,rate_idr lines")
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("Usage: fximport -file rates.csv")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("Failed to open rates file: %v", err)
+	}
+	defer f.Close()
+
+	rates, err := importer.ParseCSV(f)
+	if err != nil {
+		log.Fatalf("Failed to parse rates file: %v", err)
+	}
+
+	// Load configuration
+	cfg := config.Load()
+
+	// Initialize database
+	db, err := database.NewPostgresConnection(cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	err = fxRepository.NewFXRateRepository(db).Upsert(context.Background(), rates)
+	if err != nil {
+		log.Fatalf("Failed to import rates: %v", err)
+	}
+	log.Printf("Imported %d exchange rates", len(rates))
+}
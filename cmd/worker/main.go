@@ -5,14 +5,26 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/evrintobing17/expense-management-backend/config"
+	advanceRepository "github.com/evrintobing17/expense-management-backend/internal/advance/repository"
+	approvalRepository "github.com/evrintobing17/expense-management-backend/internal/approval/repository"
+	approvalWorker "github.com/evrintobing17/expense-management-backend/internal/approval/worker"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	escalationRepository "github.com/evrintobing17/expense-management-backend/internal/escalation/repository"
 	"github.com/evrintobing17/expense-management-backend/internal/expense/repository"
+	historyRepository "github.com/evrintobing17/expense-management-backend/internal/history/repository"
+	notificationService "github.com/evrintobing17/expense-management-backend/internal/notification/service"
+	paymentRepository "github.com/evrintobing17/expense-management-backend/internal/payment/repository"
 	"github.com/evrintobing17/expense-management-backend/internal/payment/service"
 	"github.com/evrintobing17/expense-management-backend/internal/payment/worker"
+	reportRepository "github.com/evrintobing17/expense-management-backend/internal/report/repository"
+	userRepository "github.com/evrintobing17/expense-management-backend/internal/user/repository"
 	"github.com/evrintobing17/expense-management-backend/pkg/database"
+	"github.com/evrintobing17/expense-management-backend/pkg/ratelimit"
 )
 
 func main() {
@@ -26,22 +38,78 @@ func main() {
 	}
 	defer db.Close()
 
+	approvalPolicy := domain.ApprovalPolicy{Tiers: domain.DefaultApprovalTiers}
+	if cfg.ApprovalTiers != "" {
+		approvalPolicy.Tiers, err = domain.ParseApprovalTiers(cfg.ApprovalTiers)
+		if err != nil {
+			log.Fatalf("Invalid APPROVAL_TIERS: %v", err)
+		}
+	}
+
+	holidaySpec := cfg.SLAHolidays
+	if holidaySpec == "" {
+		holidaySpec = strings.Join(domain.DefaultIndonesianHolidays, ",")
+	}
+	holidays, err := domain.ParseHolidays(holidaySpec)
+	if err != nil {
+		log.Fatalf("Invalid SLA_HOLIDAYS: %v", err)
+	}
+
+	slaPolicy := domain.SLAPolicy{
+		ReminderAfterDays:   cfg.SLAReminderBusinessDays,
+		EscalationAfterDays: cfg.SLAEscalationBusinessDays,
+		Calendar:            domain.NewBusinessCalendar(domain.WIB, holidays),
+	}
+
 	// Initialize repositories
 	expenseRepo := repository.NewExpenseRepository(db)
+	reportRepo := reportRepository.NewReportRepository(db)
+	advanceRepo := advanceRepository.NewAdvanceRepository(db)
+	historyRepo := historyRepository.NewHistoryRepository(db)
+	paymentRepo := paymentRepository.NewPaymentRepository(db)
+	approvalRepo := approvalRepository.NewApprovalRepository(db)
+	escalationRepo := escalationRepository.NewEscalationRepository(db)
+	userRepo := userRepository.NewUserRepository(db)
 
 	// Initialize services
 	paymentService := service.NewPaymentService(cfg.PaymentAPIURL)
-
-	// Initialize worker
-	paymentWorker := worker.NewPaymentWorker(expenseRepo, paymentService, time.Duration(cfg.WorkerInterval)*time.Second)
+	if cfg.PaymentRateLimit > 0 {
+		paymentService = service.NewRateLimitedService(paymentService, ratelimit.NewTokenBucket(float64(cfg.PaymentRateLimit), cfg.PaymentRateBurst))
+	}
+	notifier := notificationService.NewLogNotifier()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Start worker in a goroutine
+	// Wake the payment worker as soon as work is approved; the interval sweep remains as a
+	// fallback, and the only one when listening fails.
+	wake, err := database.Listen(ctx, cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName, database.PayableWorkChannel)
+	if err != nil {
+		log.Printf("Payment worker falls back to polling: %v", err)
+	}
+
+	// Initialize workers
+	paymentWorker := worker.NewPaymentWorker(expenseRepo, reportRepo, advanceRepo, historyRepo, paymentRepo, paymentService, worker.Options{
+		Interval:     time.Duration(cfg.WorkerInterval) * time.Second,
+		BatchSize:    cfg.PaymentBatchSize,
+		Concurrency:  cfg.PaymentConcurrency,
+		ClaimTimeout: time.Duration(cfg.PaymentClaimTimeout) * time.Second,
+		Retry: domain.RetryPolicy{
+			MaxAttempts: cfg.PaymentMaxAttempts,
+			BaseDelay:   time.Duration(cfg.PaymentRetryBaseDelay) * time.Second,
+			MaxDelay:    time.Duration(cfg.PaymentRetryMaxDelay) * time.Second,
+		},
+		Wake: wake,
+	})
+	slaWorker := approvalWorker.NewSLAWorker(expenseRepo, reportRepo, approvalRepo, historyRepo, escalationRepo, userRepo, notifier,
+		approvalPolicy, slaPolicy, time.Duration(cfg.SLACheckInterval)*time.Second)
+
+	// Start workers in goroutines
 	go paymentWorker.Start(ctx)
+	go slaWorker.Start(ctx)
 
 	log.Printf("Payment worker started with interval %d seconds", cfg.WorkerInterval)
+	log.Printf("Approval SLA worker started with interval %d seconds", cfg.SLACheckInterval)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
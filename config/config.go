@@ -15,6 +15,51 @@ type Config struct {
 	ServerPort     string
 	PaymentAPIURL  string
 	WorkerInterval int
+
+	PaymentBatchSize      int
+	PaymentClaimTimeout   int
+	PaymentMaxAttempts    int
+	PaymentRetryBaseDelay int
+	PaymentRetryMaxDelay  int
+	PaymentConcurrency    int
+	PaymentRateLimit      int
+	PaymentRateBurst      int
+
+	ApprovalTiers                  string
+	ApprovalIncludeIndirectReports bool
+	SoDMaxConsecutiveApprovals     int
+
+	SLAReminderBusinessDays   int
+	SLAEscalationBusinessDays int
+	SLACheckInterval          int
+	SLAHolidays               string
+
+	ReceiptStorage       string
+	ReceiptLocalDir      string
+	ReceiptPublicBaseURL string
+	ReceiptURLSecret     string
+	ReceiptURLTTL        int
+	ReceiptMaxBytes      int
+
+	S3Endpoint       string
+	S3PublicEndpoint string
+	S3Region         string
+	S3Bucket         string
+	S3AccessKey      string
+	S3SecretKey      string
+	S3PathStyle      bool
+
+	DuplicateWindowDays             int
+	DuplicateAmountTolerancePercent int
+	DuplicateDescriptionSimilarity  int
+
+	SplitWindowDays            int
+	SplitDescriptionSimilarity int
+
+	FXMaxRateAgeDays int
+
+	BudgetWarningPercent         int
+	BudgetOverrunFinanceApproval bool
 }
 
 func Load() *Config {
@@ -28,6 +73,51 @@ func Load() *Config {
 		ServerPort:     getEnv("SERVER_PORT", "8080"),
 		PaymentAPIURL:  getEnv("PAYMENT_API_URL", "https://1620e98f-7759-431c-a2aa-f449d591150b.mock.pstmn.io"),
 		WorkerInterval: getEnvAsInt("WORKER_INTERVAL", 30),
+
+		PaymentBatchSize:      getEnvAsInt("PAYMENT_BATCH_SIZE", 50),
+		PaymentClaimTimeout:   getEnvAsInt("PAYMENT_CLAIM_TIMEOUT", 600),
+		PaymentMaxAttempts:    getEnvAsInt("PAYMENT_MAX_ATTEMPTS", 5),
+		PaymentRetryBaseDelay: getEnvAsInt("PAYMENT_RETRY_BASE_DELAY", 30),
+		PaymentRetryMaxDelay:  getEnvAsInt("PAYMENT_RETRY_MAX_DELAY", 3600),
+		PaymentConcurrency:    getEnvAsInt("PAYMENT_CONCURRENCY", 4),
+		PaymentRateLimit:      getEnvAsInt("PAYMENT_RATE_LIMIT", 10),
+		PaymentRateBurst:      getEnvAsInt("PAYMENT_RATE_BURST", 10),
+
+		ApprovalTiers:                  getEnv("APPROVAL_TIERS", ""),
+		ApprovalIncludeIndirectReports: getEnvAsBool("APPROVAL_INCLUDE_INDIRECT_REPORTS", false),
+		SoDMaxConsecutiveApprovals:     getEnvAsInt("SOD_MAX_CONSECUTIVE_APPROVALS", 0),
+
+		SLAReminderBusinessDays:   getEnvAsInt("SLA_REMINDER_BUSINESS_DAYS", 2),
+		SLAEscalationBusinessDays: getEnvAsInt("SLA_ESCALATION_BUSINESS_DAYS", 4),
+		SLACheckInterval:          getEnvAsInt("SLA_CHECK_INTERVAL", 3600),
+		SLAHolidays:               getEnv("SLA_HOLIDAYS", ""),
+
+		ReceiptStorage:       getEnv("RECEIPT_STORAGE", "local"),
+		ReceiptLocalDir:      getEnv("RECEIPT_LOCAL_DIR", "./data/receipts"),
+		ReceiptPublicBaseURL: getEnv("RECEIPT_PUBLIC_BASE_URL", "http://localhost:8080"),
+		ReceiptURLSecret:     getEnv("RECEIPT_URL_SECRET", "your-receipt-url-secret"),
+		ReceiptURLTTL:        getEnvAsInt("RECEIPT_URL_TTL", 300),
+		ReceiptMaxBytes:      getEnvAsInt("RECEIPT_MAX_BYTES", 10<<20),
+
+		S3Endpoint:       getEnv("S3_ENDPOINT", "http://localhost:9000"),
+		S3PublicEndpoint: getEnv("S3_PUBLIC_ENDPOINT", ""),
+		S3Region:         getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:         getEnv("S3_BUCKET", "receipts"),
+		S3AccessKey:      getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:      getEnv("S3_SECRET_KEY", ""),
+		S3PathStyle:      getEnvAsBool("S3_PATH_STYLE", true),
+
+		DuplicateWindowDays:             getEnvAsInt("DUPLICATE_WINDOW_DAYS", 3),
+		DuplicateAmountTolerancePercent: getEnvAsInt("DUPLICATE_AMOUNT_TOLERANCE_PERCENT", 5),
+		DuplicateDescriptionSimilarity:  getEnvAsInt("DUPLICATE_DESCRIPTION_SIMILARITY", 60),
+
+		SplitWindowDays:            getEnvAsInt("SPLIT_WINDOW_DAYS", 7),
+		SplitDescriptionSimilarity: getEnvAsInt("SPLIT_DESCRIPTION_SIMILARITY", 60),
+
+		FXMaxRateAgeDays: getEnvAsInt("FX_MAX_RATE_AGE_DAYS", 7),
+
+		BudgetWarningPercent:         getEnvAsInt("BUDGET_WARNING_PERCENT", 80),
+		BudgetOverrunFinanceApproval: getEnvAsBool("BUDGET_OVERRUN_FINANCE_APPROVAL", false),
 	}
 }
 
@@ -46,3 +136,12 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
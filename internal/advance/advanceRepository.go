@@ -0,0 +1,19 @@
+package advance
+
+import (
+	"context"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type AdvanceRepository interface {
+	Create(ctx context.Context, advance *domain.CashAdvance) error
+	FindByID(ctx context.Context, id int) (*domain.CashAdvance, error)
+	FindByUserID(ctx context.Context, userID int) ([]*domain.CashAdvance, error)
+	FindByStatus(ctx context.Context, statuses ...domain.AdvanceStatus) ([]*domain.CashAdvance, error)
+	ClaimForPayment(ctx context.Context, limit int, timeout time.Duration) ([]*domain.CashAdvance, error)
+	UpdateStatus(ctx context.Context, advance *domain.CashAdvance, from domain.AdvanceStatus) error
+	Settle(ctx context.Context, advance *domain.CashAdvance, expenses []*domain.Expense) error
+	Balance(ctx context.Context, userID int) (*domain.AdvanceBalance, error)
+}
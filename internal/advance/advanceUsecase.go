@@ -0,0 +1,20 @@
+package advance
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type AdvanceUseCase interface {
+	RequestAdvance(ctx context.Context, advance *domain.CashAdvance) (*domain.CashAdvance, error)
+	GetAdvance(ctx context.Context, id int, userID int, role domain.Role) (*domain.CashAdvance, error)
+	GetUserAdvances(ctx context.Context, userID int) ([]*domain.CashAdvance, error)
+	CancelAdvance(ctx context.Context, id int, userID int) (*domain.CashAdvance, error)
+	GetPendingApproval(ctx context.Context, approverID int) ([]*domain.CashAdvance, error)
+	ApproveAdvance(ctx context.Context, id int, approverID int, notes string) error
+	RejectAdvance(ctx context.Context, id int, approverID int, notes string) error
+	SettleAdvance(ctx context.Context, id int, userID int) (*domain.CashAdvance, error)
+	RecordRepayment(ctx context.Context, id int) (*domain.CashAdvance, error)
+	GetBalance(ctx context.Context, balanceUserID int, userID int, role domain.Role) (*domain.AdvanceBalance, error)
+}
@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/evrintobing17/expense-management-backend/internal/advance"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/gorilla/mux"
+)
+
+type AdvanceHandler struct {
+	advanceUseCase advance.AdvanceUseCase
+}
+
+func NewAdvanceHandler(advanceUseCase advance.AdvanceUseCase) *AdvanceHandler {
+	return &AdvanceHandler{advanceUseCase: advanceUseCase}
+}
+
+func (h *AdvanceHandler) RequestAdvance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req struct {
+		Purpose   string `json:"purpose"`
+		AmountIDR int    `json:"amount_idr"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	advance, err := h.advanceUseCase.RequestAdvance(ctx, &domain.CashAdvance{
+		UserID:    userID,
+		Purpose:   req.Purpose,
+		AmountIDR: req.AmountIDR,
+	})
+	if err != nil {
+		writeError(w, err, "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(advance)
+}
+
+func (h *AdvanceHandler) GetAdvances(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	advances, err := h.advanceUseCase.GetUserAdvances(ctx, userID)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(advances)
+}
+
+func (h *AdvanceHandler) GetAdvance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	role, _ := middleware.GetUserRoleFromContext(ctx)
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid advance ID", http.StatusBadRequest)
+		return
+	}
+
+	advance, err := h.advanceUseCase.GetAdvance(ctx, id, userID, role)
+	if err != nil {
+		writeError(w, err, "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(advance)
+}
+
+// GetBalance returns the caller's advance balance, or with ?user_id= another employee's
+// for finance and admins.
+func (h *AdvanceHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	role, _ := middleware.GetUserRoleFromContext(ctx)
+
+	balanceUserID := userID
+	if raw := r.URL.Query().Get("user_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "Invalid user ID", http.StatusBadRequest)
+			return
+		}
+		balanceUserID = id
+	}
+
+	balance, err := h.advanceUseCase.GetBalance(ctx, balanceUserID, userID, role)
+	if err != nil {
+		writeError(w, err, "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(balance)
+}
+
+func (h *AdvanceHandler) CancelAdvance(w http.ResponseWriter, r *http.Request) {
+	h.ownAction(w, r, h.advanceUseCase.CancelAdvance, "Cash advance can no longer be cancelled")
+}
+
+func (h *AdvanceHandler) SettleAdvance(w http.ResponseWriter, r *http.Request) {
+	h.ownAction(w, r, h.advanceUseCase.SettleAdvance, "Only outstanding cash advances can be settled")
+}
+
+// ownAction handles the requester's cancel and settle endpoints, which only differ in the
+// use case they call.
+func (h *AdvanceHandler) ownAction(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(ctx context.Context, id int, userID int) (*domain.CashAdvance, error),
+	invalidStatus string,
+) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid advance ID", http.StatusBadRequest)
+		return
+	}
+
+	advance, err := action(ctx, id, userID)
+	if err != nil {
+		writeError(w, err, invalidStatus)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(advance)
+}
+
+func (h *AdvanceHandler) GetPendingApproval(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	approverID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	advances, err := h.advanceUseCase.GetPendingApproval(ctx, approverID)
+	if err != nil {
+		writeError(w, err, "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(advances)
+}
+
+func (h *AdvanceHandler) ApproveAdvance(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.advanceUseCase.ApproveAdvance, "Cash advance cannot be approved")
+}
+
+func (h *AdvanceHandler) RejectAdvance(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.advanceUseCase.RejectAdvance, "Cash advance cannot be rejected")
+}
+
+// decide handles the approve and reject endpoints, which only differ in the use case
+// they call.
+func (h *AdvanceHandler) decide(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(ctx context.Context, id int, approverID int, notes string) error,
+	invalidStatus string,
+) {
+	ctx := r.Context()
+
+	approverID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid advance ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Notes string `json:"notes"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := action(ctx, id, approverID, req.Notes); err != nil {
+		writeError(w, err, invalidStatus)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// RecordRepayment is used by finance once the employee has paid back what they owed.
+func (h *AdvanceHandler) RecordRepayment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid advance ID", http.StatusBadRequest)
+		return
+	}
+
+	advance, err := h.advanceUseCase.RecordRepayment(ctx, id)
+	if err != nil {
+		writeError(w, err, "Cash advance has no repayment due")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(advance)
+}
+
+func writeError(w http.ResponseWriter, err error, invalidStatus string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidAdvance):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrAdvanceNotFound):
+		http.Error(w, "Cash advance not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidAdvanceStatus):
+		if invalidStatus == "" {
+			invalidStatus = err.Error()
+		}
+		http.Error(w, invalidStatus, http.StatusBadRequest)
+	case errors.Is(err, domain.ErrAdvanceExpensesPending):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrStatusConflict):
+		http.Error(w, "Cash advance was updated by another request", http.StatusConflict)
+	case errors.Is(err, domain.ErrUnauthorizedAction):
+		http.Error(w, "Access denied", http.StatusForbidden)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
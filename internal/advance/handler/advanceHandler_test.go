@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func withUser(req *http.Request, userID int, role domain.Role) *http.Request {
+	// Reuse auth middleware to set the same context keys used by handlers.
+	mockAuth := new(mocks.AuthService)
+	mockAuth.On("ValidateToken", mock.Anything, "t").Return(userID, role, nil).Maybe()
+	rr := httptest.NewRecorder()
+	next := middleware.AuthMiddleware(mockAuth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+	}))
+	req.Header.Set("Authorization", "Bearer t")
+	next.ServeHTTP(rr, req)
+	return req
+}
+
+func TestAdvanceHandlerRequestAdvance(t *testing.T) {
+	t.Run("unauthorized", func(t *testing.T) {
+		h := NewAdvanceHandler(new(mocks.AdvanceUseCase))
+		req := httptest.NewRequest(http.MethodPost, "/advances", strings.NewReader(`{"purpose":"Trip"}`))
+		rr := httptest.NewRecorder()
+
+		h.RequestAdvance(rr, req)
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("invalid details", func(t *testing.T) {
+		mockUC := new(mocks.AdvanceUseCase)
+		h := NewAdvanceHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/advances", strings.NewReader(`{"purpose":"Trip"}`))
+		req = withUser(req, 1, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+		mockUC.On("RequestAdvance", mock.Anything, mock.Anything).Return((*domain.CashAdvance)(nil), domain.ErrInvalidAdvance).Once()
+
+		h.RequestAdvance(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.AdvanceUseCase)
+		h := NewAdvanceHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/advances", strings.NewReader(`{"purpose":"Site survey in Kupang","amount_idr":3000000}`))
+		req = withUser(req, 1, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+		mockUC.On("RequestAdvance", mock.Anything, mock.MatchedBy(func(a *domain.CashAdvance) bool {
+			return a.UserID == 1 && a.Purpose == "Site survey in Kupang" && a.AmountIDR == 3000000
+		})).Return(&domain.CashAdvance{ID: 4, UserID: 1, Status: domain.AdvanceStatusRequested}, nil).Once()
+
+		h.RequestAdvance(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.Contains(t, rr.Body.String(), `"id":4`)
+	})
+}
+
+func TestAdvanceHandlerSettleAdvance(t *testing.T) {
+	t.Run("expenses pending", func(t *testing.T) {
+		mockUC := new(mocks.AdvanceUseCase)
+		h := NewAdvanceHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/advances/4/settle", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		req = mux.SetURLVars(req, map[string]string{"id": "4"})
+		rr := httptest.NewRecorder()
+		mockUC.On("SettleAdvance", mock.Anything, 4, 1).Return((*domain.CashAdvance)(nil), domain.ErrAdvanceExpensesPending).Once()
+
+		h.SettleAdvance(rr, req)
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("not outstanding", func(t *testing.T) {
+		mockUC := new(mocks.AdvanceUseCase)
+		h := NewAdvanceHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/advances/4/settle", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		req = mux.SetURLVars(req, map[string]string{"id": "4"})
+		rr := httptest.NewRecorder()
+		mockUC.On("SettleAdvance", mock.Anything, 4, 1).Return((*domain.CashAdvance)(nil), domain.ErrInvalidAdvanceStatus).Once()
+
+		h.SettleAdvance(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), "Only outstanding cash advances can be settled")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.AdvanceUseCase)
+		h := NewAdvanceHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/advances/4/settle", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		req = mux.SetURLVars(req, map[string]string{"id": "4"})
+		rr := httptest.NewRecorder()
+		mockUC.On("SettleAdvance", mock.Anything, 4, 1).Return(&domain.CashAdvance{ID: 4, Status: domain.AdvanceStatusRepaymentDue, RepaymentIDR: 800000}, nil).Once()
+
+		h.SettleAdvance(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"repayment_idr":800000`)
+	})
+}
+
+func TestAdvanceHandlerDecide(t *testing.T) {
+	t.Run("outside the approver's scope", func(t *testing.T) {
+		mockUC := new(mocks.AdvanceUseCase)
+		h := NewAdvanceHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPut, "/advances/4/approve", strings.NewReader(`{"notes":"ok"}`))
+		req = withUser(req, 2, domain.RoleManager)
+		req = mux.SetURLVars(req, map[string]string{"id": "4"})
+		rr := httptest.NewRecorder()
+		mockUC.On("ApproveAdvance", mock.Anything, 4, 2, "ok").Return(domain.ErrUnauthorizedAction).Once()
+
+		h.ApproveAdvance(rr, req)
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		mockUC := new(mocks.AdvanceUseCase)
+		h := NewAdvanceHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPut, "/advances/4/reject", strings.NewReader(`{"notes":"trip cancelled"}`))
+		req = withUser(req, 2, domain.RoleManager)
+		req = mux.SetURLVars(req, map[string]string{"id": "4"})
+		rr := httptest.NewRecorder()
+		mockUC.On("RejectAdvance", mock.Anything, 4, 2, "trip cancelled").Return(nil).Once()
+
+		h.RejectAdvance(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestAdvanceHandlerGetBalance(t *testing.T) {
+	t.Run("own balance", func(t *testing.T) {
+		mockUC := new(mocks.AdvanceUseCase)
+		h := NewAdvanceHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/advances/balance", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+		mockUC.On("GetBalance", mock.Anything, 1, 1, domain.RoleEmployee).Return(&domain.AdvanceBalance{UserID: 1, BalanceIDR: 3000000}, nil).Once()
+
+		h.GetBalance(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"balance_idr":3000000`)
+	})
+
+	t.Run("someone else's balance", func(t *testing.T) {
+		mockUC := new(mocks.AdvanceUseCase)
+		h := NewAdvanceHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/advances/balance?user_id=5", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+		mockUC.On("GetBalance", mock.Anything, 5, 1, domain.RoleEmployee).Return((*domain.AdvanceBalance)(nil), domain.ErrUnauthorizedAction).Once()
+
+		h.GetBalance(rr, req)
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("invalid user id", func(t *testing.T) {
+		h := NewAdvanceHandler(new(mocks.AdvanceUseCase))
+		req := httptest.NewRequest(http.MethodGet, "/advances/balance?user_id=abc", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+
+		h.GetBalance(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
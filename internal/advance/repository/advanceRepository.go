@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/advance"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+	"github.com/lib/pq"
+)
+
+type advanceRepository struct {
+	db *sql.DB
+}
+
+func NewAdvanceRepository(db *sql.DB) advance.AdvanceRepository {
+	return &advanceRepository{db: db}
+}
+
+func (r *advanceRepository) Create(ctx context.Context, advance *domain.CashAdvance) error {
+	query := `
+		INSERT INTO cash_advances (user_id, purpose, amount_idr, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	return database.Conn(ctx, r.db).QueryRowContext(ctx, query,
+		advance.UserID,
+		advance.Purpose,
+		advance.AmountIDR,
+		advance.Status,
+	).Scan(&advance.ID, &advance.CreatedAt)
+}
+
+func (r *advanceRepository) FindByID(ctx context.Context, id int) (*domain.CashAdvance, error) {
+	query := `
+		SELECT id, user_id, purpose, amount_idr, status, approver_id, decision_notes,
+			expenses_idr, reimbursement_idr, repayment_idr, decided_at, paid_at, settled_at, created_at
+		FROM cash_advances
+		WHERE id = $1
+	`
+
+	advances, err := r.query(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(advances) == 0 {
+		return nil, nil
+	}
+
+	return advances[0], nil
+}
+
+func (r *advanceRepository) FindByUserID(ctx context.Context, userID int) ([]*domain.CashAdvance, error) {
+	query := `
+		SELECT id, user_id, purpose, amount_idr, status, approver_id, decision_notes,
+			expenses_idr, reimbursement_idr, repayment_idr, decided_at, paid_at, settled_at, created_at
+		FROM cash_advances
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	return r.query(ctx, query, userID)
+}
+
+// FindByStatus returns the advances in any of the statuses, oldest request first.
+func (r *advanceRepository) FindByStatus(ctx context.Context, statuses ...domain.AdvanceStatus) ([]*domain.CashAdvance, error) {
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("at least one status must be provided")
+	}
+
+	query := `
+		SELECT id, user_id, purpose, amount_idr, status, approver_id, decision_notes,
+			expenses_idr, reimbursement_idr, repayment_idr, decided_at, paid_at, settled_at, created_at
+		FROM cash_advances
+		WHERE status = ANY($1)
+		ORDER BY created_at ASC, id ASC
+	`
+
+	return r.query(ctx, query, pq.Array(statusNames(statuses)))
+}
+
+// ClaimForPayment claims up to limit advances waiting to be paid, oldest request first:
+// approved advances move to disbursing and advances with a reimbursement due to
+// reimbursing. Rows locked by another worker are skipped rather than waited for, and
+// advances claimed more than timeout ago and still disbursing or reimbursing are claimed
+// again. Advances whose payment retry has come due move back to disbursing until they
+// have been paid out and to reimbursing after. The advances are returned in their
+// claimed status.
+func (r *advanceRepository) ClaimForPayment(ctx context.Context, limit int, timeout time.Duration) ([]*domain.CashAdvance, error) {
+	query := `
+		UPDATE cash_advances a
+		SET status = CASE
+				WHEN claimed.status = $1 THEN $3
+				WHEN claimed.status = $2 THEN $4
+				WHEN claimed.status = $7 AND a.paid_at IS NULL THEN $3
+				WHEN claimed.status = $7 THEN $4
+				ELSE claimed.status
+			END,
+			claimed_at = CURRENT_TIMESTAMP
+		FROM (
+			SELECT id, status
+			FROM cash_advances
+			WHERE status IN ($1, $2)
+				OR (status IN ($3, $4) AND claimed_at < CURRENT_TIMESTAMP - make_interval(secs => $5))
+				OR (status = $7 AND NOT EXISTS (
+					SELECT 1 FROM payments p
+					WHERE p.kind IN ($8, $9) AND p.subject_id = cash_advances.id AND p.next_attempt_at > CURRENT_TIMESTAMP
+				))
+			ORDER BY created_at ASC, id ASC
+			LIMIT $6
+			FOR UPDATE SKIP LOCKED
+		) claimed
+		WHERE a.id = claimed.id
+		RETURNING a.id, a.user_id, a.purpose, a.amount_idr, a.status, a.approver_id, a.decision_notes,
+			a.expenses_idr, a.reimbursement_idr, a.repayment_idr, a.decided_at, a.paid_at, a.settled_at, a.created_at
+	`
+
+	return r.query(ctx, query,
+		domain.AdvanceStatusApproved,
+		domain.AdvanceStatusReimbursementDue,
+		domain.AdvanceStatusDisbursing,
+		domain.AdvanceStatusReimbursing,
+		timeout.Seconds(),
+		limit,
+		domain.AdvanceStatusRetryScheduled,
+		domain.PaymentKindAdvance,
+		domain.PaymentKindAdvanceReimbursement,
+	)
+}
+
+func (r *advanceRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.CashAdvance, error) {
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var advances []*domain.CashAdvance
+	for rows.Next() {
+		advance := &domain.CashAdvance{}
+		err := rows.Scan(
+			&advance.ID,
+			&advance.UserID,
+			&advance.Purpose,
+			&advance.AmountIDR,
+			&advance.Status,
+			&advance.ApproverID,
+			&advance.DecisionNotes,
+			&advance.ExpensesIDR,
+			&advance.ReimbursementIDR,
+			&advance.RepaymentIDR,
+			&advance.DecidedAt,
+			&advance.PaidAt,
+			&advance.SettledAt,
+			&advance.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		advances = append(advances, advance)
+	}
+
+	return advances, rows.Err()
+}
+
+// UpdateStatus saves the advance's status together with its decision, payment and
+// settlement details, provided the stored status is still from. ErrStatusConflict is
+// returned when another request or worker moved the advance first.
+func (r *advanceRepository) UpdateStatus(ctx context.Context, advance *domain.CashAdvance, from domain.AdvanceStatus) error {
+	return updateStatus(ctx, database.Conn(ctx, r.db), advance, from)
+}
+
+// Settle closes an outstanding advance that offsets the given expenses. Updating the
+// advance locks its row first, so an expense filed or approved against it since the
+// expenses were loaded fails the settlement with ErrAdvanceExpensesPending instead of
+// being left unpaid. The caller moves the offset expenses in the same transaction.
+func (r *advanceRepository) Settle(ctx context.Context, advance *domain.CashAdvance, expenses []*domain.Expense) error {
+	return database.WithinTx(ctx, r.db, func(ctx context.Context) error {
+		return r.settle(ctx, advance, expenses)
+	})
+}
+
+func (r *advanceRepository) settle(ctx context.Context, advance *domain.CashAdvance, expenses []*domain.Expense) error {
+	tx := database.Conn(ctx, r.db)
+
+	if err := updateStatus(ctx, tx, advance, domain.AdvanceStatusOutstanding); err != nil {
+		return err
+	}
+
+	query := `
+		SELECT COUNT(*)
+		FROM expenses
+		WHERE advance_id = $1 AND status = ANY($2) AND id <> ALL($3)
+	`
+
+	offsetIDs := make([]int64, len(expenses))
+	for i, expense := range expenses {
+		offsetIDs[i] = int64(expense.ID)
+	}
+
+	unsettled := append(append([]domain.ExpenseStatus{}, domain.AdvancePendingStatuses...), domain.AdvanceOffsetStatuses...)
+	var remaining int
+	err := tx.QueryRowContext(ctx, query, advance.ID, pq.Array(expenseStatusNames(unsettled)), pq.Array(offsetIDs)).Scan(&remaining)
+	if err != nil {
+		return err
+	}
+
+	if remaining > 0 {
+		return domain.ErrAdvanceExpensesPending
+	}
+
+	return nil
+}
+
+// Balance totals the user's advances that are paid out and not fully settled yet. A
+// reimbursement whose payment is being retried or was dead-lettered is still due, while
+// an advance whose payout is being retried has not been paid out and is left out.
+func (r *advanceRepository) Balance(ctx context.Context, userID int) (*domain.AdvanceBalance, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(amount_idr) FILTER (WHERE status = $2), 0),
+			COALESCE(SUM(repayment_idr) FILTER (WHERE status = $3), 0),
+			COALESCE(SUM(reimbursement_idr) FILTER (WHERE status = ANY($4)), 0)
+		FROM cash_advances
+		WHERE user_id = $1 AND status = ANY($5) AND paid_at IS NOT NULL
+	`
+
+	reimbursing := []domain.AdvanceStatus{
+		domain.AdvanceStatusReimbursementDue,
+		domain.AdvanceStatusReimbursing,
+		domain.AdvanceStatusRetryScheduled,
+		domain.AdvanceStatusDeadLetter,
+	}
+	open := append([]domain.AdvanceStatus{domain.AdvanceStatusOutstanding, domain.AdvanceStatusRepaymentDue}, reimbursing...)
+
+	balance := &domain.AdvanceBalance{UserID: userID}
+	err := database.Conn(ctx, r.db).QueryRowContext(ctx, query,
+		userID,
+		domain.AdvanceStatusOutstanding,
+		domain.AdvanceStatusRepaymentDue,
+		pq.Array(statusNames(reimbursing)),
+		pq.Array(statusNames(open)),
+	).Scan(
+		&balance.OpenAdvances,
+		&balance.OutstandingIDR,
+		&balance.RepaymentDueIDR,
+		&balance.ReimbursementDueIDR,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	balance.BalanceIDR = balance.OutstandingIDR + balance.RepaymentDueIDR - balance.ReimbursementDueIDR
+	return balance, nil
+}
+
+func updateStatus(ctx context.Context, db database.Querier, advance *domain.CashAdvance, from domain.AdvanceStatus) error {
+	if advance.Status != from && !from.CanTransitionTo(advance.Status) {
+		return domain.ErrInvalidAdvanceStatus
+	}
+
+	query := `
+		UPDATE cash_advances
+		SET status = $1, approver_id = $2, decision_notes = $3, expenses_idr = $4,
+			reimbursement_idr = $5, repayment_idr = $6, decided_at = $7, paid_at = $8, settled_at = $9
+		WHERE id = $10 AND status = $11
+	`
+
+	result, err := db.ExecContext(ctx, query,
+		advance.Status,
+		advance.ApproverID,
+		advance.DecisionNotes,
+		advance.ExpensesIDR,
+		advance.ReimbursementIDR,
+		advance.RepaymentIDR,
+		advance.DecidedAt,
+		advance.PaidAt,
+		advance.SettledAt,
+		advance.ID,
+		from,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result)
+}
+
+// checkAffected turns an update that matched no row into ErrStatusConflict.
+func checkAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrStatusConflict
+	}
+
+	return nil
+}
+
+func statusNames(statuses []domain.AdvanceStatus) []string {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+	return names
+}
+
+func expenseStatusNames(statuses []domain.ExpenseStatus) []string {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+	return names
+}
@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var advanceColumns = []string{
+	"id", "user_id", "purpose", "amount_idr", "status", "approver_id", "decision_notes",
+	"expenses_idr", "reimbursement_idr", "repayment_idr", "decided_at", "paid_at", "settled_at", "created_at",
+}
+
+var updateStatusQuery = regexp.QuoteMeta(`
+		UPDATE cash_advances
+		SET status = $1, approver_id = $2, decision_notes = $3, expenses_idr = $4,
+			reimbursement_idr = $5, repayment_idr = $6, decided_at = $7, paid_at = $8, settled_at = $9
+		WHERE id = $10 AND status = $11
+	`)
+
+func updateStatusArgs(advance *domain.CashAdvance, from domain.AdvanceStatus) []driver.Value {
+	return []driver.Value{
+		advance.Status, advance.ApproverID, advance.DecisionNotes, advance.ExpensesIDR,
+		advance.ReimbursementIDR, advance.RepaymentIDR, advance.DecidedAt, advance.PaidAt, advance.SettledAt,
+		advance.ID, from,
+	}
+}
+
+func TestAdvanceRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &advanceRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO cash_advances (user_id, purpose, amount_idr, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`)
+	createdAt := time.Now()
+	advance := &domain.CashAdvance{UserID: 1, Purpose: "Site survey in Kupang", AmountIDR: 3000000, Status: domain.AdvanceStatusRequested}
+
+	mock.ExpectQuery(query).
+		WithArgs(advance.UserID, advance.Purpose, advance.AmountIDR, advance.Status).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(4, createdAt))
+
+	require.NoError(t, repo.Create(context.Background(), advance))
+	require.Equal(t, 4, advance.ID)
+	require.Equal(t, createdAt, advance.CreatedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdvanceRepositoryFindByStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &advanceRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id, user_id, purpose, amount_idr, status, approver_id, decision_notes,
+			expenses_idr, reimbursement_idr, repayment_idr, decided_at, paid_at, settled_at, created_at
+		FROM cash_advances
+		WHERE status = ANY($1)
+		ORDER BY created_at ASC, id ASC
+	`)
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows(advanceColumns).
+			AddRow(4, 1, "Site survey in Kupang", 3000000, domain.AdvanceStatusApproved, 2, "", 0, 0, 0, now, nil, nil, now)
+		mock.ExpectQuery(query).
+			WithArgs(pq.Array([]string{"approved", "reimbursement_due"})).
+			WillReturnRows(rows)
+
+		advances, findErr := repo.FindByStatus(context.Background(), domain.AdvanceStatusApproved, domain.AdvanceStatusReimbursementDue)
+		require.NoError(t, findErr)
+		require.Len(t, advances, 1)
+		require.Equal(t, 2, *advances[0].ApproverID)
+		require.Nil(t, advances[0].PaidAt)
+	})
+
+	t.Run("no statuses", func(t *testing.T) {
+		_, findErr := repo.FindByStatus(context.Background())
+		require.Error(t, findErr)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdvanceRepositoryClaimForPayment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &advanceRepository{db: db}
+	query := regexp.QuoteMeta(`
+		UPDATE cash_advances a
+		SET status = CASE
+				WHEN claimed.status = $1 THEN $3
+				WHEN claimed.status = $2 THEN $4
+				WHEN claimed.status = $7 AND a.paid_at IS NULL THEN $3
+				WHEN claimed.status = $7 THEN $4
+				ELSE claimed.status
+			END,
+			claimed_at = CURRENT_TIMESTAMP
+		FROM (
+			SELECT id, status
+			FROM cash_advances
+			WHERE status IN ($1, $2)
+				OR (status IN ($3, $4) AND claimed_at < CURRENT_TIMESTAMP - make_interval(secs => $5))
+				OR (status = $7 AND NOT EXISTS (
+					SELECT 1 FROM payments p
+					WHERE p.kind IN ($8, $9) AND p.subject_id = cash_advances.id AND p.next_attempt_at > CURRENT_TIMESTAMP
+				))
+			ORDER BY created_at ASC, id ASC
+			LIMIT $6
+			FOR UPDATE SKIP LOCKED
+		) claimed
+		WHERE a.id = claimed.id
+		RETURNING a.id, a.user_id, a.purpose, a.amount_idr, a.status, a.approver_id, a.decision_notes,
+			a.expenses_idr, a.reimbursement_idr, a.repayment_idr, a.decided_at, a.paid_at, a.settled_at, a.created_at
+	`)
+	now := time.Now()
+	rows := sqlmock.NewRows(advanceColumns).
+		AddRow(4, 1, "Site survey in Kupang", 3000000, domain.AdvanceStatusDisbursing, 2, "", 0, 0, 0, now, nil, nil, now).
+		AddRow(5, 1, "Workshop in Ambon", 2000000, domain.AdvanceStatusReimbursing, 2, "", 2400000, 400000, 0, now, now, nil, now)
+	mock.ExpectQuery(query).
+		WithArgs(domain.AdvanceStatusApproved, domain.AdvanceStatusReimbursementDue, domain.AdvanceStatusDisbursing, domain.AdvanceStatusReimbursing, 600.0, 10,
+			domain.AdvanceStatusRetryScheduled, domain.PaymentKindAdvance, domain.PaymentKindAdvanceReimbursement).
+		WillReturnRows(rows)
+
+	advances, claimErr := repo.ClaimForPayment(context.Background(), 10, 10*time.Minute)
+	require.NoError(t, claimErr)
+	require.Len(t, advances, 2)
+	require.Equal(t, domain.AdvanceStatusDisbursing, advances[0].Status)
+	require.Equal(t, 400000, advances[1].ReimbursementIDR)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdvanceRepositoryUpdateStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &advanceRepository{db: db}
+	paidAt := time.Now()
+	advance := &domain.CashAdvance{ID: 4, Status: domain.AdvanceStatusOutstanding, PaidAt: &paidAt}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectExec(updateStatusQuery).
+			WithArgs(updateStatusArgs(advance, domain.AdvanceStatusDisbursing)...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.UpdateStatus(context.Background(), advance, domain.AdvanceStatusDisbursing))
+	})
+
+	t.Run("status changed concurrently", func(t *testing.T) {
+		mock.ExpectExec(updateStatusQuery).
+			WithArgs(updateStatusArgs(advance, domain.AdvanceStatusDisbursing)...).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		require.ErrorIs(t, repo.UpdateStatus(context.Background(), advance, domain.AdvanceStatusDisbursing), domain.ErrStatusConflict)
+	})
+
+	t.Run("transition not allowed", func(t *testing.T) {
+		require.ErrorIs(t, repo.UpdateStatus(context.Background(), advance, domain.AdvanceStatusRequested), domain.ErrInvalidAdvanceStatus)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdvanceRepositorySettle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &advanceRepository{db: db}
+	remainingQuery := regexp.QuoteMeta(`
+		SELECT COUNT(*)
+		FROM expenses
+		WHERE advance_id = $1 AND status = ANY($2) AND id <> ALL($3)
+	`)
+	advance := &domain.CashAdvance{
+		ID:               4,
+		AmountIDR:        3000000,
+		Status:           domain.AdvanceStatusReimbursementDue,
+		ExpensesIDR:      3500000,
+		ReimbursementIDR: 500000,
+	}
+	expenses := []*domain.Expense{
+		{ID: 11, Status: domain.ExpenseStatusApproved},
+		{ID: 12, Status: domain.ExpenseStatusAutoApproved},
+	}
+
+	t.Run("no other expense left to offset", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(updateStatusQuery).
+			WithArgs(updateStatusArgs(advance, domain.AdvanceStatusOutstanding)...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(remainingQuery).
+			WithArgs(advance.ID, sqlmock.AnyArg(), "{11,12}").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Settle(context.Background(), advance, expenses))
+	})
+
+	t.Run("expense filed or approved since loading", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(updateStatusQuery).
+			WithArgs(updateStatusArgs(advance, domain.AdvanceStatusOutstanding)...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(remainingQuery).
+			WithArgs(advance.ID, sqlmock.AnyArg(), "{11,12}").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		require.ErrorIs(t, repo.Settle(context.Background(), advance, expenses), domain.ErrAdvanceExpensesPending)
+	})
+
+	t.Run("advance changed concurrently", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(updateStatusQuery).
+			WithArgs(updateStatusArgs(advance, domain.AdvanceStatusOutstanding)...).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		require.ErrorIs(t, repo.Settle(context.Background(), advance, expenses), domain.ErrStatusConflict)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdvanceRepositoryBalance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &advanceRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(amount_idr) FILTER (WHERE status = $2), 0),
+			COALESCE(SUM(repayment_idr) FILTER (WHERE status = $3), 0),
+			COALESCE(SUM(reimbursement_idr) FILTER (WHERE status = ANY($4)), 0)
+		FROM cash_advances
+		WHERE user_id = $1 AND status = ANY($5) AND paid_at IS NOT NULL
+	`)
+
+	mock.ExpectQuery(query).
+		WithArgs(
+			1,
+			domain.AdvanceStatusOutstanding,
+			domain.AdvanceStatusRepaymentDue,
+			pq.Array([]string{"reimbursement_due", "reimbursing", "retry_scheduled", "dead_letter"}),
+			pq.Array([]string{"outstanding", "repayment_due", "reimbursement_due", "reimbursing", "retry_scheduled", "dead_letter"}),
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "outstanding", "repayment", "reimbursement"}).AddRow(3, 3000000, 800000, 500000))
+
+	balance, err := repo.Balance(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, balance.OpenAdvances)
+	require.Equal(t, 3300000, balance.BalanceIDR)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/advance"
+	"github.com/evrintobing17/expense-management-backend/internal/approval"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/expense"
+	"github.com/evrintobing17/expense-management-backend/internal/history"
+	"github.com/evrintobing17/expense-management-backend/internal/user"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+)
+
+type advanceUseCase struct {
+	advanceRepo advance.AdvanceRepository
+	expenseRepo expense.ExpenseRepository
+	historyRepo history.HistoryRepository
+	userRepo    user.UserRepository
+	scope       approval.ApproverScope
+	transactor  database.Transactor
+}
+
+func NewAdvanceUseCase(
+	advanceRepo advance.AdvanceRepository,
+	expenseRepo expense.ExpenseRepository,
+	historyRepo history.HistoryRepository,
+	userRepo user.UserRepository,
+	scope approval.ApproverScope,
+	transactor database.Transactor,
+) advance.AdvanceUseCase {
+	return &advanceUseCase{
+		advanceRepo: advanceRepo,
+		expenseRepo: expenseRepo,
+		historyRepo: historyRepo,
+		userRepo:    userRepo,
+		scope:       scope,
+		transactor:  transactor,
+	}
+}
+
+// RequestAdvance stores a new advance request, waiting for approval.
+func (uc *advanceUseCase) RequestAdvance(ctx context.Context, advance *domain.CashAdvance) (*domain.CashAdvance, error) {
+	if err := advance.Validate(); err != nil {
+		return nil, err
+	}
+
+	advance.Status = domain.AdvanceStatusRequested
+	if err := uc.advanceRepo.Create(ctx, advance); err != nil {
+		return nil, err
+	}
+
+	return advance, nil
+}
+
+// GetAdvance returns an advance with the expenses filed against it to its requester, finance
+// or a manager whose reporting line includes the requester.
+func (uc *advanceUseCase) GetAdvance(ctx context.Context, id int, userID int, role domain.Role) (*domain.CashAdvance, error) {
+	advance, err := uc.findAdvance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if advance.UserID != userID && !role.IsFinance() {
+		allowed, err := uc.inReportingLine(ctx, userID, advance.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowed {
+			return nil, domain.ErrUnauthorizedAction
+		}
+	}
+
+	advance.Expenses, err = uc.expenseRepo.FindByAdvanceID(ctx, advance.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return advance, nil
+}
+
+func (uc *advanceUseCase) GetUserAdvances(ctx context.Context, userID int) ([]*domain.CashAdvance, error) {
+	return uc.advanceRepo.FindByUserID(ctx, userID)
+}
+
+// CancelAdvance withdraws the requester's advance until the payment worker picks it up.
+func (uc *advanceUseCase) CancelAdvance(ctx context.Context, id int, userID int) (*domain.CashAdvance, error) {
+	advance, err := uc.ownAdvance(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.transition(ctx, advance, domain.AdvanceStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	return advance, nil
+}
+
+// GetPendingApproval returns the advance requests the approver may decide.
+func (uc *advanceUseCase) GetPendingApproval(ctx context.Context, approverID int) ([]*domain.CashAdvance, error) {
+	approver, err := uc.userRepo.FindByID(ctx, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	if approver == nil {
+		return nil, domain.ErrUnauthorizedAction
+	}
+
+	scope, err := uc.scope.ManagerScope(ctx, approver)
+	if err != nil {
+		return nil, err
+	}
+
+	advances, err := uc.advanceRepo.FindByStatus(ctx, domain.AdvanceStatusRequested)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*domain.CashAdvance
+	for _, advance := range advances {
+		// Segregation of duties: approvers never decide their own advances.
+		if advance.UserID == approver.ID {
+			continue
+		}
+
+		if _, inScope := scope[advance.UserID]; inScope || approver.Role.IsFinance() {
+			pending = append(pending, advance)
+		}
+	}
+
+	return pending, nil
+}
+
+// ApproveAdvance approves a requested advance, which the payment worker then pays out.
+func (uc *advanceUseCase) ApproveAdvance(ctx context.Context, id int, approverID int, notes string) error {
+	return uc.decide(ctx, id, approverID, domain.AdvanceStatusApproved, notes)
+}
+
+func (uc *advanceUseCase) RejectAdvance(ctx context.Context, id int, approverID int, notes string) error {
+	return uc.decide(ctx, id, approverID, domain.AdvanceStatusRejected, notes)
+}
+
+// SettleAdvance offsets the approved expenses filed against the requester's outstanding
+// advance.
+func (uc *advanceUseCase) SettleAdvance(ctx context.Context, id int, userID int) (*domain.CashAdvance, error) {
+	advance, err := uc.ownAdvance(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !advance.AcceptsExpenses() {
+		return nil, domain.ErrInvalidAdvanceStatus
+	}
+
+	advance.Expenses, err = uc.expenseRepo.FindByAdvanceID(ctx, advance.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var offset []*domain.Expense
+	expensesIDR := 0
+	for _, e := range advance.Expenses {
+		switch e.Status {
+		case domain.ExpenseStatusDraft, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusChangesRequested:
+			return nil, domain.ErrAdvanceExpensesPending
+		case domain.ExpenseStatusApproved, domain.ExpenseStatusAutoApproved:
+			offset = append(offset, e)
+			expensesIDR += e.AmountIDR
+		}
+	}
+
+	now := time.Now()
+	advance.Settle(expensesIDR, now)
+	reason := fmt.Sprintf("settled against cash advance %d", advance.ID)
+	err = uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.advanceRepo.Settle(ctx, advance, offset); err != nil {
+			return err
+		}
+
+		// Each offset expense passes through processing like a payment would.
+		for _, e := range offset {
+			if err := uc.transitionExpense(ctx, e, domain.ExpenseStatusProcessing, nil, userID, reason); err != nil {
+				return err
+			}
+			if err := uc.transitionExpense(ctx, e, domain.ExpenseStatusCompleted, &now, userID, reason); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return advance, nil
+}
+
+// transitionExpense moves an expense to its next status and appends the change to its
+// history.
+func (uc *advanceUseCase) transitionExpense(ctx context.Context, expense *domain.Expense, to domain.ExpenseStatus, processedAt *time.Time, userID int, reason string) error {
+	from := expense.Status
+	if err := uc.expenseRepo.UpdateStatus(ctx, expense.ID, from, to, processedAt); err != nil {
+		return err
+	}
+
+	if err := uc.historyRepo.Create(ctx, domain.UserStatusChange(expense.ID, from, to, userID, reason)); err != nil {
+		return err
+	}
+
+	expense.Status = to
+	expense.ProcessedAt = processedAt
+	return nil
+}
+
+// RecordRepayment closes an advance once the employee has paid back what they owed.
+func (uc *advanceUseCase) RecordRepayment(ctx context.Context, id int) (*domain.CashAdvance, error) {
+	advance, err := uc.findAdvance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if advance.Status != domain.AdvanceStatusRepaymentDue {
+		return nil, domain.ErrInvalidAdvanceStatus
+	}
+
+	now := time.Now()
+	advance.SettledAt = &now
+	if err := uc.transition(ctx, advance, domain.AdvanceStatusSettled); err != nil {
+		return nil, err
+	}
+
+	return advance, nil
+}
+
+// GetBalance returns what an employee holds in cash advances.
+func (uc *advanceUseCase) GetBalance(ctx context.Context, balanceUserID int, userID int, role domain.Role) (*domain.AdvanceBalance, error) {
+	if balanceUserID != userID && !role.IsFinance() && role != domain.RoleAdmin {
+		return nil, domain.ErrUnauthorizedAction
+	}
+
+	return uc.advanceRepo.Balance(ctx, balanceUserID)
+}
+
+// inReportingLine reports whether the requester is within the approver's manager scope.
+func (uc *advanceUseCase) inReportingLine(ctx context.Context, approverID int, requesterID int) (bool, error) {
+	approver, err := uc.userRepo.FindByID(ctx, approverID)
+	if err != nil || approver == nil {
+		return false, err
+	}
+
+	scope, err := uc.scope.ManagerScope(ctx, approver)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := scope[requesterID]
+	return ok, nil
+}
+
+// decide records an approver's decision on a requested advance.
+func (uc *advanceUseCase) decide(ctx context.Context, id int, approverID int, to domain.AdvanceStatus, notes string) error {
+	advance, err := uc.findAdvance(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if advance.Status != domain.AdvanceStatusRequested {
+		return domain.ErrInvalidAdvanceStatus
+	}
+
+	approver, err := uc.userRepo.FindByID(ctx, approverID)
+	if err != nil {
+		return err
+	}
+
+	if approver == nil || approver.ID == advance.UserID {
+		return domain.ErrUnauthorizedAction
+	}
+
+	if !approver.Role.IsFinance() {
+		scope, err := uc.scope.ManagerScope(ctx, approver)
+		if err != nil {
+			return err
+		}
+
+		if _, inScope := scope[advance.UserID]; !inScope {
+			return domain.ErrUnauthorizedAction
+		}
+	}
+
+	now := time.Now()
+	advance.ApproverID = &approverID
+	advance.DecisionNotes = notes
+	advance.DecidedAt = &now
+
+	return uc.transition(ctx, advance, to)
+}
+
+// transition moves the advance to its next status.
+func (uc *advanceUseCase) transition(ctx context.Context, advance *domain.CashAdvance, to domain.AdvanceStatus) error {
+	from := advance.Status
+	if !from.CanTransitionTo(to) {
+		return domain.ErrInvalidAdvanceStatus
+	}
+
+	advance.Status = to
+	if err := uc.advanceRepo.UpdateStatus(ctx, advance, from); err != nil {
+		advance.Status = from
+		return err
+	}
+
+	return nil
+}
+
+// findAdvance loads the advance with the given id.
+func (uc *advanceUseCase) findAdvance(ctx context.Context, id int) (*domain.CashAdvance, error) {
+	advance, err := uc.advanceRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if advance == nil {
+		return nil, domain.ErrAdvanceNotFound
+	}
+
+	return advance, nil
+}
+
+// ownAdvance loads the advance and checks that userID requested it.
+func (uc *advanceUseCase) ownAdvance(ctx context.Context, id int, userID int) (*domain.CashAdvance, error) {
+	advance, err := uc.findAdvance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if advance.UserID != userID {
+		return nil, domain.ErrUnauthorizedAction
+	}
+
+	return advance, nil
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/advance"
+	"github.com/evrintobing17/expense-management-backend/internal/approval/scope"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type useCaseMocks struct {
+	advance    *mocks.AdvanceRepository
+	expense    *mocks.ExpenseRepository
+	history    *mocks.HistoryRepository
+	user       *mocks.UserRepository
+	delegation *mocks.DelegationRepository
+	tx         *mocks.Transactor
+}
+
+func newUseCaseMocks() *useCaseMocks {
+	m := &useCaseMocks{
+		advance:    new(mocks.AdvanceRepository),
+		expense:    new(mocks.ExpenseRepository),
+		history:    new(mocks.HistoryRepository),
+		user:       new(mocks.UserRepository),
+		delegation: new(mocks.DelegationRepository),
+		tx:         new(mocks.Transactor),
+	}
+	m.tx.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}).Maybe()
+	m.history.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.delegation.On("FindActiveByDelegateID", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Delegation(nil), nil).Maybe()
+	return m
+}
+
+func (m *useCaseMocks) useCase() advance.AdvanceUseCase {
+	return NewAdvanceUseCase(m.advance, m.expense, m.history, m.user, scope.NewApproverScope(m.user, m.delegation, false), m.tx)
+}
+
+func (m *useCaseMocks) expectAdvance(advance *domain.CashAdvance) {
+	m.advance.On("FindByID", mock.Anything, advance.ID).Return(advance, nil)
+}
+
+func (m *useCaseMocks) expectApprover(id int, role domain.Role) {
+	m.user.On("FindByID", mock.Anything, id).Return(&domain.User{ID: id, Role: role}, nil)
+}
+
+func outstanding() *domain.CashAdvance {
+	return &domain.CashAdvance{ID: 4, UserID: 1, AmountIDR: 3000000, Status: domain.AdvanceStatusOutstanding}
+}
+
+func filed(id int, amount int, status domain.ExpenseStatus) *domain.Expense {
+	advanceID := 4
+	return &domain.Expense{ID: id, UserID: 1, AdvanceID: &advanceID, AmountIDR: amount, Status: status}
+}
+
+func TestRequestAdvance(t *testing.T) {
+	t.Run("stored as requested", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.advance.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		advance, err := m.useCase().RequestAdvance(context.Background(), &domain.CashAdvance{UserID: 1, Purpose: "Site survey in Kupang", AmountIDR: 3000000})
+		require.NoError(t, err)
+		require.Equal(t, domain.AdvanceStatusRequested, advance.Status)
+	})
+
+	t.Run("no amount", func(t *testing.T) {
+		m := newUseCaseMocks()
+
+		_, err := m.useCase().RequestAdvance(context.Background(), &domain.CashAdvance{UserID: 1, Purpose: "Site survey in Kupang"})
+		require.ErrorIs(t, err, domain.ErrInvalidAdvance)
+		m.advance.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetAdvance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("manager of the requester", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(outstanding())
+		m.expectApprover(2, domain.RoleManager)
+		m.user.On("FindReportIDs", mock.Anything, 2, false).Return([]int{1}, nil).Once()
+		m.expense.On("FindByAdvanceID", mock.Anything, 4).Return([]*domain.Expense{filed(7, 500000, domain.ExpenseStatusApproved)}, nil).Once()
+
+		advance, err := m.useCase().GetAdvance(ctx, 4, 2, domain.RoleManager)
+		require.NoError(t, err)
+		require.Len(t, advance.Expenses, 1)
+	})
+
+	t.Run("manager outside the reporting line", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(outstanding())
+		m.expectApprover(2, domain.RoleManager)
+		m.user.On("FindReportIDs", mock.Anything, 2, false).Return([]int{9}, nil).Once()
+
+		_, err := m.useCase().GetAdvance(ctx, 4, 2, domain.RoleManager)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		m.expense.AssertNotCalled(t, "FindByAdvanceID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("finance", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(outstanding())
+		m.expense.On("FindByAdvanceID", mock.Anything, 4).Return([]*domain.Expense(nil), nil).Once()
+
+		_, err := m.useCase().GetAdvance(ctx, 4, 3, domain.RoleCFO)
+		require.NoError(t, err)
+		m.user.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDecideAdvance(t *testing.T) {
+	requested := func() *domain.CashAdvance {
+		return &domain.CashAdvance{ID: 4, UserID: 1, AmountIDR: 3000000, Status: domain.AdvanceStatusRequested}
+	}
+
+	t.Run("manager approves their report's advance", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(requested())
+		m.expectApprover(2, domain.RoleManager)
+		m.user.On("FindReportIDs", mock.Anything, 2, false).Return([]int{1}, nil)
+		m.advance.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(a *domain.CashAdvance) bool {
+			return a.Status == domain.AdvanceStatusApproved && *a.ApproverID == 2 && a.DecidedAt != nil
+		}), domain.AdvanceStatusRequested).Return(nil)
+
+		require.NoError(t, m.useCase().ApproveAdvance(context.Background(), 4, 2, "ok"))
+	})
+
+	t.Run("manager outside the team", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(requested())
+		m.expectApprover(2, domain.RoleManager)
+		m.user.On("FindReportIDs", mock.Anything, 2, false).Return([]int{5}, nil)
+
+		require.ErrorIs(t, m.useCase().ApproveAdvance(context.Background(), 4, 2, ""), domain.ErrUnauthorizedAction)
+	})
+
+	t.Run("finance decides for anyone", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(requested())
+		m.expectApprover(3, domain.RoleFinanceDirector)
+		m.advance.On("UpdateStatus", mock.Anything, mock.Anything, domain.AdvanceStatusRequested).Return(nil)
+
+		require.NoError(t, m.useCase().RejectAdvance(context.Background(), 4, 3, "trip cancelled"))
+	})
+
+	t.Run("own advance", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(requested())
+		m.expectApprover(1, domain.RoleFinanceDirector)
+
+		require.ErrorIs(t, m.useCase().ApproveAdvance(context.Background(), 4, 1, ""), domain.ErrUnauthorizedAction)
+	})
+
+	t.Run("already decided", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(outstanding())
+
+		require.ErrorIs(t, m.useCase().ApproveAdvance(context.Background(), 4, 3, ""), domain.ErrInvalidAdvanceStatus)
+	})
+}
+
+func TestSettleAdvance(t *testing.T) {
+	t.Run("spent more than advanced", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(outstanding())
+		m.expense.On("FindByAdvanceID", mock.Anything, 4).Return([]*domain.Expense{
+			filed(11, 2000000, domain.ExpenseStatusApproved),
+			filed(12, 1500000, domain.ExpenseStatusAutoApproved),
+			filed(13, 900000, domain.ExpenseStatusRejected),
+		}, nil)
+		m.advance.On("Settle", mock.Anything, mock.Anything, mock.MatchedBy(func(expenses []*domain.Expense) bool {
+			return len(expenses) == 2
+		})).Return(nil)
+		m.expense.On("UpdateStatus", mock.Anything, 11, domain.ExpenseStatusApproved, domain.ExpenseStatusProcessing, (*time.Time)(nil)).Return(nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, 12, domain.ExpenseStatusAutoApproved, domain.ExpenseStatusProcessing, (*time.Time)(nil)).Return(nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, mock.Anything, domain.ExpenseStatusProcessing, domain.ExpenseStatusCompleted, mock.AnythingOfType("*time.Time")).Return(nil).Twice()
+
+		advance, err := m.useCase().SettleAdvance(context.Background(), 4, 1)
+		require.NoError(t, err)
+		require.Equal(t, domain.AdvanceStatusReimbursementDue, advance.Status)
+		require.Equal(t, 500000, advance.ReimbursementIDR)
+		require.Nil(t, advance.SettledAt)
+		require.Equal(t, domain.ExpenseStatusCompleted, advance.Expenses[0].Status)
+		require.NotNil(t, advance.Expenses[0].ProcessedAt)
+		require.Equal(t, domain.ExpenseStatusRejected, advance.Expenses[2].Status)
+		m.expense.AssertExpectations(t)
+		m.history.AssertNumberOfCalls(t, "Create", 4)
+		m.history.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.ExpenseID == 12 && *h.FromStatus == domain.ExpenseStatusAutoApproved && h.ToStatus == domain.ExpenseStatusProcessing
+		}))
+	})
+
+	t.Run("offset expense changed since loading", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(outstanding())
+		m.expense.On("FindByAdvanceID", mock.Anything, 4).Return([]*domain.Expense{
+			filed(11, 2000000, domain.ExpenseStatusApproved),
+		}, nil)
+		m.advance.On("Settle", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		m.expense.On("UpdateStatus", mock.Anything, 11, domain.ExpenseStatusApproved, domain.ExpenseStatusProcessing, (*time.Time)(nil)).
+			Return(domain.ErrStatusConflict).Once()
+
+		_, err := m.useCase().SettleAdvance(context.Background(), 4, 1)
+		require.ErrorIs(t, err, domain.ErrStatusConflict)
+		m.history.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("spent less than advanced", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(outstanding())
+		m.expense.On("FindByAdvanceID", mock.Anything, 4).Return([]*domain.Expense{
+			filed(11, 2200000, domain.ExpenseStatusApproved),
+		}, nil)
+		m.advance.On("Settle", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		m.expense.On("UpdateStatus", mock.Anything, 11, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		advance, err := m.useCase().SettleAdvance(context.Background(), 4, 1)
+		require.NoError(t, err)
+		require.Equal(t, domain.AdvanceStatusRepaymentDue, advance.Status)
+		require.Equal(t, 800000, advance.RepaymentIDR)
+	})
+
+	t.Run("expense still awaiting approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(outstanding())
+		m.expense.On("FindByAdvanceID", mock.Anything, 4).Return([]*domain.Expense{
+			filed(11, 2200000, domain.ExpenseStatusApproved),
+			filed(12, 300000, domain.ExpenseStatusAwaitingApproval),
+		}, nil)
+
+		_, err := m.useCase().SettleAdvance(context.Background(), 4, 1)
+		require.ErrorIs(t, err, domain.ErrAdvanceExpensesPending)
+		m.advance.AssertNotCalled(t, "Settle", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("not paid out yet", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(&domain.CashAdvance{ID: 4, UserID: 1, AmountIDR: 3000000, Status: domain.AdvanceStatusApproved})
+
+		_, err := m.useCase().SettleAdvance(context.Background(), 4, 1)
+		require.ErrorIs(t, err, domain.ErrInvalidAdvanceStatus)
+	})
+
+	t.Run("someone else's advance", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectAdvance(outstanding())
+
+		_, err := m.useCase().SettleAdvance(context.Background(), 4, 2)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+	})
+}
+
+func TestRecordRepayment(t *testing.T) {
+	m := newUseCaseMocks()
+	m.expectAdvance(&domain.CashAdvance{ID: 4, UserID: 1, Status: domain.AdvanceStatusRepaymentDue, RepaymentIDR: 800000})
+	m.advance.On("UpdateStatus", mock.Anything, mock.Anything, domain.AdvanceStatusRepaymentDue).Return(nil)
+
+	advance, err := m.useCase().RecordRepayment(context.Background(), 4)
+	require.NoError(t, err)
+	require.Equal(t, domain.AdvanceStatusSettled, advance.Status)
+	require.NotNil(t, advance.SettledAt)
+
+	m = newUseCaseMocks()
+	m.expectAdvance(outstanding())
+
+	_, err = m.useCase().RecordRepayment(context.Background(), 4)
+	require.ErrorIs(t, err, domain.ErrInvalidAdvanceStatus)
+}
+
+func TestGetAdvanceBalance(t *testing.T) {
+	m := newUseCaseMocks()
+	m.advance.On("Balance", mock.Anything, 1).Return(&domain.AdvanceBalance{UserID: 1, BalanceIDR: 3000000}, nil)
+
+	balance, err := m.useCase().GetBalance(context.Background(), 1, 1, domain.RoleEmployee)
+	require.NoError(t, err)
+	require.Equal(t, 3000000, balance.BalanceIDR)
+
+	_, err = m.useCase().GetBalance(context.Background(), 1, 3, domain.RoleCFO)
+	require.NoError(t, err)
+
+	_, err = m.useCase().GetBalance(context.Background(), 1, 2, domain.RoleManager)
+	require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+}
@@ -0,0 +1,12 @@
+package allocation
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+// AllocationChecker validates how an expense is split across cost centers and projects.
+type AllocationChecker interface {
+	Check(ctx context.Context, expense *domain.Expense) error
+}
@@ -0,0 +1,12 @@
+package allocation
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type AllocationRepository interface {
+	Replace(ctx context.Context, expenseID int, allocations []*domain.Allocation) error
+	FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.Allocation, error)
+}
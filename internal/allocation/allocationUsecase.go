@@ -0,0 +1,18 @@
+package allocation
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type AllocationUseCase interface {
+	CreateCostCenter(ctx context.Context, costCenter *domain.CostCenter) (*domain.CostCenter, error)
+	ListCostCenters(ctx context.Context, includeInactive bool) ([]*domain.CostCenter, error)
+	UpdateCostCenter(ctx context.Context, costCenter *domain.CostCenter) (*domain.CostCenter, error)
+	DeactivateCostCenter(ctx context.Context, id int) error
+	CreateProject(ctx context.Context, project *domain.Project) (*domain.Project, error)
+	ListProjects(ctx context.Context, includeInactive bool) ([]*domain.Project, error)
+	UpdateProject(ctx context.Context, project *domain.Project) (*domain.Project, error)
+	DeactivateProject(ctx context.Context, id int) error
+}
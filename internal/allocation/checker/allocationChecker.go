@@ -0,0 +1,52 @@
+package checker
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/allocation"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type allocationChecker struct {
+	costCenterRepo allocation.CostCenterRepository
+	projectRepo    allocation.ProjectRepository
+}
+
+func NewAllocationChecker(costCenterRepo allocation.CostCenterRepository, projectRepo allocation.ProjectRepository) allocation.AllocationChecker {
+	return &allocationChecker{
+		costCenterRepo: costCenterRepo,
+		projectRepo:    projectRepo,
+	}
+}
+
+// Check resolves the expense's allocations and makes sure every cost center and project
+// they name exists and is active.
+func (c *allocationChecker) Check(ctx context.Context, expense *domain.Expense) error {
+	if err := expense.ResolveAllocations(); err != nil {
+		return err
+	}
+
+	for _, a := range expense.Allocations {
+		costCenter, err := c.costCenterRepo.FindByCode(ctx, a.CostCenterCode)
+		if err != nil {
+			return err
+		}
+		if costCenter == nil || !costCenter.Active {
+			return &domain.AllocationCodeError{Kind: "cost center", Code: a.CostCenterCode}
+		}
+
+		if a.ProjectCode == "" {
+			continue
+		}
+
+		project, err := c.projectRepo.FindByCode(ctx, a.ProjectCode)
+		if err != nil {
+			return err
+		}
+		if project == nil || !project.Active {
+			return &domain.AllocationCodeError{Kind: "project", Code: a.ProjectCode}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package checker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationCheckerCheck(t *testing.T) {
+	ctx := context.Background()
+	finance := &domain.CostCenter{ID: 4, Code: "FIN", Active: true}
+	project := &domain.Project{ID: 2, Code: "PRJ-01", Active: true}
+
+	t.Run("unallocated expense", func(t *testing.T) {
+		costCenterRepo := new(mocks.CostCenterRepository)
+		checker := NewAllocationChecker(costCenterRepo, new(mocks.ProjectRepository))
+
+		require.NoError(t, checker.Check(ctx, &domain.Expense{AmountIDR: 100000}))
+		costCenterRepo.AssertNotCalled(t, "FindByCode", mock.Anything, mock.Anything)
+	})
+
+	t.Run("active codes", func(t *testing.T) {
+		costCenterRepo := new(mocks.CostCenterRepository)
+		projectRepo := new(mocks.ProjectRepository)
+		checker := NewAllocationChecker(costCenterRepo, projectRepo)
+		costCenterRepo.On("FindByCode", mock.Anything, "FIN").Return(finance, nil).Twice()
+		projectRepo.On("FindByCode", mock.Anything, "PRJ-01").Return(project, nil).Once()
+
+		expense := &domain.Expense{AmountIDR: 100000, Allocations: []*domain.Allocation{
+			{CostCenterCode: "fin", Percent: 70},
+			{CostCenterCode: "FIN", ProjectCode: "prj-01", Percent: 30},
+		}}
+		require.NoError(t, checker.Check(ctx, expense))
+		require.Equal(t, 70000, expense.Allocations[0].AmountIDR)
+		require.Equal(t, 30000, expense.Allocations[1].AmountIDR)
+		costCenterRepo.AssertExpectations(t)
+		projectRepo.AssertExpectations(t)
+	})
+
+	t.Run("inactive cost center", func(t *testing.T) {
+		costCenterRepo := new(mocks.CostCenterRepository)
+		checker := NewAllocationChecker(costCenterRepo, new(mocks.ProjectRepository))
+		costCenterRepo.On("FindByCode", mock.Anything, "MKT").Return(&domain.CostCenter{Code: "MKT"}, nil).Once()
+
+		expense := &domain.Expense{AmountIDR: 100000, Allocations: []*domain.Allocation{
+			{CostCenterCode: "MKT", AmountIDR: 100000},
+		}}
+		err := checker.Check(ctx, expense)
+		require.ErrorIs(t, err, domain.ErrInactiveAllocationCode)
+		require.EqualError(t, err, "cost center MKT does not exist or is not active")
+	})
+
+	t.Run("unknown project", func(t *testing.T) {
+		costCenterRepo := new(mocks.CostCenterRepository)
+		projectRepo := new(mocks.ProjectRepository)
+		checker := NewAllocationChecker(costCenterRepo, projectRepo)
+		costCenterRepo.On("FindByCode", mock.Anything, "FIN").Return(finance, nil).Once()
+		projectRepo.On("FindByCode", mock.Anything, "PRJ-99").Return((*domain.Project)(nil), nil).Once()
+
+		expense := &domain.Expense{AmountIDR: 100000, Allocations: []*domain.Allocation{
+			{CostCenterCode: "FIN", ProjectCode: "PRJ-99", AmountIDR: 100000},
+		}}
+		require.ErrorIs(t, checker.Check(ctx, expense), domain.ErrInactiveAllocationCode)
+	})
+
+	t.Run("split does not add up", func(t *testing.T) {
+		costCenterRepo := new(mocks.CostCenterRepository)
+		checker := NewAllocationChecker(costCenterRepo, new(mocks.ProjectRepository))
+
+		expense := &domain.Expense{AmountIDR: 100000, Allocations: []*domain.Allocation{
+			{CostCenterCode: "FIN", AmountIDR: 60000},
+		}}
+		require.ErrorIs(t, checker.Check(ctx, expense), domain.ErrInvalidAllocation)
+		costCenterRepo.AssertNotCalled(t, "FindByCode", mock.Anything, mock.Anything)
+	})
+}
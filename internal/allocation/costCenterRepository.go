@@ -0,0 +1,15 @@
+package allocation
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type CostCenterRepository interface {
+	Create(ctx context.Context, costCenter *domain.CostCenter) error
+	FindByID(ctx context.Context, id int) (*domain.CostCenter, error)
+	FindByCode(ctx context.Context, code string) (*domain.CostCenter, error)
+	FindAll(ctx context.Context, includeInactive bool) ([]*domain.CostCenter, error)
+	Update(ctx context.Context, costCenter *domain.CostCenter) error
+}
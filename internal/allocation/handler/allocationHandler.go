@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/evrintobing17/expense-management-backend/internal/allocation"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/gorilla/mux"
+)
+
+type AllocationHandler struct {
+	allocationUseCase allocation.AllocationUseCase
+}
+
+func NewAllocationHandler(allocationUseCase allocation.AllocationUseCase) *AllocationHandler {
+	return &AllocationHandler{allocationUseCase: allocationUseCase}
+}
+
+type costCenterRequest struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Active *bool  `json:"active"`
+}
+
+type projectRequest struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	ClientName string `json:"client_name"`
+	Active     *bool  `json:"active"`
+}
+
+func (h *AllocationHandler) CreateCostCenter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req costCenterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	costCenter, err := h.allocationUseCase.CreateCostCenter(ctx, &domain.CostCenter{
+		Code: req.Code,
+		Name: req.Name,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(costCenter)
+}
+
+// ListCostCenters returns the active cost centers. Admins can pass include_inactive=true
+// to see deactivated ones as well.
+func (h *AllocationHandler) ListCostCenters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	costCenters, err := h.allocationUseCase.ListCostCenters(ctx, includeInactive(r))
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(costCenters)
+}
+
+func (h *AllocationHandler) UpdateCostCenter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid cost center ID", http.StatusBadRequest)
+		return
+	}
+
+	var req costCenterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	costCenter, err := h.allocationUseCase.UpdateCostCenter(ctx, &domain.CostCenter{
+		ID:     id,
+		Code:   req.Code,
+		Name:   req.Name,
+		Active: active,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(costCenter)
+}
+
+// DeleteCostCenter deactivates the cost center; expenses already allocated to it keep it.
+func (h *AllocationHandler) DeleteCostCenter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid cost center ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.allocationUseCase.DeactivateCostCenter(ctx, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AllocationHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req projectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	project, err := h.allocationUseCase.CreateProject(ctx, &domain.Project{
+		Code:       req.Code,
+		Name:       req.Name,
+		ClientName: req.ClientName,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(project)
+}
+
+// ListProjects returns the active projects. Admins can pass include_inactive=true to see
+// deactivated ones as well.
+func (h *AllocationHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projects, err := h.allocationUseCase.ListProjects(ctx, includeInactive(r))
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(projects)
+}
+
+func (h *AllocationHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	var req projectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	project, err := h.allocationUseCase.UpdateProject(ctx, &domain.Project{
+		ID:         id,
+		Code:       req.Code,
+		Name:       req.Name,
+		ClientName: req.ClientName,
+		Active:     active,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(project)
+}
+
+// DeleteProject deactivates the project; expenses already allocated to it keep it.
+func (h *AllocationHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.allocationUseCase.DeactivateProject(ctx, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func includeInactive(r *http.Request) bool {
+	role, _ := middleware.GetUserRoleFromContext(r.Context())
+	return role == domain.RoleAdmin && r.URL.Query().Get("include_inactive") == "true"
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCostCenter), errors.Is(err, domain.ErrInvalidProject):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrCostCenterNotFound):
+		http.Error(w, "Cost center not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrProjectNotFound):
+		http.Error(w, "Project not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrDuplicateCostCenter), errors.Is(err, domain.ErrDuplicateProject):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func withRole(req *http.Request, userID int, role domain.Role) *http.Request {
+	mockAuth := new(mocks.AuthService)
+	mockAuth.On("ValidateToken", mock.Anything, "t").Return(userID, role, nil).Maybe()
+	rr := httptest.NewRecorder()
+	next := middleware.AuthMiddleware(mockAuth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+	}))
+	req.Header.Set("Authorization", "Bearer t")
+	next.ServeHTTP(rr, req)
+	return req
+}
+
+func TestAllocationHandlerCreateCostCenter(t *testing.T) {
+	t.Run("invalid cost center", func(t *testing.T) {
+		mockUC := new(mocks.AllocationUseCase)
+		h := NewAllocationHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/cost-centers", strings.NewReader(`{"code":"FIN"}`))
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateCostCenter", mock.Anything, mock.AnythingOfType("*domain.CostCenter")).Return((*domain.CostCenter)(nil), domain.ErrInvalidCostCenter).Once()
+
+		h.CreateCostCenter(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		mockUC := new(mocks.AllocationUseCase)
+		h := NewAllocationHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/cost-centers", strings.NewReader(`{"code":"FIN","name":"Finance"}`))
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateCostCenter", mock.Anything, mock.AnythingOfType("*domain.CostCenter")).Return((*domain.CostCenter)(nil), domain.ErrDuplicateCostCenter).Once()
+
+		h.CreateCostCenter(rr, req)
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.AllocationUseCase)
+		h := NewAllocationHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/cost-centers", strings.NewReader(`{"code":"FIN","name":"Finance"}`))
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateCostCenter", mock.Anything, mock.MatchedBy(func(c *domain.CostCenter) bool {
+			return c.Code == "FIN" && c.Name == "Finance"
+		})).Return(&domain.CostCenter{ID: 4, Code: "FIN", Name: "Finance", Active: true}, nil).Once()
+
+		h.CreateCostCenter(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.Contains(t, rr.Body.String(), `"id":4`)
+	})
+}
+
+func TestAllocationHandlerListProjects(t *testing.T) {
+	t.Run("employees only see active projects", func(t *testing.T) {
+		mockUC := new(mocks.AllocationUseCase)
+		h := NewAllocationHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/projects?include_inactive=true", nil)
+		req = withRole(req, 1, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+		mockUC.On("ListProjects", mock.Anything, false).Return([]*domain.Project{{ID: 2, Code: "PRJ-01"}}, nil).Once()
+
+		h.ListProjects(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("admins can include inactive projects", func(t *testing.T) {
+		mockUC := new(mocks.AllocationUseCase)
+		h := NewAllocationHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/projects?include_inactive=true", nil)
+		req = withRole(req, 1, domain.RoleAdmin)
+		rr := httptest.NewRecorder()
+		mockUC.On("ListProjects", mock.Anything, true).Return([]*domain.Project{}, nil).Once()
+
+		h.ListProjects(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		mockUC.AssertExpectations(t)
+	})
+}
+
+func TestAllocationHandlerDeleteProject(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		mockUC := new(mocks.AllocationUseCase)
+		h := NewAllocationHandler(mockUC)
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/projects/9", nil), map[string]string{"id": "9"})
+		rr := httptest.NewRecorder()
+		mockUC.On("DeactivateProject", mock.Anything, 9).Return(domain.ErrProjectNotFound).Once()
+
+		h.DeleteProject(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.AllocationUseCase)
+		h := NewAllocationHandler(mockUC)
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/projects/2", nil), map[string]string{"id": "2"})
+		rr := httptest.NewRecorder()
+		mockUC.On("DeactivateProject", mock.Anything, 2).Return(nil).Once()
+
+		h.DeleteProject(rr, req)
+		require.Equal(t, http.StatusNoContent, rr.Code)
+	})
+}
@@ -0,0 +1,15 @@
+package allocation
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type ProjectRepository interface {
+	Create(ctx context.Context, project *domain.Project) error
+	FindByID(ctx context.Context, id int) (*domain.Project, error)
+	FindByCode(ctx context.Context, code string) (*domain.Project, error)
+	FindAll(ctx context.Context, includeInactive bool) ([]*domain.Project, error)
+	Update(ctx context.Context, project *domain.Project) error
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/allocation"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+	"github.com/lib/pq"
+)
+
+type allocationRepository struct {
+	db *sql.DB
+}
+
+func NewAllocationRepository(db *sql.DB) allocation.AllocationRepository {
+	return &allocationRepository{db: db}
+}
+
+// Replace swaps the expense's allocations for the given ones. Cost center and project
+// codes are stored as they are, so renaming a cost center does not rewrite history.
+func (r *allocationRepository) Replace(ctx context.Context, expenseID int, allocations []*domain.Allocation) error {
+	return database.WithinTx(ctx, r.db, func(ctx context.Context) error {
+		return r.replace(ctx, expenseID, allocations)
+	})
+}
+
+func (r *allocationRepository) replace(ctx context.Context, expenseID int, allocations []*domain.Allocation) error {
+	tx := database.Conn(ctx, r.db)
+
+	_, err := tx.ExecContext(ctx, `DELETE FROM expense_allocations WHERE expense_id = $1`, expenseID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO expense_allocations (expense_id, cost_center_code, project_code, percent, amount_idr)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	for _, a := range allocations {
+		_, err = tx.ExecContext(ctx, query, expenseID, a.CostCenterCode, a.ProjectCode, a.Percent, a.AmountIDR)
+		if err != nil {
+			return err
+		}
+		a.ExpenseID = expenseID
+	}
+
+	return nil
+}
+
+// FindByExpenseIDs returns the allocations keyed by expense id. Unallocated expenses are
+// left out of the map.
+func (r *allocationRepository) FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.Allocation, error) {
+	query := `
+		SELECT expense_id, cost_center_code, project_code, percent, amount_idr
+		FROM expense_allocations
+		WHERE expense_id = ANY($1)
+		ORDER BY expense_id ASC, id ASC
+	`
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, pq.Array(expenseIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allocations := make(map[int][]*domain.Allocation)
+	for rows.Next() {
+		a := &domain.Allocation{}
+		err := rows.Scan(
+			&a.ExpenseID,
+			&a.CostCenterCode,
+			&a.ProjectCode,
+			&a.Percent,
+			&a.AmountIDR,
+		)
+		if err != nil {
+			return nil, err
+		}
+		allocations[a.ExpenseID] = append(allocations[a.ExpenseID], a)
+	}
+
+	return allocations, rows.Err()
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationRepositoryReplace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &allocationRepository{db: db}
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM expense_allocations WHERE expense_id = $1`)
+	insertQuery := regexp.QuoteMeta(`
+		INSERT INTO expense_allocations (expense_id, cost_center_code, project_code, percent, amount_idr)
+		VALUES ($1, $2, $3, $4, $5)
+	`)
+	allocations := []*domain.Allocation{
+		{CostCenterCode: "FIN", Percent: 60, AmountIDR: 60000},
+		{CostCenterCode: "MKT", ProjectCode: "PRJ-01", Percent: 40, AmountIDR: 40000},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(insertQuery).WithArgs(10, "FIN", "", 60.0, 60000).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(insertQuery).WithArgs(10, "MKT", "PRJ-01", 40.0, 40000).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Replace(context.Background(), 10, allocations))
+		require.Equal(t, 10, allocations[1].ExpenseID)
+	})
+
+	t.Run("clearing", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Replace(context.Background(), 10, nil))
+	})
+
+	t.Run("insert fails", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(insertQuery).WillReturnError(errors.New("insert failed"))
+		mock.ExpectRollback()
+
+		require.Error(t, repo.Replace(context.Background(), 10, allocations))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAllocationRepositoryFindByExpenseIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &allocationRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT expense_id, cost_center_code, project_code, percent, amount_idr
+		FROM expense_allocations
+		WHERE expense_id = ANY($1)
+		ORDER BY expense_id ASC, id ASC
+	`)
+	mock.ExpectQuery(query).WithArgs(pq.Array([]int{10, 11})).
+		WillReturnRows(sqlmock.NewRows([]string{"expense_id", "cost_center_code", "project_code", "percent", "amount_idr"}).
+			AddRow(10, "FIN", "", 60.0, 60000).
+			AddRow(10, "MKT", "PRJ-01", 40.0, 40000))
+
+	allocations, findErr := repo.FindByExpenseIDs(context.Background(), []int{10, 11})
+	require.NoError(t, findErr)
+	require.Len(t, allocations[10], 2)
+	require.Equal(t, "PRJ-01", allocations[10][1].ProjectCode)
+	require.Empty(t, allocations[11])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/allocation"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+)
+
+const uniqueViolation = "23505"
+
+type costCenterRepository struct {
+	db *sql.DB
+}
+
+func NewCostCenterRepository(db *sql.DB) allocation.CostCenterRepository {
+	return &costCenterRepository{db: db}
+}
+
+func (r *costCenterRepository) Create(ctx context.Context, costCenter *domain.CostCenter) error {
+	query := `
+		INSERT INTO cost_centers (code, name, active)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		costCenter.Code,
+		costCenter.Name,
+		costCenter.Active,
+	).Scan(&costCenter.ID, &costCenter.CreatedAt, &costCenter.UpdatedAt)
+
+	return duplicateCode(err, domain.ErrDuplicateCostCenter)
+}
+
+func (r *costCenterRepository) FindByID(ctx context.Context, id int) (*domain.CostCenter, error) {
+	query := `
+		SELECT id, code, name, active, created_at, updated_at
+		FROM cost_centers
+		WHERE id = $1
+	`
+
+	return r.findOne(ctx, query, id)
+}
+
+func (r *costCenterRepository) FindByCode(ctx context.Context, code string) (*domain.CostCenter, error) {
+	query := `
+		SELECT id, code, name, active, created_at, updated_at
+		FROM cost_centers
+		WHERE code = $1
+	`
+
+	return r.findOne(ctx, query, code)
+}
+
+func (r *costCenterRepository) findOne(ctx context.Context, query string, arg interface{}) (*domain.CostCenter, error) {
+	costCenter := &domain.CostCenter{}
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&costCenter.ID,
+		&costCenter.Code,
+		&costCenter.Name,
+		&costCenter.Active,
+		&costCenter.CreatedAt,
+		&costCenter.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return costCenter, nil
+}
+
+func (r *costCenterRepository) FindAll(ctx context.Context, includeInactive bool) ([]*domain.CostCenter, error) {
+	query := `
+		SELECT id, code, name, active, created_at, updated_at
+		FROM cost_centers
+		WHERE active = true OR $1
+		ORDER BY code ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var costCenters []*domain.CostCenter
+	for rows.Next() {
+		costCenter := &domain.CostCenter{}
+		err := rows.Scan(
+			&costCenter.ID,
+			&costCenter.Code,
+			&costCenter.Name,
+			&costCenter.Active,
+			&costCenter.CreatedAt,
+			&costCenter.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		costCenters = append(costCenters, costCenter)
+	}
+
+	return costCenters, rows.Err()
+}
+
+// Update overwrites the stored cost center and returns domain.ErrCostCenterNotFound when
+// no row has its id.
+func (r *costCenterRepository) Update(ctx context.Context, costCenter *domain.CostCenter) error {
+	query := `
+		UPDATE cost_centers
+		SET code = $1, name = $2, active = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		costCenter.Code,
+		costCenter.Name,
+		costCenter.Active,
+		costCenter.ID,
+	).Scan(&costCenter.CreatedAt, &costCenter.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domain.ErrCostCenterNotFound
+	}
+
+	return duplicateCode(err, domain.ErrDuplicateCostCenter)
+}
+
+// duplicateCode turns the unique code violation into duplicate.
+func duplicateCode(err error, duplicate error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+		return duplicate
+	}
+	return err
+}
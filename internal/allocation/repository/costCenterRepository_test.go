@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var costCenterColumns = []string{"id", "code", "name", "active", "created_at", "updated_at"}
+
+func TestCostCenterRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &costCenterRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO cost_centers (code, name, active)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`)
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		costCenter := &domain.CostCenter{Code: "FIN", Name: "Finance", Active: true}
+		rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(4, now, now)
+		mock.ExpectQuery(query).WithArgs("FIN", "Finance", true).WillReturnRows(rows)
+
+		require.NoError(t, repo.Create(context.Background(), costCenter))
+		require.Equal(t, 4, costCenter.ID)
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		mock.ExpectQuery(query).WillReturnError(&pq.Error{Code: uniqueViolation})
+
+		createErr := repo.Create(context.Background(), &domain.CostCenter{Code: "FIN", Name: "Finance"})
+		require.ErrorIs(t, createErr, domain.ErrDuplicateCostCenter)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCostCenterRepositoryFind(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &costCenterRepository{db: db}
+	now := time.Now()
+
+	byCode := regexp.QuoteMeta(`
+		SELECT id, code, name, active, created_at, updated_at
+		FROM cost_centers
+		WHERE code = $1
+	`)
+	mock.ExpectQuery(byCode).WithArgs("FIN").
+		WillReturnRows(sqlmock.NewRows(costCenterColumns).AddRow(4, "FIN", "Finance", true, now, now))
+	costCenter, findErr := repo.FindByCode(context.Background(), "FIN")
+	require.NoError(t, findErr)
+	require.Equal(t, "Finance", costCenter.Name)
+
+	mock.ExpectQuery(byCode).WithArgs("OPS").WillReturnError(sql.ErrNoRows)
+	costCenter, findErr = repo.FindByCode(context.Background(), "OPS")
+	require.NoError(t, findErr)
+	require.Nil(t, costCenter)
+
+	all := regexp.QuoteMeta(`
+		SELECT id, code, name, active, created_at, updated_at
+		FROM cost_centers
+		WHERE active = true OR $1
+		ORDER BY code ASC
+	`)
+	mock.ExpectQuery(all).WithArgs(true).
+		WillReturnRows(sqlmock.NewRows(costCenterColumns).
+			AddRow(4, "FIN", "Finance", true, now, now).
+			AddRow(5, "MKT", "Marketing", false, now, now))
+	costCenters, findErr := repo.FindAll(context.Background(), true)
+	require.NoError(t, findErr)
+	require.Len(t, costCenters, 2)
+	require.False(t, costCenters[1].Active)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCostCenterRepositoryUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &costCenterRepository{db: db}
+	query := regexp.QuoteMeta(`
+		UPDATE cost_centers
+		SET code = $1, name = $2, active = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+		RETURNING created_at, updated_at
+	`)
+	now := time.Now()
+	costCenter := &domain.CostCenter{ID: 4, Code: "FIN", Name: "Finance & Tax"}
+
+	mock.ExpectQuery(query).WithArgs("FIN", "Finance & Tax", false, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	require.NoError(t, repo.Update(context.Background(), costCenter))
+	require.Equal(t, now, costCenter.UpdatedAt)
+
+	mock.ExpectQuery(query).WithArgs("FIN", "Finance & Tax", false, 4).WillReturnError(sql.ErrNoRows)
+	require.ErrorIs(t, repo.Update(context.Background(), costCenter), domain.ErrCostCenterNotFound)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
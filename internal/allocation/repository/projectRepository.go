@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/allocation"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type projectRepository struct {
+	db *sql.DB
+}
+
+func NewProjectRepository(db *sql.DB) allocation.ProjectRepository {
+	return &projectRepository{db: db}
+}
+
+func (r *projectRepository) Create(ctx context.Context, project *domain.Project) error {
+	query := `
+		INSERT INTO projects (code, name, client_name, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		project.Code,
+		project.Name,
+		project.ClientName,
+		project.Active,
+	).Scan(&project.ID, &project.CreatedAt, &project.UpdatedAt)
+
+	return duplicateCode(err, domain.ErrDuplicateProject)
+}
+
+func (r *projectRepository) FindByID(ctx context.Context, id int) (*domain.Project, error) {
+	query := `
+		SELECT id, code, name, client_name, active, created_at, updated_at
+		FROM projects
+		WHERE id = $1
+	`
+
+	return r.findOne(ctx, query, id)
+}
+
+func (r *projectRepository) FindByCode(ctx context.Context, code string) (*domain.Project, error) {
+	query := `
+		SELECT id, code, name, client_name, active, created_at, updated_at
+		FROM projects
+		WHERE code = $1
+	`
+
+	return r.findOne(ctx, query, code)
+}
+
+func (r *projectRepository) findOne(ctx context.Context, query string, arg interface{}) (*domain.Project, error) {
+	project := &domain.Project{}
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&project.ID,
+		&project.Code,
+		&project.Name,
+		&project.ClientName,
+		&project.Active,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (r *projectRepository) FindAll(ctx context.Context, includeInactive bool) ([]*domain.Project, error) {
+	query := `
+		SELECT id, code, name, client_name, active, created_at, updated_at
+		FROM projects
+		WHERE active = true OR $1
+		ORDER BY code ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*domain.Project
+	for rows.Next() {
+		project := &domain.Project{}
+		err := rows.Scan(
+			&project.ID,
+			&project.Code,
+			&project.Name,
+			&project.ClientName,
+			&project.Active,
+			&project.CreatedAt,
+			&project.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+// Update overwrites the stored project and returns domain.ErrProjectNotFound when no row
+// has its id.
+func (r *projectRepository) Update(ctx context.Context, project *domain.Project) error {
+	query := `
+		UPDATE projects
+		SET code = $1, name = $2, client_name = $3, active = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $5
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		project.Code,
+		project.Name,
+		project.ClientName,
+		project.Active,
+		project.ID,
+	).Scan(&project.CreatedAt, &project.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domain.ErrProjectNotFound
+	}
+
+	return duplicateCode(err, domain.ErrDuplicateProject)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &projectRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO projects (code, name, client_name, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`)
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		project := &domain.Project{Code: "PRJ-01", Name: "Core banking", ClientName: "Bank Nusantara", Active: true}
+		rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(2, now, now)
+		mock.ExpectQuery(query).WithArgs("PRJ-01", "Core banking", "Bank Nusantara", true).WillReturnRows(rows)
+
+		require.NoError(t, repo.Create(context.Background(), project))
+		require.Equal(t, 2, project.ID)
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		mock.ExpectQuery(query).WillReturnError(&pq.Error{Code: uniqueViolation})
+
+		createErr := repo.Create(context.Background(), &domain.Project{Code: "PRJ-01", Name: "Core banking"})
+		require.ErrorIs(t, createErr, domain.ErrDuplicateProject)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProjectRepositoryFindAndUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &projectRepository{db: db}
+	now := time.Now()
+
+	byID := regexp.QuoteMeta(`
+		SELECT id, code, name, client_name, active, created_at, updated_at
+		FROM projects
+		WHERE id = $1
+	`)
+	mock.ExpectQuery(byID).WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "client_name", "active", "created_at", "updated_at"}).
+			AddRow(2, "PRJ-01", "Core banking", "Bank Nusantara", true, now, now))
+	project, findErr := repo.FindByID(context.Background(), 2)
+	require.NoError(t, findErr)
+	require.Equal(t, "Bank Nusantara", project.ClientName)
+
+	update := regexp.QuoteMeta(`
+		UPDATE projects
+		SET code = $1, name = $2, client_name = $3, active = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $5
+		RETURNING created_at, updated_at
+	`)
+	project.Active = false
+	mock.ExpectQuery(update).WithArgs("PRJ-01", "Core banking", "Bank Nusantara", false, 2).WillReturnError(sql.ErrNoRows)
+	require.ErrorIs(t, repo.Update(context.Background(), project), domain.ErrProjectNotFound)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/allocation"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type allocationUseCase struct {
+	costCenterRepo allocation.CostCenterRepository
+	projectRepo    allocation.ProjectRepository
+}
+
+func NewAllocationUseCase(costCenterRepo allocation.CostCenterRepository, projectRepo allocation.ProjectRepository) allocation.AllocationUseCase {
+	return &allocationUseCase{
+		costCenterRepo: costCenterRepo,
+		projectRepo:    projectRepo,
+	}
+}
+
+// CreateCostCenter adds an active cost center.
+func (uc *allocationUseCase) CreateCostCenter(ctx context.Context, costCenter *domain.CostCenter) (*domain.CostCenter, error) {
+	if err := costCenter.Validate(); err != nil {
+		return nil, err
+	}
+
+	costCenter.Active = true
+	err := uc.costCenterRepo.Create(ctx, costCenter)
+	if err != nil {
+		return nil, err
+	}
+
+	return costCenter, nil
+}
+
+func (uc *allocationUseCase) ListCostCenters(ctx context.Context, includeInactive bool) ([]*domain.CostCenter, error) {
+	return uc.costCenterRepo.FindAll(ctx, includeInactive)
+}
+
+// UpdateCostCenter replaces a cost center's definition. Allocations already recorded keep
+// the code they were made with.
+func (uc *allocationUseCase) UpdateCostCenter(ctx context.Context, costCenter *domain.CostCenter) (*domain.CostCenter, error) {
+	if err := costCenter.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := uc.costCenterRepo.Update(ctx, costCenter)
+	if err != nil {
+		return nil, err
+	}
+
+	return costCenter, nil
+}
+
+// DeactivateCostCenter stops new allocations to a cost center. Existing ones keep it.
+func (uc *allocationUseCase) DeactivateCostCenter(ctx context.Context, id int) error {
+	costCenter, err := uc.costCenterRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if costCenter == nil {
+		return domain.ErrCostCenterNotFound
+	}
+
+	if !costCenter.Active {
+		return nil
+	}
+
+	costCenter.Active = false
+	return uc.costCenterRepo.Update(ctx, costCenter)
+}
+
+// CreateProject adds an active project.
+func (uc *allocationUseCase) CreateProject(ctx context.Context, project *domain.Project) (*domain.Project, error) {
+	if err := project.Validate(); err != nil {
+		return nil, err
+	}
+
+	project.Active = true
+	err := uc.projectRepo.Create(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (uc *allocationUseCase) ListProjects(ctx context.Context, includeInactive bool) ([]*domain.Project, error) {
+	return uc.projectRepo.FindAll(ctx, includeInactive)
+}
+
+func (uc *allocationUseCase) UpdateProject(ctx context.Context, project *domain.Project) (*domain.Project, error) {
+	if err := project.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := uc.projectRepo.Update(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// DeactivateProject stops new allocations to a project, typically once it has closed.
+func (uc *allocationUseCase) DeactivateProject(ctx context.Context, id int) error {
+	project, err := uc.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if project == nil {
+		return domain.ErrProjectNotFound
+	}
+
+	if !project.Active {
+		return nil
+	}
+
+	project.Active = false
+	return uc.projectRepo.Update(ctx, project)
+}
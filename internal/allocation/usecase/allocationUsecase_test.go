@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCostCenter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		costCenterRepo := new(mocks.CostCenterRepository)
+		uc := NewAllocationUseCase(costCenterRepo, new(mocks.ProjectRepository))
+		costCenterRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.CostCenter) bool {
+			return c.Code == "FIN" && c.Active
+		})).Return(nil).Once()
+
+		result, err := uc.CreateCostCenter(ctx, &domain.CostCenter{Code: " fin ", Name: "Finance"})
+		require.NoError(t, err)
+		require.Equal(t, "FIN", result.Code)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		costCenterRepo := new(mocks.CostCenterRepository)
+		uc := NewAllocationUseCase(costCenterRepo, new(mocks.ProjectRepository))
+
+		_, err := uc.CreateCostCenter(ctx, &domain.CostCenter{Code: "FIN"})
+		require.ErrorIs(t, err, domain.ErrInvalidCostCenter)
+		costCenterRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeactivateCostCenter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("active cost center", func(t *testing.T) {
+		costCenterRepo := new(mocks.CostCenterRepository)
+		uc := NewAllocationUseCase(costCenterRepo, new(mocks.ProjectRepository))
+		costCenterRepo.On("FindByID", mock.Anything, 4).Return(&domain.CostCenter{ID: 4, Code: "FIN", Active: true}, nil).Once()
+		costCenterRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.CostCenter) bool {
+			return c.ID == 4 && !c.Active
+		})).Return(nil).Once()
+
+		require.NoError(t, uc.DeactivateCostCenter(ctx, 4))
+		costCenterRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		costCenterRepo := new(mocks.CostCenterRepository)
+		uc := NewAllocationUseCase(costCenterRepo, new(mocks.ProjectRepository))
+		costCenterRepo.On("FindByID", mock.Anything, 9).Return((*domain.CostCenter)(nil), nil).Once()
+
+		require.ErrorIs(t, uc.DeactivateCostCenter(ctx, 9), domain.ErrCostCenterNotFound)
+	})
+}
+
+func TestCreateProject(t *testing.T) {
+	projectRepo := new(mocks.ProjectRepository)
+	uc := NewAllocationUseCase(new(mocks.CostCenterRepository), projectRepo)
+	projectRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *domain.Project) bool {
+		return p.Code == "PRJ-01" && p.Active
+	})).Return(nil).Once()
+
+	result, err := uc.CreateProject(context.Background(), &domain.Project{Code: "prj-01", Name: "Core banking"})
+	require.NoError(t, err)
+	require.True(t, result.Active)
+
+	_, err = uc.CreateProject(context.Background(), &domain.Project{Name: "Core banking"})
+	require.ErrorIs(t, err, domain.ErrInvalidProject)
+}
+
+func TestDeactivateProject(t *testing.T) {
+	projectRepo := new(mocks.ProjectRepository)
+	uc := NewAllocationUseCase(new(mocks.CostCenterRepository), projectRepo)
+	projectRepo.On("FindByID", mock.Anything, 2).Return(&domain.Project{ID: 2, Code: "PRJ-01"}, nil).Once()
+
+	require.NoError(t, uc.DeactivateProject(context.Background(), 2))
+	projectRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
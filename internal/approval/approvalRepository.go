@@ -8,5 +8,7 @@ import (
 
 type ApprovalRepository interface {
 	Create(ctx context.Context, approval *domain.Approval) error
-	FindByExpenseID(ctx context.Context, expenseID int) (*domain.Approval, error)
+	FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.Approval, error)
+	FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.Approval, error)
+	CountRecentApprovalsByApprover(ctx context.Context, submitterID, approverID, excludeExpenseID int, level domain.ApprovalLevel, window int) (int, int, error)
 }
@@ -0,0 +1,14 @@
+package approval
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+// ApproverScope decides whose expenses, reports and advances an approver may sign at the
+// manager level.
+type ApproverScope interface {
+	ManagerScope(ctx context.Context, approver *domain.User) (map[int]*int, error)
+	ManagerSigners(ctx context.Context, submitterID int) ([]int, error)
+}
@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evrintobing17/expense-management-backend/internal/approval"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/user"
+)
+
+type segregationPolicy struct {
+	approvalRepo            approval.ApprovalRepository
+	userRepo                user.UserRepository
+	scope                   approval.ApproverScope
+	maxConsecutiveApprovals int
+}
+
+// NewSegregationPolicy blocks self-approval and, when maxConsecutiveApprovals is
+// positive, blocks an approver who has signed the same level on each of the submitter's
+// last maxConsecutiveApprovals expenses, as long as someone else could sign it instead.
+func NewSegregationPolicy(
+	approvalRepo approval.ApprovalRepository,
+	userRepo user.UserRepository,
+	scope approval.ApproverScope,
+	maxConsecutiveApprovals int,
+) approval.SegregationPolicy {
+	return &segregationPolicy{
+		approvalRepo:            approvalRepo,
+		userRepo:                userRepo,
+		scope:                   scope,
+		maxConsecutiveApprovals: maxConsecutiveApprovals,
+	}
+}
+
+func (p *segregationPolicy) CheckApprover(ctx context.Context, expense *domain.Expense, level domain.ApprovalLevel, approverID int) error {
+	return p.check(ctx, expense.UserID, expense.ID, level, approverID)
+}
+
+// CheckReportApprover applies the same rules to an expense report, counting the
+// submitter's recent standalone expenses.
+func (p *segregationPolicy) CheckReportApprover(ctx context.Context, report *domain.ExpenseReport, level domain.ApprovalLevel, approverID int) error {
+	return p.check(ctx, report.UserID, 0, level, approverID)
+}
+
+func (p *segregationPolicy) check(ctx context.Context, submitterID, excludeExpenseID int, level domain.ApprovalLevel, approverID int) error {
+	if approverID == submitterID {
+		return &domain.PolicyViolationError{Reason: "approvers cannot approve their own expenses"}
+	}
+
+	if p.maxConsecutiveApprovals <= 0 {
+		return nil
+	}
+
+	considered, approvedByApprover, err := p.approvalRepo.CountRecentApprovalsByApprover(
+		ctx, submitterID, approverID, excludeExpenseID, level, p.maxConsecutiveApprovals,
+	)
+	if err != nil {
+		return err
+	}
+
+	if considered < p.maxConsecutiveApprovals || approvedByApprover < considered {
+		return nil
+	}
+
+	// A sole approver, such as the only manager of a small team, keeps signing.
+	alternative, err := p.hasOtherSigner(ctx, submitterID, level, approverID)
+	if err != nil {
+		return err
+	}
+
+	if !alternative {
+		return nil
+	}
+
+	return &domain.PolicyViolationError{
+		Reason: fmt.Sprintf("approver has signed the %s level of the submitter's last %d expenses in a row; another approver must sign this one", level, considered),
+	}
+}
+
+// hasOtherSigner reports whether anyone besides approverID and the submitter may sign
+// level for the submitter.
+func (p *segregationPolicy) hasOtherSigner(ctx context.Context, submitterID int, level domain.ApprovalLevel, approverID int) (bool, error) {
+	var signers []int
+	var err error
+	if level == domain.ApprovalLevelManager {
+		signers, err = p.scope.ManagerSigners(ctx, submitterID)
+	} else {
+		signers, err = p.userRepo.FindIDsByRole(ctx, level.Role())
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, id := range signers {
+		if id != approverID && id != submitterID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,127 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/approval/scope"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type policyMocks struct {
+	approval   *mocks.ApprovalRepository
+	user       *mocks.UserRepository
+	delegation *mocks.DelegationRepository
+}
+
+func newPolicy(maxConsecutiveApprovals int) (*policyMocks, *segregationPolicy) {
+	m := &policyMocks{
+		approval:   new(mocks.ApprovalRepository),
+		user:       new(mocks.UserRepository),
+		delegation: new(mocks.DelegationRepository),
+	}
+	p := NewSegregationPolicy(m.approval, m.user, scope.NewApproverScope(m.user, m.delegation, false), maxConsecutiveApprovals)
+	return m, p.(*segregationPolicy)
+}
+
+// expectManagers sets up the submitter's reporting line: managerID is their manager and
+// delegates hold active delegations from that manager.
+func (m *policyMocks) expectManagers(submitterID, managerID int, delegates ...int) {
+	m.user.On("FindByID", mock.Anything, submitterID).Return(&domain.User{ID: submitterID, ManagerID: &managerID}, nil).Maybe()
+	m.user.On("FindByID", mock.Anything, managerID).Return(&domain.User{ID: managerID, Role: domain.RoleManager}, nil).Maybe()
+
+	var delegations []*domain.Delegation
+	for _, id := range delegates {
+		delegations = append(delegations, &domain.Delegation{ManagerID: managerID, DelegateID: id})
+	}
+	m.delegation.On("FindActiveByManagerID", mock.Anything, managerID, mock.Anything).Return(delegations, nil).Maybe()
+}
+
+func TestSegregationPolicyCheckApprover(t *testing.T) {
+	ctx := context.Background()
+	expense := &domain.Expense{ID: 10, UserID: 2}
+	manager := domain.ApprovalLevelManager
+
+	t.Run("self approval", func(t *testing.T) {
+		m, p := newPolicy(3)
+
+		err := p.CheckApprover(ctx, expense, manager, 2)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		require.Contains(t, err.Error(), "own expenses")
+		m.approval.AssertNotCalled(t, "CountRecentApprovalsByApprover", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("signed the level on every recent expense", func(t *testing.T) {
+		m, p := newPolicy(3)
+		m.approval.On("CountRecentApprovalsByApprover", mock.Anything, 2, 7, 10, manager, 3).Return(3, 3, nil).Once()
+		m.expectManagers(2, 7, 9)
+
+		err := p.CheckApprover(ctx, expense, manager, 7)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		require.Contains(t, err.Error(), "manager level of the submitter's last 3 expenses")
+	})
+
+	t.Run("single-manager team is still approved after N expenses", func(t *testing.T) {
+		m, p := newPolicy(3)
+		m.approval.On("CountRecentApprovalsByApprover", mock.Anything, 2, 7, 10, manager, 3).Return(3, 3, nil).Once()
+		m.expectManagers(2, 7)
+
+		require.NoError(t, p.CheckApprover(ctx, expense, manager, 7))
+	})
+
+	t.Run("counts only the level being signed", func(t *testing.T) {
+		m, p := newPolicy(3)
+		m.approval.On("CountRecentApprovalsByApprover", mock.Anything, 2, 4, 10, domain.ApprovalLevelFinanceDirector, 3).Return(3, 3, nil).Once()
+		m.user.On("FindIDsByRole", mock.Anything, domain.RoleFinanceDirector).Return([]int{4, 6}, nil).Once()
+
+		err := p.CheckApprover(ctx, expense, domain.ApprovalLevelFinanceDirector, 4)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+
+		m.approval.On("CountRecentApprovalsByApprover", mock.Anything, 2, 4, 10, domain.ApprovalLevelFinanceDirector, 3).Return(3, 3, nil).Once()
+		m.user.On("FindIDsByRole", mock.Anything, domain.RoleFinanceDirector).Return([]int{4}, nil).Once()
+		require.NoError(t, p.CheckApprover(ctx, expense, domain.ApprovalLevelFinanceDirector, 4), "the only finance director keeps signing")
+	})
+
+	t.Run("streak broken by another approver", func(t *testing.T) {
+		m, p := newPolicy(3)
+		m.approval.On("CountRecentApprovalsByApprover", mock.Anything, 2, 7, 10, manager, 3).Return(3, 2, nil).Once()
+
+		require.NoError(t, p.CheckApprover(ctx, expense, manager, 7))
+		m.user.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not enough history", func(t *testing.T) {
+		m, p := newPolicy(3)
+		m.approval.On("CountRecentApprovalsByApprover", mock.Anything, 2, 7, 10, manager, 3).Return(2, 2, nil).Once()
+
+		require.NoError(t, p.CheckApprover(ctx, expense, manager, 7))
+	})
+
+	t.Run("expense report", func(t *testing.T) {
+		m, p := newPolicy(3)
+		m.approval.On("CountRecentApprovalsByApprover", mock.Anything, 2, 7, 0, manager, 3).Return(3, 3, nil).Once()
+		m.expectManagers(2, 7, 9)
+
+		require.ErrorIs(t, p.CheckReportApprover(ctx, &domain.ExpenseReport{ID: 4, UserID: 2}, manager, 7), domain.ErrUnauthorizedAction)
+		require.ErrorIs(t, p.CheckReportApprover(ctx, &domain.ExpenseReport{ID: 4, UserID: 2}, manager, 2), domain.ErrUnauthorizedAction)
+	})
+
+	t.Run("streak check disabled", func(t *testing.T) {
+		m, p := newPolicy(0)
+
+		require.NoError(t, p.CheckApprover(ctx, expense, manager, 7))
+		m.approval.AssertNotCalled(t, "CountRecentApprovalsByApprover", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		m, p := newPolicy(3)
+		expectedErr := errors.New("db error")
+		m.approval.On("CountRecentApprovalsByApprover", mock.Anything, 2, 7, 10, manager, 3).Return(0, 0, expectedErr).Once()
+
+		require.ErrorIs(t, p.CheckApprover(ctx, expense, manager, 7), expectedErr)
+	})
+}
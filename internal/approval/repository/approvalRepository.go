@@ -6,8 +6,13 @@ import (
 
 	"github.com/evrintobing17/expense-management-backend/internal/approval"
 	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+	"github.com/lib/pq"
 )
 
+// uniqueViolation is the Postgres error code raised when a unique index rejects a row.
+const uniqueViolation = "23505"
+
 type approvalRepository struct {
 	db *sql.DB
 }
@@ -18,44 +23,116 @@ func NewApprovalRepository(db *sql.DB) approval.ApprovalRepository {
 
 func (r *approvalRepository) Create(ctx context.Context, approval *domain.Approval) error {
 	query := `
-		INSERT INTO approvals (expense_id, approver_id, status, notes)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO approvals (expense_id, approver_id, round, level, status, notes, on_behalf_of_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id, created_at
 	`
 
-	err := r.db.QueryRowContext(ctx, query,
+	err := database.Conn(ctx, r.db).QueryRowContext(ctx, query,
 		approval.ExpenseID,
 		approval.ApproverID,
+		approval.Round,
+		approval.Level,
 		approval.Status,
 		approval.Notes,
+		approval.OnBehalfOfID,
 	).Scan(&approval.ID, &approval.CreatedAt)
 
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+		return domain.ErrApprovalConflict
+	}
+
 	return err
 }
 
-func (r *approvalRepository) FindByExpenseID(ctx context.Context, expenseID int) (*domain.Approval, error) {
+// FindByExpenseID returns the expense's approval chain in the order it was signed.
+func (r *approvalRepository) FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.Approval, error) {
 	query := `
-		SELECT id, expense_id, approver_id, status, notes, created_at
+		SELECT id, expense_id, approver_id, round, level, status, notes, on_behalf_of_id, created_at
 		FROM approvals
 		WHERE expense_id = $1
+		ORDER BY created_at ASC, id ASC
 	`
 
-	approval := &domain.Approval{}
-	err := r.db.QueryRowContext(ctx, query, expenseID).Scan(
-		&approval.ID,
-		&approval.ExpenseID,
-		&approval.ApproverID,
-		&approval.Status,
-		&approval.Notes,
-		&approval.CreatedAt,
-	)
+	return r.findApprovals(ctx, query, expenseID)
+}
+
+// FindByExpenseIDs returns the approval chains keyed by expense id, each in the order it
+// was signed. Expenses without approvals are left out of the map.
+func (r *approvalRepository) FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.Approval, error) {
+	query := `
+		SELECT id, expense_id, approver_id, round, level, status, notes, on_behalf_of_id, created_at
+		FROM approvals
+		WHERE expense_id = ANY($1)
+		ORDER BY expense_id ASC, created_at ASC, id ASC
+	`
 
+	approvals, err := r.findApprovals(ctx, query, pq.Array(expenseIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	chains := make(map[int][]*domain.Approval)
+	for _, approval := range approvals {
+		chains[approval.ExpenseID] = append(chains[approval.ExpenseID], approval)
+	}
+
+	return chains, nil
+}
+
+func (r *approvalRepository) findApprovals(ctx context.Context, query string, args ...interface{}) ([]*domain.Approval, error) {
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
 		return nil, err
 	}
+	defer rows.Close()
+
+	var approvals []*domain.Approval
+	for rows.Next() {
+		approval := &domain.Approval{}
+		err := rows.Scan(
+			&approval.ID,
+			&approval.ExpenseID,
+			&approval.ApproverID,
+			&approval.Round,
+			&approval.Level,
+			&approval.Status,
+			&approval.Notes,
+			&approval.OnBehalfOfID,
+			&approval.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, rows.Err()
+}
+
+// CountRecentApprovalsByApprover looks at the submitter's most recent window expenses that
+// were signed at level, ignoring excludeExpenseID. It returns how many were considered
+// and on how many of those approverID signed the level's approval.
+func (r *approvalRepository) CountRecentApprovalsByApprover(ctx context.Context, submitterID, approverID, excludeExpenseID int, level domain.ApprovalLevel, window int) (int, int, error) {
+	query := `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE approved_by_approver)
+		FROM (
+			SELECT e.id, bool_or(a.approver_id = $2 AND a.status = 'approved') AS approved_by_approver
+			FROM expenses e
+			JOIN approvals a ON a.expense_id = e.id AND a.level = $4
+			WHERE e.user_id = $1 AND e.id <> $3
+			GROUP BY e.id
+			ORDER BY e.id DESC
+			LIMIT $5
+		) recent
+	`
+
+	var considered, approvedByApprover int
+	err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, submitterID, approverID, excludeExpenseID, level, window).
+		Scan(&considered, &approvedByApprover)
+	if err != nil {
+		return 0, 0, err
+	}
 
-	return approval, nil
+	return considered, approvedByApprover, nil
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,14 +21,16 @@ func TestApprovalRepositoryCreate(t *testing.T) {
 
 	repo := &approvalRepository{db: db}
 	query := regexp.QuoteMeta(`
-		INSERT INTO approvals (expense_id, approver_id, status, notes)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO approvals (expense_id, approver_id, round, level, status, notes, on_behalf_of_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id, created_at
 	`)
 	createdAt := time.Now()
 	approval := &domain.Approval{
 		ExpenseID:  1,
 		ApproverID: 2,
+		Round:      1,
+		Level:      domain.ApprovalLevelManager,
 		Status:     domain.ApprovalStatusApproved,
 		Notes:      "looks good",
 	}
@@ -35,7 +38,7 @@ func TestApprovalRepositoryCreate(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "created_at"}).AddRow(88, createdAt)
 		mock.ExpectQuery(query).
-			WithArgs(approval.ExpenseID, approval.ApproverID, approval.Status, approval.Notes).
+			WithArgs(approval.ExpenseID, approval.ApproverID, approval.Round, approval.Level, approval.Status, approval.Notes, approval.OnBehalfOfID).
 			WillReturnRows(rows)
 
 		createErr := repo.Create(context.Background(), approval)
@@ -47,13 +50,22 @@ func TestApprovalRepositoryCreate(t *testing.T) {
 	t.Run("query error", func(t *testing.T) {
 		expectedErr := errors.New("insert failed")
 		mock.ExpectQuery(query).
-			WithArgs(approval.ExpenseID, approval.ApproverID, approval.Status, approval.Notes).
+			WithArgs(approval.ExpenseID, approval.ApproverID, approval.Round, approval.Level, approval.Status, approval.Notes, approval.OnBehalfOfID).
 			WillReturnError(expectedErr)
 
 		createErr := repo.Create(context.Background(), approval)
 		require.ErrorIs(t, createErr, expectedErr)
 	})
 
+	t.Run("level already signed", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(approval.ExpenseID, approval.ApproverID, approval.Round, approval.Level, approval.Status, approval.Notes, approval.OnBehalfOfID).
+			WillReturnError(&pq.Error{Code: "23505"})
+
+		createErr := repo.Create(context.Background(), approval)
+		require.ErrorIs(t, createErr, domain.ErrApprovalConflict)
+	})
+
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -64,30 +76,107 @@ func TestApprovalRepositoryFindByExpenseID(t *testing.T) {
 
 	repo := &approvalRepository{db: db}
 	query := regexp.QuoteMeta(`
-		SELECT id, expense_id, approver_id, status, notes, created_at
+		SELECT id, expense_id, approver_id, round, level, status, notes, on_behalf_of_id, created_at
 		FROM approvals
 		WHERE expense_id = $1
+		ORDER BY created_at ASC, id ASC
 	`)
 	createdAt := time.Now()
 
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "expense_id", "approver_id", "status", "notes", "created_at"}).
-			AddRow(1, 2, 3, "approved", "ok", createdAt)
+		rows := sqlmock.NewRows([]string{"id", "expense_id", "approver_id", "round", "level", "status", "notes", "on_behalf_of_id", "created_at"}).
+			AddRow(1, 2, 3, 1, "manager", "approved", "ok", 9, createdAt).
+			AddRow(2, 2, 4, 1, "finance_director", "approved", "fine", nil, createdAt)
 		mock.ExpectQuery(query).WithArgs(2).WillReturnRows(rows)
 
 		result, findErr := repo.FindByExpenseID(context.Background(), 2)
 		require.NoError(t, findErr)
-		require.NotNil(t, result)
-		require.Equal(t, domain.ApprovalStatusApproved, result.Status)
+		require.Len(t, result, 2)
+		require.Equal(t, domain.ApprovalLevelManager, result[0].Level)
+		require.Equal(t, domain.ApprovalLevelFinanceDirector, result[1].Level)
+		require.True(t, result[0].IsDelegated())
+		require.Equal(t, 9, *result[0].OnBehalfOfID)
+		require.False(t, result[1].IsDelegated())
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mock.ExpectQuery(query).WithArgs(404).WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(query).WithArgs(404).WillReturnRows(sqlmock.NewRows([]string{"id", "expense_id", "approver_id", "round", "level", "status", "notes", "on_behalf_of_id", "created_at"}))
 
 		result, findErr := repo.FindByExpenseID(context.Background(), 404)
 		require.NoError(t, findErr)
-		require.Nil(t, result)
+		require.Empty(t, result)
 	})
 
+	t.Run("query error", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs(500).WillReturnError(sql.ErrConnDone)
+
+		_, findErr := repo.FindByExpenseID(context.Background(), 500)
+		require.ErrorIs(t, findErr, sql.ErrConnDone)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApprovalRepositoryFindByExpenseIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &approvalRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id, expense_id, approver_id, round, level, status, notes, on_behalf_of_id, created_at
+		FROM approvals
+		WHERE expense_id = ANY($1)
+		ORDER BY expense_id ASC, created_at ASC, id ASC
+	`)
+	createdAt := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "expense_id", "approver_id", "round", "level", "status", "notes", "on_behalf_of_id", "created_at"}).
+			AddRow(1, 2, 3, 1, "manager", "approved", "ok", nil, createdAt).
+			AddRow(2, 2, 4, 1, "finance_director", "approved", "fine", nil, createdAt).
+			AddRow(3, 5, 3, 1, "manager", "approved", "", nil, createdAt)
+		mock.ExpectQuery(query).WithArgs(pq.Array([]int{2, 5, 7})).WillReturnRows(rows)
+
+		result, findErr := repo.FindByExpenseIDs(context.Background(), []int{2, 5, 7})
+		require.NoError(t, findErr)
+		require.Len(t, result, 2)
+		require.Len(t, result[2], 2)
+		require.Equal(t, domain.ApprovalLevelFinanceDirector, result[2][1].Level)
+		require.Len(t, result[5], 1)
+		require.Empty(t, result[7])
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs(pq.Array([]int{2})).WillReturnError(sql.ErrConnDone)
+
+		_, findErr := repo.FindByExpenseIDs(context.Background(), []int{2})
+		require.ErrorIs(t, findErr, sql.ErrConnDone)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApprovalRepositoryCountRecentApprovalsByApprover(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &approvalRepository{db: db}
+
+	rows := sqlmock.NewRows([]string{"count", "count"}).AddRow(3, 2)
+	mock.ExpectQuery(`JOIN approvals a ON a.expense_id = e.id AND a.level = \$4`).
+		WithArgs(2, 7, 10, domain.ApprovalLevelManager, 3).
+		WillReturnRows(rows)
+
+	considered, approvedByApprover, countErr := repo.CountRecentApprovalsByApprover(context.Background(), 2, 7, 10, domain.ApprovalLevelManager, 3)
+	require.NoError(t, countErr)
+	require.Equal(t, 3, considered)
+	require.Equal(t, 2, approvedByApprover)
+
+	mock.ExpectQuery(`FROM expenses e`).WithArgs(2, 7, 10, domain.ApprovalLevelManager, 3).WillReturnError(sql.ErrConnDone)
+	_, _, countErr = repo.CountRecentApprovalsByApprover(context.Background(), 2, 7, 10, domain.ApprovalLevelManager, 3)
+	require.ErrorIs(t, countErr, sql.ErrConnDone)
+
 	require.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,108 @@
+package scope
+
+import (
+	"context"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/approval"
+	"github.com/evrintobing17/expense-management-backend/internal/delegation"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/user"
+)
+
+type approverScope struct {
+	userRepo               user.UserRepository
+	delegationRepo         delegation.DelegationRepository
+	includeIndirectReports bool
+}
+
+func NewApproverScope(userRepo user.UserRepository, delegationRepo delegation.DelegationRepository, includeIndirectReports bool) approval.ApproverScope {
+	return &approverScope{
+		userRepo:               userRepo,
+		delegationRepo:         delegationRepo,
+		includeIndirectReports: includeIndirectReports,
+	}
+}
+
+// ManagerScope maps every submitter whose manager level the approver may sign to the
+// manager they act for: nil for the approver's own reports, or the delegating manager
+// for teams covered by an active delegation.
+func (s *approverScope) ManagerScope(ctx context.Context, approver *domain.User) (map[int]*int, error) {
+	scope := make(map[int]*int)
+
+	delegations, err := s.delegationRepo.FindActiveByDelegateID(ctx, approver.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range delegations {
+		managerID := d.ManagerID
+		reportIDs, err := s.userRepo.FindReportIDs(ctx, managerID, s.includeIndirectReports)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range reportIDs {
+			scope[id] = &managerID
+		}
+	}
+
+	// The approver's own reports win over a delegation covering the same person.
+	if approver.Role == domain.RoleManager {
+		reportIDs, err := s.userRepo.FindReportIDs(ctx, approver.ID, s.includeIndirectReports)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range reportIDs {
+			scope[id] = nil
+		}
+	}
+
+	return scope, nil
+}
+
+// ManagerSigners is the reverse of ManagerScope: it lists the approvers who may sign the
+// manager level for submitterID, walking up the reporting line as far as
+// ManagerScope reaches and adding the active delegates of each manager on the way.
+func (s *approverScope) ManagerSigners(ctx context.Context, submitterID int) ([]int, error) {
+	submitter, err := s.userRepo.FindByID(ctx, submitterID)
+	if err != nil || submitter == nil {
+		return nil, err
+	}
+
+	var signers []int
+	seen := map[int]bool{submitterID: true}
+	for managerID := submitter.ManagerID; managerID != nil && !seen[*managerID]; {
+		seen[*managerID] = true
+
+		manager, err := s.userRepo.FindByID(ctx, *managerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if manager == nil {
+			break
+		}
+
+		if manager.Role == domain.RoleManager {
+			signers = append(signers, manager.ID)
+		}
+
+		delegations, err := s.delegationRepo.FindActiveByManagerID(ctx, manager.ID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, d := range delegations {
+			signers = append(signers, d.DelegateID)
+		}
+
+		if !s.includeIndirectReports {
+			break
+		}
+		managerID = manager.ManagerID
+	}
+
+	return signers, nil
+}
@@ -0,0 +1,103 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApproverScopeManagerScope(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("own reports and delegated teams", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		mockDelegation := new(mocks.DelegationRepository)
+		s := NewApproverScope(mockUser, mockDelegation, false)
+		mockDelegation.On("FindActiveByDelegateID", mock.Anything, 2, mock.AnythingOfType("time.Time")).
+			Return([]*domain.Delegation{{ManagerID: 3, DelegateID: 2}}, nil).Once()
+		mockUser.On("FindReportIDs", mock.Anything, 3, false).Return([]int{7, 8}, nil).Once()
+		mockUser.On("FindReportIDs", mock.Anything, 2, false).Return([]int{5, 8}, nil).Once()
+
+		scope, err := s.ManagerScope(ctx, &domain.User{ID: 2, Role: domain.RoleManager})
+		require.NoError(t, err)
+		require.Len(t, scope, 3)
+		require.Nil(t, scope[5])
+		require.Equal(t, 3, *scope[7])
+		require.Nil(t, scope[8], "the approver's own report wins over the delegation")
+	})
+
+	t.Run("delegate without a team of their own", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		mockDelegation := new(mocks.DelegationRepository)
+		s := NewApproverScope(mockUser, mockDelegation, true)
+		mockDelegation.On("FindActiveByDelegateID", mock.Anything, 9, mock.AnythingOfType("time.Time")).
+			Return([]*domain.Delegation{{ManagerID: 3, DelegateID: 9}}, nil).Once()
+		mockUser.On("FindReportIDs", mock.Anything, 3, true).Return([]int{7}, nil).Once()
+
+		scope, err := s.ManagerScope(ctx, &domain.User{ID: 9, Role: domain.RoleFinanceDirector})
+		require.NoError(t, err)
+		require.Equal(t, 3, *scope[7])
+		mockUser.AssertNotCalled(t, "FindReportIDs", mock.Anything, 9, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		mockDelegation := new(mocks.DelegationRepository)
+		s := NewApproverScope(mockUser, mockDelegation, false)
+		mockDelegation.On("FindActiveByDelegateID", mock.Anything, 2, mock.Anything).Return([]*domain.Delegation(nil), errors.New("db down")).Once()
+
+		_, err := s.ManagerScope(ctx, &domain.User{ID: 2, Role: domain.RoleManager})
+		require.Error(t, err)
+	})
+}
+
+func TestApproverScopeManagerSigners(t *testing.T) {
+	ctx := context.Background()
+	managerID := 2
+	seniorID := 3
+
+	t.Run("direct manager and their delegates", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		mockDelegation := new(mocks.DelegationRepository)
+		s := NewApproverScope(mockUser, mockDelegation, false)
+		mockUser.On("FindByID", mock.Anything, 7).Return(&domain.User{ID: 7, ManagerID: &managerID}, nil).Once()
+		mockUser.On("FindByID", mock.Anything, managerID).Return(&domain.User{ID: managerID, Role: domain.RoleManager, ManagerID: &seniorID}, nil).Once()
+		mockDelegation.On("FindActiveByManagerID", mock.Anything, managerID, mock.AnythingOfType("time.Time")).
+			Return([]*domain.Delegation{{ManagerID: managerID, DelegateID: 9}}, nil).Once()
+
+		signers, err := s.ManagerSigners(ctx, 7)
+		require.NoError(t, err)
+		require.Equal(t, []int{managerID, 9}, signers)
+		mockUser.AssertNotCalled(t, "FindByID", mock.Anything, seniorID)
+	})
+
+	t.Run("whole reporting line", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		mockDelegation := new(mocks.DelegationRepository)
+		s := NewApproverScope(mockUser, mockDelegation, true)
+		mockUser.On("FindByID", mock.Anything, 7).Return(&domain.User{ID: 7, ManagerID: &managerID}, nil).Once()
+		mockUser.On("FindByID", mock.Anything, managerID).Return(&domain.User{ID: managerID, Role: domain.RoleManager, ManagerID: &seniorID}, nil).Once()
+		mockUser.On("FindByID", mock.Anything, seniorID).Return(&domain.User{ID: seniorID, Role: domain.RoleManager}, nil).Once()
+		mockDelegation.On("FindActiveByManagerID", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Delegation(nil), nil)
+
+		signers, err := s.ManagerSigners(ctx, 7)
+		require.NoError(t, err)
+		require.Equal(t, []int{managerID, seniorID}, signers)
+	})
+
+	t.Run("no manager", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		mockDelegation := new(mocks.DelegationRepository)
+		s := NewApproverScope(mockUser, mockDelegation, true)
+		mockUser.On("FindByID", mock.Anything, 7).Return(&domain.User{ID: 7}, nil).Once()
+
+		signers, err := s.ManagerSigners(ctx, 7)
+		require.NoError(t, err)
+		require.Empty(t, signers)
+	})
+}
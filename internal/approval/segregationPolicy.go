@@ -0,0 +1,14 @@
+package approval
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+// SegregationPolicy enforces segregation of duties on approval decisions, on top of the
+// role and reporting-line checks that decide who may sign a level.
+type SegregationPolicy interface {
+	CheckApprover(ctx context.Context, expense *domain.Expense, level domain.ApprovalLevel, approverID int) error
+	CheckReportApprover(ctx context.Context, report *domain.ExpenseReport, level domain.ApprovalLevel, approverID int) error
+}
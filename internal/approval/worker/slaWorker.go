@@ -0,0 +1,346 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/approval"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/escalation"
+	"github.com/evrintobing17/expense-management-backend/internal/expense"
+	"github.com/evrintobing17/expense-management-backend/internal/history"
+	"github.com/evrintobing17/expense-management-backend/internal/notification"
+	"github.com/evrintobing17/expense-management-backend/internal/report"
+	"github.com/evrintobing17/expense-management-backend/internal/user"
+)
+
+// SLAWorker reminds approvers about expenses and expense reports that have waited too
+// long on their approval level and escalates the level to the approvers' own managers
+// after a second deadline.
+type SLAWorker struct {
+	expenseRepo    expense.ExpenseRepository
+	reportRepo     report.ReportRepository
+	approvalRepo   approval.ApprovalRepository
+	historyRepo    history.HistoryRepository
+	escalationRepo escalation.EscalationRepository
+	userRepo       user.UserRepository
+	notifier       notification.Notifier
+	approvalPolicy domain.ApprovalPolicy
+	slaPolicy      domain.SLAPolicy
+	interval       time.Duration
+	now            func() time.Time
+}
+
+func NewSLAWorker(
+	expenseRepo expense.ExpenseRepository,
+	reportRepo report.ReportRepository,
+	approvalRepo approval.ApprovalRepository,
+	historyRepo history.HistoryRepository,
+	escalationRepo escalation.EscalationRepository,
+	userRepo user.UserRepository,
+	notifier notification.Notifier,
+	approvalPolicy domain.ApprovalPolicy,
+	slaPolicy domain.SLAPolicy,
+	interval time.Duration,
+) *SLAWorker {
+	return &SLAWorker{
+		expenseRepo:    expenseRepo,
+		reportRepo:     reportRepo,
+		approvalRepo:   approvalRepo,
+		historyRepo:    historyRepo,
+		escalationRepo: escalationRepo,
+		userRepo:       userRepo,
+		notifier:       notifier,
+		approvalPolicy: approvalPolicy,
+		slaPolicy:      slaPolicy,
+		interval:       interval,
+		now:            time.Now,
+	}
+}
+
+func (w *SLAWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.checkSLAs(ctx)
+		case <-ctx.Done():
+			log.Println("SLA worker stopped")
+			return
+		}
+	}
+}
+
+// slaItem is an expense or expense report waiting on an approval level. Reminders,
+// escalations and their history are kept on its expenses: the expense itself, or the
+// report's lines still awaiting approval.
+type slaItem struct {
+	kind         string
+	id           int
+	userID       int
+	level        domain.ApprovalLevel
+	waitingSince time.Time
+	expenseIDs   []int
+}
+
+func (w *SLAWorker) checkSLAs(ctx context.Context) {
+	now := w.now()
+
+	expenses, err := w.expenseRepo.FindByStatus(ctx, domain.ExpenseStatusAwaitingApproval)
+	if err != nil {
+		log.Printf("Error fetching expenses for SLA check: %v", err)
+	}
+
+	for _, expense := range expenses {
+		if err := w.checkExpense(ctx, expense, now); err != nil {
+			log.Printf("Error checking approval SLA for expense %d: %v", expense.ID, err)
+		}
+	}
+
+	reports, err := w.reportRepo.FindByStatus(ctx, domain.ExpenseStatusAwaitingApproval)
+	if err != nil {
+		log.Printf("Error fetching expense reports for SLA check: %v", err)
+		return
+	}
+
+	for _, report := range reports {
+		if err := w.checkReport(ctx, report, now); err != nil {
+			log.Printf("Error checking approval SLA for expense report %d: %v", report.ID, err)
+		}
+	}
+}
+
+func (w *SLAWorker) checkExpense(ctx context.Context, expense *domain.Expense, now time.Time) error {
+	chain, err := w.approvalRepo.FindByExpenseID(ctx, expense.ID)
+	if err != nil {
+		return err
+	}
+
+	level, ok := domain.NextApprovalLevel(w.approvalPolicy.ExpenseLevels(expense), chain)
+	if !ok {
+		return nil
+	}
+
+	// The level became outstanding when the previous level signed off, or on submission.
+	waitingSince := expense.SubmittedAt
+	for _, a := range chain {
+		if a.CreatedAt.After(waitingSince) {
+			waitingSince = a.CreatedAt
+		}
+	}
+
+	return w.check(ctx, &slaItem{
+		kind:         "Expense",
+		id:           expense.ID,
+		userID:       expense.UserID,
+		level:        level,
+		waitingSince: waitingSince,
+		expenseIDs:   []int{expense.ID},
+	}, now)
+}
+
+func (w *SLAWorker) checkReport(ctx context.Context, report *domain.ExpenseReport, now time.Time) error {
+	approvals, err := w.reportRepo.FindApprovals(ctx, report.ID)
+	if err != nil {
+		return err
+	}
+	report.Approvals = approvals
+
+	level, ok := report.NextApprovalLevel(w.approvalPolicy)
+	if !ok || report.SubmittedAt == nil {
+		return nil
+	}
+
+	waitingSince := *report.SubmittedAt
+	for _, a := range approvals {
+		if a.CreatedAt.After(waitingSince) {
+			waitingSince = a.CreatedAt
+		}
+	}
+
+	lines, err := w.expenseRepo.FindByReportID(ctx, report.ID)
+	if err != nil {
+		return err
+	}
+
+	var expenseIDs []int
+	for _, line := range lines {
+		if line.Status == domain.ExpenseStatusAwaitingApproval {
+			expenseIDs = append(expenseIDs, line.ID)
+		}
+	}
+
+	if len(expenseIDs) == 0 {
+		return nil
+	}
+
+	return w.check(ctx, &slaItem{
+		kind:         "Expense report",
+		id:           report.ID,
+		userID:       report.UserID,
+		level:        level,
+		waitingSince: waitingSince,
+		expenseIDs:   expenseIDs,
+	}, now)
+}
+
+// check measures how long the item's outstanding level has been waiting and sends at
+// most one reminder and one escalation for that level.
+func (w *SLAWorker) check(ctx context.Context, item *slaItem, now time.Time) error {
+	days := w.slaPolicy.Calendar.BusinessDaysBetween(item.waitingSince, now)
+	if days < w.slaPolicy.ReminderAfterDays {
+		return nil
+	}
+
+	// Every expense of the item gets the same entries, so the first one tells for all.
+	entries, err := w.historyRepo.FindByExpenseID(ctx, item.expenseIDs[0])
+	if err != nil {
+		return err
+	}
+
+	var reminded, escalated bool
+	for _, entry := range entries {
+		if entry.CreatedAt.Before(item.waitingSince) {
+			continue
+		}
+		switch entry.Event {
+		case domain.HistoryEventReminder:
+			reminded = true
+		case domain.HistoryEventEscalation:
+			escalated = true
+		}
+	}
+
+	overdue := days >= w.slaPolicy.EscalationAfterDays
+	if (overdue && escalated) || (!overdue && reminded) {
+		return nil
+	}
+
+	approverIDs, err := w.approverIDs(ctx, item.userID, item.level)
+	if err != nil {
+		return err
+	}
+
+	if overdue {
+		return w.escalate(ctx, item, approverIDs, days)
+	}
+	return w.remind(ctx, item, approverIDs, days)
+}
+
+func (w *SLAWorker) remind(ctx context.Context, item *slaItem, approverIDs []int, days int) error {
+	for _, id := range approverIDs {
+		w.notify(ctx, id, "Approval reminder",
+			fmt.Sprintf("%s #%d has been waiting on your %s approval for %d business days", item.kind, item.id, item.level, days))
+	}
+
+	reason := fmt.Sprintf("%s approval pending for %d business days; reminded %s", item.level, days, userList(approverIDs))
+	return w.record(ctx, item, domain.HistoryEventReminder, reason)
+}
+
+// escalate hands the level to the managers of its approvers. The submitter is never an
+// escalation target, so that segregation of duties still holds. When nobody can take the
+// level, nothing is recorded, so the escalation is tried again on the next sweep.
+func (w *SLAWorker) escalate(ctx context.Context, item *slaItem, approverIDs []int, days int) error {
+	var targetIDs []int
+	seen := make(map[int]bool)
+	for _, id := range approverIDs {
+		approver, err := w.userRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if approver == nil || approver.ManagerID == nil {
+			continue
+		}
+
+		targetID := *approver.ManagerID
+		if seen[targetID] || targetID == item.userID {
+			continue
+		}
+		seen[targetID] = true
+
+		for _, expenseID := range item.expenseIDs {
+			err = w.escalationRepo.Create(ctx, &domain.Escalation{
+				ExpenseID:     expenseID,
+				Level:         item.level,
+				EscalatedToID: targetID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		targetIDs = append(targetIDs, targetID)
+
+		w.notify(ctx, targetID, "Approval escalated",
+			fmt.Sprintf("%s #%d missed its %s approval SLA and has been escalated to you", item.kind, item.id, item.level))
+	}
+
+	if len(targetIDs) == 0 {
+		log.Printf("No one to escalate %s approval of %s #%d to", item.level, strings.ToLower(item.kind), item.id)
+		return nil
+	}
+
+	reason := fmt.Sprintf("%s approval pending for %d business days; escalated to %s", item.level, days, userList(targetIDs))
+	return w.record(ctx, item, domain.HistoryEventEscalation, reason)
+}
+
+// approverIDs returns who is expected to sign level: the submitter's manager for the
+// manager level, and every holder of the matching role for the finance levels.
+func (w *SLAWorker) approverIDs(ctx context.Context, submitterID int, level domain.ApprovalLevel) ([]int, error) {
+	if level != domain.ApprovalLevelManager {
+		return w.userRepo.FindIDsByRole(ctx, level.Role())
+	}
+
+	submitter, err := w.userRepo.FindByID(ctx, submitterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if submitter == nil || submitter.ManagerID == nil {
+		return nil, nil
+	}
+
+	return []int{*submitter.ManagerID}, nil
+}
+
+func (w *SLAWorker) notify(ctx context.Context, userID int, subject, message string) {
+	if err := w.notifier.Notify(ctx, userID, subject, message); err != nil {
+		log.Printf("Error notifying user %d: %v", userID, err)
+	}
+}
+
+func (w *SLAWorker) record(ctx context.Context, item *slaItem, event domain.HistoryEvent, reason string) error {
+	status := domain.ExpenseStatusAwaitingApproval
+	for _, expenseID := range item.expenseIDs {
+		err := w.historyRepo.Create(ctx, &domain.StatusHistory{
+			ExpenseID:  expenseID,
+			Event:      event,
+			FromStatus: &status,
+			ToStatus:   status,
+			ActorType:  domain.ActorTypeWorker,
+			Reason:     reason,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func userList(ids []int) string {
+	if len(ids) == 0 {
+		return "nobody"
+	}
+
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = "user " + strconv.Itoa(id)
+	}
+	return strings.Join(names, ", ")
+}
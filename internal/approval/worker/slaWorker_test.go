@@ -0,0 +1,269 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+type slaMocks struct {
+	expense    *mocks.ExpenseRepository
+	report     *mocks.ReportRepository
+	approval   *mocks.ApprovalRepository
+	history    *mocks.HistoryRepository
+	escalation *mocks.EscalationRepository
+	user       *mocks.UserRepository
+	notifier   *mocks.Notifier
+}
+
+func newSLAMocks() *slaMocks {
+	m := &slaMocks{
+		expense:    new(mocks.ExpenseRepository),
+		report:     new(mocks.ReportRepository),
+		approval:   new(mocks.ApprovalRepository),
+		history:    new(mocks.HistoryRepository),
+		escalation: new(mocks.EscalationRepository),
+		user:       new(mocks.UserRepository),
+		notifier:   new(mocks.Notifier),
+	}
+	m.report.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.ExpenseReport(nil), nil).Maybe()
+	return m
+}
+
+func (m *slaMocks) worker(now time.Time) *SLAWorker {
+	w := NewSLAWorker(m.expense, m.report, m.approval, m.history, m.escalation, m.user, m.notifier,
+		domain.ApprovalPolicy{Tiers: domain.DefaultApprovalTiers},
+		domain.SLAPolicy{ReminderAfterDays: 2, EscalationAfterDays: 4, Calendar: domain.NewBusinessCalendar(domain.WIB, nil)},
+		time.Hour)
+	w.now = func() time.Time { return now }
+	return w
+}
+
+func TestSLAWorkerCheckSLAs(t *testing.T) {
+	ctx := context.Background()
+	managerID := 3
+	seniorID := 8
+	submittedAt := time.Date(2026, 9, 7, 9, 0, 0, 0, domain.WIB) // Monday
+	expense := &domain.Expense{ID: 5, UserID: 20, AmountIDR: 2000000, Status: domain.ExpenseStatusAwaitingApproval, SubmittedAt: submittedAt}
+	submitter := &domain.User{ID: 20, Role: domain.RoleEmployee, ManagerID: &managerID}
+	manager := &domain.User{ID: managerID, Role: domain.RoleManager, ManagerID: &seniorID}
+	isEvent := func(event domain.HistoryEvent) interface{} {
+		return mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.Event == event && h.ExpenseID == expense.ID && *h.FromStatus == domain.ExpenseStatusAwaitingApproval &&
+				h.ToStatus == domain.ExpenseStatusAwaitingApproval && h.ActorType == domain.ActorTypeWorker
+		})
+	}
+
+	t.Run("within SLA", func(t *testing.T) {
+		m := newSLAMocks()
+		m.expense.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.Expense{expense}, nil).Once()
+		m.approval.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.Approval{}, nil).Once()
+
+		m.worker(submittedAt.AddDate(0, 0, 1)).checkSLAs(ctx)
+		m.history.AssertNotCalled(t, "FindByExpenseID", mock.Anything, mock.Anything)
+		m.notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reminds approver", func(t *testing.T) {
+		m := newSLAMocks()
+		m.expense.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.Expense{expense}, nil).Once()
+		m.approval.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.Approval{}, nil).Once()
+		m.history.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.StatusHistory{}, nil).Once()
+		m.user.On("FindByID", mock.Anything, 20).Return(submitter, nil).Once()
+		m.notifier.On("Notify", mock.Anything, managerID, "Approval reminder", mock.AnythingOfType("string")).Return(nil).Once()
+		m.history.On("Create", mock.Anything, isEvent(domain.HistoryEventReminder)).Return(nil).Once()
+
+		m.worker(submittedAt.AddDate(0, 0, 2)).checkSLAs(ctx)
+		m.notifier.AssertExpectations(t)
+		m.history.AssertExpectations(t)
+		m.escalation.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reminds only once", func(t *testing.T) {
+		m := newSLAMocks()
+		m.expense.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.Expense{expense}, nil).Once()
+		m.approval.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.Approval{}, nil).Once()
+		m.history.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.StatusHistory{
+			{Event: domain.HistoryEventReminder, CreatedAt: submittedAt.AddDate(0, 0, 2)},
+		}, nil).Once()
+
+		m.worker(submittedAt.AddDate(0, 0, 3)).checkSLAs(ctx)
+		m.notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		m.history.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("escalates to approver's manager", func(t *testing.T) {
+		m := newSLAMocks()
+		m.expense.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.Expense{expense}, nil).Once()
+		m.approval.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.Approval{}, nil).Once()
+		m.history.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.StatusHistory{
+			{Event: domain.HistoryEventReminder, CreatedAt: submittedAt.AddDate(0, 0, 2)},
+		}, nil).Once()
+		m.user.On("FindByID", mock.Anything, 20).Return(submitter, nil).Once()
+		m.user.On("FindByID", mock.Anything, managerID).Return(manager, nil).Once()
+		m.escalation.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.Escalation) bool {
+			return e.ExpenseID == 5 && e.Level == domain.ApprovalLevelManager && e.EscalatedToID == seniorID
+		})).Return(nil).Once()
+		m.notifier.On("Notify", mock.Anything, seniorID, "Approval escalated", mock.AnythingOfType("string")).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.Event == domain.HistoryEventEscalation && h.Reason == "manager approval pending for 4 business days; escalated to user 8"
+		})).Return(nil).Once()
+
+		// Monday to Friday is four business days.
+		m.worker(submittedAt.AddDate(0, 0, 4)).checkSLAs(ctx)
+		m.escalation.AssertExpectations(t)
+		m.notifier.AssertExpectations(t)
+		m.history.AssertExpectations(t)
+	})
+
+	t.Run("escalates only once", func(t *testing.T) {
+		m := newSLAMocks()
+		m.expense.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.Expense{expense}, nil).Once()
+		m.approval.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.Approval{}, nil).Once()
+		m.history.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.StatusHistory{
+			{Event: domain.HistoryEventEscalation, CreatedAt: submittedAt.AddDate(0, 0, 4)},
+		}, nil).Once()
+
+		m.worker(submittedAt.AddDate(0, 0, 8)).checkSLAs(ctx)
+		m.escalation.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.history.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("finance level restarts the clock", func(t *testing.T) {
+		m := newSLAMocks()
+		large := &domain.Expense{ID: 6, UserID: 20, AmountIDR: 12000000, Status: domain.ExpenseStatusAwaitingApproval, SubmittedAt: submittedAt}
+		signedAt := submittedAt.AddDate(0, 0, 2)
+		m.expense.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.Expense{large}, nil).Once()
+		m.approval.On("FindByExpenseID", mock.Anything, 6).Return([]*domain.Approval{
+			{ExpenseID: 6, Level: domain.ApprovalLevelManager, Status: domain.ApprovalStatusApproved, CreatedAt: signedAt},
+		}, nil).Once()
+		// The manager-level reminder predates the finance level and does not count.
+		m.history.On("FindByExpenseID", mock.Anything, 6).Return([]*domain.StatusHistory{
+			{Event: domain.HistoryEventReminder, CreatedAt: submittedAt.AddDate(0, 0, 1)},
+		}, nil).Once()
+		m.user.On("FindIDsByRole", mock.Anything, domain.RoleFinanceDirector).Return([]int{4, 6}, nil).Once()
+		m.notifier.On("Notify", mock.Anything, 4, "Approval reminder", mock.AnythingOfType("string")).Return(nil).Once()
+		m.notifier.On("Notify", mock.Anything, 6, "Approval reminder", mock.AnythingOfType("string")).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.Event == domain.HistoryEventReminder && h.ExpenseID == 6 &&
+				h.Reason == "finance_director approval pending for 2 business days; reminded user 4, user 6"
+		})).Return(nil).Once()
+
+		m.worker(signedAt.AddDate(0, 0, 2)).checkSLAs(ctx)
+		m.notifier.AssertExpectations(t)
+		m.history.AssertExpectations(t)
+	})
+
+	t.Run("no manager to escalate to", func(t *testing.T) {
+		m := newSLAMocks()
+		topManager := &domain.User{ID: managerID, Role: domain.RoleManager}
+		m.expense.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.Expense{expense}, nil).Once()
+		m.approval.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.Approval{}, nil).Once()
+		m.history.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.StatusHistory{}, nil).Once()
+		m.user.On("FindByID", mock.Anything, 20).Return(submitter, nil).Once()
+		m.user.On("FindByID", mock.Anything, managerID).Return(topManager, nil).Once()
+
+		// Nothing is recorded, so the escalation is tried again once a manager is assigned.
+		m.worker(submittedAt.AddDate(0, 0, 4)).checkSLAs(ctx)
+		m.escalation.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.history.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("only the submitter to escalate to", func(t *testing.T) {
+		m := newSLAMocks()
+		selfManaged := &domain.User{ID: managerID, Role: domain.RoleManager, ManagerID: &submitter.ID}
+		m.expense.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.Expense{expense}, nil).Once()
+		m.approval.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.Approval{}, nil).Once()
+		m.history.On("FindByExpenseID", mock.Anything, 5).Return([]*domain.StatusHistory{}, nil).Once()
+		m.user.On("FindByID", mock.Anything, 20).Return(submitter, nil).Once()
+		m.user.On("FindByID", mock.Anything, managerID).Return(selfManaged, nil).Once()
+
+		m.worker(submittedAt.AddDate(0, 0, 4)).checkSLAs(ctx)
+		m.escalation.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.history.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSLAWorkerCheckReportSLAs(t *testing.T) {
+	ctx := context.Background()
+	managerID := 3
+	seniorID := 8
+	submittedAt := time.Date(2026, 9, 7, 9, 0, 0, 0, domain.WIB) // Monday
+	report := func() *domain.ExpenseReport {
+		return &domain.ExpenseReport{ID: 10, UserID: 20, TotalIDR: 3000000, Status: domain.ExpenseStatusAwaitingApproval, SubmittedAt: &submittedAt}
+	}
+	lines := []*domain.Expense{
+		{ID: 11, ReportID: new(int), Status: domain.ExpenseStatusAwaitingApproval},
+		{ID: 12, ReportID: new(int), Status: domain.ExpenseStatusRejected},
+		{ID: 13, ReportID: new(int), Status: domain.ExpenseStatusAwaitingApproval},
+	}
+	submitter := &domain.User{ID: 20, Role: domain.RoleEmployee, ManagerID: &managerID}
+	manager := &domain.User{ID: managerID, Role: domain.RoleManager, ManagerID: &seniorID}
+
+	newMocks := func() *slaMocks {
+		m := newSLAMocks()
+		m.report = new(mocks.ReportRepository)
+		m.expense.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.Expense(nil), nil).Once()
+		m.report.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.ExpenseReport{report()}, nil).Once()
+		m.report.On("FindApprovals", mock.Anything, 10).Return([]*domain.ReportApproval{}, nil).Once()
+		return m
+	}
+
+	t.Run("within SLA", func(t *testing.T) {
+		m := newMocks()
+		m.expense.On("FindByReportID", mock.Anything, 10).Return(lines, nil).Once()
+
+		m.worker(submittedAt.AddDate(0, 0, 1)).checkSLAs(ctx)
+		m.history.AssertNotCalled(t, "FindByExpenseID", mock.Anything, mock.Anything)
+		m.notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reminds approver on the awaiting lines", func(t *testing.T) {
+		m := newMocks()
+		m.expense.On("FindByReportID", mock.Anything, 10).Return(lines, nil).Once()
+		m.history.On("FindByExpenseID", mock.Anything, 11).Return([]*domain.StatusHistory{}, nil).Once()
+		m.user.On("FindByID", mock.Anything, 20).Return(submitter, nil).Once()
+		m.notifier.On("Notify", mock.Anything, managerID, "Approval reminder", "Expense report #10 has been waiting on your manager approval for 2 business days").Return(nil).Once()
+		for _, id := range []int{11, 13} {
+			id := id
+			m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+				return h.ExpenseID == id && h.Event == domain.HistoryEventReminder
+			})).Return(nil).Once()
+		}
+
+		m.worker(submittedAt.AddDate(0, 0, 2)).checkSLAs(ctx)
+		m.notifier.AssertExpectations(t)
+		m.history.AssertExpectations(t)
+		m.escalation.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("escalates the report's level", func(t *testing.T) {
+		m := newMocks()
+		m.expense.On("FindByReportID", mock.Anything, 10).Return(lines, nil).Once()
+		m.history.On("FindByExpenseID", mock.Anything, 11).Return([]*domain.StatusHistory{
+			{Event: domain.HistoryEventReminder, CreatedAt: submittedAt.AddDate(0, 0, 2)},
+		}, nil).Once()
+		m.user.On("FindByID", mock.Anything, 20).Return(submitter, nil).Once()
+		m.user.On("FindByID", mock.Anything, managerID).Return(manager, nil).Once()
+		for _, id := range []int{11, 13} {
+			id := id
+			m.escalation.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.Escalation) bool {
+				return e.ExpenseID == id && e.Level == domain.ApprovalLevelManager && e.EscalatedToID == seniorID
+			})).Return(nil).Once()
+			m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+				return h.ExpenseID == id && h.Event == domain.HistoryEventEscalation
+			})).Return(nil).Once()
+		}
+		m.notifier.On("Notify", mock.Anything, seniorID, "Approval escalated", "Expense report #10 missed its manager approval SLA and has been escalated to you").Return(nil).Once()
+
+		m.worker(submittedAt.AddDate(0, 0, 4)).checkSLAs(ctx)
+		m.escalation.AssertExpectations(t)
+		m.notifier.AssertExpectations(t)
+		m.history.AssertExpectations(t)
+	})
+}
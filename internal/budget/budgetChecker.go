@@ -0,0 +1,12 @@
+package budget
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+// BudgetChecker warns about the budgets a submitted expense would use up.
+type BudgetChecker interface {
+	Check(ctx context.Context, expense *domain.Expense) ([]*domain.BudgetWarning, error)
+}
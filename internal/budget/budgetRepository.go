@@ -0,0 +1,17 @@
+package budget
+
+import (
+	"context"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type BudgetRepository interface {
+	Create(ctx context.Context, budget *domain.Budget) error
+	FindByID(ctx context.Context, id int) (*domain.Budget, error)
+	FindAll(ctx context.Context, userID *int) ([]*domain.Budget, error)
+	Update(ctx context.Context, budget *domain.Budget) error
+	FindCovering(ctx context.Context, date time.Time, userID int, costCenterCodes []string) ([]*domain.Budget, error)
+	Consumption(ctx context.Context, budget *domain.Budget, statuses ...domain.ExpenseStatus) (int, int, error)
+}
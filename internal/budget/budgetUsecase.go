@@ -0,0 +1,14 @@
+package budget
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type BudgetUseCase interface {
+	CreateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error)
+	UpdateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error)
+	ListBudgets(ctx context.Context, userID int, role domain.Role) ([]*domain.Budget, error)
+	GetBudget(ctx context.Context, id int, userID int, role domain.Role) (*domain.BudgetUsage, error)
+}
@@ -0,0 +1,58 @@
+package checker
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/budget"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type budgetChecker struct {
+	budgetRepo budget.BudgetRepository
+	policy     domain.BudgetPolicy
+}
+
+func NewBudgetChecker(budgetRepo budget.BudgetRepository, policy domain.BudgetPolicy) budget.BudgetChecker {
+	return &budgetChecker{
+		budgetRepo: budgetRepo,
+		policy:     policy,
+	}
+}
+
+// Check looks up the submitter's budget and the budgets of the cost centers the expense
+// is allocated to for the period it was incurred in, and warns about each one the
+// expense would take past the warning share.
+func (c *budgetChecker) Check(ctx context.Context, expense *domain.Expense) ([]*domain.BudgetWarning, error) {
+	var codes []string
+	seen := make(map[string]bool)
+	for _, a := range expense.Allocations {
+		if !seen[a.CostCenterCode] {
+			seen[a.CostCenterCode] = true
+			codes = append(codes, a.CostCenterCode)
+		}
+	}
+
+	budgets, err := c.budgetRepo.FindCovering(ctx, expense.IncurredOn, expense.UserID, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []*domain.BudgetWarning
+	for _, b := range budgets {
+		charge := b.Charge(expense)
+		if charge == 0 {
+			continue
+		}
+
+		consumed, _, err := c.budgetRepo.Consumption(ctx, b, domain.BudgetConsumedStatuses...)
+		if err != nil {
+			return nil, err
+		}
+
+		if warning := c.policy.Check(b, consumed, charge); warning != nil {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return warnings, nil
+}
@@ -0,0 +1,80 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetCheckerCheck(t *testing.T) {
+	ctx := context.Background()
+	policy := domain.BudgetPolicy{WarningPercent: domain.DefaultBudgetWarningPercent}
+	userID := 4
+	incurredOn := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	own := &domain.Budget{ID: 3, Scope: domain.BudgetScopeUser, UserID: &userID, PeriodStart: start, PeriodEnd: end, AmountIDR: 5000000}
+	finance := &domain.Budget{ID: 5, Scope: domain.BudgetScopeCostCenter, CostCenterCode: "FIN", PeriodStart: start, PeriodEnd: end, AmountIDR: 20000000}
+	newExpense := func() *domain.Expense {
+		return &domain.Expense{UserID: userID, AmountIDR: 2000000, IncurredOn: incurredOn, Allocations: []*domain.Allocation{
+			{CostCenterCode: "FIN", AmountIDR: 1500000},
+			{CostCenterCode: "OPS", AmountIDR: 500000},
+		}}
+	}
+
+	t.Run("within budget", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		checker := NewBudgetChecker(budgetRepo, policy)
+		budgetRepo.On("FindCovering", mock.Anything, incurredOn, userID, []string{"FIN", "OPS"}).Return([]*domain.Budget{own, finance}, nil).Once()
+		budgetRepo.On("Consumption", mock.Anything, own, domain.ExpenseStatusApproved, domain.ExpenseStatusAutoApproved, domain.ExpenseStatusProcessing, domain.ExpenseStatusRetryScheduled, domain.ExpenseStatusDeadLetter, domain.ExpenseStatusCompleted).Return(1000000, 1, nil).Once()
+		budgetRepo.On("Consumption", mock.Anything, finance, domain.ExpenseStatusApproved, domain.ExpenseStatusAutoApproved, domain.ExpenseStatusProcessing, domain.ExpenseStatusRetryScheduled, domain.ExpenseStatusDeadLetter, domain.ExpenseStatusCompleted).Return(5000000, 4, nil).Once()
+
+		warnings, err := checker.Check(ctx, newExpense())
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+		budgetRepo.AssertExpectations(t)
+	})
+
+	t.Run("warns per budget", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		checker := NewBudgetChecker(budgetRepo, policy)
+		budgetRepo.On("FindCovering", mock.Anything, incurredOn, userID, []string{"FIN", "OPS"}).Return([]*domain.Budget{own, finance}, nil).Once()
+		budgetRepo.On("Consumption", mock.Anything, own, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(3500000, 2, nil).Once()
+		budgetRepo.On("Consumption", mock.Anything, finance, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(15000000, 9, nil).Once()
+
+		warnings, err := checker.Check(ctx, newExpense())
+		require.NoError(t, err)
+		require.Len(t, warnings, 2)
+		require.Equal(t, domain.BudgetExceeded, warnings[0].Level)
+		require.Equal(t, 2000000, warnings[0].ExpenseIDR)
+		require.Equal(t, domain.BudgetNearLimit, warnings[1].Level)
+		require.Equal(t, 1500000, warnings[1].ExpenseIDR)
+	})
+
+	t.Run("no budgets", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		checker := NewBudgetChecker(budgetRepo, policy)
+		budgetRepo.On("FindCovering", mock.Anything, incurredOn, userID, []string(nil)).Return([]*domain.Budget(nil), nil).Once()
+
+		warnings, err := checker.Check(ctx, &domain.Expense{UserID: userID, AmountIDR: 2000000, IncurredOn: incurredOn})
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+		budgetRepo.AssertNotCalled(t, "Consumption", mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		checker := NewBudgetChecker(budgetRepo, policy)
+		budgetRepo.On("FindCovering", mock.Anything, incurredOn, userID, []string{"FIN", "OPS"}).Return([]*domain.Budget{own}, nil).Once()
+		budgetRepo.On("Consumption", mock.Anything, own, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, 0, errors.New("db error")).Once()
+
+		_, err := checker.Check(ctx, newExpense())
+		require.Error(t, err)
+	})
+}
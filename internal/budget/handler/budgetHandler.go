@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/budget"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/gorilla/mux"
+)
+
+type BudgetHandler struct {
+	budgetUseCase budget.BudgetUseCase
+}
+
+func NewBudgetHandler(budgetUseCase budget.BudgetUseCase) *BudgetHandler {
+	return &BudgetHandler{budgetUseCase: budgetUseCase}
+}
+
+type budgetRequest struct {
+	Scope          domain.BudgetScope `json:"scope"`
+	CostCenterCode string             `json:"cost_center_code"`
+	UserID         *int               `json:"user_id"`
+	PeriodStart    time.Time          `json:"period_start"`
+	PeriodEnd      time.Time          `json:"period_end"`
+	AmountIDR      int                `json:"amount_idr"`
+}
+
+func (req budgetRequest) budget(id int) *domain.Budget {
+	return &domain.Budget{
+		ID:             id,
+		Scope:          req.Scope,
+		CostCenterCode: req.CostCenterCode,
+		UserID:         req.UserID,
+		PeriodStart:    req.PeriodStart,
+		PeriodEnd:      req.PeriodEnd,
+		AmountIDR:      req.AmountIDR,
+	}
+}
+
+// CreateBudget adds a budget. Without a period_end it covers the calendar month of
+// period_start.
+func (h *BudgetHandler) CreateBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req budgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	budget, err := h.budgetUseCase.CreateBudget(ctx, req.budget(0))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(budget)
+}
+
+func (h *BudgetHandler) UpdateBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid budget ID", http.StatusBadRequest)
+		return
+	}
+
+	var req budgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	budget, err := h.budgetUseCase.UpdateBudget(ctx, req.budget(id))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(budget)
+}
+
+// ListBudgets returns every budget to finance and admins, and the caller's own per-user
+// budgets to everyone else.
+func (h *BudgetHandler) ListBudgets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	role, _ := middleware.GetUserRoleFromContext(ctx)
+
+	budgets, err := h.budgetUseCase.ListBudgets(ctx, userID, role)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(budgets)
+}
+
+// GetBudget returns the budget together with what has been spent against it.
+func (h *BudgetHandler) GetBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	role, _ := middleware.GetUserRoleFromContext(ctx)
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid budget ID", http.StatusBadRequest)
+		return
+	}
+
+	usage, err := h.budgetUseCase.GetBudget(ctx, id, userID, role)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(usage)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidBudget):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrBudgetNotFound):
+		http.Error(w, "Budget not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrUnauthorizedAction):
+		http.Error(w, "Access denied", http.StatusForbidden)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func withRole(req *http.Request, userID int, role domain.Role) *http.Request {
+	mockAuth := new(mocks.AuthService)
+	mockAuth.On("ValidateToken", mock.Anything, "t").Return(userID, role, nil).Maybe()
+	rr := httptest.NewRecorder()
+	next := middleware.AuthMiddleware(mockAuth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+	}))
+	req.Header.Set("Authorization", "Bearer t")
+	next.ServeHTTP(rr, req)
+	return req
+}
+
+func TestBudgetHandlerCreateBudget(t *testing.T) {
+	t.Run("invalid budget", func(t *testing.T) {
+		mockUC := new(mocks.BudgetUseCase)
+		h := NewBudgetHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/budgets", strings.NewReader(`{"scope":"team","amount_idr":1000}`))
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateBudget", mock.Anything, mock.AnythingOfType("*domain.Budget")).Return((*domain.Budget)(nil), domain.ErrInvalidBudget).Once()
+
+		h.CreateBudget(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.BudgetUseCase)
+		h := NewBudgetHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/budgets", strings.NewReader(`{"scope":"cost_center","cost_center_code":"FIN","period_start":"2026-03-01T00:00:00Z","amount_idr":50000000}`))
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateBudget", mock.Anything, mock.MatchedBy(func(b *domain.Budget) bool {
+			return b.Scope == domain.BudgetScopeCostCenter && b.CostCenterCode == "FIN" && b.AmountIDR == 50000000
+		})).Return(&domain.Budget{ID: 3, Scope: domain.BudgetScopeCostCenter, CostCenterCode: "FIN", AmountIDR: 50000000}, nil).Once()
+
+		h.CreateBudget(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.Contains(t, rr.Body.String(), `"id":3`)
+	})
+}
+
+func TestBudgetHandlerGetBudget(t *testing.T) {
+	t.Run("budget vs actual", func(t *testing.T) {
+		mockUC := new(mocks.BudgetUseCase)
+		h := NewBudgetHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/budgets/3", nil)
+		req = withRole(req, 9, domain.RoleCFO)
+		req = mux.SetURLVars(req, map[string]string{"id": "3"})
+		rr := httptest.NewRecorder()
+		usage := domain.NewBudgetUsage(&domain.Budget{ID: 3, Scope: domain.BudgetScopeCostCenter, CostCenterCode: "FIN", AmountIDR: 20000000}, 15000000, 7)
+		mockUC.On("GetBudget", mock.Anything, 3, 9, domain.RoleCFO).Return(usage, nil).Once()
+
+		h.GetBudget(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"actual_idr":15000000`)
+		require.Contains(t, rr.Body.String(), `"remaining_idr":5000000`)
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		mockUC := new(mocks.BudgetUseCase)
+		h := NewBudgetHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/budgets/3", nil)
+		req = withRole(req, 4, domain.RoleEmployee)
+		req = mux.SetURLVars(req, map[string]string{"id": "3"})
+		rr := httptest.NewRecorder()
+		mockUC.On("GetBudget", mock.Anything, 3, 4, domain.RoleEmployee).Return((*domain.BudgetUsage)(nil), domain.ErrUnauthorizedAction).Once()
+
+		h.GetBudget(rr, req)
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockUC := new(mocks.BudgetUseCase)
+		h := NewBudgetHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/budgets/9", nil)
+		req = withRole(req, 1, domain.RoleAdmin)
+		req = mux.SetURLVars(req, map[string]string{"id": "9"})
+		rr := httptest.NewRecorder()
+		mockUC.On("GetBudget", mock.Anything, 9, 1, domain.RoleAdmin).Return((*domain.BudgetUsage)(nil), domain.ErrBudgetNotFound).Once()
+
+		h.GetBudget(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
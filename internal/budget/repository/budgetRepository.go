@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/budget"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+)
+
+type budgetRepository struct {
+	db *sql.DB
+}
+
+func NewBudgetRepository(db *sql.DB) budget.BudgetRepository {
+	return &budgetRepository{db: db}
+}
+
+func (r *budgetRepository) Create(ctx context.Context, budget *domain.Budget) error {
+	query := `
+		INSERT INTO budgets (scope, cost_center_code, user_id, period_start, period_end, amount_idr)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		budget.Scope,
+		budget.CostCenterCode,
+		budget.UserID,
+		budget.PeriodStart,
+		budget.PeriodEnd,
+		budget.AmountIDR,
+	).Scan(&budget.ID, &budget.CreatedAt, &budget.UpdatedAt)
+}
+
+func (r *budgetRepository) FindByID(ctx context.Context, id int) (*domain.Budget, error) {
+	query := `
+		SELECT id, scope, cost_center_code, user_id, period_start, period_end, amount_idr, created_at, updated_at
+		FROM budgets
+		WHERE id = $1
+	`
+
+	budget := &domain.Budget{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&budget.ID,
+		&budget.Scope,
+		&budget.CostCenterCode,
+		&budget.UserID,
+		&budget.PeriodStart,
+		&budget.PeriodEnd,
+		&budget.AmountIDR,
+		&budget.CreatedAt,
+		&budget.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// FindAll returns every budget, latest period first, or only the per-user budgets of
+// userID when it is set.
+func (r *budgetRepository) FindAll(ctx context.Context, userID *int) ([]*domain.Budget, error) {
+	query := `
+		SELECT id, scope, cost_center_code, user_id, period_start, period_end, amount_idr, created_at, updated_at
+		FROM budgets
+		WHERE $1::INTEGER IS NULL OR user_id = $1
+		ORDER BY period_start DESC, id ASC
+	`
+
+	return r.findMany(ctx, query, userID)
+}
+
+// FindCovering returns the budgets whose period includes date and that belong to the
+// user or to one of the cost centers.
+func (r *budgetRepository) FindCovering(ctx context.Context, date time.Time, userID int, costCenterCodes []string) ([]*domain.Budget, error) {
+	query := `
+		SELECT id, scope, cost_center_code, user_id, period_start, period_end, amount_idr, created_at, updated_at
+		FROM budgets
+		WHERE period_start <= $1 AND period_end >= $1
+			AND ((scope = 'user' AND user_id = $2) OR (scope = 'cost_center' AND cost_center_code = ANY($3)))
+		ORDER BY id ASC
+	`
+
+	return r.findMany(ctx, query, date, userID, pq.Array(costCenterCodes))
+}
+
+func (r *budgetRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*domain.Budget, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var budgets []*domain.Budget
+	for rows.Next() {
+		budget := &domain.Budget{}
+		err := rows.Scan(
+			&budget.ID,
+			&budget.Scope,
+			&budget.CostCenterCode,
+			&budget.UserID,
+			&budget.PeriodStart,
+			&budget.PeriodEnd,
+			&budget.AmountIDR,
+			&budget.CreatedAt,
+			&budget.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, budget)
+	}
+
+	return budgets, rows.Err()
+}
+
+// Update overwrites the stored budget and returns domain.ErrBudgetNotFound when no row
+// has its id.
+func (r *budgetRepository) Update(ctx context.Context, budget *domain.Budget) error {
+	query := `
+		UPDATE budgets
+		SET scope = $1, cost_center_code = $2, user_id = $3, period_start = $4, period_end = $5,
+			amount_idr = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $7
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		budget.Scope,
+		budget.CostCenterCode,
+		budget.UserID,
+		budget.PeriodStart,
+		budget.PeriodEnd,
+		budget.AmountIDR,
+		budget.ID,
+	).Scan(&budget.CreatedAt, &budget.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domain.ErrBudgetNotFound
+	}
+
+	return err
+}
+
+// Consumption sums the IDR amounts of the expenses in the given statuses that were
+// incurred in the budget's period and count against it, and counts those expenses. A
+// cost center budget only takes the allocated part of each expense.
+func (r *budgetRepository) Consumption(ctx context.Context, budget *domain.Budget, statuses ...domain.ExpenseStatus) (int, int, error) {
+	query := `
+		SELECT COALESCE(SUM(amount_idr), 0), COUNT(*)
+		FROM expenses
+		WHERE user_id = $1 AND incurred_on BETWEEN $2 AND $3 AND status = ANY($4)
+	`
+	var holder interface{} = budget.UserID
+	if budget.Scope == domain.BudgetScopeCostCenter {
+		query = `
+			SELECT COALESCE(SUM(a.amount_idr), 0), COUNT(DISTINCT e.id)
+			FROM expense_allocations a
+			JOIN expenses e ON e.id = a.expense_id
+			WHERE a.cost_center_code = $1 AND e.incurred_on BETWEEN $2 AND $3 AND e.status = ANY($4)
+		`
+		holder = budget.CostCenterCode
+	}
+
+	var amount, count int
+	err := r.db.QueryRowContext(ctx, query, holder, budget.PeriodStart, budget.PeriodEnd, statusNames(statuses)).Scan(&amount, &count)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return amount, count, nil
+}
+
+func statusNames(statuses []domain.ExpenseStatus) interface{} {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return pq.Array(names)
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var budgetColumns = []string{"id", "scope", "cost_center_code", "user_id", "period_start", "period_end", "amount_idr", "created_at", "updated_at"}
+
+func TestBudgetRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &budgetRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO budgets (scope, cost_center_code, user_id, period_start, period_end, amount_idr)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`)
+	start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	budget := &domain.Budget{Scope: domain.BudgetScopeCostCenter, CostCenterCode: "FIN", PeriodStart: start, PeriodEnd: end, AmountIDR: 50000000}
+	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(3, now, now)
+	mock.ExpectQuery(query).WithArgs(domain.BudgetScopeCostCenter, "FIN", nil, start, end, 50000000).WillReturnRows(rows)
+
+	require.NoError(t, repo.Create(context.Background(), budget))
+	require.Equal(t, 3, budget.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBudgetRepositoryFind(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &budgetRepository{db: db}
+	start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	byID := regexp.QuoteMeta(`
+		SELECT id, scope, cost_center_code, user_id, period_start, period_end, amount_idr, created_at, updated_at
+		FROM budgets
+		WHERE id = $1
+	`)
+
+	t.Run("by id", func(t *testing.T) {
+		rows := sqlmock.NewRows(budgetColumns).AddRow(3, "user", "", 4, start, end, 5000000, now, now)
+		mock.ExpectQuery(byID).WithArgs(3).WillReturnRows(rows)
+
+		budget, findErr := repo.FindByID(context.Background(), 3)
+		require.NoError(t, findErr)
+		require.Equal(t, domain.BudgetScopeUser, budget.Scope)
+		require.Equal(t, 4, *budget.UserID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(byID).WithArgs(9).WillReturnRows(sqlmock.NewRows(budgetColumns))
+
+		budget, findErr := repo.FindByID(context.Background(), 9)
+		require.NoError(t, findErr)
+		require.Nil(t, budget)
+	})
+
+	t.Run("covering a date", func(t *testing.T) {
+		query := regexp.QuoteMeta(`
+		SELECT id, scope, cost_center_code, user_id, period_start, period_end, amount_idr, created_at, updated_at
+		FROM budgets
+		WHERE period_start <= $1 AND period_end >= $1
+			AND ((scope = 'user' AND user_id = $2) OR (scope = 'cost_center' AND cost_center_code = ANY($3)))
+		ORDER BY id ASC
+	`)
+		date := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+		rows := sqlmock.NewRows(budgetColumns).
+			AddRow(3, "user", "", 4, start, end, 5000000, now, now).
+			AddRow(5, "cost_center", "FIN", nil, start, end, 50000000, now, now)
+		mock.ExpectQuery(query).WithArgs(date, 4, pq.Array([]string{"FIN"})).WillReturnRows(rows)
+
+		budgets, findErr := repo.FindCovering(context.Background(), date, 4, []string{"FIN"})
+		require.NoError(t, findErr)
+		require.Len(t, budgets, 2)
+		require.Nil(t, budgets[1].UserID)
+		require.Equal(t, "FIN", budgets[1].CostCenterCode)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBudgetRepositoryUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &budgetRepository{db: db}
+	query := regexp.QuoteMeta(`
+		UPDATE budgets
+		SET scope = $1, cost_center_code = $2, user_id = $3, period_start = $4, period_end = $5,
+			amount_idr = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $7
+		RETURNING created_at, updated_at
+	`)
+
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}))
+
+	updateErr := repo.Update(context.Background(), &domain.Budget{ID: 9, Scope: domain.BudgetScopeCostCenter, CostCenterCode: "FIN"})
+	require.ErrorIs(t, updateErr, domain.ErrBudgetNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBudgetRepositoryConsumption(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &budgetRepository{db: db}
+	start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	statuses := pq.Array([]string{"approved", "completed"})
+
+	t.Run("user budget", func(t *testing.T) {
+		userID := 4
+		query := regexp.QuoteMeta(`
+		SELECT COALESCE(SUM(amount_idr), 0), COUNT(*)
+		FROM expenses
+		WHERE user_id = $1 AND incurred_on BETWEEN $2 AND $3 AND status = ANY($4)
+	`)
+		mock.ExpectQuery(query).WithArgs(userID, start, end, statuses).
+			WillReturnRows(sqlmock.NewRows([]string{"sum", "count"}).AddRow(3200000, 3))
+
+		amount, count, consumptionErr := repo.Consumption(context.Background(),
+			&domain.Budget{Scope: domain.BudgetScopeUser, UserID: &userID, PeriodStart: start, PeriodEnd: end},
+			domain.ExpenseStatusApproved, domain.ExpenseStatusCompleted)
+		require.NoError(t, consumptionErr)
+		require.Equal(t, 3200000, amount)
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("cost center budget", func(t *testing.T) {
+		query := regexp.QuoteMeta(`
+			SELECT COALESCE(SUM(a.amount_idr), 0), COUNT(DISTINCT e.id)
+			FROM expense_allocations a
+			JOIN expenses e ON e.id = a.expense_id
+			WHERE a.cost_center_code = $1 AND e.incurred_on BETWEEN $2 AND $3 AND e.status = ANY($4)
+		`)
+		mock.ExpectQuery(query).WithArgs("FIN", start, end, statuses).
+			WillReturnRows(sqlmock.NewRows([]string{"sum", "count"}).AddRow(0, 0))
+
+		amount, count, consumptionErr := repo.Consumption(context.Background(),
+			&domain.Budget{Scope: domain.BudgetScopeCostCenter, CostCenterCode: "FIN", PeriodStart: start, PeriodEnd: end},
+			domain.ExpenseStatusApproved, domain.ExpenseStatusCompleted)
+		require.NoError(t, consumptionErr)
+		require.Zero(t, amount)
+		require.Zero(t, count)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
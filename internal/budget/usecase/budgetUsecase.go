@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/budget"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type budgetUseCase struct {
+	budgetRepo budget.BudgetRepository
+}
+
+func NewBudgetUseCase(budgetRepo budget.BudgetRepository) budget.BudgetUseCase {
+	return &budgetUseCase{budgetRepo: budgetRepo}
+}
+
+func (uc *budgetUseCase) CreateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error) {
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := uc.budgetRepo.Create(ctx, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// UpdateBudget replaces a budget's definition. Its consumption is worked out again from
+// the expenses whenever it is read.
+func (uc *budgetUseCase) UpdateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error) {
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := uc.budgetRepo.Update(ctx, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// ListBudgets returns every budget to finance and admins, and only their own per-user
+// budgets to everyone else.
+func (uc *budgetUseCase) ListBudgets(ctx context.Context, userID int, role domain.Role) ([]*domain.Budget, error) {
+	if canSeeAllBudgets(role) {
+		return uc.budgetRepo.FindAll(ctx, nil)
+	}
+
+	return uc.budgetRepo.FindAll(ctx, &userID)
+}
+
+// GetBudget compares the budget with the approved and paid expenses charged to it.
+func (uc *budgetUseCase) GetBudget(ctx context.Context, id int, userID int, role domain.Role) (*domain.BudgetUsage, error) {
+	budget, err := uc.budgetRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if budget == nil {
+		return nil, domain.ErrBudgetNotFound
+	}
+
+	own := budget.UserID != nil && *budget.UserID == userID
+	if !own && !canSeeAllBudgets(role) {
+		return nil, domain.ErrUnauthorizedAction
+	}
+
+	actual, count, err := uc.budgetRepo.Consumption(ctx, budget, domain.BudgetConsumedStatuses...)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewBudgetUsage(budget, actual, count), nil
+}
+
+func canSeeAllBudgets(role domain.Role) bool {
+	return role.IsFinance() || role == domain.RoleAdmin
+}
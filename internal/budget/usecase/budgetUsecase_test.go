@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBudget(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("monthly cost center budget", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		uc := NewBudgetUseCase(budgetRepo)
+		budgetRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Budget) bool {
+			return b.CostCenterCode == "FIN" && b.PeriodEnd.Equal(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+		})).Return(nil).Once()
+
+		result, err := uc.CreateBudget(ctx, &domain.Budget{Scope: domain.BudgetScopeCostCenter, CostCenterCode: "fin", PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), AmountIDR: 50000000})
+		require.NoError(t, err)
+		require.Equal(t, "FIN", result.CostCenterCode)
+	})
+
+	t.Run("invalid budget", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		uc := NewBudgetUseCase(budgetRepo)
+
+		_, err := uc.CreateBudget(ctx, &domain.Budget{Scope: domain.BudgetScopeUser, PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), AmountIDR: 50000000})
+		require.ErrorIs(t, err, domain.ErrInvalidBudget)
+		budgetRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetBudget(t *testing.T) {
+	ctx := context.Background()
+	userID := 4
+	own := &domain.Budget{ID: 3, Scope: domain.BudgetScopeUser, UserID: &userID, AmountIDR: 5000000}
+	finance := &domain.Budget{ID: 5, Scope: domain.BudgetScopeCostCenter, CostCenterCode: "FIN", AmountIDR: 20000000}
+
+	t.Run("finance sees budget vs actual", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		uc := NewBudgetUseCase(budgetRepo)
+		budgetRepo.On("FindByID", mock.Anything, 5).Return(finance, nil).Once()
+		budgetRepo.On("Consumption", mock.Anything, finance, domain.ExpenseStatusApproved, domain.ExpenseStatusAutoApproved, domain.ExpenseStatusProcessing, domain.ExpenseStatusRetryScheduled, domain.ExpenseStatusDeadLetter, domain.ExpenseStatusCompleted).Return(15000000, 7, nil).Once()
+
+		usage, err := uc.GetBudget(ctx, 5, 9, domain.RoleFinanceDirector)
+		require.NoError(t, err)
+		require.Equal(t, 15000000, usage.ActualIDR)
+		require.Equal(t, 5000000, usage.RemainingIDR)
+		require.Equal(t, 75.0, usage.UsedPercent)
+		require.Equal(t, 7, usage.ExpenseCount)
+	})
+
+	t.Run("own budget", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		uc := NewBudgetUseCase(budgetRepo)
+		budgetRepo.On("FindByID", mock.Anything, 3).Return(own, nil).Once()
+		budgetRepo.On("Consumption", mock.Anything, own, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, 0, nil).Once()
+
+		usage, err := uc.GetBudget(ctx, 3, userID, domain.RoleEmployee)
+		require.NoError(t, err)
+		require.Equal(t, 5000000, usage.RemainingIDR)
+	})
+
+	t.Run("someone else's budget", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		uc := NewBudgetUseCase(budgetRepo)
+		budgetRepo.On("FindByID", mock.Anything, 5).Return(finance, nil).Once()
+
+		_, err := uc.GetBudget(ctx, 5, userID, domain.RoleManager)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		budgetRepo.AssertNotCalled(t, "Consumption", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		uc := NewBudgetUseCase(budgetRepo)
+		budgetRepo.On("FindByID", mock.Anything, 9).Return((*domain.Budget)(nil), nil).Once()
+
+		_, err := uc.GetBudget(ctx, 9, userID, domain.RoleAdmin)
+		require.ErrorIs(t, err, domain.ErrBudgetNotFound)
+	})
+}
+
+func TestListBudgets(t *testing.T) {
+	ctx := context.Background()
+	userID := 4
+
+	t.Run("admins see every budget", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		uc := NewBudgetUseCase(budgetRepo)
+		budgetRepo.On("FindAll", mock.Anything, (*int)(nil)).Return([]*domain.Budget{{ID: 3}, {ID: 5}}, nil).Once()
+
+		budgets, err := uc.ListBudgets(ctx, 1, domain.RoleAdmin)
+		require.NoError(t, err)
+		require.Len(t, budgets, 2)
+	})
+
+	t.Run("employees see their own", func(t *testing.T) {
+		budgetRepo := new(mocks.BudgetRepository)
+		uc := NewBudgetUseCase(budgetRepo)
+		budgetRepo.On("FindAll", mock.Anything, &userID).Return([]*domain.Budget{{ID: 3}}, nil).Once()
+
+		budgets, err := uc.ListBudgets(ctx, userID, domain.RoleEmployee)
+		require.NoError(t, err)
+		require.Len(t, budgets, 1)
+	})
+}
@@ -0,0 +1,14 @@
+package category
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *domain.Category) error
+	FindByID(ctx context.Context, id int) (*domain.Category, error)
+	FindAll(ctx context.Context, includeInactive bool) ([]*domain.Category, error)
+	Update(ctx context.Context, category *domain.Category) error
+}
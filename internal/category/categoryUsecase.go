@@ -0,0 +1,15 @@
+package category
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type CategoryUseCase interface {
+	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	GetCategory(ctx context.Context, id int) (*domain.Category, error)
+	ListCategories(ctx context.Context, includeInactive bool) ([]*domain.Category, error)
+	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	DeactivateCategory(ctx context.Context, id int) error
+}
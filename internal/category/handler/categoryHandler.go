@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/evrintobing17/expense-management-backend/internal/category"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/gorilla/mux"
+)
+
+type CategoryHandler struct {
+	categoryUseCase category.CategoryUseCase
+}
+
+func NewCategoryHandler(categoryUseCase category.CategoryUseCase) *CategoryHandler {
+	return &CategoryHandler{categoryUseCase: categoryUseCase}
+}
+
+type categoryRequest struct {
+	Name            string `json:"name"`
+	MinAmountIDR    int    `json:"min_amount_idr"`
+	MaxAmountIDR    int    `json:"max_amount_idr"`
+	ReceiptRequired bool   `json:"receipt_required"`
+	Active          *bool  `json:"active"`
+}
+
+func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req categoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	category, err := h.categoryUseCase.CreateCategory(ctx, &domain.Category{
+		Name:            req.Name,
+		MinAmountIDR:    req.MinAmountIDR,
+		MaxAmountIDR:    req.MaxAmountIDR,
+		ReceiptRequired: req.ReceiptRequired,
+	})
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(category)
+}
+
+// ListCategories returns the active categories. Admins can pass include_inactive=true to
+// see deactivated ones as well.
+func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	role, _ := middleware.GetUserRoleFromContext(ctx)
+	includeInactive := role == domain.RoleAdmin && r.URL.Query().Get("include_inactive") == "true"
+
+	categories, err := h.categoryUseCase.ListCategories(ctx, includeInactive)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(categories)
+}
+
+func (h *CategoryHandler) GetCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	category, err := h.categoryUseCase.GetCategory(ctx, id)
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(category)
+}
+
+func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	var req categoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	category, err := h.categoryUseCase.UpdateCategory(ctx, &domain.Category{
+		ID:              id,
+		Name:            req.Name,
+		MinAmountIDR:    req.MinAmountIDR,
+		MaxAmountIDR:    req.MaxAmountIDR,
+		ReceiptRequired: req.ReceiptRequired,
+		Active:          active,
+	})
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(category)
+}
+
+// DeleteCategory deactivates the category; expenses already filed under it keep it.
+func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.categoryUseCase.DeactivateCategory(ctx, id)
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeCategoryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCategory):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrCategoryNotFound):
+		http.Error(w, "Category not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrDuplicateCategory):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
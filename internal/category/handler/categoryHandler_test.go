@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func withRole(req *http.Request, userID int, role domain.Role) *http.Request {
+	mockAuth := new(mocks.AuthService)
+	mockAuth.On("ValidateToken", mock.Anything, "t").Return(userID, role, nil).Maybe()
+	rr := httptest.NewRecorder()
+	next := middleware.AuthMiddleware(mockAuth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+	}))
+	req.Header.Set("Authorization", "Bearer t")
+	next.ServeHTTP(rr, req)
+	return req
+}
+
+func TestCategoryHandlerCreateCategory(t *testing.T) {
+	t.Run("invalid category", func(t *testing.T) {
+		mockUC := new(mocks.CategoryUseCase)
+		h := NewCategoryHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(`{"name":"Meals","min_amount_idr":0}`))
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateCategory", mock.Anything, mock.AnythingOfType("*domain.Category")).Return((*domain.Category)(nil), domain.ErrInvalidCategory).Once()
+
+		h.CreateCategory(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		mockUC := new(mocks.CategoryUseCase)
+		h := NewCategoryHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(`{"name":"Meals","min_amount_idr":10000,"max_amount_idr":500000}`))
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateCategory", mock.Anything, mock.AnythingOfType("*domain.Category")).Return((*domain.Category)(nil), domain.ErrDuplicateCategory).Once()
+
+		h.CreateCategory(rr, req)
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.CategoryUseCase)
+		h := NewCategoryHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(`{"name":"Meals","min_amount_idr":10000,"max_amount_idr":500000,"receipt_required":true}`))
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateCategory", mock.Anything, mock.MatchedBy(func(c *domain.Category) bool {
+			return c.Name == "Meals" && c.MinAmountIDR == 10000 && c.MaxAmountIDR == 500000 && c.ReceiptRequired
+		})).Return(&domain.Category{ID: 3, Name: "Meals", Active: true}, nil).Once()
+
+		h.CreateCategory(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.Contains(t, rr.Body.String(), `"id":3`)
+	})
+}
+
+func TestCategoryHandlerListCategories(t *testing.T) {
+	t.Run("employees only see active categories", func(t *testing.T) {
+		mockUC := new(mocks.CategoryUseCase)
+		h := NewCategoryHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/categories?include_inactive=true", nil)
+		req = withRole(req, 1, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+		mockUC.On("ListCategories", mock.Anything, false).Return([]*domain.Category{{ID: 3, Name: "Meals"}}, nil).Once()
+
+		h.ListCategories(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("admins can include inactive categories", func(t *testing.T) {
+		mockUC := new(mocks.CategoryUseCase)
+		h := NewCategoryHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/categories?include_inactive=true", nil)
+		req = withRole(req, 9, domain.RoleAdmin)
+		rr := httptest.NewRecorder()
+		mockUC.On("ListCategories", mock.Anything, true).Return([]*domain.Category{}, nil).Once()
+
+		h.ListCategories(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		mockUC.AssertExpectations(t)
+	})
+}
+
+func TestCategoryHandlerUpdateAndDelete(t *testing.T) {
+	t.Run("update keeps category active by default", func(t *testing.T) {
+		mockUC := new(mocks.CategoryUseCase)
+		h := NewCategoryHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPut, "/categories/3", strings.NewReader(`{"name":"Meals","min_amount_idr":10000,"max_amount_idr":750000}`))
+		req = mux.SetURLVars(req, map[string]string{"id": "3"})
+		rr := httptest.NewRecorder()
+		mockUC.On("UpdateCategory", mock.Anything, mock.MatchedBy(func(c *domain.Category) bool {
+			return c.ID == 3 && c.MaxAmountIDR == 750000 && c.Active
+		})).Return(&domain.Category{ID: 3, Name: "Meals", Active: true}, nil).Once()
+
+		h.UpdateCategory(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("update unknown category", func(t *testing.T) {
+		mockUC := new(mocks.CategoryUseCase)
+		h := NewCategoryHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPut, "/categories/9", strings.NewReader(`{"name":"Meals","min_amount_idr":10000,"max_amount_idr":750000}`))
+		req = mux.SetURLVars(req, map[string]string{"id": "9"})
+		rr := httptest.NewRecorder()
+		mockUC.On("UpdateCategory", mock.Anything, mock.AnythingOfType("*domain.Category")).Return((*domain.Category)(nil), domain.ErrCategoryNotFound).Once()
+
+		h.UpdateCategory(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("delete deactivates", func(t *testing.T) {
+		mockUC := new(mocks.CategoryUseCase)
+		h := NewCategoryHandler(mockUC)
+		req := httptest.NewRequest(http.MethodDelete, "/categories/3", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": "3"})
+		rr := httptest.NewRecorder()
+		mockUC.On("DeactivateCategory", mock.Anything, 3).Return(nil).Once()
+
+		h.DeleteCategory(rr, req)
+		require.Equal(t, http.StatusNoContent, rr.Code)
+	})
+}
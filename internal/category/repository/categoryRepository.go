@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/category"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+)
+
+const uniqueViolation = "23505"
+
+type categoryRepository struct {
+	db *sql.DB
+}
+
+func NewCategoryRepository(db *sql.DB) category.CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category *domain.Category) error {
+	query := `
+		INSERT INTO expense_categories (name, min_amount_idr, max_amount_idr, receipt_required, active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		category.Name,
+		category.MinAmountIDR,
+		category.MaxAmountIDR,
+		category.ReceiptRequired,
+		category.Active,
+	).Scan(&category.ID, &category.CreatedAt, &category.UpdatedAt)
+
+	return duplicateName(err)
+}
+
+func (r *categoryRepository) FindByID(ctx context.Context, id int) (*domain.Category, error) {
+	query := `
+		SELECT id, name, min_amount_idr, max_amount_idr, receipt_required, active, created_at, updated_at
+		FROM expense_categories
+		WHERE id = $1
+	`
+
+	category := &domain.Category{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&category.ID,
+		&category.Name,
+		&category.MinAmountIDR,
+		&category.MaxAmountIDR,
+		&category.ReceiptRequired,
+		&category.Active,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (r *categoryRepository) FindAll(ctx context.Context, includeInactive bool) ([]*domain.Category, error) {
+	query := `
+		SELECT id, name, min_amount_idr, max_amount_idr, receipt_required, active, created_at, updated_at
+		FROM expense_categories
+		WHERE active = true OR $1
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []*domain.Category
+	for rows.Next() {
+		category := &domain.Category{}
+		err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.MinAmountIDR,
+			&category.MaxAmountIDR,
+			&category.ReceiptRequired,
+			&category.Active,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+// Update overwrites the stored category and returns domain.ErrCategoryNotFound when no
+// row has its id.
+func (r *categoryRepository) Update(ctx context.Context, category *domain.Category) error {
+	query := `
+		UPDATE expense_categories
+		SET name = $1, min_amount_idr = $2, max_amount_idr = $3, receipt_required = $4, active = $5,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		category.Name,
+		category.MinAmountIDR,
+		category.MaxAmountIDR,
+		category.ReceiptRequired,
+		category.Active,
+		category.ID,
+	).Scan(&category.CreatedAt, &category.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domain.ErrCategoryNotFound
+	}
+
+	return duplicateName(err)
+}
+
+// duplicateName turns the unique name violation into domain.ErrDuplicateCategory.
+func duplicateName(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+		return domain.ErrDuplicateCategory
+	}
+	return err
+}
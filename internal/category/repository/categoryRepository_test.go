@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var categoryColumns = []string{"id", "name", "min_amount_idr", "max_amount_idr", "receipt_required", "active", "created_at", "updated_at"}
+
+func TestCategoryRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &categoryRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO expense_categories (name, min_amount_idr, max_amount_idr, receipt_required, active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`)
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		category := &domain.Category{Name: "Meals", MinAmountIDR: 10000, MaxAmountIDR: 500000, ReceiptRequired: true, Active: true}
+		rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(3, now, now)
+		mock.ExpectQuery(query).WithArgs("Meals", 10000, 500000, true, true).WillReturnRows(rows)
+
+		createErr := repo.Create(context.Background(), category)
+		require.NoError(t, createErr)
+		require.Equal(t, 3, category.ID)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		mock.ExpectQuery(query).WillReturnError(&pq.Error{Code: uniqueViolation})
+
+		createErr := repo.Create(context.Background(), &domain.Category{Name: "Meals"})
+		require.ErrorIs(t, createErr, domain.ErrDuplicateCategory)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryRepositoryFind(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &categoryRepository{db: db}
+	now := time.Now()
+
+	byID := regexp.QuoteMeta(`
+		SELECT id, name, min_amount_idr, max_amount_idr, receipt_required, active, created_at, updated_at
+		FROM expense_categories
+		WHERE id = $1
+	`)
+	mock.ExpectQuery(byID).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows(categoryColumns).AddRow(3, "Meals", 10000, 500000, true, true, now, now))
+	category, findErr := repo.FindByID(context.Background(), 3)
+	require.NoError(t, findErr)
+	require.Equal(t, "Meals", category.Name)
+	require.True(t, category.ReceiptRequired)
+
+	mock.ExpectQuery(byID).WithArgs(9).WillReturnError(sql.ErrNoRows)
+	category, findErr = repo.FindByID(context.Background(), 9)
+	require.NoError(t, findErr)
+	require.Nil(t, category)
+
+	all := regexp.QuoteMeta(`
+		SELECT id, name, min_amount_idr, max_amount_idr, receipt_required, active, created_at, updated_at
+		FROM expense_categories
+		WHERE active = true OR $1
+		ORDER BY name ASC
+	`)
+	mock.ExpectQuery(all).WithArgs(false).
+		WillReturnRows(sqlmock.NewRows(categoryColumns).
+			AddRow(3, "Meals", 10000, 500000, true, true, now, now).
+			AddRow(2, "Travel", 10000, 50000000, false, true, now, now))
+	categories, findErr := repo.FindAll(context.Background(), false)
+	require.NoError(t, findErr)
+	require.Len(t, categories, 2)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryRepositoryUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &categoryRepository{db: db}
+	query := regexp.QuoteMeta(`
+		UPDATE expense_categories
+		SET name = $1, min_amount_idr = $2, max_amount_idr = $3, receipt_required = $4, active = $5,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6
+		RETURNING created_at, updated_at
+	`)
+	now := time.Now()
+	category := &domain.Category{ID: 3, Name: "Meals", MinAmountIDR: 10000, MaxAmountIDR: 750000}
+
+	mock.ExpectQuery(query).WithArgs("Meals", 10000, 750000, false, false, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	require.NoError(t, repo.Update(context.Background(), category))
+	require.Equal(t, now, category.UpdatedAt)
+
+	mock.ExpectQuery(query).WithArgs("Meals", 10000, 750000, false, false, 3).WillReturnError(sql.ErrNoRows)
+	require.ErrorIs(t, repo.Update(context.Background(), category), domain.ErrCategoryNotFound)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
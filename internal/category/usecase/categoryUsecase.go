@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/category"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type categoryUseCase struct {
+	categoryRepo category.CategoryRepository
+}
+
+func NewCategoryUseCase(categoryRepo category.CategoryRepository) category.CategoryUseCase {
+	return &categoryUseCase{categoryRepo: categoryRepo}
+}
+
+// CreateCategory adds an active category to the catalog.
+func (uc *categoryUseCase) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	category.Active = true
+	err := uc.categoryRepo.Create(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (uc *categoryUseCase) GetCategory(ctx context.Context, id int) (*domain.Category, error) {
+	category, err := uc.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, domain.ErrCategoryNotFound
+	}
+
+	return category, nil
+}
+
+func (uc *categoryUseCase) ListCategories(ctx context.Context, includeInactive bool) ([]*domain.Category, error) {
+	return uc.categoryRepo.FindAll(ctx, includeInactive)
+}
+
+// UpdateCategory replaces a category's definition. New limits only apply to expenses
+// created or edited afterwards.
+func (uc *categoryUseCase) UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := uc.categoryRepo.Update(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// DeactivateCategory hides a category from new expenses. Existing expenses keep it.
+func (uc *categoryUseCase) DeactivateCategory(ctx context.Context, id int) error {
+	category, err := uc.GetCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !category.Active {
+		return nil
+	}
+
+	category.Active = false
+	return uc.categoryRepo.Update(ctx, category)
+}
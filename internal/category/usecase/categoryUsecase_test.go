@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCategory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		uc := NewCategoryUseCase(mockRepo)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.Category) bool {
+			return c.Name == "Meals" && c.Active
+		})).Return(nil).Once()
+
+		result, err := uc.CreateCategory(ctx, &domain.Category{Name: "Meals", MinAmountIDR: 10000, MaxAmountIDR: 500000})
+		require.NoError(t, err)
+		require.True(t, result.Active)
+	})
+
+	t.Run("invalid limits", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		uc := NewCategoryUseCase(mockRepo)
+
+		_, err := uc.CreateCategory(ctx, &domain.Category{Name: "Meals", MinAmountIDR: 500000, MaxAmountIDR: 10000})
+		require.ErrorIs(t, err, domain.ErrInvalidCategory)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetCategory(t *testing.T) {
+	mockRepo := new(mocks.CategoryRepository)
+	uc := NewCategoryUseCase(mockRepo)
+	mockRepo.On("FindByID", mock.Anything, 9).Return((*domain.Category)(nil), nil).Once()
+
+	_, err := uc.GetCategory(context.Background(), 9)
+	require.ErrorIs(t, err, domain.ErrCategoryNotFound)
+}
+
+func TestDeactivateCategory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("active category", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		uc := NewCategoryUseCase(mockRepo)
+		mockRepo.On("FindByID", mock.Anything, 3).Return(&domain.Category{ID: 3, Name: "Meals", Active: true}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.Category) bool {
+			return c.ID == 3 && !c.Active
+		})).Return(nil).Once()
+
+		require.NoError(t, uc.DeactivateCategory(ctx, 3))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("already inactive", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		uc := NewCategoryUseCase(mockRepo)
+		mockRepo.On("FindByID", mock.Anything, 3).Return(&domain.Category{ID: 3, Name: "Meals"}, nil).Once()
+
+		require.NoError(t, uc.DeactivateCategory(ctx, 3))
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,18 @@
+package delegation
+
+import (
+	"context"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type DelegationRepository interface {
+	Create(ctx context.Context, delegation *domain.Delegation) error
+	FindByID(ctx context.Context, id int) (*domain.Delegation, error)
+	FindCurrentByManagerID(ctx context.Context, managerID int, at time.Time) ([]*domain.Delegation, error)
+	FindActiveByDelegateID(ctx context.Context, delegateID int, at time.Time) ([]*domain.Delegation, error)
+	FindActiveByManagerID(ctx context.Context, managerID int, at time.Time) ([]*domain.Delegation, error)
+	UpdateEndsAt(ctx context.Context, id int, endsAt time.Time) error
+	Delete(ctx context.Context, id int) error
+}
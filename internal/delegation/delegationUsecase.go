@@ -0,0 +1,14 @@
+package delegation
+
+import (
+	"context"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type DelegationUseCase interface {
+	CreateDelegation(ctx context.Context, managerID, delegateID int, startsAt, endsAt time.Time) (*domain.Delegation, error)
+	ListDelegations(ctx context.Context, managerID int) ([]*domain.Delegation, error)
+	RevokeDelegation(ctx context.Context, id, managerID int) error
+}
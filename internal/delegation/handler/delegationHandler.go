@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/delegation"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/gorilla/mux"
+)
+
+type DelegationHandler struct {
+	delegationUseCase delegation.DelegationUseCase
+}
+
+func NewDelegationHandler(delegationUseCase delegation.DelegationUseCase) *DelegationHandler {
+	return &DelegationHandler{delegationUseCase: delegationUseCase}
+}
+
+func (h *DelegationHandler) CreateDelegation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managerID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		DelegateID int       `json:"delegate_id"`
+		StartsAt   time.Time `json:"starts_at"`
+		EndsAt     time.Time `json:"ends_at"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	delegation, err := h.delegationUseCase.CreateDelegation(ctx, managerID, req.DelegateID, req.StartsAt, req.EndsAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidDelegation):
+			http.Error(w, "Delegation must end after it starts and must not be in the past", http.StatusBadRequest)
+		case errors.Is(err, domain.ErrInvalidDelegate):
+			http.Error(w, "Delegate must be another manager, finance director or CFO", http.StatusBadRequest)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(delegation)
+}
+
+// ListDelegations returns the caller's active and upcoming delegations.
+func (h *DelegationHandler) ListDelegations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managerID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	delegations, err := h.delegationUseCase.ListDelegations(ctx, managerID)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(delegations)
+}
+
+func (h *DelegationHandler) RevokeDelegation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managerID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid delegation ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.delegationUseCase.RevokeDelegation(ctx, id, managerID)
+	if err != nil {
+		if errors.Is(err, domain.ErrDelegationNotFound) {
+			http.Error(w, "Delegation not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func withManagerID(req *http.Request, userID int) *http.Request {
+	mockAuth := new(mocks.AuthService)
+	mockAuth.On("ValidateToken", mock.Anything, "t").Return(userID, domain.RoleManager, nil).Maybe()
+	rr := httptest.NewRecorder()
+	next := middleware.AuthMiddleware(mockAuth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+	}))
+	req.Header.Set("Authorization", "Bearer t")
+	next.ServeHTTP(rr, req)
+	return req
+}
+
+func TestDelegationHandlerCreateDelegation(t *testing.T) {
+	body := `{"delegate_id":5,"starts_at":"2026-03-02T00:00:00Z","ends_at":"2026-03-09T00:00:00Z"}`
+	startsAt := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	t.Run("unauthorized", func(t *testing.T) {
+		mockUC := new(mocks.DelegationUseCase)
+		h := NewDelegationHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/delegations", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		h.CreateDelegation(rr, req)
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		mockUC := new(mocks.DelegationUseCase)
+		h := NewDelegationHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/delegations", strings.NewReader(`{"starts_at":"tomorrow"}`))
+		req = withManagerID(req, 2)
+		rr := httptest.NewRecorder()
+
+		h.CreateDelegation(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.DelegationUseCase)
+		h := NewDelegationHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/delegations", strings.NewReader(body))
+		req = withManagerID(req, 2)
+		rr := httptest.NewRecorder()
+		delegation := &domain.Delegation{ID: 1, ManagerID: 2, DelegateID: 5, StartsAt: startsAt, EndsAt: endsAt}
+		mockUC.On("CreateDelegation", mock.Anything, 2, 5, startsAt, endsAt).Return(delegation, nil).Once()
+
+		h.CreateDelegation(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.Contains(t, rr.Body.String(), `"delegate_id":5`)
+	})
+
+	t.Run("domain bad request", func(t *testing.T) {
+		for _, domainErr := range []error{domain.ErrInvalidDelegation, domain.ErrInvalidDelegate} {
+			mockUC := new(mocks.DelegationUseCase)
+			h := NewDelegationHandler(mockUC)
+			req := httptest.NewRequest(http.MethodPost, "/delegations", strings.NewReader(body))
+			req = withManagerID(req, 2)
+			rr := httptest.NewRecorder()
+			mockUC.On("CreateDelegation", mock.Anything, 2, 5, startsAt, endsAt).Return((*domain.Delegation)(nil), domainErr).Once()
+
+			h.CreateDelegation(rr, req)
+			require.Equal(t, http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		mockUC := new(mocks.DelegationUseCase)
+		h := NewDelegationHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/delegations", strings.NewReader(body))
+		req = withManagerID(req, 2)
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateDelegation", mock.Anything, 2, 5, startsAt, endsAt).Return((*domain.Delegation)(nil), errors.New("db")).Once()
+
+		h.CreateDelegation(rr, req)
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestDelegationHandlerListDelegations(t *testing.T) {
+	t.Run("unauthorized", func(t *testing.T) {
+		h := NewDelegationHandler(new(mocks.DelegationUseCase))
+		rr := httptest.NewRecorder()
+
+		h.ListDelegations(rr, httptest.NewRequest(http.MethodGet, "/delegations", nil))
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.DelegationUseCase)
+		h := NewDelegationHandler(mockUC)
+		req := withManagerID(httptest.NewRequest(http.MethodGet, "/delegations", nil), 2)
+		rr := httptest.NewRecorder()
+		mockUC.On("ListDelegations", mock.Anything, 2).Return([]*domain.Delegation{{ID: 1, ManagerID: 2, DelegateID: 5}}, nil).Once()
+
+		h.ListDelegations(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"delegate_id":5`)
+	})
+}
+
+func TestDelegationHandlerRevokeDelegation(t *testing.T) {
+	revokeRequest := func(id string) *http.Request {
+		req := withManagerID(httptest.NewRequest(http.MethodDelete, "/delegations/"+id, nil), 2)
+		return mux.SetURLVars(req, map[string]string{"id": id})
+	}
+
+	t.Run("invalid id", func(t *testing.T) {
+		h := NewDelegationHandler(new(mocks.DelegationUseCase))
+		rr := httptest.NewRecorder()
+
+		h.RevokeDelegation(rr, revokeRequest("abc"))
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.DelegationUseCase)
+		h := NewDelegationHandler(mockUC)
+		rr := httptest.NewRecorder()
+		mockUC.On("RevokeDelegation", mock.Anything, 4, 2).Return(nil).Once()
+
+		h.RevokeDelegation(rr, revokeRequest("4"))
+		require.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockUC := new(mocks.DelegationUseCase)
+		h := NewDelegationHandler(mockUC)
+		rr := httptest.NewRecorder()
+		mockUC.On("RevokeDelegation", mock.Anything, 4, 2).Return(domain.ErrDelegationNotFound).Once()
+
+		h.RevokeDelegation(rr, revokeRequest("4"))
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/delegation"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type delegationRepository struct {
+	db *sql.DB
+}
+
+func NewDelegationRepository(db *sql.DB) delegation.DelegationRepository {
+	return &delegationRepository{db: db}
+}
+
+func (r *delegationRepository) Create(ctx context.Context, delegation *domain.Delegation) error {
+	query := `
+		INSERT INTO approval_delegations (manager_id, delegate_id, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		delegation.ManagerID,
+		delegation.DelegateID,
+		delegation.StartsAt,
+		delegation.EndsAt,
+	).Scan(&delegation.ID, &delegation.CreatedAt)
+
+	return err
+}
+
+func (r *delegationRepository) FindByID(ctx context.Context, id int) (*domain.Delegation, error) {
+	query := `
+		SELECT id, manager_id, delegate_id, starts_at, ends_at, created_at
+		FROM approval_delegations
+		WHERE id = $1
+	`
+
+	delegations, err := r.query(ctx, query, id)
+	if err != nil || len(delegations) == 0 {
+		return nil, err
+	}
+
+	return delegations[0], nil
+}
+
+// FindCurrentByManagerID returns managerID's delegations that have not ended by the given
+// time, both active and upcoming.
+func (r *delegationRepository) FindCurrentByManagerID(ctx context.Context, managerID int, at time.Time) ([]*domain.Delegation, error) {
+	query := `
+		SELECT id, manager_id, delegate_id, starts_at, ends_at, created_at
+		FROM approval_delegations
+		WHERE manager_id = $1 AND ends_at > $2
+		ORDER BY starts_at ASC, id ASC
+	`
+
+	return r.query(ctx, query, managerID, at)
+}
+
+// UpdateEndsAt cuts the delegation short so that it stops at endsAt.
+func (r *delegationRepository) UpdateEndsAt(ctx context.Context, id int, endsAt time.Time) error {
+	query := `
+		UPDATE approval_delegations
+		SET ends_at = $1
+		WHERE id = $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, endsAt, id)
+	return err
+}
+
+func (r *delegationRepository) Delete(ctx context.Context, id int) error {
+	query := `
+		DELETE FROM approval_delegations
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// FindActiveByDelegateID returns the delegations delegateID can act under at the given time.
+func (r *delegationRepository) FindActiveByDelegateID(ctx context.Context, delegateID int, at time.Time) ([]*domain.Delegation, error) {
+	query := `
+		SELECT id, manager_id, delegate_id, starts_at, ends_at, created_at
+		FROM approval_delegations
+		WHERE delegate_id = $1 AND starts_at <= $2 AND ends_at > $2
+		ORDER BY id ASC
+	`
+
+	return r.query(ctx, query, delegateID, at)
+}
+
+// FindActiveByManagerID returns the delegations managerID has in force at the given time.
+func (r *delegationRepository) FindActiveByManagerID(ctx context.Context, managerID int, at time.Time) ([]*domain.Delegation, error) {
+	query := `
+		SELECT id, manager_id, delegate_id, starts_at, ends_at, created_at
+		FROM approval_delegations
+		WHERE manager_id = $1 AND starts_at <= $2 AND ends_at > $2
+		ORDER BY id ASC
+	`
+
+	return r.query(ctx, query, managerID, at)
+}
+
+func (r *delegationRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Delegation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var delegations []*domain.Delegation
+	for rows.Next() {
+		delegation := &domain.Delegation{}
+		err := rows.Scan(
+			&delegation.ID,
+			&delegation.ManagerID,
+			&delegation.DelegateID,
+			&delegation.StartsAt,
+			&delegation.EndsAt,
+			&delegation.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		delegations = append(delegations, delegation)
+	}
+
+	return delegations, nil
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegationRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &delegationRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO approval_delegations (manager_id, delegate_id, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`)
+	startsAt := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	endsAt := startsAt.AddDate(0, 0, 7)
+	createdAt := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		delegation := &domain.Delegation{ManagerID: 2, DelegateID: 5, StartsAt: startsAt, EndsAt: endsAt}
+		rows := sqlmock.NewRows([]string{"id", "created_at"}).AddRow(4, createdAt)
+		mock.ExpectQuery(query).WithArgs(2, 5, startsAt, endsAt).WillReturnRows(rows)
+
+		createErr := repo.Create(context.Background(), delegation)
+		require.NoError(t, createErr)
+		require.Equal(t, 4, delegation.ID)
+		require.Equal(t, createdAt, delegation.CreatedAt)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		expectedErr := errors.New("insert failed")
+		mock.ExpectQuery(query).WillReturnError(expectedErr)
+
+		createErr := repo.Create(context.Background(), &domain.Delegation{ManagerID: 2, DelegateID: 5})
+		require.ErrorIs(t, createErr, expectedErr)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelegationRepositoryFindActiveByDelegateID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &delegationRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id, manager_id, delegate_id, starts_at, ends_at, created_at
+		FROM approval_delegations
+		WHERE delegate_id = $1 AND starts_at <= $2 AND ends_at > $2
+		ORDER BY id ASC
+	`)
+	at := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	startsAt := at.AddDate(0, 0, -2)
+	endsAt := at.AddDate(0, 0, 5)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "manager_id", "delegate_id", "starts_at", "ends_at", "created_at"}).
+			AddRow(1, 2, 5, startsAt, endsAt, startsAt).
+			AddRow(3, 8, 5, startsAt, endsAt, startsAt)
+		mock.ExpectQuery(query).WithArgs(5, at).WillReturnRows(rows)
+
+		result, findErr := repo.FindActiveByDelegateID(context.Background(), 5, at)
+		require.NoError(t, findErr)
+		require.Len(t, result, 2)
+		require.Equal(t, 2, result[0].ManagerID)
+		require.Equal(t, 8, result[1].ManagerID)
+		require.True(t, result[0].IsActiveAt(at))
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		expectedErr := errors.New("select failed")
+		mock.ExpectQuery(query).WithArgs(5, at).WillReturnError(expectedErr)
+
+		result, findErr := repo.FindActiveByDelegateID(context.Background(), 5, at)
+		require.ErrorIs(t, findErr, expectedErr)
+		require.Nil(t, result)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelegationRepositoryFindActiveByManagerID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &delegationRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id, manager_id, delegate_id, starts_at, ends_at, created_at
+		FROM approval_delegations
+		WHERE manager_id = $1 AND starts_at <= $2 AND ends_at > $2
+		ORDER BY id ASC
+	`)
+	at := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	startsAt := at.AddDate(0, 0, -2)
+	endsAt := at.AddDate(0, 0, 5)
+
+	rows := sqlmock.NewRows([]string{"id", "manager_id", "delegate_id", "starts_at", "ends_at", "created_at"}).
+		AddRow(1, 2, 5, startsAt, endsAt, startsAt)
+	mock.ExpectQuery(query).WithArgs(2, at).WillReturnRows(rows)
+
+	result, findErr := repo.FindActiveByManagerID(context.Background(), 2, at)
+	require.NoError(t, findErr)
+	require.Len(t, result, 1)
+	require.Equal(t, 5, result[0].DelegateID)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelegationRepositoryFindByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &delegationRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id, manager_id, delegate_id, starts_at, ends_at, created_at
+		FROM approval_delegations
+		WHERE id = $1
+	`)
+	startsAt := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	endsAt := startsAt.AddDate(0, 0, 7)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "manager_id", "delegate_id", "starts_at", "ends_at", "created_at"}).
+			AddRow(4, 2, 5, startsAt, endsAt, startsAt)
+		mock.ExpectQuery(query).WithArgs(4).WillReturnRows(rows)
+
+		result, findErr := repo.FindByID(context.Background(), 4)
+		require.NoError(t, findErr)
+		require.Equal(t, 2, result.ManagerID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"id", "manager_id", "delegate_id", "starts_at", "ends_at", "created_at"}))
+
+		result, findErr := repo.FindByID(context.Background(), 9)
+		require.NoError(t, findErr)
+		require.Nil(t, result)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelegationRepositoryFindCurrentByManagerID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &delegationRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id, manager_id, delegate_id, starts_at, ends_at, created_at
+		FROM approval_delegations
+		WHERE manager_id = $1 AND ends_at > $2
+		ORDER BY starts_at ASC, id ASC
+	`)
+	at := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "manager_id", "delegate_id", "starts_at", "ends_at", "created_at"}).
+		AddRow(1, 2, 5, at.AddDate(0, 0, -1), at.AddDate(0, 0, 1), at).
+		AddRow(2, 2, 6, at.AddDate(0, 0, 3), at.AddDate(0, 0, 5), at)
+	mock.ExpectQuery(query).WithArgs(2, at).WillReturnRows(rows)
+
+	result, findErr := repo.FindCurrentByManagerID(context.Background(), 2, at)
+	require.NoError(t, findErr)
+	require.Len(t, result, 2)
+	require.Equal(t, 6, result[1].DelegateID)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelegationRepositoryRevoke(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &delegationRepository{db: db}
+	at := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta(`
+		UPDATE approval_delegations
+		SET ends_at = $1
+		WHERE id = $2
+	`)).WithArgs(at, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.UpdateEndsAt(context.Background(), 4, at))
+
+	expectedErr := errors.New("delete failed")
+	mock.ExpectExec(regexp.QuoteMeta(`
+		DELETE FROM approval_delegations
+		WHERE id = $1
+	`)).WithArgs(4).WillReturnError(expectedErr)
+	require.ErrorIs(t, repo.Delete(context.Background(), 4), expectedErr)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/delegation"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/user"
+)
+
+type delegationUseCase struct {
+	delegationRepo delegation.DelegationRepository
+	userRepo       user.UserRepository
+}
+
+func NewDelegationUseCase(delegationRepo delegation.DelegationRepository, userRepo user.UserRepository) delegation.DelegationUseCase {
+	return &delegationUseCase{
+		delegationRepo: delegationRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// CreateDelegation hands managerID's manager-level approvals to delegateID between
+// startsAt and endsAt. The delegate has to be another approver so that they can reach
+// the approval endpoints.
+func (uc *delegationUseCase) CreateDelegation(ctx context.Context, managerID, delegateID int, startsAt, endsAt time.Time) (*domain.Delegation, error) {
+	if !endsAt.After(startsAt) || !endsAt.After(time.Now()) {
+		return nil, domain.ErrInvalidDelegation
+	}
+
+	if delegateID == managerID {
+		return nil, domain.ErrInvalidDelegate
+	}
+
+	delegate, err := uc.userRepo.FindByID(ctx, delegateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delegate == nil || !delegate.Role.IsApprover() {
+		return nil, domain.ErrInvalidDelegate
+	}
+
+	delegation := &domain.Delegation{
+		ManagerID:  managerID,
+		DelegateID: delegateID,
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+	}
+
+	err = uc.delegationRepo.Create(ctx, delegation)
+	if err != nil {
+		return nil, err
+	}
+
+	return delegation, nil
+}
+
+// ListDelegations returns managerID's active and upcoming delegations.
+func (uc *delegationUseCase) ListDelegations(ctx context.Context, managerID int) ([]*domain.Delegation, error) {
+	return uc.delegationRepo.FindCurrentByManagerID(ctx, managerID, time.Now())
+}
+
+// RevokeDelegation withdraws one of managerID's delegations. An active delegation ends
+// now so that the sign-offs made under it keep their record; one that has not started
+// yet is removed. Revoking a delegation that already ended is a no-op.
+func (uc *delegationUseCase) RevokeDelegation(ctx context.Context, id, managerID int) error {
+	delegation, err := uc.delegationRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if delegation == nil || delegation.ManagerID != managerID {
+		return domain.ErrDelegationNotFound
+	}
+
+	now := time.Now()
+	switch {
+	case !now.Before(delegation.EndsAt):
+		return nil
+	case now.Before(delegation.StartsAt):
+		return uc.delegationRepo.Delete(ctx, id)
+	default:
+		return uc.delegationRepo.UpdateEndsAt(ctx, id, now)
+	}
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDelegation(t *testing.T) {
+	ctx := context.Background()
+	managerID := 2
+	delegateID := 5
+	startsAt := time.Now().Add(24 * time.Hour)
+	endsAt := startsAt.AddDate(0, 0, 7)
+
+	t.Run("success", func(t *testing.T) {
+		mockDelegation := new(mocks.DelegationRepository)
+		mockUser := new(mocks.UserRepository)
+		uc := NewDelegationUseCase(mockDelegation, mockUser)
+		mockUser.On("FindByID", mock.Anything, delegateID).Return(&domain.User{ID: delegateID, Role: domain.RoleManager}, nil).Once()
+		mockDelegation.On("Create", mock.Anything, mock.MatchedBy(func(d *domain.Delegation) bool {
+			return d.ManagerID == managerID && d.DelegateID == delegateID && d.StartsAt.Equal(startsAt) && d.EndsAt.Equal(endsAt)
+		})).Return(nil).Once()
+
+		result, err := uc.CreateDelegation(ctx, managerID, delegateID, startsAt, endsAt)
+		require.NoError(t, err)
+		require.Equal(t, managerID, result.ManagerID)
+		require.Equal(t, delegateID, result.DelegateID)
+	})
+
+	t.Run("invalid date range", func(t *testing.T) {
+		mockDelegation := new(mocks.DelegationRepository)
+		mockUser := new(mocks.UserRepository)
+		uc := NewDelegationUseCase(mockDelegation, mockUser)
+
+		_, err := uc.CreateDelegation(ctx, managerID, delegateID, endsAt, startsAt)
+		require.ErrorIs(t, err, domain.ErrInvalidDelegation)
+
+		past := time.Now().Add(-48 * time.Hour)
+		_, err = uc.CreateDelegation(ctx, managerID, delegateID, past, past.Add(time.Hour))
+		require.ErrorIs(t, err, domain.ErrInvalidDelegation)
+		mockDelegation.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("delegate to self", func(t *testing.T) {
+		mockDelegation := new(mocks.DelegationRepository)
+		mockUser := new(mocks.UserRepository)
+		uc := NewDelegationUseCase(mockDelegation, mockUser)
+
+		_, err := uc.CreateDelegation(ctx, managerID, managerID, startsAt, endsAt)
+		require.ErrorIs(t, err, domain.ErrInvalidDelegate)
+	})
+
+	t.Run("delegate is not an approver", func(t *testing.T) {
+		for _, delegate := range []*domain.User{nil, {ID: delegateID, Role: domain.RoleEmployee}} {
+			mockDelegation := new(mocks.DelegationRepository)
+			mockUser := new(mocks.UserRepository)
+			uc := NewDelegationUseCase(mockDelegation, mockUser)
+			mockUser.On("FindByID", mock.Anything, delegateID).Return(delegate, nil).Once()
+
+			_, err := uc.CreateDelegation(ctx, managerID, delegateID, startsAt, endsAt)
+			require.ErrorIs(t, err, domain.ErrInvalidDelegate)
+			mockDelegation.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockDelegation := new(mocks.DelegationRepository)
+		mockUser := new(mocks.UserRepository)
+		uc := NewDelegationUseCase(mockDelegation, mockUser)
+		expectedErr := errors.New("db error")
+		mockUser.On("FindByID", mock.Anything, delegateID).Return(&domain.User{ID: delegateID, Role: domain.RoleCFO}, nil).Once()
+		mockDelegation.On("Create", mock.Anything, mock.AnythingOfType("*domain.Delegation")).Return(expectedErr).Once()
+
+		result, err := uc.CreateDelegation(ctx, managerID, delegateID, startsAt, endsAt)
+		require.ErrorIs(t, err, expectedErr)
+		require.Nil(t, result)
+	})
+}
+
+func TestListDelegations(t *testing.T) {
+	mockDelegation := new(mocks.DelegationRepository)
+	mockUser := new(mocks.UserRepository)
+	uc := NewDelegationUseCase(mockDelegation, mockUser)
+	delegations := []*domain.Delegation{{ID: 1, ManagerID: 2, DelegateID: 5}}
+	mockDelegation.On("FindCurrentByManagerID", mock.Anything, 2, mock.AnythingOfType("time.Time")).Return(delegations, nil).Once()
+
+	result, err := uc.ListDelegations(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, delegations, result)
+}
+
+func TestRevokeDelegation(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	newUseCase := func(d *domain.Delegation) (*mocks.DelegationRepository, *delegationUseCase) {
+		mockDelegation := new(mocks.DelegationRepository)
+		mockDelegation.On("FindByID", mock.Anything, 4).Return(d, nil).Once()
+		return mockDelegation, NewDelegationUseCase(mockDelegation, new(mocks.UserRepository)).(*delegationUseCase)
+	}
+
+	t.Run("active delegation ends now", func(t *testing.T) {
+		mockDelegation, uc := newUseCase(&domain.Delegation{ID: 4, ManagerID: 2, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)})
+		mockDelegation.On("UpdateEndsAt", mock.Anything, 4, mock.MatchedBy(func(at time.Time) bool {
+			return !at.Before(now) && at.Before(now.Add(time.Hour))
+		})).Return(nil).Once()
+
+		require.NoError(t, uc.RevokeDelegation(ctx, 4, 2))
+		mockDelegation.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("upcoming delegation is removed", func(t *testing.T) {
+		mockDelegation, uc := newUseCase(&domain.Delegation{ID: 4, ManagerID: 2, StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)})
+		mockDelegation.On("Delete", mock.Anything, 4).Return(nil).Once()
+
+		require.NoError(t, uc.RevokeDelegation(ctx, 4, 2))
+		mockDelegation.AssertNotCalled(t, "UpdateEndsAt", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ended delegation is left alone", func(t *testing.T) {
+		mockDelegation, uc := newUseCase(&domain.Delegation{ID: 4, ManagerID: 2, StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)})
+
+		require.NoError(t, uc.RevokeDelegation(ctx, 4, 2))
+		mockDelegation.AssertNotCalled(t, "UpdateEndsAt", mock.Anything, mock.Anything, mock.Anything)
+		mockDelegation.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("another manager's delegation", func(t *testing.T) {
+		for _, d := range []*domain.Delegation{nil, {ID: 4, ManagerID: 3, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}} {
+			mockDelegation, uc := newUseCase(d)
+
+			require.ErrorIs(t, uc.RevokeDelegation(ctx, 4, 2), domain.ErrDelegationNotFound)
+			mockDelegation.AssertNotCalled(t, "UpdateEndsAt", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockDelegation := new(mocks.DelegationRepository)
+		uc := NewDelegationUseCase(mockDelegation, new(mocks.UserRepository))
+		expectedErr := errors.New("db error")
+		mockDelegation.On("FindByID", mock.Anything, 4).Return((*domain.Delegation)(nil), expectedErr).Once()
+
+		require.ErrorIs(t, uc.RevokeDelegation(ctx, 4, 2), expectedErr)
+	})
+}
@@ -0,0 +1,131 @@
+package domain
+
+import "time"
+
+type AdvanceStatus string
+
+const (
+	AdvanceStatusRequested        AdvanceStatus = "requested"
+	AdvanceStatusApproved         AdvanceStatus = "approved"
+	AdvanceStatusRejected         AdvanceStatus = "rejected"
+	AdvanceStatusCancelled        AdvanceStatus = "cancelled"
+	AdvanceStatusDisbursing       AdvanceStatus = "disbursing"
+	AdvanceStatusOutstanding      AdvanceStatus = "outstanding"
+	AdvanceStatusReimbursementDue AdvanceStatus = "reimbursement_due"
+	AdvanceStatusReimbursing      AdvanceStatus = "reimbursing"
+	AdvanceStatusRepaymentDue     AdvanceStatus = "repayment_due"
+	AdvanceStatusSettled          AdvanceStatus = "settled"
+	AdvanceStatusRetryScheduled   AdvanceStatus = "retry_scheduled"
+	AdvanceStatusDeadLetter       AdvanceStatus = "dead_letter"
+	AdvanceStatusFailed           AdvanceStatus = "failed"
+)
+
+// advanceTransitions lists, for every status, the statuses a cash advance may move to
+// next. Statuses without an entry are terminal.
+var advanceTransitions = map[AdvanceStatus][]AdvanceStatus{
+	AdvanceStatusRequested:        {AdvanceStatusApproved, AdvanceStatusRejected, AdvanceStatusCancelled},
+	AdvanceStatusApproved:         {AdvanceStatusDisbursing, AdvanceStatusCancelled},
+	AdvanceStatusDisbursing:       {AdvanceStatusOutstanding, AdvanceStatusFailed, AdvanceStatusRetryScheduled, AdvanceStatusDeadLetter},
+	AdvanceStatusOutstanding:      {AdvanceStatusSettled, AdvanceStatusReimbursementDue, AdvanceStatusRepaymentDue},
+	AdvanceStatusReimbursementDue: {AdvanceStatusReimbursing},
+	AdvanceStatusReimbursing:      {AdvanceStatusSettled, AdvanceStatusFailed, AdvanceStatusRetryScheduled, AdvanceStatusDeadLetter},
+	AdvanceStatusRepaymentDue:     {AdvanceStatusSettled},
+	AdvanceStatusRetryScheduled:   {AdvanceStatusDisbursing, AdvanceStatusReimbursing},
+	AdvanceStatusDeadLetter:       {AdvanceStatusRetryScheduled, AdvanceStatusFailed},
+}
+
+// CanTransitionTo reports whether a cash advance may move from s to next.
+func (s AdvanceStatus) CanTransitionTo(next AdvanceStatus) bool {
+	for _, allowed := range advanceTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// CashAdvance is cash paid to an employee ahead of a trip. Once it has been paid out it
+// is outstanding until the employee settles it with the expenses filed against it; the
+// difference is then reimbursed to the employee or paid back by them.
+type CashAdvance struct {
+	ID               int           `json:"id"`
+	UserID           int           `json:"user_id"`
+	Purpose          string        `json:"purpose"`
+	AmountIDR        int           `json:"amount_idr"`
+	Status           AdvanceStatus `json:"status"`
+	ApproverID       *int          `json:"approver_id"`
+	DecisionNotes    string        `json:"decision_notes"`
+	ExpensesIDR      int           `json:"expenses_idr"`
+	ReimbursementIDR int           `json:"reimbursement_idr"`
+	RepaymentIDR     int           `json:"repayment_idr"`
+	DecidedAt        *time.Time    `json:"decided_at"`
+	PaidAt           *time.Time    `json:"paid_at"`
+	SettledAt        *time.Time    `json:"settled_at"`
+	CreatedAt        time.Time     `json:"created_at"`
+	Expenses         []*Expense    `json:"expenses,omitempty"`
+}
+
+// Validate checks the advance request.
+func (a *CashAdvance) Validate() error {
+	if a.Purpose == "" || a.AmountIDR <= 0 {
+		return ErrInvalidAdvance
+	}
+	return nil
+}
+
+// PaymentKind is what the advance's next payment pays for: the advance itself until it
+// has been paid out, and the reimbursement due after settling it from then on.
+func (a *CashAdvance) PaymentKind() PaymentKind {
+	if a.PaidAt == nil {
+		return PaymentKindAdvance
+	}
+	return PaymentKindAdvanceReimbursement
+}
+
+// AcceptsExpenses reports whether expenses can still be filed against the advance: only
+// once it has been paid out and until it is settled.
+func (a *CashAdvance) AcceptsExpenses() bool {
+	return a.Status == AdvanceStatusOutstanding
+}
+
+// Settle offsets the IDR total of the approved expenses filed against the advance. When
+// they come to more than the advance the difference is due to the employee, when they
+// come to less the employee owes it back. Only an advance that is square is closed at
+// now; the others are closed once the difference has been paid.
+func (a *CashAdvance) Settle(expensesIDR int, now time.Time) {
+	a.ExpensesIDR = expensesIDR
+	a.ReimbursementIDR = 0
+	a.RepaymentIDR = 0
+
+	switch {
+	case expensesIDR > a.AmountIDR:
+		a.ReimbursementIDR = expensesIDR - a.AmountIDR
+		a.Status = AdvanceStatusReimbursementDue
+	case expensesIDR < a.AmountIDR:
+		a.RepaymentIDR = a.AmountIDR - expensesIDR
+		a.Status = AdvanceStatusRepaymentDue
+	default:
+		a.Status = AdvanceStatusSettled
+		a.SettledAt = &now
+	}
+}
+
+// AdvanceOffsetStatuses are the statuses of expenses that are offset when an advance is
+// settled.
+var AdvanceOffsetStatuses = []ExpenseStatus{ExpenseStatusApproved, ExpenseStatusAutoApproved}
+
+// AdvancePendingStatuses are the statuses of expenses that hold up settling an advance.
+var AdvancePendingStatuses = []ExpenseStatus{ExpenseStatusDraft, ExpenseStatusAwaitingApproval, ExpenseStatusChangesRequested}
+
+// AdvanceBalance is what an employee holds in cash advances. OutstandingIDR is paid out
+// and not settled yet, RepaymentDueIDR is owed back after settlement, and
+// ReimbursementDueIDR is owed to the employee. BalanceIDR is what the employee still has
+// to account for: the outstanding and repayment amounts less the reimbursements due.
+type AdvanceBalance struct {
+	UserID              int `json:"user_id"`
+	OpenAdvances        int `json:"open_advances"`
+	OutstandingIDR      int `json:"outstanding_idr"`
+	RepaymentDueIDR     int `json:"repayment_due_idr"`
+	ReimbursementDueIDR int `json:"reimbursement_due_idr"`
+	BalanceIDR          int `json:"balance_idr"`
+}
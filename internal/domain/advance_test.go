@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCashAdvanceValidate(t *testing.T) {
+	require.NoError(t, (&CashAdvance{Purpose: "Site survey in Kupang", AmountIDR: 3000000}).Validate())
+	require.ErrorIs(t, (&CashAdvance{AmountIDR: 3000000}).Validate(), ErrInvalidAdvance)
+	require.ErrorIs(t, (&CashAdvance{Purpose: "Site survey in Kupang"}).Validate(), ErrInvalidAdvance)
+}
+
+func TestCashAdvanceSettle(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		expensesIDR   int
+		status        AdvanceStatus
+		reimbursement int
+		repayment     int
+		closed        bool
+	}{
+		{"spent more than advanced", 3500000, AdvanceStatusReimbursementDue, 500000, 0, false},
+		{"spent less than advanced", 2200000, AdvanceStatusRepaymentDue, 0, 800000, false},
+		{"spent exactly the advance", 3000000, AdvanceStatusSettled, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &CashAdvance{AmountIDR: 3000000, Status: AdvanceStatusOutstanding}
+			a.Settle(tt.expensesIDR, now)
+
+			require.Equal(t, tt.status, a.Status)
+			require.Equal(t, tt.expensesIDR, a.ExpensesIDR)
+			require.Equal(t, tt.reimbursement, a.ReimbursementIDR)
+			require.Equal(t, tt.repayment, a.RepaymentIDR)
+			if tt.closed {
+				require.Equal(t, now, *a.SettledAt)
+			} else {
+				require.Nil(t, a.SettledAt)
+			}
+		})
+	}
+}
+
+func TestAdvanceStatusCanTransitionTo(t *testing.T) {
+	require.True(t, AdvanceStatusRequested.CanTransitionTo(AdvanceStatusApproved))
+	require.True(t, AdvanceStatusOutstanding.CanTransitionTo(AdvanceStatusRepaymentDue))
+	require.True(t, AdvanceStatusReimbursing.CanTransitionTo(AdvanceStatusSettled))
+	require.True(t, AdvanceStatusDisbursing.CanTransitionTo(AdvanceStatusRetryScheduled))
+	require.True(t, AdvanceStatusRetryScheduled.CanTransitionTo(AdvanceStatusReimbursing))
+	require.True(t, AdvanceStatusDeadLetter.CanTransitionTo(AdvanceStatusRetryScheduled))
+
+	require.False(t, AdvanceStatusOutstanding.CanTransitionTo(AdvanceStatusCancelled))
+	require.False(t, AdvanceStatusRequested.CanTransitionTo(AdvanceStatusOutstanding))
+	require.False(t, AdvanceStatusSettled.CanTransitionTo(AdvanceStatusRepaymentDue))
+	require.False(t, AdvanceStatusDeadLetter.CanTransitionTo(AdvanceStatusOutstanding))
+}
+
+func TestCashAdvancePaymentKind(t *testing.T) {
+	a := &CashAdvance{Status: AdvanceStatusRetryScheduled}
+	require.Equal(t, PaymentKindAdvance, a.PaymentKind())
+
+	paidAt := time.Now()
+	a.PaidAt = &paidAt
+	require.Equal(t, PaymentKindAdvanceReimbursement, a.PaymentKind())
+}
+
+func TestExpenseChangesApplyAdvance(t *testing.T) {
+	advanceID := 4
+	e := &Expense{}
+
+	(&ExpenseChanges{AdvanceID: &advanceID}).Apply(e)
+	require.Equal(t, 4, *e.AdvanceID)
+
+	unlink := 0
+	(&ExpenseChanges{AdvanceID: &unlink}).Apply(e)
+	require.Nil(t, e.AdvanceID)
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CostCenter is a budget holder inside the company that expenses are charged to. Like
+// categories, cost centers are deactivated rather than deleted.
+type CostCenter struct {
+	ID        int       `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate checks the cost center definition and normalises its code.
+func (c *CostCenter) Validate() error {
+	c.Code = NormalizeCode(c.Code)
+	if c.Code == "" || c.Name == "" {
+		return ErrInvalidCostCenter
+	}
+	return nil
+}
+
+// Project is a client project that expenses can be charged to on top of a cost center.
+type Project struct {
+	ID         int       `json:"id"`
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	ClientName string    `json:"client_name"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Validate checks the project definition and normalises its code.
+func (p *Project) Validate() error {
+	p.Code = NormalizeCode(p.Code)
+	if p.Code == "" || p.Name == "" {
+		return ErrInvalidProject
+	}
+	return nil
+}
+
+// NormalizeCode trims a cost center or project code and upper-cases it, so that codes
+// are matched regardless of how they were typed.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Allocation charges part of an expense to a cost center and, optionally, a project. An
+// allocation is given either as a percentage of the expense or as an IDR amount; the
+// IDR amount is always worked out.
+type Allocation struct {
+	ExpenseID      int     `json:"expense_id"`
+	CostCenterCode string  `json:"cost_center_code"`
+	ProjectCode    string  `json:"project_code"`
+	Percent        float64 `json:"percent"`
+	AmountIDR      int     `json:"amount_idr"`
+}
+
+// allocationTolerance absorbs floating point noise when percentages are summed.
+const allocationTolerance = 0.0001
+
+// ResolveAllocations checks that the expense's allocations cover exactly its IDR amount
+// and works out the IDR amount of percentage allocations. All allocations must use the
+// same kind of split: percentages adding up to 100, or IDR amounts adding up to the
+// expense amount. Rounding differences of a percentage split go to the last allocation.
+// An expense without allocations is left unallocated.
+func (e *Expense) ResolveAllocations() error {
+	if len(e.Allocations) == 0 {
+		return nil
+	}
+
+	byPercent := e.Allocations[0].Percent > 0
+	percent, amount := 0.0, 0
+	for _, a := range e.Allocations {
+		a.CostCenterCode = NormalizeCode(a.CostCenterCode)
+		a.ProjectCode = NormalizeCode(a.ProjectCode)
+		if a.CostCenterCode == "" || a.Percent < 0 || (byPercent != (a.Percent > 0)) {
+			return ErrInvalidAllocation
+		}
+		if byPercent {
+			percent += a.Percent
+		} else if a.AmountIDR <= 0 {
+			return ErrInvalidAllocation
+		}
+		amount += a.AmountIDR
+	}
+
+	if !byPercent {
+		if amount != e.AmountIDR {
+			return ErrInvalidAllocation
+		}
+		return nil
+	}
+
+	if math.Abs(percent-100) > allocationTolerance {
+		return ErrInvalidAllocation
+	}
+
+	remaining := e.AmountIDR
+	for i, a := range e.Allocations {
+		if i == len(e.Allocations)-1 {
+			a.AmountIDR = remaining
+			break
+		}
+		a.AmountIDR = int(math.Round(float64(e.AmountIDR) * a.Percent / 100))
+		remaining -= a.AmountIDR
+	}
+
+	return nil
+}
+
+// AllocationTotal sums the IDR amounts charged to one cost center and project.
+type AllocationTotal struct {
+	CostCenterCode string `json:"cost_center_code"`
+	ProjectCode    string `json:"project_code"`
+	ExpenseCount   int    `json:"expense_count"`
+	AmountIDR      int    `json:"amount_idr"`
+}
+
+// SumAllocations totals the allocations of the expenses per cost center and project.
+// Unallocated expenses are totalled under an empty cost center code.
+func SumAllocations(expenses []*Expense) []*AllocationTotal {
+	type key struct{ costCenter, project string }
+	totals := make(map[key]*AllocationTotal)
+	add := func(k key, amount int) {
+		t, ok := totals[k]
+		if !ok {
+			t = &AllocationTotal{CostCenterCode: k.costCenter, ProjectCode: k.project}
+			totals[k] = t
+		}
+		t.ExpenseCount++
+		t.AmountIDR += amount
+	}
+
+	for _, e := range expenses {
+		if len(e.Allocations) == 0 {
+			add(key{}, e.AmountIDR)
+			continue
+		}
+		for _, a := range e.Allocations {
+			add(key{a.CostCenterCode, a.ProjectCode}, a.AmountIDR)
+		}
+	}
+
+	rows := make([]*AllocationTotal, 0, len(totals))
+	for _, t := range totals {
+		rows = append(rows, t)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].CostCenterCode != rows[j].CostCenterCode {
+			return rows[i].CostCenterCode < rows[j].CostCenterCode
+		}
+		return rows[i].ProjectCode < rows[j].ProjectCode
+	})
+	return rows
+}
+
+// AllocationCodeError reports an allocation to a cost center or project that does not
+// exist or has been deactivated. It matches ErrInactiveAllocationCode.
+type AllocationCodeError struct {
+	Kind string
+	Code string
+}
+
+func (e *AllocationCodeError) Error() string {
+	return fmt.Sprintf("%s %s does not exist or is not active", e.Kind, e.Code)
+}
+
+func (e *AllocationCodeError) Is(target error) bool {
+	return target == ErrInactiveAllocationCode
+}
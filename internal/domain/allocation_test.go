@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpenseResolveAllocations(t *testing.T) {
+	t.Run("unallocated", func(t *testing.T) {
+		e := &Expense{AmountIDR: 100000}
+		require.NoError(t, e.ResolveAllocations())
+	})
+
+	t.Run("percent split", func(t *testing.T) {
+		e := &Expense{AmountIDR: 100000, Allocations: []*Allocation{
+			{CostCenterCode: " cc-100 ", Percent: 33.33},
+			{CostCenterCode: "CC-200", ProjectCode: "prj-7", Percent: 33.33},
+			{CostCenterCode: "CC-300", Percent: 33.34},
+		}}
+		require.NoError(t, e.ResolveAllocations())
+		require.Equal(t, "CC-100", e.Allocations[0].CostCenterCode)
+		require.Equal(t, "PRJ-7", e.Allocations[1].ProjectCode)
+		require.Equal(t, 33330, e.Allocations[0].AmountIDR)
+		require.Equal(t, 33330, e.Allocations[1].AmountIDR)
+		require.Equal(t, 33340, e.Allocations[2].AmountIDR)
+	})
+
+	t.Run("rounding goes to the last allocation", func(t *testing.T) {
+		e := &Expense{AmountIDR: 100001, Allocations: []*Allocation{
+			{CostCenterCode: "CC-100", Percent: 50},
+			{CostCenterCode: "CC-200", Percent: 50},
+		}}
+		require.NoError(t, e.ResolveAllocations())
+		require.Equal(t, 50001, e.Allocations[0].AmountIDR)
+		require.Equal(t, 50000, e.Allocations[1].AmountIDR)
+	})
+
+	t.Run("percentages do not add up", func(t *testing.T) {
+		e := &Expense{AmountIDR: 100000, Allocations: []*Allocation{
+			{CostCenterCode: "CC-100", Percent: 60},
+			{CostCenterCode: "CC-200", Percent: 30},
+		}}
+		require.ErrorIs(t, e.ResolveAllocations(), ErrInvalidAllocation)
+	})
+
+	t.Run("amount split", func(t *testing.T) {
+		e := &Expense{AmountIDR: 100000, Allocations: []*Allocation{
+			{CostCenterCode: "CC-100", AmountIDR: 70000},
+			{CostCenterCode: "CC-200", AmountIDR: 30000},
+		}}
+		require.NoError(t, e.ResolveAllocations())
+	})
+
+	t.Run("amounts do not add up", func(t *testing.T) {
+		e := &Expense{AmountIDR: 100000, Allocations: []*Allocation{
+			{CostCenterCode: "CC-100", AmountIDR: 70000},
+			{CostCenterCode: "CC-200", AmountIDR: 20000},
+		}}
+		require.ErrorIs(t, e.ResolveAllocations(), ErrInvalidAllocation)
+	})
+
+	t.Run("mixed split", func(t *testing.T) {
+		e := &Expense{AmountIDR: 100000, Allocations: []*Allocation{
+			{CostCenterCode: "CC-100", Percent: 50},
+			{CostCenterCode: "CC-200", AmountIDR: 50000},
+		}}
+		require.ErrorIs(t, e.ResolveAllocations(), ErrInvalidAllocation)
+	})
+
+	t.Run("missing cost center", func(t *testing.T) {
+		e := &Expense{AmountIDR: 100000, Allocations: []*Allocation{{ProjectCode: "PRJ-7", Percent: 100}}}
+		require.ErrorIs(t, e.ResolveAllocations(), ErrInvalidAllocation)
+	})
+}
+
+func TestSumAllocations(t *testing.T) {
+	totals := SumAllocations([]*Expense{
+		{AmountIDR: 100000, Allocations: []*Allocation{
+			{CostCenterCode: "CC-200", AmountIDR: 60000},
+			{CostCenterCode: "CC-100", ProjectCode: "PRJ-7", AmountIDR: 40000},
+		}},
+		{AmountIDR: 50000, Allocations: []*Allocation{{CostCenterCode: "CC-200", AmountIDR: 50000}}},
+		{AmountIDR: 25000},
+	})
+
+	require.Equal(t, []*AllocationTotal{
+		{CostCenterCode: "", ProjectCode: "", ExpenseCount: 1, AmountIDR: 25000},
+		{CostCenterCode: "CC-100", ProjectCode: "PRJ-7", ExpenseCount: 1, AmountIDR: 40000},
+		{CostCenterCode: "CC-200", ProjectCode: "", ExpenseCount: 2, AmountIDR: 110000},
+	}, totals)
+}
+
+func TestAllocationCodeError(t *testing.T) {
+	err := &AllocationCodeError{Kind: "cost center", Code: "CC-999"}
+	require.ErrorIs(t, err, ErrInactiveAllocationCode)
+	require.Equal(t, "cost center CC-999 does not exist or is not active", err.Error())
+}
@@ -1,21 +1,237 @@
 package domain
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type ApprovalStatus string
 
 const (
-	ApprovalStatusApproved ApprovalStatus = "approved"
-	ApprovalStatusRejected ApprovalStatus = "rejected"
+	ApprovalStatusApproved         ApprovalStatus = "approved"
+	ApprovalStatusRejected         ApprovalStatus = "rejected"
+	ApprovalStatusChangesRequested ApprovalStatus = "changes_requested"
 )
 
+// ApprovalLevel is one sign-off step in an expense's approval chain.
+type ApprovalLevel string
+
+const (
+	ApprovalLevelManager         ApprovalLevel = "manager"
+	ApprovalLevelFinanceDirector ApprovalLevel = "finance_director"
+	ApprovalLevelCFO             ApprovalLevel = "cfo"
+)
+
+// Role returns the user role that is allowed to sign off this level.
+func (l ApprovalLevel) Role() Role {
+	switch l {
+	case ApprovalLevelFinanceDirector:
+		return RoleFinanceDirector
+	case ApprovalLevelCFO:
+		return RoleCFO
+	default:
+		return RoleManager
+	}
+}
+
+func (l ApprovalLevel) IsValid() bool {
+	switch l {
+	case ApprovalLevelManager, ApprovalLevelFinanceDirector, ApprovalLevelCFO:
+		return true
+	}
+	return false
+}
+
+// Approval is one signed level of an expense's approval chain. OnBehalfOfID is set when
+// the approver acted under a manager's delegation and names that manager. Round counts
+// submissions: every resubmission after a change request starts a new round in which all
+// levels sign again.
 type Approval struct {
-	ID         int            `json:"id"`
-	ExpenseID  int            `json:"expense_id"`
-	ApproverID int            `json:"approver_id"`
-	Status     ApprovalStatus `json:"status"`
-	Notes      string         `json:"notes"`
-	CreatedAt  time.Time      `json:"created_at"`
+	ID           int            `json:"id"`
+	ExpenseID    int            `json:"expense_id"`
+	ApproverID   int            `json:"approver_id"`
+	OnBehalfOfID *int           `json:"on_behalf_of_id"`
+	Round        int            `json:"round"`
+	Level        ApprovalLevel  `json:"level"`
+	Status       ApprovalStatus `json:"status"`
+	Notes        string         `json:"notes"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+func (a *Approval) IsDelegated() bool {
+	return a.OnBehalfOfID != nil
+}
+
+// ApprovalPolicy configures who has to sign off an expense that requires approval.
+type ApprovalPolicy struct {
+	Tiers []ApprovalTier
+	// IncludeIndirectReports lets a manager sign the manager level for their whole
+	// reporting tree instead of only their direct reports.
+	IncludeIndirectReports bool
+	// FinanceApprovalOverBudget sends an expense that exceeds a budget to approval and
+	// adds the finance director to its chain.
+	FinanceApprovalOverBudget bool
+}
+
+// Threshold returns the amount from which an expense waits for approval: the minimum of
+// the lowest tier, or ApprovalThreshold when no tiers are configured.
+func (p ApprovalPolicy) Threshold() int {
+	if len(p.Tiers) == 0 {
+		return ApprovalThreshold
+	}
+	return p.Tiers[0].MinAmount
+}
+
+// RequiredLevels returns the approval chain for an expense of amount that is awaiting
+// approval. Such an expense needs at least a manager, even if the configured tiers start
+// above the auto-approval threshold.
+func (p ApprovalPolicy) RequiredLevels(amount int) []ApprovalLevel {
+	required := RequiredApprovalLevels(p.Tiers, amount)
+	if len(required) == 0 {
+		required = []ApprovalLevel{ApprovalLevelManager}
+	}
+	return required
+}
+
+// ExpenseLevels returns the approval chain for a standalone expense: the levels of its
+// amount tier, with the finance director added when the expense went over budget.
+func (p ApprovalPolicy) ExpenseLevels(e *Expense) []ApprovalLevel {
+	return p.levels(e.AmountIDR, e.RequiresFinanceApproval)
+}
+
+// ReportLevels returns the approval chain for an expense report: the levels of its total's
+// tier, with the finance director added when one of its lines went over budget.
+func (p ApprovalPolicy) ReportLevels(r *ExpenseReport) []ApprovalLevel {
+	return p.levels(r.TotalIDR, r.RequiresFinanceApproval)
+}
+
+func (p ApprovalPolicy) levels(amount int, financeApproval bool) []ApprovalLevel {
+	required := p.RequiredLevels(amount)
+	if !financeApproval {
+		return required
+	}
+
+	for _, l := range required {
+		if l == ApprovalLevelFinanceDirector {
+			return required
+		}
+	}
+
+	// The finance director signs after the manager and before the CFO.
+	levels := make([]ApprovalLevel, 0, len(required)+1)
+	for _, l := range required {
+		if l == ApprovalLevelCFO {
+			levels = append(levels, ApprovalLevelFinanceDirector)
+		}
+		levels = append(levels, l)
+	}
+	if len(levels) == len(required) {
+		levels = append(levels, ApprovalLevelFinanceDirector)
+	}
+	return levels
+}
+
+// ApprovalTier lists the levels that must sign off an expense of at least MinAmount IDR.
+type ApprovalTier struct {
+	MinAmount int
+	Levels    []ApprovalLevel
+}
+
+// RequiredApprovalLevels returns the ordered approval chain for amount, taken from the
+// highest tier whose MinAmount the amount reaches. Tiers must be sorted by MinAmount.
+func RequiredApprovalLevels(tiers []ApprovalTier, amount int) []ApprovalLevel {
+	var levels []ApprovalLevel
+	for _, tier := range tiers {
+		if amount >= tier.MinAmount {
+			levels = tier.Levels
+		}
+	}
+	return levels
+}
+
+// NextApprovalLevel returns the first level of required that has not been approved in
+// the current round of chain yet. ok is false once every level has signed off.
+func NextApprovalLevel(required []ApprovalLevel, chain []*Approval) (level ApprovalLevel, ok bool) {
+	chain = currentRound(chain)
+	approved := make(map[ApprovalLevel]bool, len(chain))
+	for _, a := range chain {
+		if a.Status == ApprovalStatusApproved {
+			approved[a.Level] = true
+		}
+	}
+
+	for _, l := range required {
+		if !approved[l] {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// ApprovalRound returns the round the next approval of chain belongs to.
+func ApprovalRound(chain []*Approval) int {
+	round := 1
+	for _, a := range chain {
+		if a.Status == ApprovalStatusChangesRequested {
+			round++
+		}
+	}
+	return round
+}
+
+// currentRound returns the approvals of chain signed since changes were last requested.
+// chain must be in the order it was signed.
+func currentRound(chain []*Approval) []*Approval {
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].Status == ApprovalStatusChangesRequested {
+			return chain[i+1:]
+		}
+	}
+	return chain
+}
+
+// ParseApprovalTiers parses tiers written as "minAmount:level,level;minAmount:level",
+// e.g. "1000000:manager;10000000:manager,finance_director". The result is sorted by
+// MinAmount.
+func ParseApprovalTiers(s string) ([]ApprovalTier, error) {
+	var tiers []ApprovalTier
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		amountStr, levelsStr, found := strings.Cut(part, ":")
+		if !found {
+			return nil, fmt.Errorf("approval tier %q must be in the form amount:levels", part)
+		}
+
+		amount, err := strconv.Atoi(strings.TrimSpace(amountStr))
+		if err != nil {
+			return nil, fmt.Errorf("approval tier %q has an invalid amount: %v", part, err)
+		}
+
+		tier := ApprovalTier{MinAmount: amount}
+		for _, l := range strings.Split(levelsStr, ",") {
+			level := ApprovalLevel(strings.TrimSpace(l))
+			if !level.IsValid() {
+				return nil, fmt.Errorf("approval tier %q has an unknown level %q", part, level)
+			}
+			tier.Levels = append(tier.Levels, level)
+		}
+		tiers = append(tiers, tier)
+	}
+
+	if len(tiers) == 0 {
+		return nil, fmt.Errorf("at least one approval tier must be configured")
+	}
+
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].MinAmount < tiers[j].MinAmount
+	})
+
+	return tiers, nil
 }
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredApprovalLevels(t *testing.T) {
+	require.Empty(t, RequiredApprovalLevels(DefaultApprovalTiers, 500000))
+	require.Equal(t, []ApprovalLevel{ApprovalLevelManager}, RequiredApprovalLevels(DefaultApprovalTiers, ApprovalThreshold))
+	require.Equal(t, []ApprovalLevel{ApprovalLevelManager, ApprovalLevelFinanceDirector}, RequiredApprovalLevels(DefaultApprovalTiers, 10000000))
+	require.Equal(t, []ApprovalLevel{ApprovalLevelManager, ApprovalLevelFinanceDirector, ApprovalLevelCFO}, RequiredApprovalLevels(DefaultApprovalTiers, 30000000))
+}
+
+func TestApprovalPolicyExpenseLevels(t *testing.T) {
+	policy := ApprovalPolicy{Tiers: DefaultApprovalTiers}
+	require.Equal(t, []ApprovalLevel{ApprovalLevelManager}, policy.ExpenseLevels(&Expense{AmountIDR: 500000}))
+	require.Equal(t, []ApprovalLevel{ApprovalLevelManager, ApprovalLevelFinanceDirector}, policy.ExpenseLevels(&Expense{AmountIDR: 500000, RequiresFinanceApproval: true}))
+	require.Equal(t, []ApprovalLevel{ApprovalLevelManager, ApprovalLevelFinanceDirector}, policy.ExpenseLevels(&Expense{AmountIDR: 12000000, RequiresFinanceApproval: true}))
+
+	cfoOnly := ApprovalPolicy{Tiers: []ApprovalTier{{MinAmount: ApprovalThreshold, Levels: []ApprovalLevel{ApprovalLevelManager, ApprovalLevelCFO}}}}
+	require.Equal(t, []ApprovalLevel{ApprovalLevelManager, ApprovalLevelFinanceDirector, ApprovalLevelCFO}, cfoOnly.ExpenseLevels(&Expense{AmountIDR: 2000000, RequiresFinanceApproval: true}))
+}
+
+func TestApprovalPolicyReportLevels(t *testing.T) {
+	policy := ApprovalPolicy{Tiers: DefaultApprovalTiers}
+	require.Equal(t, []ApprovalLevel{ApprovalLevelManager}, policy.ReportLevels(&ExpenseReport{TotalIDR: 500000}))
+	require.Equal(t, []ApprovalLevel{ApprovalLevelManager, ApprovalLevelFinanceDirector}, policy.ReportLevels(&ExpenseReport{TotalIDR: 500000, RequiresFinanceApproval: true}))
+}
+
+func TestApprovalPolicyThreshold(t *testing.T) {
+	require.Equal(t, ApprovalThreshold, ApprovalPolicy{}.Threshold())
+
+	policy := ApprovalPolicy{Tiers: []ApprovalTier{{MinAmount: 2500000, Levels: []ApprovalLevel{ApprovalLevelManager}}}}
+	require.Equal(t, 2500000, policy.Threshold())
+
+	e := &Expense{AmountIDR: 2000000}
+	e.Route(policy)
+	require.Equal(t, ExpenseStatusAutoApproved, e.Status)
+
+	e = &Expense{AmountIDR: 2500000}
+	e.Route(policy)
+	require.Equal(t, ExpenseStatusAwaitingApproval, e.Status)
+}
+
+func TestNextApprovalLevel(t *testing.T) {
+	required := []ApprovalLevel{ApprovalLevelManager, ApprovalLevelFinanceDirector}
+
+	level, ok := NextApprovalLevel(required, nil)
+	require.True(t, ok)
+	require.Equal(t, ApprovalLevelManager, level)
+
+	chain := []*Approval{{Level: ApprovalLevelManager, Status: ApprovalStatusApproved}}
+	level, ok = NextApprovalLevel(required, chain)
+	require.True(t, ok)
+	require.Equal(t, ApprovalLevelFinanceDirector, level)
+
+	chain = append(chain, &Approval{Level: ApprovalLevelFinanceDirector, Status: ApprovalStatusApproved})
+	_, ok = NextApprovalLevel(required, chain)
+	require.False(t, ok)
+}
+
+func TestNextApprovalLevelAfterChangesRequested(t *testing.T) {
+	required := []ApprovalLevel{ApprovalLevelManager, ApprovalLevelFinanceDirector}
+	chain := []*Approval{
+		{Level: ApprovalLevelManager, Status: ApprovalStatusApproved, Round: 1},
+		{Level: ApprovalLevelFinanceDirector, Status: ApprovalStatusChangesRequested, Round: 1},
+	}
+	require.Equal(t, 2, ApprovalRound(chain))
+
+	level, ok := NextApprovalLevel(required, chain)
+	require.True(t, ok)
+	require.Equal(t, ApprovalLevelManager, level, "a resubmission needs every level again")
+
+	chain = append(chain, &Approval{Level: ApprovalLevelManager, Status: ApprovalStatusApproved, Round: 2})
+	level, ok = NextApprovalLevel(required, chain)
+	require.True(t, ok)
+	require.Equal(t, ApprovalLevelFinanceDirector, level)
+	require.Equal(t, 2, ApprovalRound(chain))
+}
+
+func TestParseApprovalTiers(t *testing.T) {
+	tiers, err := ParseApprovalTiers("10000000:manager,finance_director; 1000000:manager")
+	require.NoError(t, err)
+	require.Equal(t, []ApprovalTier{
+		{MinAmount: 1000000, Levels: []ApprovalLevel{ApprovalLevelManager}},
+		{MinAmount: 10000000, Levels: []ApprovalLevel{ApprovalLevelManager, ApprovalLevelFinanceDirector}},
+	}, tiers)
+
+	for _, invalid := range []string{"", "manager", "abc:manager", "1000000:janitor"} {
+		_, err := ParseApprovalTiers(invalid)
+		require.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// BudgetScope says what a budget limits: the expenses allocated to a cost center, which
+// stands for a department, or the expenses of a single user.
+type BudgetScope string
+
+const (
+	BudgetScopeCostCenter BudgetScope = "cost_center"
+	BudgetScopeUser       BudgetScope = "user"
+)
+
+// Budget caps what a cost center or a user may spend in a period, usually a calendar
+// month. Its consumption is not stored but summed from the claimed expenses incurred in
+// the period.
+type Budget struct {
+	ID             int         `json:"id"`
+	Scope          BudgetScope `json:"scope"`
+	CostCenterCode string      `json:"cost_center_code,omitempty"`
+	UserID         *int        `json:"user_id,omitempty"`
+	PeriodStart    time.Time   `json:"period_start"`
+	PeriodEnd      time.Time   `json:"period_end"`
+	AmountIDR      int         `json:"amount_idr"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// BudgetConsumedStatuses are the expenses that have used up budget: approved, and paid
+// or about to be paid. Expenses still waiting for approval do not count yet, while a
+// dead-lettered payment counts until finance abandons it.
+var BudgetConsumedStatuses = []ExpenseStatus{
+	ExpenseStatusApproved,
+	ExpenseStatusAutoApproved,
+	ExpenseStatusProcessing,
+	ExpenseStatusRetryScheduled,
+	ExpenseStatusDeadLetter,
+	ExpenseStatusCompleted,
+}
+
+// Validate checks the budget definition and normalises its cost center code and period.
+// A budget given only a period start covers the calendar month it falls in.
+func (b *Budget) Validate() error {
+	b.PeriodStart = DateOf(b.PeriodStart)
+	if b.PeriodEnd.IsZero() && !b.PeriodStart.IsZero() {
+		b.PeriodEnd = b.PeriodStart.AddDate(0, 1, -b.PeriodStart.Day())
+	}
+	b.PeriodEnd = DateOf(b.PeriodEnd)
+	if b.PeriodStart.IsZero() || b.PeriodEnd.Before(b.PeriodStart) || b.AmountIDR <= 0 {
+		return ErrInvalidBudget
+	}
+
+	switch b.Scope {
+	case BudgetScopeCostCenter:
+		b.CostCenterCode = NormalizeCode(b.CostCenterCode)
+		b.UserID = nil
+		if b.CostCenterCode == "" {
+			return ErrInvalidBudget
+		}
+	case BudgetScopeUser:
+		b.CostCenterCode = ""
+		if b.UserID == nil {
+			return ErrInvalidBudget
+		}
+	default:
+		return ErrInvalidBudget
+	}
+
+	return nil
+}
+
+// Charge returns the part of the expense's IDR amount that counts against the budget: the
+// whole amount for the submitter's own budget, and the allocated amount for a cost
+// center budget.
+func (b *Budget) Charge(e *Expense) int {
+	switch b.Scope {
+	case BudgetScopeUser:
+		if b.UserID != nil && *b.UserID == e.UserID {
+			return e.AmountIDR
+		}
+	case BudgetScopeCostCenter:
+		charge := 0
+		for _, a := range e.Allocations {
+			if a.CostCenterCode == b.CostCenterCode {
+				charge += a.AmountIDR
+			}
+		}
+		return charge
+	}
+	return 0
+}
+
+// describe names the budget holder in messages.
+func (b *Budget) describe() string {
+	if b.Scope == BudgetScopeUser && b.UserID != nil {
+		return fmt.Sprintf("user %d", *b.UserID)
+	}
+	return "cost center " + b.CostCenterCode
+}
+
+// BudgetUsage compares a budget with what has actually been spent against it.
+type BudgetUsage struct {
+	*Budget
+	ActualIDR    int     `json:"actual_idr"`
+	RemainingIDR int     `json:"remaining_idr"`
+	UsedPercent  float64 `json:"used_percent"`
+	ExpenseCount int     `json:"expense_count"`
+}
+
+// NewBudgetUsage works out the remaining amount and the share of the budget used. The
+// remaining amount goes negative once the budget is overspent.
+func NewBudgetUsage(budget *Budget, actual, expenseCount int) *BudgetUsage {
+	return &BudgetUsage{
+		Budget:       budget,
+		ActualIDR:    actual,
+		RemainingIDR: budget.AmountIDR - actual,
+		UsedPercent:  usedPercent(actual, budget.AmountIDR),
+		ExpenseCount: expenseCount,
+	}
+}
+
+// usedPercent is rounded to two decimals for display.
+func usedPercent(spent, budget int) float64 {
+	return math.Round(float64(spent)*10000/float64(budget)) / 100
+}
+
+type BudgetWarningLevel string
+
+const (
+	// BudgetNearLimit marks a submission that takes a budget past its warning share.
+	BudgetNearLimit BudgetWarningLevel = "near_limit"
+	// BudgetExceeded marks a submission that takes a budget past its full amount.
+	BudgetExceeded BudgetWarningLevel = "exceeded"
+)
+
+// BudgetWarning tells the submitter and the approvers that an expense uses up most or
+// all of a budget. ConsumedIDR is what had been spent before the expense.
+type BudgetWarning struct {
+	BudgetID       int                `json:"budget_id"`
+	Scope          BudgetScope        `json:"scope"`
+	CostCenterCode string             `json:"cost_center_code,omitempty"`
+	UserID         *int               `json:"user_id,omitempty"`
+	Level          BudgetWarningLevel `json:"level"`
+	BudgetIDR      int                `json:"budget_idr"`
+	ConsumedIDR    int                `json:"consumed_idr"`
+	ExpenseIDR     int                `json:"expense_idr"`
+	UsedPercent    float64            `json:"used_percent"`
+	Message        string             `json:"message"`
+}
+
+// DefaultBudgetWarningPercent is used when BUDGET_WARNING_PERCENT is not configured.
+const DefaultBudgetWarningPercent = 80
+
+// BudgetPolicy decides when a submission is warned about a budget.
+type BudgetPolicy struct {
+	// WarningPercent is the share of a budget, 0 to 100, above which a submission is
+	// warned that the budget is nearly used up.
+	WarningPercent int
+}
+
+// Check returns the warning for charging an expense's charge to the budget on top of
+// consumed, or nil when the budget stays at or below the warning share.
+func (p BudgetPolicy) Check(budget *Budget, consumed, charge int) *BudgetWarning {
+	if charge <= 0 {
+		return nil
+	}
+
+	spent := consumed + charge
+	var level BudgetWarningLevel
+	switch {
+	case spent > budget.AmountIDR:
+		level = BudgetExceeded
+	case spent*100 > budget.AmountIDR*p.WarningPercent:
+		level = BudgetNearLimit
+	default:
+		return nil
+	}
+
+	warning := &BudgetWarning{
+		BudgetID:       budget.ID,
+		Scope:          budget.Scope,
+		CostCenterCode: budget.CostCenterCode,
+		UserID:         budget.UserID,
+		Level:          level,
+		BudgetIDR:      budget.AmountIDR,
+		ConsumedIDR:    consumed,
+		ExpenseIDR:     charge,
+		UsedPercent:    usedPercent(spent, budget.AmountIDR),
+	}
+
+	period := budget.PeriodStart.Format("2006-01-02") + " to " + budget.PeriodEnd.Format("2006-01-02")
+	if level == BudgetExceeded {
+		warning.Message = fmt.Sprintf("the budget of %s for %s is exceeded by IDR %d",
+			budget.describe(), period, spent-budget.AmountIDR)
+	} else {
+		warning.Message = fmt.Sprintf("the budget of %s for %s is %.2f%% used, above the %d%% warning level",
+			budget.describe(), period, warning.UsedPercent, p.WarningPercent)
+	}
+
+	return warning
+}
+
+// ExceedsBudget reports whether any of the warnings is for an exceeded budget.
+func ExceedsBudget(warnings []*BudgetWarning) bool {
+	for _, w := range warnings {
+		if w.Level == BudgetExceeded {
+			return true
+		}
+	}
+	return false
+}
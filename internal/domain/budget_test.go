@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetValidate(t *testing.T) {
+	userID := 4
+
+	t.Run("cost center budget for a month", func(t *testing.T) {
+		b := &Budget{Scope: BudgetScopeCostCenter, CostCenterCode: " fin ", PeriodStart: time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC), AmountIDR: 50000000}
+		require.NoError(t, b.Validate())
+		require.Equal(t, "FIN", b.CostCenterCode)
+		require.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), b.PeriodStart)
+		require.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), b.PeriodEnd)
+	})
+
+	t.Run("user budget drops the cost center", func(t *testing.T) {
+		b := &Budget{Scope: BudgetScopeUser, UserID: &userID, CostCenterCode: "FIN", PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), AmountIDR: 5000000}
+		require.NoError(t, b.Validate())
+		require.Empty(t, b.CostCenterCode)
+	})
+
+	t.Run("user budget without user", func(t *testing.T) {
+		b := &Budget{Scope: BudgetScopeUser, PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), AmountIDR: 5000000}
+		require.ErrorIs(t, b.Validate(), ErrInvalidBudget)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		b := &Budget{Scope: "team", PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), AmountIDR: 5000000}
+		require.ErrorIs(t, b.Validate(), ErrInvalidBudget)
+	})
+
+	t.Run("period ends before it starts", func(t *testing.T) {
+		b := &Budget{Scope: BudgetScopeCostCenter, CostCenterCode: "FIN", PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), PeriodEnd: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), AmountIDR: 5000000}
+		require.ErrorIs(t, b.Validate(), ErrInvalidBudget)
+	})
+
+	t.Run("no amount", func(t *testing.T) {
+		b := &Budget{Scope: BudgetScopeCostCenter, CostCenterCode: "FIN", PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}
+		require.ErrorIs(t, b.Validate(), ErrInvalidBudget)
+	})
+}
+
+func TestBudgetCharge(t *testing.T) {
+	userID := 4
+	e := &Expense{UserID: userID, AmountIDR: 1000000, Allocations: []*Allocation{
+		{CostCenterCode: "FIN", AmountIDR: 600000},
+		{CostCenterCode: "OPS", AmountIDR: 300000},
+		{CostCenterCode: "FIN", ProjectCode: "PRJ-01", AmountIDR: 100000},
+	}}
+
+	require.Equal(t, 700000, (&Budget{Scope: BudgetScopeCostCenter, CostCenterCode: "FIN"}).Charge(e))
+	require.Equal(t, 0, (&Budget{Scope: BudgetScopeCostCenter, CostCenterCode: "HR"}).Charge(e))
+	require.Equal(t, 1000000, (&Budget{Scope: BudgetScopeUser, UserID: &userID}).Charge(e))
+
+	otherID := 5
+	require.Equal(t, 0, (&Budget{Scope: BudgetScopeUser, UserID: &otherID}).Charge(e))
+}
+
+func TestBudgetPolicyCheck(t *testing.T) {
+	policy := BudgetPolicy{WarningPercent: DefaultBudgetWarningPercent}
+	budget := &Budget{ID: 3, Scope: BudgetScopeCostCenter, CostCenterCode: "FIN",
+		PeriodStart: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), PeriodEnd: time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), AmountIDR: 10000000}
+
+	t.Run("below warning share", func(t *testing.T) {
+		require.Nil(t, policy.Check(budget, 7000000, 1000000))
+	})
+
+	t.Run("near the limit", func(t *testing.T) {
+		warning := policy.Check(budget, 7000000, 1500000)
+		require.NotNil(t, warning)
+		require.Equal(t, BudgetNearLimit, warning.Level)
+		require.Equal(t, 85.0, warning.UsedPercent)
+		require.Equal(t, "FIN", warning.CostCenterCode)
+		require.Contains(t, warning.Message, "cost center FIN")
+	})
+
+	t.Run("exactly used up", func(t *testing.T) {
+		warning := policy.Check(budget, 9000000, 1000000)
+		require.NotNil(t, warning)
+		require.Equal(t, BudgetNearLimit, warning.Level)
+	})
+
+	t.Run("exceeded", func(t *testing.T) {
+		warning := policy.Check(budget, 9000000, 1500000)
+		require.NotNil(t, warning)
+		require.Equal(t, BudgetExceeded, warning.Level)
+		require.Equal(t, 9000000, warning.ConsumedIDR)
+		require.Equal(t, 1500000, warning.ExpenseIDR)
+		require.Contains(t, warning.Message, "exceeded by IDR 500000")
+		require.True(t, ExceedsBudget([]*BudgetWarning{warning}))
+	})
+
+	t.Run("nothing charged", func(t *testing.T) {
+		require.Nil(t, policy.Check(budget, 12000000, 0))
+	})
+}
+
+func TestNewBudgetUsage(t *testing.T) {
+	usage := NewBudgetUsage(&Budget{AmountIDR: 8000000}, 9000000, 6)
+	require.Equal(t, -1000000, usage.RemainingIDR)
+	require.Equal(t, 112.5, usage.UsedPercent)
+	require.Equal(t, 6, usage.ExpenseCount)
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WIB is Western Indonesian Time, the zone approval SLAs are counted in. Indonesia does
+// not observe daylight saving, so a fixed offset is enough.
+var WIB = time.FixedZone("WIB", 7*60*60)
+
+// BusinessCalendar counts working days, skipping weekends and public holidays.
+type BusinessCalendar struct {
+	location *time.Location
+	holidays map[string]bool
+}
+
+func NewBusinessCalendar(location *time.Location, holidays []time.Time) *BusinessCalendar {
+	c := &BusinessCalendar{
+		location: location,
+		holidays: make(map[string]bool, len(holidays)),
+	}
+	for _, h := range holidays {
+		c.holidays[h.Format(time.DateOnly)] = true
+	}
+	return c
+}
+
+func (c *BusinessCalendar) IsBusinessDay(t time.Time) bool {
+	t = t.In(c.location)
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.holidays[t.Format(time.DateOnly)]
+}
+
+// BusinessDaysBetween returns how many business days have started after from, up to and
+// including the day of to. Something submitted on a Friday is one business day old on
+// Monday.
+func (c *BusinessCalendar) BusinessDaysBetween(from, to time.Time) int {
+	from, to = from.In(c.location), to.In(c.location)
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, c.location).AddDate(0, 0, 1)
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, c.location)
+
+	days := 0
+	for ; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(day) {
+			days++
+		}
+	}
+	return days
+}
+
+// ParseHolidays parses a comma-separated list of YYYY-MM-DD dates.
+func ParseHolidays(s string) ([]time.Time, error) {
+	var holidays []time.Time
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		day, err := time.ParseInLocation(time.DateOnly, part, WIB)
+		if err != nil {
+			return nil, fmt.Errorf("holiday %q must be a YYYY-MM-DD date", part)
+		}
+		holidays = append(holidays, day)
+	}
+	return holidays, nil
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusinessCalendar(t *testing.T) {
+	holidays, err := ParseHolidays(strings.Join(DefaultIndonesianHolidays, ","))
+	require.NoError(t, err)
+	calendar := NewBusinessCalendar(WIB, holidays)
+	at := func(date string, hour int) time.Time {
+		day, err := time.ParseInLocation(time.DateOnly, date, WIB)
+		require.NoError(t, err)
+		return day.Add(time.Duration(hour) * time.Hour)
+	}
+
+	require.True(t, calendar.IsBusinessDay(at("2026-08-14", 9)))
+	require.False(t, calendar.IsBusinessDay(at("2026-08-15", 9)), "saturday")
+	require.False(t, calendar.IsBusinessDay(at("2026-08-17", 9)), "independence day")
+
+	// Friday to Tuesday skips the weekend and the Independence Day Monday.
+	require.Equal(t, 1, calendar.BusinessDaysBetween(at("2026-08-14", 16), at("2026-08-18", 9)))
+	require.Equal(t, 0, calendar.BusinessDaysBetween(at("2026-08-14", 9), at("2026-08-14", 17)))
+
+	// Evening in UTC is already the next morning in Jakarta.
+	require.Equal(t, 1, calendar.BusinessDaysBetween(at("2026-08-12", 9), time.Date(2026, 8, 12, 20, 0, 0, 0, time.UTC)))
+}
+
+func TestParseHolidays(t *testing.T) {
+	holidays, err := ParseHolidays(" 2026-12-25, ,2026-01-01")
+	require.NoError(t, err)
+	require.Len(t, holidays, 2)
+
+	_, err = ParseHolidays("25-12-2026")
+	require.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Category classifies an expense and carries the policy limits for it. Categories are
+// deactivated rather than deleted so that existing expenses keep pointing at them.
+type Category struct {
+	ID              int       `json:"id"`
+	Name            string    `json:"name"`
+	MinAmountIDR    int       `json:"min_amount_idr"`
+	MaxAmountIDR    int       `json:"max_amount_idr"`
+	ReceiptRequired bool      `json:"receipt_required"`
+	Active          bool      `json:"active"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Validate checks the category definition itself.
+func (c *Category) Validate() error {
+	if c.Name == "" || c.MinAmountIDR <= 0 || c.MaxAmountIDR < c.MinAmountIDR {
+		return ErrInvalidCategory
+	}
+	return nil
+}
+
+// CheckAmount returns an *AmountRangeError when amountIDR is outside the category's limits.
+func (c *Category) CheckAmount(amountIDR int) error {
+	if amountIDR < c.MinAmountIDR || amountIDR > c.MaxAmountIDR {
+		return &AmountRangeError{Category: c.Name, Min: c.MinAmountIDR, Max: c.MaxAmountIDR}
+	}
+	return nil
+}
+
+// CheckReceipt returns ErrMissingReceipt when the category needs a receipt and none is attached.
+func (c *Category) CheckReceipt(receiptURL string) error {
+	if c.ReceiptRequired && receiptURL == "" {
+		return ErrMissingReceipt
+	}
+	return nil
+}
+
+// AmountRangeError reports an amount outside its category's limits. It matches
+// ErrInvalidAmount.
+type AmountRangeError struct {
+	Category string
+	Min      int
+	Max      int
+}
+
+func (e *AmountRangeError) Error() string {
+	return fmt.Sprintf("%s expenses must be between %d and %d IDR", e.Category, e.Min, e.Max)
+}
+
+func (e *AmountRangeError) Is(target error) bool {
+	return target == ErrInvalidAmount
+}
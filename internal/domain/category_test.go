@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryValidate(t *testing.T) {
+	require.NoError(t, (&Category{Name: "Meals", MinAmountIDR: 10000, MaxAmountIDR: 500000}).Validate())
+	require.ErrorIs(t, (&Category{MinAmountIDR: 10000, MaxAmountIDR: 500000}).Validate(), ErrInvalidCategory)
+	require.ErrorIs(t, (&Category{Name: "Meals", MaxAmountIDR: 500000}).Validate(), ErrInvalidCategory)
+	require.ErrorIs(t, (&Category{Name: "Meals", MinAmountIDR: 500000, MaxAmountIDR: 10000}).Validate(), ErrInvalidCategory)
+}
+
+func TestCategoryChecks(t *testing.T) {
+	meals := &Category{Name: "Meals", MinAmountIDR: 10000, MaxAmountIDR: 500000, ReceiptRequired: true}
+
+	require.NoError(t, meals.CheckAmount(10000))
+	require.NoError(t, meals.CheckAmount(500000))
+
+	err := meals.CheckAmount(500001)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+	require.EqualError(t, err, "Meals expenses must be between 10000 and 500000 IDR")
+
+	require.ErrorIs(t, meals.CheckReceipt(""), ErrMissingReceipt)
+	require.NoError(t, meals.CheckReceipt("https://receipts.example.com/1.jpg"))
+	require.NoError(t, (&Category{Name: "Taxi"}).CheckReceipt(""))
+}
@@ -1,7 +1,12 @@
 package domain
 
 const (
-	MinExpenseAmount  = 10000      // IDR 10,000
-	MaxExpenseAmount  = 50000000   // IDR 50,000,000
-	ApprovalThreshold = 1000000    // IDR 1,000,000
-)
\ No newline at end of file
+	ApprovalThreshold = 1000000 // IDR 1,000,000
+)
+
+// DefaultApprovalTiers is used when APPROVAL_TIERS is not configured.
+var DefaultApprovalTiers = []ApprovalTier{
+	{MinAmount: ApprovalThreshold, Levels: []ApprovalLevel{ApprovalLevelManager}},
+	{MinAmount: 10000000, Levels: []ApprovalLevel{ApprovalLevelManager, ApprovalLevelFinanceDirector}},
+	{MinAmount: 25000000, Levels: []ApprovalLevel{ApprovalLevelManager, ApprovalLevelFinanceDirector, ApprovalLevelCFO}},
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// CurrencyIDR is the settlement currency. Every expense is paid out in IDR, whatever
+// currency it was incurred in.
+const CurrencyIDR = "IDR"
+
+// FXRate is how many IDR one unit of Currency was worth on RateDate.
+type FXRate struct {
+	Currency string    `json:"currency"`
+	RateDate time.Time `json:"rate_date"`
+	RateIDR  float64   `json:"rate_idr"`
+}
+
+// ValidCurrency reports whether code looks like an ISO 4217 alphabetic code.
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// ConvertToIDR converts amount at rate and rounds to whole rupiah.
+func ConvertToIDR(amount, rate float64) int {
+	return int(math.Round(amount * rate))
+}
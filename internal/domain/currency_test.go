@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidCurrency(t *testing.T) {
+	require.True(t, ValidCurrency("IDR"))
+	require.True(t, ValidCurrency("USD"))
+	require.False(t, ValidCurrency("usd"))
+	require.False(t, ValidCurrency("US"))
+	require.False(t, ValidCurrency("US1"))
+}
+
+func TestConvertToIDR(t *testing.T) {
+	require.Equal(t, 1560000, ConvertToIDR(100, 15600))
+	require.Equal(t, 663011, ConvertToIDR(42.5, 15600.25))
+	require.Equal(t, 1, ConvertToIDR(0.01, 104.16))
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+)
+
+// Delegation lets DelegateID sign the manager approval level for ManagerID's team while
+// the manager is away. It is active from StartsAt up to, but not including, EndsAt.
+type Delegation struct {
+	ID         int       `json:"id"`
+	ManagerID  int       `json:"manager_id"`
+	DelegateID int       `json:"delegate_id"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (d *Delegation) IsActiveAt(t time.Time) bool {
+	return !t.Before(d.StartsAt) && t.Before(d.EndsAt)
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+type DuplicateReason string
+
+const (
+	// DuplicateReceipt marks an expense with a receipt file already uploaded to another
+	// expense, by anyone.
+	DuplicateReceipt DuplicateReason = "same_receipt"
+	// DuplicateSimilarExpense marks an expense that closely matches another one from the
+	// same submitter.
+	DuplicateSimilarExpense DuplicateReason = "similar_expense"
+)
+
+// DuplicateMatch flags an expense as a possible duplicate of another. Matches are shown to
+// approvers; they do not stop the submission.
+type DuplicateMatch struct {
+	ExpenseID     int             `json:"-"`
+	DuplicateOfID int             `json:"duplicate_of_id"`
+	Reason        DuplicateReason `json:"reason"`
+	Detail        string          `json:"detail"`
+}
+
+// DuplicatePolicy decides when two expenses from the same submitter are close enough to
+// be flagged.
+type DuplicatePolicy struct {
+	// WindowDays is how many days apart the two expenses may have been incurred.
+	WindowDays int
+	// AmountTolerancePercent is how far apart the amounts may be, relative to the larger.
+	AmountTolerancePercent int
+	// MinDescriptionSimilarity is the share of description words, 0 to 100, the two must
+	// have in common.
+	MinDescriptionSimilarity int
+}
+
+// DefaultDuplicatePolicy flags expenses within three days of each other whose amounts
+// differ by at most 5% and whose descriptions share at least 60% of their words.
+var DefaultDuplicatePolicy = DuplicatePolicy{
+	WindowDays:               3,
+	AmountTolerancePercent:   5,
+	MinDescriptionSimilarity: 60,
+}
+
+// Similar reports whether a and b look like the same claim.
+func (p DuplicatePolicy) Similar(a, b *Expense) bool {
+	days := int(a.IncurredOn.Sub(b.IncurredOn).Hours() / 24)
+	if days < 0 {
+		days = -days
+	}
+	if days > p.WindowDays {
+		return false
+	}
+
+	larger, diff := a.AmountIDR, a.AmountIDR-b.AmountIDR
+	if b.AmountIDR > larger {
+		larger = b.AmountIDR
+	}
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff*100 > larger*p.AmountTolerancePercent {
+		return false
+	}
+
+	return DescriptionSimilarity(a.Description, b.Description) >= p.MinDescriptionSimilarity
+}
+
+// DescriptionSimilarity compares the words of two descriptions, ignoring case and
+// punctuation. It returns the percentage of distinct words the two have in common.
+func DescriptionSimilarity(a, b string) int {
+	wordsA, wordsB := descriptionWords(a), descriptionWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	common := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			common++
+		}
+	}
+
+	return common * 100 / (len(wordsA) + len(wordsB) - common)
+}
+
+func descriptionWords(s string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		words[word] = true
+	}
+	return words
+}
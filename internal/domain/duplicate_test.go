@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescriptionSimilarity(t *testing.T) {
+	require.Equal(t, 100, DescriptionSimilarity("Taxi to airport", "taxi TO airport!"))
+	require.Equal(t, 50, DescriptionSimilarity("Taxi to airport", "taxi to hotel"))
+	require.Equal(t, 0, DescriptionSimilarity("Team lunch", "Hotel"))
+	require.Equal(t, 0, DescriptionSimilarity("", "Hotel"))
+}
+
+func TestDuplicatePolicySimilar(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	original := &Expense{ID: 1, AmountIDR: 250000, Description: "Taxi to client office", IncurredOn: day}
+	p := DefaultDuplicatePolicy
+
+	require.True(t, p.Similar(&Expense{AmountIDR: 250000, Description: "Taxi to client office", IncurredOn: day}, original))
+	require.True(t, p.Similar(&Expense{AmountIDR: 240000, Description: "taxi to the client office", IncurredOn: day.AddDate(0, 0, -3)}, original))
+	require.False(t, p.Similar(&Expense{AmountIDR: 250000, Description: "Taxi to client office", IncurredOn: day.AddDate(0, 0, 4)}, original))
+	require.False(t, p.Similar(&Expense{AmountIDR: 200000, Description: "Taxi to client office", IncurredOn: day}, original))
+	require.False(t, p.Similar(&Expense{AmountIDR: 250000, Description: "Dinner with client", IncurredOn: day}, original))
+}
@@ -3,9 +3,73 @@ package domain
 import "errors"
 
 var (
-	ErrExpenseNotFound      = errors.New("expense not found")
-	ErrInvalidExpenseStatus = errors.New("invalid expense status for this operation")
-	ErrUnauthorizedAction   = errors.New("unauthorized action")
-	ErrInvalidAmount        = errors.New("amount must be between 10,000 and 50,000,000 IDR")
-	ErrMissingDescription   = errors.New("description is required")
+	ErrExpenseNotFound        = errors.New("expense not found")
+	ErrInvalidExpenseStatus   = errors.New("invalid expense status for this operation")
+	ErrInvalidTransition      = errors.New("invalid expense status transition")
+	ErrStatusConflict         = errors.New("expense status was changed by another process")
+	ErrApprovalConflict       = errors.New("approval level has already been decided")
+	ErrUnauthorizedAction     = errors.New("unauthorized action")
+	ErrInvalidAmount          = errors.New("amount is outside the category's allowed range")
+	ErrMissingDescription     = errors.New("description is required")
+	ErrMissingReceipt         = errors.New("a receipt is required for this category")
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrInvalidCategory        = errors.New("category needs a name and a minimum amount above zero that does not exceed its maximum")
+	ErrInactiveCategory       = errors.New("category is not available for new expenses")
+	ErrDuplicateCategory      = errors.New("a category with this name already exists")
+	ErrMissingNotes           = errors.New("notes are required when requesting changes")
+	ErrExpenseNotEditable     = errors.New("only draft expenses or expenses returned for changes can be edited")
+	ErrRuleNotFound           = errors.New("policy rule not found")
+	ErrInvalidRule            = errors.New("policy rule needs a name, a known type and action, and a threshold above zero for amount-based types")
+	ErrPolicyBlocked          = errors.New("expense blocked by policy")
+	ErrReceiptNotFound        = errors.New("receipt not found")
+	ErrUnsupportedReceipt     = errors.New("receipts must be JPEG, PNG or PDF files")
+	ErrReceiptTooLarge        = errors.New("receipt file is too large")
+	ErrInvalidReceiptLink     = errors.New("receipt link is invalid or has expired")
+	ErrInvalidCurrency        = errors.New("currency must be a three-letter ISO 4217 code")
+	ErrFXRateNotFound         = errors.New("no exchange rate is available for this currency and date")
+	ErrTaxMismatch            = errors.New("net amount plus PPN must equal the expense amount")
+	ErrInvalidTax             = errors.New("PPN needs a rate between 0 and 100 percent and must not be negative")
+	ErrInvalidEFaktur         = errors.New("e-Faktur number must have 16 or 17 digits")
+	ErrInvalidPeriod          = errors.New("report period must start on or before its end")
+	ErrReportNotFound         = errors.New("expense report not found")
+	ErrInvalidReport          = errors.New("expense report needs a title and a trip end on or after its start")
+	ErrReportNotEditable      = errors.New("only draft expense reports can be changed")
+	ErrEmptyReport            = errors.New("expense report has no line items")
+	ErrLineNotAttachable      = errors.New("only your own draft expenses that are not in another report or filed against a cash advance can be added")
+	ErrExpenseInReport        = errors.New("expense is a line of an expense report; act on the report instead")
+	ErrCostCenterNotFound     = errors.New("cost center not found")
+	ErrInvalidCostCenter      = errors.New("cost center needs a code and a name")
+	ErrDuplicateCostCenter    = errors.New("a cost center with this code already exists")
+	ErrProjectNotFound        = errors.New("project not found")
+	ErrInvalidProject         = errors.New("project needs a code and a name")
+	ErrDuplicateProject       = errors.New("a project with this code already exists")
+	ErrInvalidAllocation      = errors.New("allocations need a cost center and must all be percentages adding up to 100 or IDR amounts adding up to the expense amount")
+	ErrInactiveAllocationCode = errors.New("allocation refers to a cost center or project that does not exist or is not active")
+	ErrBudgetNotFound         = errors.New("budget not found")
+	ErrInvalidBudget          = errors.New("budget needs a cost center or user scope, a period that starts on or before its end and an amount above zero")
+	ErrAdvanceNotFound        = errors.New("cash advance not found")
+	ErrInvalidAdvance         = errors.New("cash advance needs a purpose and an amount above zero")
+	ErrInvalidAdvanceStatus   = errors.New("invalid cash advance status for this operation")
+	ErrAdvanceNotLinkable     = errors.New("expenses can only be filed against your own cash advance while it is outstanding")
+	ErrAdvanceExpensesPending = errors.New("expenses filed against the cash advance are still waiting for a decision")
+	ErrInvalidDelegation      = errors.New("invalid delegation")
+	ErrDelegationNotFound     = errors.New("delegation not found")
+	ErrInvalidDelegate        = errors.New("delegate must be another approver")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrInvalidManager         = errors.New("manager must be another approver who does not report to the user")
+	ErrInvalidPaymentKind     = errors.New("payment kind must be expense, expense_report, cash_advance or cash_advance_reimbursement")
 )
+
+// PolicyViolationError is returned when an approval policy such as segregation of duties
+// blocks an otherwise authorised approver. It matches ErrUnauthorizedAction.
+type PolicyViolationError struct {
+	Reason string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return e.Reason
+}
+
+func (e *PolicyViolationError) Is(target error) bool {
+	return target == ErrUnauthorizedAction
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+)
+
+// SLAPolicy configures when the SLA job reminds approvers about an expense that is
+// waiting on them and when it escalates the expense to their manager. Both deadlines are
+// business days since the current approval level became outstanding.
+type SLAPolicy struct {
+	ReminderAfterDays   int
+	EscalationAfterDays int
+	Calendar            *BusinessCalendar
+}
+
+// Escalation lets EscalatedToID sign Level of an expense whose approvers missed the SLA.
+type Escalation struct {
+	ID            int           `json:"id"`
+	ExpenseID     int           `json:"expense_id"`
+	Level         ApprovalLevel `json:"level"`
+	EscalatedToID int           `json:"escalated_to_id"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
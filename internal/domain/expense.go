@@ -7,25 +7,159 @@ import (
 type ExpenseStatus string
 
 const (
+	ExpenseStatusDraft            ExpenseStatus = "draft"
 	ExpenseStatusPending          ExpenseStatus = "pending"
 	ExpenseStatusAwaitingApproval ExpenseStatus = "awaiting_approval"
 	ExpenseStatusApproved         ExpenseStatus = "approved"
 	ExpenseStatusRejected         ExpenseStatus = "rejected"
+	ExpenseStatusChangesRequested ExpenseStatus = "changes_requested"
 	ExpenseStatusAutoApproved     ExpenseStatus = "auto_approved"
 	ExpenseStatusProcessing       ExpenseStatus = "processing"
+	ExpenseStatusRetryScheduled   ExpenseStatus = "retry_scheduled"
+	ExpenseStatusDeadLetter       ExpenseStatus = "dead_letter"
 	ExpenseStatusCompleted        ExpenseStatus = "completed"
 	ExpenseStatusFailed           ExpenseStatus = "failed"
+	ExpenseStatusCancelled        ExpenseStatus = "cancelled"
 )
 
 type Expense struct {
-	ID               int           `json:"id"`
-	UserID           int           `json:"user_id"`
-	AmountIDR        int           `json:"amount_idr"`
-	Description      string        `json:"description"`
-	ReceiptURL       string        `json:"receipt_url"`
-	Status           ExpenseStatus `json:"status"`
-	SubmittedAt      time.Time     `json:"submitted_at"`
-	ProcessedAt      *time.Time    `json:"processed_at"`
-	RequiresApproval bool          `json:"requires_approval"`
-	AutoApproved     bool          `json:"auto_approved"`
+	ID                      int               `json:"id"`
+	UserID                  int               `json:"user_id"`
+	ReportID                *int              `json:"report_id,omitempty"`
+	AdvanceID               *int              `json:"advance_id,omitempty"`
+	CategoryID              int               `json:"category_id"`
+	AmountIDR               int               `json:"amount_idr"`
+	Currency                string            `json:"currency"`
+	OriginalAmount          float64           `json:"original_amount"`
+	FXRate                  float64           `json:"fx_rate"`
+	NetAmountIDR            int               `json:"net_amount_idr"`
+	TaxAmountIDR            int               `json:"tax_amount_idr"`
+	TaxRatePercent          float64           `json:"tax_rate_percent"`
+	EFakturNumber           string            `json:"efaktur_number"`
+	Description             string            `json:"description"`
+	Merchant                string            `json:"merchant"`
+	IncurredOn              time.Time         `json:"incurred_on"`
+	ReceiptURL              string            `json:"receipt_url"`
+	Status                  ExpenseStatus     `json:"status"`
+	SubmittedAt             time.Time         `json:"submitted_at"`
+	ProcessedAt             *time.Time        `json:"processed_at"`
+	RequiresApproval        bool              `json:"requires_approval"`
+	AutoApproved            bool              `json:"auto_approved"`
+	RequiresFinanceApproval bool              `json:"requires_finance_approval"`
+	PolicyViolations        []*RuleViolation  `json:"policy_violations,omitempty"`
+	PossibleDuplicates      []*DuplicateMatch `json:"possible_duplicates,omitempty"`
+	FraudFlags              []*FraudFlag      `json:"fraud_flags,omitempty"`
+	Allocations             []*Allocation     `json:"allocations,omitempty"`
+	BudgetWarnings          []*BudgetWarning  `json:"budget_warnings,omitempty"`
+}
+
+// Route decides how a submitted expense continues: amounts at or above the policy's
+// approval threshold wait for approval, anything smaller is auto-approved.
+func (e *Expense) Route(policy ApprovalPolicy) {
+	e.RequiresApproval = e.AmountIDR >= policy.Threshold()
+	e.AutoApproved = !e.RequiresApproval
+	if e.RequiresApproval {
+		e.Status = ExpenseStatusAwaitingApproval
+	} else {
+		e.Status = ExpenseStatusAutoApproved
+	}
+}
+
+// RequireApproval sends the expense to manual approval regardless of its amount.
+func (e *Expense) RequireApproval() {
+	e.RequiresApproval = true
+	e.AutoApproved = false
+	e.Status = ExpenseStatusAwaitingApproval
+}
+
+// IsEditable reports whether the submitter may still change the expense's details.
+func (e *Expense) IsEditable() bool {
+	return e.Status == ExpenseStatusDraft || e.Status == ExpenseStatusChangesRequested
+}
+
+// ExpenseChanges holds the submitter's edits to an expense. Nil fields are left as they
+// are.
+type ExpenseChanges struct {
+	CategoryID     *int          `json:"category_id"`
+	AmountIDR      *int          `json:"amount_idr"`
+	Currency       *string       `json:"currency"`
+	OriginalAmount *float64      `json:"original_amount"`
+	NetAmountIDR   *int          `json:"net_amount_idr"`
+	TaxAmountIDR   *int          `json:"tax_amount_idr"`
+	TaxRatePercent *float64      `json:"tax_rate_percent"`
+	EFakturNumber  *string       `json:"efaktur_number"`
+	Description    *string       `json:"description"`
+	Merchant       *string       `json:"merchant"`
+	IncurredOn     *time.Time    `json:"incurred_on"`
+	ReceiptURL     *string       `json:"receipt_url"`
+	Allocations    []*Allocation `json:"allocations"`
+	AdvanceID      *int          `json:"advance_id"`
+}
+
+// Apply copies the set fields of c onto e. An IDR amount given without a currency or
+// original amount means the expense was paid in rupiah. Changing the amount without a
+// new net amount lets the net amount be worked out again from the PPN. Allocations
+// replace the expense's allocations as a whole; an empty list removes them. An advance
+// id of 0 takes the expense off its cash advance.
+func (c ExpenseChanges) Apply(e *Expense) {
+	if c.CategoryID != nil {
+		e.CategoryID = *c.CategoryID
+	}
+	if c.AmountIDR != nil {
+		e.AmountIDR = *c.AmountIDR
+		if c.Currency == nil && c.OriginalAmount == nil {
+			e.Currency = CurrencyIDR
+			e.OriginalAmount = float64(e.AmountIDR)
+		}
+	}
+	if c.Currency != nil {
+		e.Currency = *c.Currency
+	}
+	if c.OriginalAmount != nil {
+		e.OriginalAmount = *c.OriginalAmount
+	}
+	if c.AmountIDR != nil || c.Currency != nil || c.OriginalAmount != nil {
+		e.NetAmountIDR = 0
+	}
+	if c.NetAmountIDR != nil {
+		e.NetAmountIDR = *c.NetAmountIDR
+	}
+	if c.TaxAmountIDR != nil {
+		e.TaxAmountIDR = *c.TaxAmountIDR
+	}
+	if c.TaxRatePercent != nil {
+		e.TaxRatePercent = *c.TaxRatePercent
+	}
+	if c.EFakturNumber != nil {
+		e.EFakturNumber = *c.EFakturNumber
+	}
+	if c.Description != nil {
+		e.Description = *c.Description
+	}
+	if c.Merchant != nil {
+		e.Merchant = *c.Merchant
+	}
+	if c.IncurredOn != nil {
+		e.IncurredOn = DateOf(*c.IncurredOn)
+	}
+	if c.ReceiptURL != nil {
+		e.ReceiptURL = *c.ReceiptURL
+	}
+	if c.Allocations != nil {
+		e.Allocations = c.Allocations
+	}
+	if c.AdvanceID != nil {
+		e.AdvanceID = nil
+		if *c.AdvanceID != 0 {
+			advanceID := *c.AdvanceID
+			e.AdvanceID = &advanceID
+		}
+	}
+}
+
+// DateOf drops the time of day from t, keeping its calendar date. Expense dates are
+// stored as plain dates, so this is how they are compared.
+func DateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
 }
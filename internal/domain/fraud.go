@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type FraudFlagKind string
+
+const (
+	// FraudThresholdSplit marks an expense that, together with the submitter's related
+	// recent claims, crosses the approval threshold none of them crossed alone.
+	FraudThresholdSplit FraudFlagKind = "threshold_split"
+)
+
+// FraudFlag records why an expense looks like an attempt to get around the approval
+// rules.
+type FraudFlag struct {
+	ExpenseID         int           `json:"-"`
+	Kind              FraudFlagKind `json:"kind"`
+	RelatedExpenseIDs []int         `json:"related_expense_ids"`
+	Explanation       string        `json:"explanation"`
+}
+
+// SplitPolicy decides which of a submitter's recent claims are summed up when looking
+// for a claim split to stay under the approval threshold.
+type SplitPolicy struct {
+	// WindowDays is how many days apart the claims may have been incurred.
+	WindowDays int
+	// MinDescriptionSimilarity is the share of description words, 0 to 100, that makes
+	// claims from different categories related.
+	MinDescriptionSimilarity int
+	// Threshold is the approval threshold the claims would be split to stay under.
+	Threshold int
+}
+
+// DefaultSplitPolicy sums claims incurred within a week of each other that share a
+// category or at least 60% of their description words.
+var DefaultSplitPolicy = SplitPolicy{
+	WindowDays:               7,
+	MinDescriptionSimilarity: 60,
+	Threshold:                ApprovalThreshold,
+}
+
+// Related reports whether a and b could be parts of one split claim.
+func (p SplitPolicy) Related(a, b *Expense) bool {
+	return a.CategoryID == b.CategoryID ||
+		DescriptionSimilarity(a.Description, b.Description) >= p.MinDescriptionSimilarity
+}
+
+// DetectSplit checks an expense that is about to be auto-approved against the
+// submitter's recent claims. Only claims that were auto-approved themselves count;
+// anything above the threshold has been looked at by an approver already. It returns
+// nil when the related claims stay below the threshold together.
+func (p SplitPolicy) DetectSplit(expense *Expense, recent []*Expense) *FraudFlag {
+	if expense.RequiresApproval {
+		return nil
+	}
+
+	total := expense.AmountIDR
+	var related []int
+	for _, other := range recent {
+		if other.RequiresApproval || !p.Related(expense, other) {
+			continue
+		}
+		total += other.AmountIDR
+		related = append(related, other.ID)
+	}
+
+	if len(related) == 0 || total < p.Threshold {
+		return nil
+	}
+
+	ids := make([]string, len(related))
+	for i, id := range related {
+		ids[i] = strconv.Itoa(id)
+	}
+
+	return &FraudFlag{
+		ExpenseID:         expense.ID,
+		Kind:              FraudThresholdSplit,
+		RelatedExpenseIDs: related,
+		Explanation: fmt.Sprintf("together with expense %s the claims add up to IDR %d, at or above the IDR %d approval threshold",
+			strings.Join(ids, ", "), total, p.Threshold),
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPolicyDetectSplit(t *testing.T) {
+	p := DefaultSplitPolicy
+	expense := &Expense{ID: 10, CategoryID: 2, AmountIDR: 750000, Description: "Hotel Bandung night 2"}
+
+	t.Run("split claim", func(t *testing.T) {
+		flag := p.DetectSplit(expense, []*Expense{
+			{ID: 8, CategoryID: 2, AmountIDR: 750000, Description: "Hotel Bandung night 1"},
+			{ID: 9, CategoryID: 5, AmountIDR: 90000, Description: "Lunch"},
+		})
+		require.NotNil(t, flag)
+		require.Equal(t, FraudThresholdSplit, flag.Kind)
+		require.Equal(t, []int{8}, flag.RelatedExpenseIDs)
+		require.Equal(t, "together with expense 8 the claims add up to IDR 1500000, at or above the IDR 1000000 approval threshold", flag.Explanation)
+	})
+
+	t.Run("related by description", func(t *testing.T) {
+		flag := p.DetectSplit(expense, []*Expense{{ID: 8, CategoryID: 7, AmountIDR: 300000, Description: "hotel bandung night 3"}})
+		require.NotNil(t, flag)
+	})
+
+	t.Run("below the threshold together", func(t *testing.T) {
+		require.Nil(t, p.DetectSplit(expense, []*Expense{{ID: 8, CategoryID: 2, AmountIDR: 200000}}))
+	})
+
+	t.Run("approved claims do not count", func(t *testing.T) {
+		require.Nil(t, p.DetectSplit(expense, []*Expense{{ID: 8, CategoryID: 2, AmountIDR: 2000000, RequiresApproval: true}}))
+	})
+
+	t.Run("expense already needs approval", func(t *testing.T) {
+		big := &Expense{ID: 11, CategoryID: 2, AmountIDR: 1200000, RequiresApproval: true}
+		require.Nil(t, p.DetectSplit(big, []*Expense{{ID: 8, CategoryID: 2, AmountIDR: 750000}}))
+	})
+}
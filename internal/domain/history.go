@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type ActorType string
+
+const (
+	ActorTypeUser   ActorType = "user"
+	ActorTypeWorker ActorType = "worker"
+)
+
+// HistoryEvent distinguishes status changes from the sign-offs and SLA events that are
+// recorded on the timeline without moving the expense.
+type HistoryEvent string
+
+const (
+	HistoryEventStatusChange  HistoryEvent = "status_change"
+	HistoryEventLevelApproved HistoryEvent = "level_approved"
+	HistoryEventReminder      HistoryEvent = "sla_reminder"
+	HistoryEventEscalation    HistoryEvent = "sla_escalation"
+)
+
+// StatusHistory is one entry in an expense's status timeline. FromStatus is nil for the
+// entry written when the expense is first submitted. Intermediate sign-offs, SLA reminders
+// and escalations keep the status unchanged, so their FromStatus and ToStatus are equal.
+type StatusHistory struct {
+	ID         int            `json:"id"`
+	ExpenseID  int            `json:"expense_id"`
+	Event      HistoryEvent   `json:"event"`
+	FromStatus *ExpenseStatus `json:"from_status"`
+	ToStatus   ExpenseStatus  `json:"to_status"`
+	ActorType  ActorType      `json:"actor_type"`
+	ActorID    *int           `json:"actor_id"`
+	Reason     string         `json:"reason"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// UserStatusChange returns the timeline entry for a transition made by a user. An empty
+// from marks the initial submission.
+func UserStatusChange(expenseID int, from, to ExpenseStatus, actorID int, reason string) *StatusHistory {
+	entry := &StatusHistory{
+		ExpenseID: expenseID,
+		ToStatus:  to,
+		ActorType: ActorTypeUser,
+		ActorID:   &actorID,
+		Reason:    reason,
+	}
+	if from != "" {
+		entry.FromStatus = &from
+	}
+	return entry
+}
+
+// WorkerStatusChange returns the timeline entry for a transition made by a background
+// worker.
+func WorkerStatusChange(expenseID int, from, to ExpenseStatus, reason string) *StatusHistory {
+	return &StatusHistory{
+		ExpenseID:  expenseID,
+		FromStatus: &from,
+		ToStatus:   to,
+		ActorType:  ActorTypeWorker,
+		Reason:     reason,
+	}
+}
+
+// LevelApproved returns the timeline entry for a sign-off that leaves further levels
+// outstanding.
+func LevelApproved(expenseID int, status ExpenseStatus, level ApprovalLevel, actorID int, notes string) *StatusHistory {
+	entry := UserStatusChange(expenseID, status, status, actorID, fmt.Sprintf("%s level approved", level))
+	entry.Event = HistoryEventLevelApproved
+	if notes != "" {
+		entry.Reason += ": " + notes
+	}
+	return entry
+}
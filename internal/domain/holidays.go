@@ -0,0 +1,25 @@
+package domain
+
+// DefaultIndonesianHolidays lists the 2026 national public holidays (hari libur nasional)
+// set by the joint ministerial decree. Collective leave days (cuti bersama) are not
+// included. Set SLA_HOLIDAYS to replace the list, e.g. once the next year's decree is
+// published.
+var DefaultIndonesianHolidays = []string{
+	"2026-01-01", // Tahun Baru Masehi
+	"2026-01-16", // Isra Mikraj
+	"2026-02-17", // Tahun Baru Imlek
+	"2026-03-19", // Hari Suci Nyepi
+	"2026-03-21", // Idul Fitri
+	"2026-03-22", // Idul Fitri
+	"2026-04-03", // Wafat Yesus Kristus
+	"2026-04-05", // Kebangkitan Yesus Kristus
+	"2026-05-01", // Hari Buruh Internasional
+	"2026-05-14", // Kenaikan Yesus Kristus
+	"2026-05-27", // Idul Adha
+	"2026-05-31", // Hari Raya Waisak
+	"2026-06-01", // Hari Lahir Pancasila
+	"2026-06-16", // Tahun Baru Islam
+	"2026-08-17", // Hari Kemerdekaan
+	"2026-08-25", // Maulid Nabi Muhammad SAW
+	"2026-12-25", // Hari Raya Natal
+}
@@ -1,5 +1,13 @@
 package domain
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
 type PaymentRequest struct {
 	Amount     int    `json:"amount"`
 	ExternalID string `json:"external_id"`
@@ -12,4 +20,122 @@ type PaymentResponse struct {
 		Status     string `json:"status"`
 	} `json:"data"`
 	Message string `json:"message,omitempty"`
+
+	// Raw is the response body exactly as the provider sent it.
+	Raw json.RawMessage `json:"-"`
+}
+
+// ErrDuplicatePayment is matched by a PaymentError with which the provider turned down a
+// payment because one was already made under its external id.
+var ErrDuplicatePayment = errors.New("external id already exists")
+
+// Statuses the provider reports for a payment it accepted.
+const (
+	ProviderStatusSuccess = "success"
+	ProviderStatusFailed  = "failed"
+)
+
+// PaymentError is returned when the provider answered a payment request with an error
+// status. Unlike a timeout or a dropped connection it means the provider did see the
+// request.
+type PaymentError struct {
+	StatusCode int
+	Message    string
+	Body       []byte
+}
+
+func (e *PaymentError) Error() string {
+	return fmt.Sprintf("payment failed: %s", e.Message)
+}
+
+// Is matches ErrDuplicatePayment when the provider answered 409 Conflict, which is how it
+// reports an external id it has already paid.
+func (e *PaymentError) Is(target error) bool {
+	return target == ErrDuplicatePayment && e.StatusCode == http.StatusConflict
+}
+
+// PaymentKind names what a payment pays for. Together with the subject's id it
+// identifies the payment, so each subject is paid under one external id.
+type PaymentKind string
+
+const (
+	PaymentKindExpense              PaymentKind = "expense"
+	PaymentKindExpenseReport        PaymentKind = "expense_report"
+	PaymentKindAdvance              PaymentKind = "cash_advance"
+	PaymentKindAdvanceReimbursement PaymentKind = "cash_advance_reimbursement"
+)
+
+type PaymentStatus string
+
+const (
+	// PaymentStatusPending covers both a payment not sent yet and one whose outcome is
+	// unknown because the request timed out, the connection dropped or the provider has
+	// not decided yet.
+	PaymentStatusPending   PaymentStatus = "pending"
+	PaymentStatusSucceeded PaymentStatus = "succeeded"
+	// PaymentStatusFailed is only recorded once the provider confirmed that the payment
+	// did not go through, either by refusing the request or by reporting it failed.
+	PaymentStatusFailed PaymentStatus = "failed"
+)
+
+// Payment records a payment sent to the provider. The external id is stored before the
+// provider is called and reused whenever the payment is sent again, so the provider's
+// duplicate check stops a second payout.
+type Payment struct {
+	ID          int           `json:"id"`
+	Kind        PaymentKind   `json:"kind"`
+	SubjectID   int           `json:"subject_id"`
+	ExternalID  string        `json:"external_id"`
+	AmountIDR   int           `json:"amount_idr"`
+	Status      PaymentStatus `json:"status"`
+	ProviderID  string        `json:"provider_id"`
+	RawResponse string        `json:"raw_response"`
+	// Attempts counts the requests sent to the provider since the payment was created or
+	// last requeued. LastError is the error of the latest failed one, and NextAttemptAt is
+	// set while the payment waits to be retried.
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	LastError     string     `json:"last_error"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// RetryPolicy decides how often and how soon a payment that failed for a transient
+// reason, such as a timeout or a server error at the provider, is sent again.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// CanRetry reports whether a payment that has been attempted attempts times may be sent
+// again.
+func (p RetryPolicy) CanRetry(attempts int) bool {
+	return attempts < p.MaxAttempts
+}
+
+// Delay returns how long to wait after the given failed attempt. The delay doubles with
+// every attempt up to MaxDelay, and jitter, between 0 and 1, spreads it over its upper
+// half so payments that failed together are not all retried at once.
+func (p RetryPolicy) Delay(attempts int, jitter float64) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return delay/2 + time.Duration(jitter*float64(delay/2))
+}
+
+// DeadLetter is an expense, expense report or cash advance whose payment ran out of
+// retries, together with that payment, waiting for finance to requeue or abandon it. Kind
+// is the payment's kind and says which of the three is set.
+type DeadLetter struct {
+	Kind    PaymentKind    `json:"kind"`
+	Expense *Expense       `json:"expense,omitempty"`
+	Report  *ExpenseReport `json:"report,omitempty"`
+	Advance *CashAdvance   `json:"advance,omitempty"`
+	Payment *Payment       `json:"payment"`
 }
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 30 * time.Second, MaxDelay: 5 * time.Minute}
+
+	require.Equal(t, 15*time.Second, policy.Delay(1, 0))
+	require.Equal(t, 30*time.Second, policy.Delay(1, 1))
+	require.Equal(t, 90*time.Second, policy.Delay(3, 0.5))
+	require.Equal(t, 5*time.Minute, policy.Delay(5, 1))
+	require.Equal(t, 5*time.Minute, policy.Delay(100, 1))
+}
+
+func TestRetryPolicyCanRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3}
+
+	require.True(t, policy.CanRetry(2))
+	require.False(t, policy.CanRetry(3))
+	require.False(t, RetryPolicy{MaxAttempts: 1}.CanRetry(1))
+}
+
+func TestPaymentErrorIsDuplicate(t *testing.T) {
+	require.ErrorIs(t, &PaymentError{StatusCode: http.StatusConflict, Message: "Conflict"}, ErrDuplicatePayment)
+	require.NotErrorIs(t, &PaymentError{StatusCode: http.StatusBadRequest, Message: "external id already exists"}, ErrDuplicatePayment)
+}
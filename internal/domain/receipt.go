@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// receiptExtensions maps the accepted receipt content types to the extension their files
+// are stored under.
+var receiptExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+// Receipt is a file uploaded as proof of an expense. Files are stored under a key derived
+// from their SHA-256 digest and are only served through short-lived signed URLs.
+type Receipt struct {
+	ID          int        `json:"id"`
+	ExpenseID   int        `json:"expense_id"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	SizeBytes   int64      `json:"size_bytes"`
+	SHA256      string     `json:"sha256"`
+	StorageKey  string     `json:"-"`
+	UploadedBy  int        `json:"uploaded_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	URLExpires  *time.Time `json:"download_url_expires_at,omitempty"`
+}
+
+// ReceiptExtension returns the file extension for an accepted receipt content type.
+func ReceiptExtension(contentType string) (string, bool) {
+	ext, ok := receiptExtensions[contentType]
+	return ext, ok
+}
+
+// ReceiptStorageKey is where the receipt's file is stored: one folder per expense, named
+// after the digest so that uploading the same file twice overwrites nothing new.
+func ReceiptStorageKey(expenseID int, sha256Hex, contentType string) string {
+	ext, _ := ReceiptExtension(contentType)
+	return fmt.Sprintf("expenses/%d/%s%s", expenseID, sha256Hex, ext)
+}
+
+// ReceiptPath is the API path of a stored receipt. Uploading a receipt points the
+// expense's receipt_url at it.
+func ReceiptPath(receiptID int) string {
+	return fmt.Sprintf("/api/receipts/%d", receiptID)
+}
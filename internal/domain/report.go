@@ -0,0 +1,105 @@
+package domain
+
+import "time"
+
+// ExpenseReport groups the line items of one trip or occasion into a single claim. The
+// report is submitted, approved and paid as a unit; its lines follow its status, except
+// lines an approver rejected on their own.
+type ExpenseReport struct {
+	ID                      int                `json:"id"`
+	UserID                  int                `json:"user_id"`
+	Title                   string             `json:"title"`
+	Purpose                 string             `json:"purpose"`
+	TripStart               time.Time          `json:"trip_start"`
+	TripEnd                 time.Time          `json:"trip_end"`
+	Status                  ExpenseStatus      `json:"status"`
+	TotalIDR                int                `json:"total_idr"`
+	RequiresApproval        bool               `json:"requires_approval"`
+	AutoApproved            bool               `json:"auto_approved"`
+	RequiresFinanceApproval bool               `json:"requires_finance_approval"`
+	SubmittedAt             *time.Time         `json:"submitted_at"`
+	ProcessedAt             *time.Time         `json:"processed_at"`
+	CreatedAt               time.Time          `json:"created_at"`
+	Lines                   []*Expense         `json:"lines,omitempty"`
+	AllocationTotals        []*AllocationTotal `json:"allocation_totals,omitempty"`
+	Approvals               []*ReportApproval  `json:"approvals,omitempty"`
+}
+
+// Validate checks the report's details and normalises its trip dates.
+func (r *ExpenseReport) Validate() error {
+	r.TripStart = DateOf(r.TripStart)
+	r.TripEnd = DateOf(r.TripEnd)
+	if r.Title == "" || r.TripStart.IsZero() || r.TripEnd.Before(r.TripStart) {
+		return ErrInvalidReport
+	}
+	return nil
+}
+
+// IsEditable reports whether the submitter may still change the report and its lines.
+func (r *ExpenseReport) IsEditable() bool {
+	return r.Status == ExpenseStatusDraft
+}
+
+// ClaimedLines returns the lines that have not been rejected.
+func (r *ExpenseReport) ClaimedLines() []*Expense {
+	var lines []*Expense
+	for _, line := range r.Lines {
+		if line.Status != ExpenseStatusRejected {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Line returns the report's line with the given expense id.
+func (r *ExpenseReport) Line(expenseID int) (*Expense, bool) {
+	for _, line := range r.Lines {
+		if line.ID == expenseID {
+			return line, true
+		}
+	}
+	return nil, false
+}
+
+// UpdateTotal sums the IDR amounts of the lines that have not been rejected.
+func (r *ExpenseReport) UpdateTotal() {
+	r.TotalIDR = 0
+	for _, line := range r.ClaimedLines() {
+		r.TotalIDR += line.AmountIDR
+	}
+}
+
+// Route decides how a submitted report continues, the same way as a single expense but
+// on the report total. requireApproval forces approval, e.g. when a policy rule asks for
+// it on one of the lines, and so does a line that needs finance approval.
+func (r *ExpenseReport) Route(policy ApprovalPolicy, requireApproval bool) {
+	r.RequiresApproval = requireApproval || r.RequiresFinanceApproval || r.TotalIDR >= policy.Threshold()
+	r.AutoApproved = !r.RequiresApproval
+	if r.RequiresApproval {
+		r.Status = ExpenseStatusAwaitingApproval
+	} else {
+		r.Status = ExpenseStatusAutoApproved
+	}
+}
+
+// NextApprovalLevel returns the first level the policy requires for the report that has
+// not signed off yet.
+func (r *ExpenseReport) NextApprovalLevel(policy ApprovalPolicy) (ApprovalLevel, bool) {
+	chain := make([]*Approval, len(r.Approvals))
+	for i, a := range r.Approvals {
+		chain[i] = &Approval{Level: a.Level, Status: a.Status}
+	}
+	return NextApprovalLevel(policy.ReportLevels(r), chain)
+}
+
+// ReportApproval is one signed level of an expense report's approval chain.
+type ReportApproval struct {
+	ID           int            `json:"id"`
+	ReportID     int            `json:"report_id"`
+	ApproverID   int            `json:"approver_id"`
+	OnBehalfOfID *int           `json:"on_behalf_of_id"`
+	Level        ApprovalLevel  `json:"level"`
+	Status       ApprovalStatus `json:"status"`
+	Notes        string         `json:"notes"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpenseReportValidate(t *testing.T) {
+	start := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+
+	r := &ExpenseReport{Title: "Jakarta client visit", TripStart: start, TripEnd: start.AddDate(0, 0, 4)}
+	require.NoError(t, r.Validate())
+	require.Equal(t, DateOf(start), r.TripStart)
+
+	require.ErrorIs(t, (&ExpenseReport{TripStart: start, TripEnd: start}).Validate(), ErrInvalidReport)
+	require.ErrorIs(t, (&ExpenseReport{Title: "trip", TripStart: start, TripEnd: start.AddDate(0, 0, -1)}).Validate(), ErrInvalidReport)
+}
+
+func TestExpenseReportTotalAndRoute(t *testing.T) {
+	r := &ExpenseReport{Lines: []*Expense{
+		{ID: 1, AmountIDR: 600000, Status: ExpenseStatusAwaitingApproval},
+		{ID: 2, AmountIDR: 500000, Status: ExpenseStatusAwaitingApproval},
+		{ID: 3, AmountIDR: 900000, Status: ExpenseStatusRejected},
+	}}
+
+	r.UpdateTotal()
+	require.Equal(t, 1100000, r.TotalIDR)
+	require.Len(t, r.ClaimedLines(), 2)
+
+	policy := ApprovalPolicy{Tiers: DefaultApprovalTiers}
+	r.Route(policy, false)
+	require.Equal(t, ExpenseStatusAwaitingApproval, r.Status)
+
+	r.TotalIDR = 200000
+	r.Route(policy, false)
+	require.Equal(t, ExpenseStatusAutoApproved, r.Status)
+
+	r.Route(policy, true)
+	require.Equal(t, ExpenseStatusAwaitingApproval, r.Status)
+
+	r.TotalIDR = 1100000
+	r.Route(ApprovalPolicy{Tiers: []ApprovalTier{{MinAmount: 2000000, Levels: []ApprovalLevel{ApprovalLevelManager}}}}, false)
+	require.Equal(t, ExpenseStatusAutoApproved, r.Status)
+
+	r.TotalIDR = 200000
+	r.RequiresFinanceApproval = true
+	r.Route(policy, false)
+	require.Equal(t, ExpenseStatusAwaitingApproval, r.Status)
+}
+
+func TestExpenseReportNextApprovalLevel(t *testing.T) {
+	policy := ApprovalPolicy{Tiers: DefaultApprovalTiers}
+	r := &ExpenseReport{TotalIDR: 12000000}
+
+	level, ok := r.NextApprovalLevel(policy)
+	require.True(t, ok)
+	require.Equal(t, ApprovalLevelManager, level)
+
+	r.Approvals = []*ReportApproval{{Level: ApprovalLevelManager, Status: ApprovalStatusApproved}}
+	level, ok = r.NextApprovalLevel(policy)
+	require.True(t, ok)
+	require.Equal(t, ApprovalLevelFinanceDirector, level)
+
+	r.Approvals = append(r.Approvals, &ReportApproval{Level: ApprovalLevelFinanceDirector, Status: ApprovalStatusApproved})
+	_, ok = r.NextApprovalLevel(policy)
+	require.False(t, ok)
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type RuleType string
+
+const (
+	// RuleMaxAmount flags expenses above Threshold.
+	RuleMaxAmount RuleType = "max_amount"
+	// RuleWeekendDate flags expenses incurred on a Saturday or Sunday.
+	RuleWeekendDate RuleType = "weekend_date"
+	// RuleMissingReceipt flags expenses of at least Threshold without a receipt.
+	RuleMissingReceipt RuleType = "missing_receipt"
+	// RuleDuplicateMerchantDay flags a second expense from the same merchant on the same day.
+	RuleDuplicateMerchantDay RuleType = "duplicate_merchant_day"
+	// RulePerDiemCap flags a submitter whose expenses on one day add up to more than Threshold.
+	RulePerDiemCap RuleType = "per_diem_cap"
+)
+
+type RuleAction string
+
+const (
+	// RuleActionBlock refuses to submit the expense.
+	RuleActionBlock RuleAction = "block"
+	// RuleActionWarn records the violation and lets the expense through.
+	RuleActionWarn RuleAction = "warn"
+	// RuleActionRequireApproval sends the expense to manual approval even below the
+	// auto-approval threshold.
+	RuleActionRequireApproval RuleAction = "require_approval"
+)
+
+// PolicyRule is one declarative check run against every submission. A rule with a
+// CategoryID only applies to expenses in that category.
+type PolicyRule struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Type       RuleType   `json:"type"`
+	CategoryID *int       `json:"category_id"`
+	Threshold  int        `json:"threshold_idr"`
+	Action     RuleAction `json:"action"`
+	Active     bool       `json:"active"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Validate checks the rule definition itself.
+func (r *PolicyRule) Validate() error {
+	if r.Name == "" {
+		return ErrInvalidRule
+	}
+
+	switch r.Action {
+	case RuleActionBlock, RuleActionWarn, RuleActionRequireApproval:
+	default:
+		return ErrInvalidRule
+	}
+
+	switch r.Type {
+	case RuleMaxAmount, RulePerDiemCap:
+		if r.Threshold <= 0 {
+			return ErrInvalidRule
+		}
+	case RuleMissingReceipt:
+		if r.Threshold < 0 {
+			return ErrInvalidRule
+		}
+	case RuleWeekendDate, RuleDuplicateMerchantDay:
+	default:
+		return ErrInvalidRule
+	}
+
+	return nil
+}
+
+// NeedsSameDayExpenses reports whether the rule looks at the submitter's other expenses
+// from the same day.
+func (r *PolicyRule) NeedsSameDayExpenses() bool {
+	return r.Type == RuleDuplicateMerchantDay || r.Type == RulePerDiemCap
+}
+
+// Check evaluates the rule against expense. sameDay holds the submitter's other open
+// expenses incurred on the same day. It returns nil when the rule is satisfied.
+func (r *PolicyRule) Check(expense *Expense, sameDay []*Expense) *RuleViolation {
+	if r.CategoryID != nil && *r.CategoryID != expense.CategoryID {
+		return nil
+	}
+
+	var message string
+	switch r.Type {
+	case RuleMaxAmount:
+		if expense.AmountIDR > r.Threshold {
+			message = fmt.Sprintf("amount exceeds IDR %d", r.Threshold)
+		}
+	case RuleWeekendDate:
+		day := expense.IncurredOn.Weekday()
+		if day == time.Saturday || day == time.Sunday {
+			message = fmt.Sprintf("incurred on a %s", day)
+		}
+	case RuleMissingReceipt:
+		if expense.ReceiptURL == "" && expense.AmountIDR >= r.Threshold {
+			message = "no receipt attached"
+		}
+	case RuleDuplicateMerchantDay:
+		for _, other := range sameDay {
+			if expense.Merchant != "" && strings.EqualFold(other.Merchant, expense.Merchant) {
+				message = fmt.Sprintf("expense %d is from the same merchant on the same day", other.ID)
+				break
+			}
+		}
+	case RulePerDiemCap:
+		total := expense.AmountIDR
+		for _, other := range sameDay {
+			if r.CategoryID == nil || other.CategoryID == *r.CategoryID {
+				total += other.AmountIDR
+			}
+		}
+		if total > r.Threshold {
+			message = fmt.Sprintf("daily total of IDR %d exceeds the IDR %d per-diem cap", total, r.Threshold)
+		}
+	}
+
+	if message == "" {
+		return nil
+	}
+
+	return &RuleViolation{
+		ExpenseID: expense.ID,
+		RuleID:    r.ID,
+		RuleName:  r.Name,
+		Action:    r.Action,
+		Message:   message,
+	}
+}
+
+// RuleViolation records a policy rule an expense broke when it was last evaluated.
+type RuleViolation struct {
+	ExpenseID int        `json:"-"`
+	RuleID    int        `json:"rule_id"`
+	RuleName  string     `json:"rule_name"`
+	Action    RuleAction `json:"action"`
+	Message   string     `json:"message"`
+}
+
+// RuleBlockedError is returned when a blocking policy rule stops a submission. It matches
+// ErrPolicyBlocked.
+type RuleBlockedError struct {
+	Violations []*RuleViolation
+}
+
+func (e *RuleBlockedError) Error() string {
+	var reasons []string
+	for _, v := range e.Violations {
+		if v.Action == RuleActionBlock {
+			reasons = append(reasons, v.RuleName+": "+v.Message)
+		}
+	}
+	return "expense blocked by policy: " + strings.Join(reasons, "; ")
+}
+
+func (e *RuleBlockedError) Is(target error) bool {
+	return target == ErrPolicyBlocked
+}
+
+// HasRuleAction reports whether any of the violations carries action.
+func HasRuleAction(violations []*RuleViolation, action RuleAction) bool {
+	for _, v := range violations {
+		if v.Action == action {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyRuleValidate(t *testing.T) {
+	require.NoError(t, (&PolicyRule{Name: "Weekend", Type: RuleWeekendDate, Action: RuleActionWarn}).Validate())
+	require.NoError(t, (&PolicyRule{Name: "Receipts", Type: RuleMissingReceipt, Action: RuleActionBlock}).Validate())
+	require.ErrorIs(t, (&PolicyRule{Type: RuleWeekendDate, Action: RuleActionWarn}).Validate(), ErrInvalidRule)
+	require.ErrorIs(t, (&PolicyRule{Name: "Cap", Type: RulePerDiemCap, Action: RuleActionWarn}).Validate(), ErrInvalidRule)
+	require.ErrorIs(t, (&PolicyRule{Name: "Cap", Type: "unknown", Action: RuleActionWarn}).Validate(), ErrInvalidRule)
+	require.ErrorIs(t, (&PolicyRule{Name: "Cap", Type: RuleMaxAmount, Threshold: 1, Action: "escalate"}).Validate(), ErrInvalidRule)
+}
+
+func TestPolicyRuleCheck(t *testing.T) {
+	meals := 4
+	friday := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	expense := &Expense{ID: 10, CategoryID: meals, AmountIDR: 400000, Merchant: "Warung Sunda", IncurredOn: friday}
+
+	t.Run("max amount", func(t *testing.T) {
+		r := &PolicyRule{ID: 1, Name: "Meal cap", Type: RuleMaxAmount, CategoryID: &meals, Threshold: 300000, Action: RuleActionBlock}
+		v := r.Check(expense, nil)
+		require.NotNil(t, v)
+		require.Equal(t, RuleViolation{ExpenseID: 10, RuleID: 1, RuleName: "Meal cap", Action: RuleActionBlock, Message: "amount exceeds IDR 300000"}, *v)
+
+		other := 2
+		r.CategoryID = &other
+		require.Nil(t, r.Check(expense, nil))
+	})
+
+	t.Run("weekend date", func(t *testing.T) {
+		r := &PolicyRule{Name: "Weekend", Type: RuleWeekendDate, Action: RuleActionWarn}
+		require.Nil(t, r.Check(expense, nil))
+
+		sunday := *expense
+		sunday.IncurredOn = friday.AddDate(0, 0, 2)
+		require.Equal(t, "incurred on a Sunday", r.Check(&sunday, nil).Message)
+	})
+
+	t.Run("missing receipt", func(t *testing.T) {
+		r := &PolicyRule{Name: "Receipts", Type: RuleMissingReceipt, Threshold: 250000, Action: RuleActionRequireApproval}
+		require.NotNil(t, r.Check(expense, nil))
+
+		small := *expense
+		small.AmountIDR = 100000
+		require.Nil(t, r.Check(&small, nil))
+
+		withReceipt := *expense
+		withReceipt.ReceiptURL = "https://receipts.example.com/1.jpg"
+		require.Nil(t, r.Check(&withReceipt, nil))
+	})
+
+	t.Run("duplicate merchant on the same day", func(t *testing.T) {
+		r := &PolicyRule{Name: "Duplicate", Type: RuleDuplicateMerchantDay, Action: RuleActionRequireApproval}
+		require.Nil(t, r.Check(expense, []*Expense{{ID: 8, Merchant: "Bluebird"}}))
+		require.Equal(t, "expense 9 is from the same merchant on the same day", r.Check(expense, []*Expense{{ID: 9, Merchant: "WARUNG SUNDA"}}).Message)
+
+		noMerchant := *expense
+		noMerchant.Merchant = ""
+		require.Nil(t, r.Check(&noMerchant, []*Expense{{ID: 9}}))
+	})
+
+	t.Run("per diem cap", func(t *testing.T) {
+		r := &PolicyRule{Name: "Meal per diem", Type: RulePerDiemCap, CategoryID: &meals, Threshold: 500000, Action: RuleActionWarn}
+		require.Nil(t, r.Check(expense, []*Expense{{ID: 9, CategoryID: 2, AmountIDR: 900000}}))
+
+		v := r.Check(expense, []*Expense{{ID: 9, CategoryID: meals, AmountIDR: 150000}})
+		require.Equal(t, "daily total of IDR 550000 exceeds the IDR 500000 per-diem cap", v.Message)
+	})
+}
+
+func TestRuleBlockedError(t *testing.T) {
+	err := &RuleBlockedError{Violations: []*RuleViolation{
+		{RuleName: "Weekend", Action: RuleActionWarn, Message: "incurred on a Saturday"},
+		{RuleName: "Meal cap", Action: RuleActionBlock, Message: "amount exceeds IDR 300000"},
+	}}
+
+	require.ErrorIs(t, err, ErrPolicyBlocked)
+	require.EqualError(t, err, "expense blocked by policy: Meal cap: amount exceeds IDR 300000")
+	require.True(t, HasRuleAction(err.Violations, RuleActionWarn))
+	require.False(t, HasRuleAction(err.Violations, RuleActionRequireApproval))
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// CheckTax fills in the net amount when only the PPN portion is given and checks that
+// the breakdown adds up to the expense's IDR amount. Expenses without PPN have a net
+// amount equal to their total.
+func (e *Expense) CheckTax() error {
+	if e.TaxAmountIDR < 0 || e.TaxRatePercent < 0 || e.TaxRatePercent > 100 {
+		return ErrInvalidTax
+	}
+
+	if e.TaxAmountIDR > 0 && e.TaxRatePercent == 0 {
+		return ErrInvalidTax
+	}
+
+	if e.NetAmountIDR == 0 {
+		e.NetAmountIDR = e.AmountIDR - e.TaxAmountIDR
+	}
+
+	if e.NetAmountIDR < 0 || e.NetAmountIDR+e.TaxAmountIDR != e.AmountIDR {
+		return ErrTaxMismatch
+	}
+
+	if e.EFakturNumber != "" && !ValidEFakturNumber(e.EFakturNumber) {
+		return ErrInvalidEFaktur
+	}
+
+	return nil
+}
+
+// ValidEFakturNumber reports whether number looks like a tax invoice serial number: 16
+// digits for the older 010.000-24.12345678 format, 17 for invoices issued through
+// Coretax. Dots, dashes and spaces are ignored.
+func ValidEFakturNumber(number string) bool {
+	digits := 0
+	for _, c := range number {
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case strings.ContainsRune(".- ", c):
+		default:
+			return false
+		}
+	}
+	return digits == 16 || digits == 17
+}
+
+// TaxSummaryRow totals the claimed expenses incurred in one month at one PPN rate.
+type TaxSummaryRow struct {
+	Month          string  `json:"month"`
+	TaxRatePercent float64 `json:"tax_rate_percent"`
+	ExpenseCount   int     `json:"expense_count"`
+	EFakturCount   int     `json:"efaktur_count"`
+	NetAmountIDR   int64   `json:"net_amount_idr"`
+	TaxAmountIDR   int64   `json:"tax_amount_idr"`
+	TotalAmountIDR int64   `json:"total_amount_idr"`
+}
+
+// TaxSummary is the input-VAT report for a period.
+type TaxSummary struct {
+	From           time.Time          `json:"from"`
+	To             time.Time          `json:"to"`
+	Rows           []*TaxSummaryRow   `json:"rows"`
+	Allocations    []*AllocationTotal `json:"allocations"`
+	NetAmountIDR   int64              `json:"net_amount_idr"`
+	TaxAmountIDR   int64              `json:"tax_amount_idr"`
+	TotalAmountIDR int64              `json:"total_amount_idr"`
+}
+
+// NewTaxSummary adds up the rows into the report's grand totals.
+func NewTaxSummary(from, to time.Time, rows []*TaxSummaryRow) *TaxSummary {
+	summary := &TaxSummary{From: from, To: to, Rows: rows, Allocations: []*AllocationTotal{}}
+	if summary.Rows == nil {
+		summary.Rows = []*TaxSummaryRow{}
+	}
+
+	for _, row := range rows {
+		summary.NetAmountIDR += row.NetAmountIDR
+		summary.TaxAmountIDR += row.TaxAmountIDR
+		summary.TotalAmountIDR += row.TotalAmountIDR
+	}
+	return summary
+}
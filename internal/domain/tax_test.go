@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpenseCheckTax(t *testing.T) {
+	t.Run("no ppn", func(t *testing.T) {
+		e := &Expense{AmountIDR: 150000}
+		require.NoError(t, e.CheckTax())
+		require.Equal(t, 150000, e.NetAmountIDR)
+	})
+
+	t.Run("net derived from ppn", func(t *testing.T) {
+		e := &Expense{AmountIDR: 111000, TaxAmountIDR: 11000, TaxRatePercent: 11}
+		require.NoError(t, e.CheckTax())
+		require.Equal(t, 100000, e.NetAmountIDR)
+	})
+
+	t.Run("full breakdown", func(t *testing.T) {
+		e := &Expense{AmountIDR: 111000, NetAmountIDR: 100000, TaxAmountIDR: 11000, TaxRatePercent: 11, EFakturNumber: "010.000-24.12345678"}
+		require.NoError(t, e.CheckTax())
+	})
+
+	t.Run("breakdown does not add up", func(t *testing.T) {
+		e := &Expense{AmountIDR: 111000, NetAmountIDR: 100000, TaxAmountIDR: 12000, TaxRatePercent: 12}
+		require.ErrorIs(t, e.CheckTax(), ErrTaxMismatch)
+	})
+
+	t.Run("ppn without rate", func(t *testing.T) {
+		e := &Expense{AmountIDR: 111000, TaxAmountIDR: 11000}
+		require.ErrorIs(t, e.CheckTax(), ErrInvalidTax)
+	})
+
+	t.Run("rate out of range", func(t *testing.T) {
+		e := &Expense{AmountIDR: 111000, TaxRatePercent: 110}
+		require.ErrorIs(t, e.CheckTax(), ErrInvalidTax)
+	})
+
+	t.Run("bad e-faktur number", func(t *testing.T) {
+		e := &Expense{AmountIDR: 111000, TaxAmountIDR: 11000, TaxRatePercent: 11, EFakturNumber: "INV-001"}
+		require.ErrorIs(t, e.CheckTax(), ErrInvalidEFaktur)
+	})
+}
+
+func TestValidEFakturNumber(t *testing.T) {
+	require.True(t, ValidEFakturNumber("010.000-24.12345678"))
+	require.True(t, ValidEFakturNumber("04002500012345678"))
+	require.False(t, ValidEFakturNumber("010.000-24.1234567"))
+	require.False(t, ValidEFakturNumber("010/000/24/12345678"))
+}
+
+func TestNewTaxSummary(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	summary := NewTaxSummary(from, to, []*TaxSummaryRow{
+		{Month: "2026-01", TaxRatePercent: 11, NetAmountIDR: 100000, TaxAmountIDR: 11000, TotalAmountIDR: 111000},
+		{Month: "2026-02", TaxRatePercent: 0, NetAmountIDR: 50000, TotalAmountIDR: 50000},
+	})
+	require.Equal(t, int64(150000), summary.NetAmountIDR)
+	require.Equal(t, int64(11000), summary.TaxAmountIDR)
+	require.Equal(t, int64(161000), summary.TotalAmountIDR)
+
+	require.Equal(t, []*TaxSummaryRow{}, NewTaxSummary(from, to, nil).Rows)
+}
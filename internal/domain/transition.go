@@ -0,0 +1,56 @@
+package domain
+
+import "fmt"
+
+// expenseTransitions lists, for every status, the statuses an expense may move to next.
+// Statuses without an entry are terminal.
+var expenseTransitions = map[ExpenseStatus][]ExpenseStatus{
+	ExpenseStatusDraft:            {ExpenseStatusAwaitingApproval, ExpenseStatusAutoApproved, ExpenseStatusCancelled},
+	ExpenseStatusPending:          {ExpenseStatusAwaitingApproval, ExpenseStatusAutoApproved},
+	ExpenseStatusAwaitingApproval: {ExpenseStatusApproved, ExpenseStatusRejected, ExpenseStatusChangesRequested, ExpenseStatusCancelled},
+	ExpenseStatusChangesRequested: {ExpenseStatusAwaitingApproval, ExpenseStatusAutoApproved, ExpenseStatusCancelled},
+	ExpenseStatusApproved:         {ExpenseStatusProcessing, ExpenseStatusCancelled},
+	ExpenseStatusAutoApproved:     {ExpenseStatusProcessing, ExpenseStatusCancelled},
+	ExpenseStatusProcessing:       {ExpenseStatusCompleted, ExpenseStatusFailed, ExpenseStatusRetryScheduled, ExpenseStatusDeadLetter},
+	ExpenseStatusRetryScheduled:   {ExpenseStatusProcessing},
+	ExpenseStatusDeadLetter:       {ExpenseStatusRetryScheduled, ExpenseStatusFailed},
+}
+
+// TransitionError is returned when an expense is asked to move between two statuses
+// that are not connected in the state machine.
+type TransitionError struct {
+	From ExpenseStatus
+	To   ExpenseStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot transition expense from %s to %s", e.From, e.To)
+}
+
+// Is lets callers keep matching illegal transitions against ErrInvalidExpenseStatus.
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition || target == ErrInvalidExpenseStatus
+}
+
+// CanTransitionTo reports whether the state machine allows moving from s to next.
+func (s ExpenseStatus) CanTransitionTo(next ExpenseStatus) bool {
+	for _, allowed := range expenseTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal reports whether no further transitions are possible from s.
+func (s ExpenseStatus) IsTerminal() bool {
+	return len(expenseTransitions[s]) == 0
+}
+
+// ValidateTransition returns a *TransitionError if from -> to is not a legal move.
+func ValidateTransition(from, to ExpenseStatus) error {
+	if !from.CanTransitionTo(to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return nil
+}
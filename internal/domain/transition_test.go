@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTransition(t *testing.T) {
+	allowed := [][2]ExpenseStatus{
+		{ExpenseStatusDraft, ExpenseStatusAwaitingApproval},
+		{ExpenseStatusDraft, ExpenseStatusAutoApproved},
+		{ExpenseStatusDraft, ExpenseStatusCancelled},
+		{ExpenseStatusPending, ExpenseStatusAwaitingApproval},
+		{ExpenseStatusPending, ExpenseStatusAutoApproved},
+		{ExpenseStatusAwaitingApproval, ExpenseStatusApproved},
+		{ExpenseStatusAwaitingApproval, ExpenseStatusRejected},
+		{ExpenseStatusAwaitingApproval, ExpenseStatusChangesRequested},
+		{ExpenseStatusChangesRequested, ExpenseStatusAwaitingApproval},
+		{ExpenseStatusChangesRequested, ExpenseStatusAutoApproved},
+		{ExpenseStatusApproved, ExpenseStatusProcessing},
+		{ExpenseStatusAutoApproved, ExpenseStatusProcessing},
+		{ExpenseStatusProcessing, ExpenseStatusCompleted},
+		{ExpenseStatusProcessing, ExpenseStatusFailed},
+		{ExpenseStatusProcessing, ExpenseStatusRetryScheduled},
+		{ExpenseStatusProcessing, ExpenseStatusDeadLetter},
+		{ExpenseStatusRetryScheduled, ExpenseStatusProcessing},
+		{ExpenseStatusDeadLetter, ExpenseStatusRetryScheduled},
+		{ExpenseStatusDeadLetter, ExpenseStatusFailed},
+		{ExpenseStatusAwaitingApproval, ExpenseStatusCancelled},
+		{ExpenseStatusChangesRequested, ExpenseStatusCancelled},
+		{ExpenseStatusApproved, ExpenseStatusCancelled},
+		{ExpenseStatusAutoApproved, ExpenseStatusCancelled},
+	}
+	for _, tr := range allowed {
+		require.NoError(t, ValidateTransition(tr[0], tr[1]), "%s -> %s", tr[0], tr[1])
+	}
+
+	rejected := [][2]ExpenseStatus{
+		{ExpenseStatusApproved, ExpenseStatusApproved},
+		{ExpenseStatusApproved, ExpenseStatusCompleted},
+		{ExpenseStatusRejected, ExpenseStatusApproved},
+		{ExpenseStatusCompleted, ExpenseStatusProcessing},
+		{ExpenseStatusAwaitingApproval, ExpenseStatusProcessing},
+		{ExpenseStatusChangesRequested, ExpenseStatusApproved},
+		{ExpenseStatusDraft, ExpenseStatusApproved},
+		{ExpenseStatusProcessing, ExpenseStatusCancelled},
+		{ExpenseStatusCancelled, ExpenseStatusAwaitingApproval},
+		{ExpenseStatusRetryScheduled, ExpenseStatusCancelled},
+		{ExpenseStatusDeadLetter, ExpenseStatusCompleted},
+	}
+	for _, tr := range rejected {
+		err := ValidateTransition(tr[0], tr[1])
+		require.ErrorIs(t, err, ErrInvalidTransition, "%s -> %s", tr[0], tr[1])
+		require.ErrorIs(t, err, ErrInvalidExpenseStatus)
+
+		var transitionErr *TransitionError
+		require.True(t, errors.As(err, &transitionErr))
+		require.Equal(t, tr[0], transitionErr.From)
+		require.Equal(t, tr[1], transitionErr.To)
+	}
+}
+
+func TestExpenseStatusIsTerminal(t *testing.T) {
+	require.True(t, ExpenseStatusCompleted.IsTerminal())
+	require.True(t, ExpenseStatusRejected.IsTerminal())
+	require.True(t, ExpenseStatusCancelled.IsTerminal())
+	require.False(t, ExpenseStatusProcessing.IsTerminal())
+	require.False(t, ExpenseStatusChangesRequested.IsTerminal())
+}
@@ -7,15 +7,29 @@ import (
 type Role string
 
 const (
-	RoleEmployee Role = "employee"
-	RoleManager  Role = "manager"
+	RoleEmployee        Role = "employee"
+	RoleManager         Role = "manager"
+	RoleFinanceDirector Role = "finance_director"
+	RoleCFO             Role = "cfo"
+	RoleAdmin           Role = "admin"
 )
 
+// IsApprover reports whether users with this role sign off any approval level.
+func (r Role) IsApprover() bool {
+	return r == RoleManager || r == RoleFinanceDirector || r == RoleCFO
+}
+
+// IsFinance reports whether users with this role see company-wide finance reports.
+func (r Role) IsFinance() bool {
+	return r == RoleFinanceDirector || r == RoleCFO
+}
+
 type User struct {
 	ID           int       `json:"id"`
 	Email        string    `json:"email"`
 	Name         string    `json:"name"`
 	Role         Role      `json:"role"`
+	ManagerID    *int      `json:"manager_id"`
 	PasswordHash string    `json:"-"`
 	CreatedAt    time.Time `json:"created_at"`
 }
@@ -0,0 +1,117 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/duplicate"
+	"github.com/evrintobing17/expense-management-backend/internal/expense"
+	"github.com/evrintobing17/expense-management-backend/internal/receipt"
+)
+
+// unclaimedStatuses are never treated as the original of a duplicate: drafts have not
+// been claimed yet, and rejected or cancelled expenses will never be paid.
+var unclaimedStatuses = []domain.ExpenseStatus{
+	domain.ExpenseStatusDraft,
+	domain.ExpenseStatusRejected,
+	domain.ExpenseStatusCancelled,
+}
+
+type duplicateDetector struct {
+	expenseRepo expense.ExpenseRepository
+	receiptRepo receipt.ReceiptRepository
+	policy      domain.DuplicatePolicy
+}
+
+// NewDuplicateDetector compares receipt digests across all expenses and, within the
+// submitter's own expenses, looks for claims that policy considers similar.
+func NewDuplicateDetector(expenseRepo expense.ExpenseRepository, receiptRepo receipt.ReceiptRepository, policy domain.DuplicatePolicy) duplicate.DuplicateDetector {
+	return &duplicateDetector{
+		expenseRepo: expenseRepo,
+		receiptRepo: receiptRepo,
+		policy:      policy,
+	}
+}
+
+func (d *duplicateDetector) Detect(ctx context.Context, exp *domain.Expense) ([]*domain.DuplicateMatch, error) {
+	matches, err := d.sameReceipt(ctx, exp)
+	if err != nil {
+		return nil, err
+	}
+
+	from := exp.IncurredOn.AddDate(0, 0, -d.policy.WindowDays)
+	to := exp.IncurredOn.AddDate(0, 0, d.policy.WindowDays)
+	candidates, err := d.expenseRepo.FindIncurredBetween(ctx, exp.UserID, from, to, exp.ID, unclaimedStatuses...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range candidates {
+		if d.policy.Similar(exp, other) {
+			matches = append(matches, &domain.DuplicateMatch{
+				ExpenseID:     exp.ID,
+				DuplicateOfID: other.ID,
+				Reason:        domain.DuplicateSimilarExpense,
+				Detail:        fmt.Sprintf("expense %d has a similar amount, date and description", other.ID),
+			})
+		}
+	}
+
+	return matches, nil
+}
+
+// sameReceipt flags every claimed expense that already holds one of exp's receipt files,
+// whoever submitted it.
+func (d *duplicateDetector) sameReceipt(ctx context.Context, exp *domain.Expense) ([]*domain.DuplicateMatch, error) {
+	receipts, err := d.receiptRepo.FindByExpenseID(ctx, exp.ID)
+	if err != nil || len(receipts) == 0 {
+		return nil, err
+	}
+
+	hashes := make([]string, len(receipts))
+	for i, r := range receipts {
+		hashes[i] = r.SHA256
+	}
+
+	others, err := d.receiptRepo.FindBySHA256(ctx, hashes, exp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*domain.DuplicateMatch
+	seen := make(map[int]bool)
+	for _, r := range others {
+		if seen[r.ExpenseID] {
+			continue
+		}
+		seen[r.ExpenseID] = true
+
+		other, err := d.expenseRepo.FindByID(ctx, r.ExpenseID)
+		if err != nil {
+			return nil, err
+		}
+
+		if other == nil || isUnclaimed(other.Status) {
+			continue
+		}
+
+		matches = append(matches, &domain.DuplicateMatch{
+			ExpenseID:     exp.ID,
+			DuplicateOfID: other.ID,
+			Reason:        domain.DuplicateReceipt,
+			Detail:        fmt.Sprintf("receipt %s was already uploaded to expense %d", r.FileName, other.ID),
+		})
+	}
+
+	return matches, nil
+}
+
+func isUnclaimed(status domain.ExpenseStatus) bool {
+	for _, s := range unclaimedStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
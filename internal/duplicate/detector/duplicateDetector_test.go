@@ -0,0 +1,70 @@
+package detector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateDetectorDetect(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	expense := &domain.Expense{ID: 10, UserID: 2, AmountIDR: 250000, Description: "Taxi to client office", IncurredOn: day}
+	from, to := day.AddDate(0, 0, -3), day.AddDate(0, 0, 3)
+	skip := []interface{}{mock.Anything, 2, from, to, 10, unclaimedStatuses[0], unclaimedStatuses[1], unclaimedStatuses[2]}
+
+	t.Run("same receipt and similar expense", func(t *testing.T) {
+		mockExpense := new(mocks.ExpenseRepository)
+		mockReceipt := new(mocks.ReceiptRepository)
+		d := NewDuplicateDetector(mockExpense, mockReceipt, domain.DefaultDuplicatePolicy)
+		mockReceipt.On("FindByExpenseID", mock.Anything, 10).Return([]*domain.Receipt{{ID: 1, ExpenseID: 10, SHA256: "abc"}}, nil).Once()
+		mockReceipt.On("FindBySHA256", mock.Anything, []string{"abc"}, 10).Return([]*domain.Receipt{
+			{ID: 2, ExpenseID: 4, SHA256: "abc", FileName: "taxi.pdf"},
+			{ID: 3, ExpenseID: 4, SHA256: "abc", FileName: "taxi-copy.pdf"},
+			{ID: 4, ExpenseID: 5, SHA256: "abc", FileName: "taxi.pdf"},
+		}, nil).Once()
+		mockExpense.On("FindByID", mock.Anything, 4).Return(&domain.Expense{ID: 4, UserID: 3, Status: domain.ExpenseStatusCompleted}, nil).Once()
+		mockExpense.On("FindByID", mock.Anything, 5).Return(&domain.Expense{ID: 5, UserID: 2, Status: domain.ExpenseStatusCancelled}, nil).Once()
+		mockExpense.On("FindIncurredBetween", skip...).Return([]*domain.Expense{
+			{ID: 7, AmountIDR: 250000, Description: "taxi to the client office", IncurredOn: day.AddDate(0, 0, -1)},
+			{ID: 8, AmountIDR: 90000, Description: "Lunch", IncurredOn: day},
+		}, nil).Once()
+
+		matches, err := d.Detect(ctx, expense)
+		require.NoError(t, err)
+		require.Equal(t, []*domain.DuplicateMatch{
+			{ExpenseID: 10, DuplicateOfID: 4, Reason: domain.DuplicateReceipt, Detail: "receipt taxi.pdf was already uploaded to expense 4"},
+			{ExpenseID: 10, DuplicateOfID: 7, Reason: domain.DuplicateSimilarExpense, Detail: "expense 7 has a similar amount, date and description"},
+		}, matches)
+		mockExpense.AssertExpectations(t)
+	})
+
+	t.Run("no receipts", func(t *testing.T) {
+		mockExpense := new(mocks.ExpenseRepository)
+		mockReceipt := new(mocks.ReceiptRepository)
+		d := NewDuplicateDetector(mockExpense, mockReceipt, domain.DefaultDuplicatePolicy)
+		mockReceipt.On("FindByExpenseID", mock.Anything, 10).Return([]*domain.Receipt(nil), nil).Once()
+		mockExpense.On("FindIncurredBetween", skip...).Return([]*domain.Expense(nil), nil).Once()
+
+		matches, err := d.Detect(ctx, expense)
+		require.NoError(t, err)
+		require.Empty(t, matches)
+		mockReceipt.AssertNotCalled(t, "FindBySHA256", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockExpense := new(mocks.ExpenseRepository)
+		mockReceipt := new(mocks.ReceiptRepository)
+		d := NewDuplicateDetector(mockExpense, mockReceipt, domain.DefaultDuplicatePolicy)
+		mockReceipt.On("FindByExpenseID", mock.Anything, 10).Return([]*domain.Receipt(nil), errors.New("db down")).Once()
+
+		_, err := d.Detect(ctx, expense)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,12 @@
+package duplicate
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+// DuplicateDetector looks for earlier claims an expense may duplicate.
+type DuplicateDetector interface {
+	Detect(ctx context.Context, expense *domain.Expense) ([]*domain.DuplicateMatch, error)
+}
@@ -0,0 +1,12 @@
+package duplicate
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type DuplicateRepository interface {
+	Replace(ctx context.Context, expenseID int, matches []*domain.DuplicateMatch) error
+	FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.DuplicateMatch, error)
+}
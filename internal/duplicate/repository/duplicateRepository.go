@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/duplicate"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+	"github.com/lib/pq"
+)
+
+type duplicateRepository struct {
+	db *sql.DB
+}
+
+func NewDuplicateRepository(db *sql.DB) duplicate.DuplicateRepository {
+	return &duplicateRepository{db: db}
+}
+
+// Replace swaps the expense's duplicate flags for the result of its latest check.
+func (r *duplicateRepository) Replace(ctx context.Context, expenseID int, matches []*domain.DuplicateMatch) error {
+	return database.WithinTx(ctx, r.db, func(ctx context.Context) error {
+		return r.replace(ctx, expenseID, matches)
+	})
+}
+
+func (r *duplicateRepository) replace(ctx context.Context, expenseID int, matches []*domain.DuplicateMatch) error {
+	tx := database.Conn(ctx, r.db)
+
+	_, err := tx.ExecContext(ctx, `DELETE FROM expense_duplicates WHERE expense_id = $1`, expenseID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO expense_duplicates (expense_id, duplicate_of_id, reason, detail)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	for _, m := range matches {
+		_, err = tx.ExecContext(ctx, query, expenseID, m.DuplicateOfID, m.Reason, m.Detail)
+		if err != nil {
+			return err
+		}
+		m.ExpenseID = expenseID
+	}
+
+	return nil
+}
+
+// FindByExpenseIDs returns the duplicate flags keyed by expense id. Expenses without
+// flags are left out of the map.
+func (r *duplicateRepository) FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.DuplicateMatch, error) {
+	query := `
+		SELECT expense_id, duplicate_of_id, reason, detail
+		FROM expense_duplicates
+		WHERE expense_id = ANY($1)
+		ORDER BY expense_id ASC, id ASC
+	`
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, pq.Array(expenseIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := make(map[int][]*domain.DuplicateMatch)
+	for rows.Next() {
+		m := &domain.DuplicateMatch{}
+		err := rows.Scan(
+			&m.ExpenseID,
+			&m.DuplicateOfID,
+			&m.Reason,
+			&m.Detail,
+		)
+		if err != nil {
+			return nil, err
+		}
+		matches[m.ExpenseID] = append(matches[m.ExpenseID], m)
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateRepositoryReplace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &duplicateRepository{db: db}
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM expense_duplicates WHERE expense_id = $1`)
+	insertQuery := regexp.QuoteMeta(`
+		INSERT INTO expense_duplicates (expense_id, duplicate_of_id, reason, detail)
+		VALUES ($1, $2, $3, $4)
+	`)
+	matches := []*domain.DuplicateMatch{
+		{DuplicateOfID: 4, Reason: domain.DuplicateReceipt, Detail: "the receipt was already uploaded to expense 4"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(insertQuery).
+			WithArgs(10, 4, domain.DuplicateReceipt, "the receipt was already uploaded to expense 4").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Replace(context.Background(), 10, matches))
+		require.Equal(t, 10, matches[0].ExpenseID)
+	})
+
+	t.Run("insert fails", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(insertQuery).WillReturnError(errors.New("insert failed"))
+		mock.ExpectRollback()
+
+		require.Error(t, repo.Replace(context.Background(), 10, matches))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDuplicateRepositoryFindByExpenseIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &duplicateRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT expense_id, duplicate_of_id, reason, detail
+		FROM expense_duplicates
+		WHERE expense_id = ANY($1)
+		ORDER BY expense_id ASC, id ASC
+	`)
+	mock.ExpectQuery(query).WithArgs(pq.Array([]int{10, 11})).
+		WillReturnRows(sqlmock.NewRows([]string{"expense_id", "duplicate_of_id", "reason", "detail"}).
+			AddRow(10, 4, "same_receipt", "the receipt was already uploaded to expense 4").
+			AddRow(10, 7, "similar_expense", "expense 7 has a similar amount, date and description"))
+
+	matches, findErr := repo.FindByExpenseIDs(context.Background(), []int{10, 11})
+	require.NoError(t, findErr)
+	require.Len(t, matches[10], 2)
+	require.Empty(t, matches[11])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,13 @@
+package escalation
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type EscalationRepository interface {
+	Create(ctx context.Context, escalation *domain.Escalation) error
+	FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.Escalation, error)
+	FindPendingByEscalatedToID(ctx context.Context, userID int) ([]*domain.Escalation, error)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/escalation"
+)
+
+type escalationRepository struct {
+	db *sql.DB
+}
+
+func NewEscalationRepository(db *sql.DB) escalation.EscalationRepository {
+	return &escalationRepository{db: db}
+}
+
+func (r *escalationRepository) Create(ctx context.Context, escalation *domain.Escalation) error {
+	query := `
+		INSERT INTO approval_escalations (expense_id, level, escalated_to_id)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		escalation.ExpenseID,
+		escalation.Level,
+		escalation.EscalatedToID,
+	).Scan(&escalation.ID, &escalation.CreatedAt)
+
+	return err
+}
+
+func (r *escalationRepository) FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.Escalation, error) {
+	query := `
+		SELECT id, expense_id, level, escalated_to_id, created_at
+		FROM approval_escalations
+		WHERE expense_id = $1
+		ORDER BY id ASC
+	`
+
+	return r.query(ctx, query, expenseID)
+}
+
+// FindPendingByEscalatedToID returns the escalations to userID whose expense is still
+// awaiting approval.
+func (r *escalationRepository) FindPendingByEscalatedToID(ctx context.Context, userID int) ([]*domain.Escalation, error) {
+	query := `
+		SELECT a.id, a.expense_id, a.level, a.escalated_to_id, a.created_at
+		FROM approval_escalations a
+		JOIN expenses e ON e.id = a.expense_id
+		WHERE a.escalated_to_id = $1 AND e.status = 'awaiting_approval'
+		ORDER BY a.expense_id ASC, a.id ASC
+	`
+
+	return r.query(ctx, query, userID)
+}
+
+func (r *escalationRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Escalation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var escalations []*domain.Escalation
+	for rows.Next() {
+		escalation := &domain.Escalation{}
+		err := rows.Scan(
+			&escalation.ID,
+			&escalation.ExpenseID,
+			&escalation.Level,
+			&escalation.EscalatedToID,
+			&escalation.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		escalations = append(escalations, escalation)
+	}
+
+	return escalations, nil
+}
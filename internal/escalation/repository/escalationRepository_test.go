@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalationRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &escalationRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO approval_escalations (expense_id, level, escalated_to_id)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`)
+	createdAt := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		escalation := &domain.Escalation{ExpenseID: 4, Level: domain.ApprovalLevelManager, EscalatedToID: 9}
+		mock.ExpectQuery(query).WithArgs(4, domain.ApprovalLevelManager, 9).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(2, createdAt))
+
+		createErr := repo.Create(context.Background(), escalation)
+		require.NoError(t, createErr)
+		require.Equal(t, 2, escalation.ID)
+		require.Equal(t, createdAt, escalation.CreatedAt)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		expectedErr := errors.New("insert failed")
+		mock.ExpectQuery(query).WillReturnError(expectedErr)
+
+		createErr := repo.Create(context.Background(), &domain.Escalation{ExpenseID: 4})
+		require.ErrorIs(t, createErr, expectedErr)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEscalationRepositoryFind(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &escalationRepository{db: db}
+	columns := []string{"id", "expense_id", "level", "escalated_to_id", "created_at"}
+	createdAt := time.Now()
+
+	t.Run("by expense", func(t *testing.T) {
+		query := regexp.QuoteMeta(`
+		SELECT id, expense_id, level, escalated_to_id, created_at
+		FROM approval_escalations
+		WHERE expense_id = $1
+		ORDER BY id ASC
+	`)
+		mock.ExpectQuery(query).WithArgs(4).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 4, "manager", 9, createdAt).AddRow(2, 4, "manager", 10, createdAt))
+
+		result, findErr := repo.FindByExpenseID(context.Background(), 4)
+		require.NoError(t, findErr)
+		require.Len(t, result, 2)
+		require.Equal(t, 10, result[1].EscalatedToID)
+	})
+
+	t.Run("pending by escalation target", func(t *testing.T) {
+		query := regexp.QuoteMeta(`
+		SELECT a.id, a.expense_id, a.level, a.escalated_to_id, a.created_at
+		FROM approval_escalations a
+		JOIN expenses e ON e.id = a.expense_id
+		WHERE a.escalated_to_id = $1 AND e.status = 'awaiting_approval'
+		ORDER BY a.expense_id ASC, a.id ASC
+	`)
+		mock.ExpectQuery(query).WithArgs(9).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 4, "finance_director", 9, createdAt))
+
+		result, findErr := repo.FindPendingByEscalatedToID(context.Background(), 9)
+		require.NoError(t, findErr)
+		require.Len(t, result, 1)
+		require.Equal(t, domain.ApprovalLevelFinanceDirector, result[0].Level)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		expectedErr := errors.New("db error")
+		mock.ExpectQuery(`FROM approval_escalations`).WithArgs(4).WillReturnError(expectedErr)
+
+		result, findErr := repo.FindByExpenseID(context.Background(), 4)
+		require.ErrorIs(t, findErr, expectedErr)
+		require.Nil(t, result)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -11,7 +11,14 @@ type ExpenseRepository interface {
 	Create(ctx context.Context, expense *domain.Expense) error
 	FindByID(ctx context.Context, id int) (*domain.Expense, error)
 	FindByUserID(ctx context.Context, userID int, status domain.ExpenseStatus, limit, offset int) ([]*domain.Expense, error)
-	UpdateStatus(ctx context.Context, id int, status domain.ExpenseStatus, processedAt *time.Time) error
+	UpdateStatus(ctx context.Context, id int, from, to domain.ExpenseStatus, processedAt *time.Time) error
+	Update(ctx context.Context, expense *domain.Expense, from domain.ExpenseStatus) error
 	FindPendingApproval(ctx context.Context) ([]*domain.Expense, error)
+	FindPendingApprovalByUserIDs(ctx context.Context, userIDs []int) ([]*domain.Expense, error)
+	FindSameDay(ctx context.Context, userID int, incurredOn time.Time, excludeID int, skip ...domain.ExpenseStatus) ([]*domain.Expense, error)
+	FindIncurredBetween(ctx context.Context, userID int, from, to time.Time, excludeID int, skip ...domain.ExpenseStatus) ([]*domain.Expense, error)
+	FindByReportID(ctx context.Context, reportID int) ([]*domain.Expense, error)
+	FindByAdvanceID(ctx context.Context, advanceID int) ([]*domain.Expense, error)
 	FindByStatus(ctx context.Context, statuses ...domain.ExpenseStatus) ([]*domain.Expense, error)
+	ClaimForPayment(ctx context.Context, limit int, timeout time.Duration) ([]*domain.Expense, error)
 }
@@ -7,10 +7,18 @@ import (
 )
 
 type ExpenseUseCase interface {
-	CreateExpense(ctx context.Context, userID int, amountIDR int, description, receiptURL string) (*domain.Expense, error)
+	CreateExpense(ctx context.Context, expense *domain.Expense, draft bool) (*domain.Expense, error)
+	UpdateExpense(ctx context.Context, expenseID int, userID int, changes domain.ExpenseChanges) (*domain.Expense, error)
+	SubmitExpense(ctx context.Context, expenseID int, userID int) (*domain.Expense, error)
+	CancelExpense(ctx context.Context, expenseID int, userID int) (*domain.Expense, error)
 	GetExpenseByID(ctx context.Context, id int, userID int) (*domain.Expense, error)
 	GetUserExpenses(ctx context.Context, userID int, status domain.ExpenseStatus, page, limit int) ([]*domain.Expense, error)
 	ApproveExpense(ctx context.Context, expenseID int, approverID int, notes string) error
 	RejectExpense(ctx context.Context, expenseID int, approverID int, notes string) error
-	GetPendingApproval(ctx context.Context) ([]*domain.Expense, error)
+	RequestChanges(ctx context.Context, expenseID int, approverID int, notes string) error
+	ResubmitExpense(ctx context.Context, expenseID int, userID int, changes domain.ExpenseChanges) (*domain.Expense, error)
+	GetPendingApproval(ctx context.Context, approverID int) ([]*domain.Expense, error)
+	CheckReportLine(ctx context.Context, expense *domain.Expense) (bool, error)
+	RecordReportLine(ctx context.Context, expense *domain.Expense) error
+	GetExpenseHistory(ctx context.Context, expenseID int, userID int, role domain.Role) ([]*domain.StatusHistory, error)
 }
@@ -2,8 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/evrintobing17/expense-management-backend/internal/domain"
 	"github.com/evrintobing17/expense-management-backend/internal/expense"
@@ -23,9 +25,21 @@ func (h *ExpenseHandler) CreateExpense(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var req struct {
-		AmountIDR   int    `json:"amount_idr"`
-		Description string `json:"description"`
-		ReceiptURL  string `json:"receipt_url"`
+		CategoryID     int                  `json:"category_id"`
+		AmountIDR      int                  `json:"amount_idr"`
+		Currency       string               `json:"currency"`
+		OriginalAmount float64              `json:"original_amount"`
+		NetAmountIDR   int                  `json:"net_amount_idr"`
+		TaxAmountIDR   int                  `json:"tax_amount_idr"`
+		TaxRatePercent float64              `json:"tax_rate_percent"`
+		EFakturNumber  string               `json:"efaktur_number"`
+		Description    string               `json:"description"`
+		Merchant       string               `json:"merchant"`
+		IncurredOn     time.Time            `json:"incurred_on"`
+		ReceiptURL     string               `json:"receipt_url"`
+		Allocations    []*domain.Allocation `json:"allocations"`
+		AdvanceID      *int                 `json:"advance_id"`
+		Draft          bool                 `json:"draft"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -39,11 +53,29 @@ func (h *ExpenseHandler) CreateExpense(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	expense, err := h.expenseUseCase.CreateExpense(ctx, userID, req.AmountIDR, req.Description, req.ReceiptURL)
+	expense, err := h.expenseUseCase.CreateExpense(ctx, &domain.Expense{
+		UserID:         userID,
+		CategoryID:     req.CategoryID,
+		AmountIDR:      req.AmountIDR,
+		Currency:       req.Currency,
+		OriginalAmount: req.OriginalAmount,
+		NetAmountIDR:   req.NetAmountIDR,
+		TaxAmountIDR:   req.TaxAmountIDR,
+		TaxRatePercent: req.TaxRatePercent,
+		EFakturNumber:  req.EFakturNumber,
+		Description:    req.Description,
+		Merchant:       req.Merchant,
+		IncurredOn:     req.IncurredOn,
+		ReceiptURL:     req.ReceiptURL,
+		Allocations:    req.Allocations,
+		AdvanceID:      req.AdvanceID,
+	}, req.Draft)
 	if err != nil {
-		switch err {
-		case domain.ErrInvalidAmount, domain.ErrMissingDescription:
+		switch {
+		case isValidationError(err):
 			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, domain.ErrPolicyBlocked):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		default:
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
 		}
@@ -118,6 +150,165 @@ func (h *ExpenseHandler) GetExpense(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(expense)
 }
 
+func (h *ExpenseHandler) UpdateExpense(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid expense ID", http.StatusBadRequest)
+		return
+	}
+
+	var changes domain.ExpenseChanges
+	if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	expense, err := h.expenseUseCase.UpdateExpense(ctx, id, userID, changes)
+	if err != nil {
+		switch {
+		case isValidationError(err), errors.Is(err, domain.ErrExpenseNotEditable):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, domain.ErrExpenseNotFound):
+			http.Error(w, "Expense not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrStatusConflict):
+			http.Error(w, "Expense was updated by another request", http.StatusConflict)
+		case errors.Is(err, domain.ErrUnauthorizedAction):
+			http.Error(w, "Access denied", http.StatusForbidden)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(expense)
+}
+
+func (h *ExpenseHandler) SubmitExpense(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid expense ID", http.StatusBadRequest)
+		return
+	}
+
+	expense, err := h.expenseUseCase.SubmitExpense(ctx, id, userID)
+	if err != nil {
+		switch {
+		case isValidationError(err):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, domain.ErrPolicyBlocked):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, domain.ErrExpenseNotFound):
+			http.Error(w, "Expense not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidExpenseStatus):
+			http.Error(w, "Only draft expenses can be submitted", http.StatusBadRequest)
+		case errors.Is(err, domain.ErrExpenseInReport):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, domain.ErrStatusConflict):
+			http.Error(w, "Expense was updated by another request", http.StatusConflict)
+		case errors.Is(err, domain.ErrUnauthorizedAction):
+			http.Error(w, "Access denied", http.StatusForbidden)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(expense)
+}
+
+func (h *ExpenseHandler) CancelExpense(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid expense ID", http.StatusBadRequest)
+		return
+	}
+
+	expense, err := h.expenseUseCase.CancelExpense(ctx, id, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrExpenseNotFound):
+			http.Error(w, "Expense not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidExpenseStatus):
+			http.Error(w, "Expense can no longer be cancelled", http.StatusBadRequest)
+		case errors.Is(err, domain.ErrExpenseInReport):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, domain.ErrStatusConflict):
+			http.Error(w, "Expense was updated by another request", http.StatusConflict)
+		case errors.Is(err, domain.ErrUnauthorizedAction):
+			http.Error(w, "Access denied", http.StatusForbidden)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(expense)
+}
+
+func (h *ExpenseHandler) GetExpenseHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	role, _ := middleware.GetUserRoleFromContext(ctx)
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid expense ID", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := h.expenseUseCase.GetExpenseHistory(ctx, id, userID, role)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrExpenseNotFound):
+			http.Error(w, "Expense not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrUnauthorizedAction):
+			http.Error(w, "Access denied", http.StatusForbidden)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
 func (h *ExpenseHandler) ApproveExpense(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -145,13 +336,19 @@ func (h *ExpenseHandler) ApproveExpense(w http.ResponseWriter, r *http.Request)
 
 	err = h.expenseUseCase.ApproveExpense(ctx, id, approverID, req.Notes)
 	if err != nil {
-		switch err {
-		case domain.ErrExpenseNotFound:
+		switch {
+		case errors.Is(err, domain.ErrExpenseNotFound):
 			http.Error(w, "Expense not found", http.StatusNotFound)
-		case domain.ErrInvalidExpenseStatus:
+		case errors.Is(err, domain.ErrInvalidExpenseStatus):
 			http.Error(w, "Expense cannot be approved", http.StatusBadRequest)
-		case domain.ErrUnauthorizedAction:
-			http.Error(w, "Only managers can approve expenses", http.StatusForbidden)
+		case errors.Is(err, domain.ErrExpenseInReport):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, domain.ErrStatusConflict):
+			http.Error(w, "Expense was updated by another request", http.StatusConflict)
+		case errors.Is(err, domain.ErrApprovalConflict):
+			http.Error(w, "Approval level has already been decided", http.StatusConflict)
+		case errors.Is(err, domain.ErrUnauthorizedAction):
+			http.Error(w, forbiddenMessage(err, "Not authorized to approve this expense at its current approval level"), http.StatusForbidden)
 		default:
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
 		}
@@ -188,13 +385,70 @@ func (h *ExpenseHandler) RejectExpense(w http.ResponseWriter, r *http.Request) {
 
 	err = h.expenseUseCase.RejectExpense(ctx, id, approverID, req.Notes)
 	if err != nil {
-		switch err {
-		case domain.ErrExpenseNotFound:
+		switch {
+		case errors.Is(err, domain.ErrExpenseNotFound):
 			http.Error(w, "Expense not found", http.StatusNotFound)
-		case domain.ErrInvalidExpenseStatus:
+		case errors.Is(err, domain.ErrInvalidExpenseStatus):
 			http.Error(w, "Expense cannot be rejected", http.StatusBadRequest)
-		case domain.ErrUnauthorizedAction:
-			http.Error(w, "Only managers can reject expenses", http.StatusForbidden)
+		case errors.Is(err, domain.ErrExpenseInReport):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, domain.ErrStatusConflict):
+			http.Error(w, "Expense was updated by another request", http.StatusConflict)
+		case errors.Is(err, domain.ErrApprovalConflict):
+			http.Error(w, "Approval level has already been decided", http.StatusConflict)
+		case errors.Is(err, domain.ErrUnauthorizedAction):
+			http.Error(w, forbiddenMessage(err, "Not authorized to reject this expense at its current approval level"), http.StatusForbidden)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *ExpenseHandler) RequestChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	approverID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid expense ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Notes string `json:"notes"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.expenseUseCase.RequestChanges(ctx, id, approverID, req.Notes)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrMissingNotes):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, domain.ErrExpenseNotFound):
+			http.Error(w, "Expense not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidExpenseStatus):
+			http.Error(w, "Changes cannot be requested for this expense", http.StatusBadRequest)
+		case errors.Is(err, domain.ErrExpenseInReport):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, domain.ErrStatusConflict):
+			http.Error(w, "Expense was updated by another request", http.StatusConflict)
+		case errors.Is(err, domain.ErrApprovalConflict):
+			http.Error(w, "Approval level has already been decided", http.StatusConflict)
+		case errors.Is(err, domain.ErrUnauthorizedAction):
+			http.Error(w, forbiddenMessage(err, "Not authorized to review this expense at its current approval level"), http.StatusForbidden)
 		default:
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
 		}
@@ -204,15 +458,103 @@ func (h *ExpenseHandler) RejectExpense(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+func (h *ExpenseHandler) ResubmitExpense(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid expense ID", http.StatusBadRequest)
+		return
+	}
+
+	var changes domain.ExpenseChanges
+	if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	expense, err := h.expenseUseCase.ResubmitExpense(ctx, id, userID, changes)
+	if err != nil {
+		switch {
+		case isValidationError(err):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, domain.ErrPolicyBlocked):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, domain.ErrExpenseNotFound):
+			http.Error(w, "Expense not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidExpenseStatus):
+			http.Error(w, "Only expenses with requested changes can be resubmitted", http.StatusBadRequest)
+		case errors.Is(err, domain.ErrExpenseInReport):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, domain.ErrStatusConflict):
+			http.Error(w, "Expense was updated by another request", http.StatusConflict)
+		case errors.Is(err, domain.ErrUnauthorizedAction):
+			http.Error(w, "Access denied", http.StatusForbidden)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(expense)
+}
+
 func (h *ExpenseHandler) GetPendingApproval(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	expenses, err := h.expenseUseCase.GetPendingApproval(ctx)
+	approverID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	expenses, err := h.expenseUseCase.GetPendingApproval(ctx, approverID)
 	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, domain.ErrUnauthorizedAction):
+			http.Error(w, "Access denied", http.StatusForbidden)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(expenses)
 }
+
+// isValidationError reports whether err rejects the expense's details against its
+// category or its allocations, so that the message can be shown to the submitter as it is.
+func isValidationError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidAmount) ||
+		errors.Is(err, domain.ErrMissingDescription) ||
+		errors.Is(err, domain.ErrMissingReceipt) ||
+		errors.Is(err, domain.ErrCategoryNotFound) ||
+		errors.Is(err, domain.ErrInactiveCategory) ||
+		errors.Is(err, domain.ErrInvalidCurrency) ||
+		errors.Is(err, domain.ErrFXRateNotFound) ||
+		errors.Is(err, domain.ErrTaxMismatch) ||
+		errors.Is(err, domain.ErrInvalidTax) ||
+		errors.Is(err, domain.ErrInvalidEFaktur) ||
+		errors.Is(err, domain.ErrInvalidAllocation) ||
+		errors.Is(err, domain.ErrInactiveAllocationCode) ||
+		errors.Is(err, domain.ErrAdvanceNotLinkable)
+}
+
+// forbiddenMessage returns the policy's reason when an approval was blocked by
+// segregation of duties, and fallback for any other unauthorized action.
+func forbiddenMessage(err error, fallback string) string {
+	var violation *domain.PolicyViolationError
+	if errors.As(err, &violation) {
+		return violation.Reason
+	}
+	return fallback
+}
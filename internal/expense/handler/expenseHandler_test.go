@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/evrintobing17/expense-management-backend/internal/domain"
 	"github.com/evrintobing17/expense-management-backend/internal/middleware"
@@ -33,7 +34,7 @@ func TestExpenseHandlerCreateExpense(t *testing.T) {
 	t.Run("unauthorized", func(t *testing.T) {
 		mockUC := new(mocks.ExpenseUseCase)
 		h := NewExpenseHandler(mockUC)
-		req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(`{"amount_idr":10000,"description":"meal","receipt_url":"u"}`))
+		req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(`{"category_id":3,"amount_idr":10000,"description":"meal","receipt_url":"u"}`))
 		rr := httptest.NewRecorder()
 		h.CreateExpense(rr, req)
 		require.Equal(t, http.StatusUnauthorized, rr.Code)
@@ -42,28 +43,153 @@ func TestExpenseHandlerCreateExpense(t *testing.T) {
 	t.Run("domain bad request", func(t *testing.T) {
 		mockUC := new(mocks.ExpenseUseCase)
 		h := NewExpenseHandler(mockUC)
-		req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(`{"amount_idr":1,"description":"meal","receipt_url":"u"}`))
+		req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(`{"category_id":3,"amount_idr":1,"description":"meal","receipt_url":"u"}`))
 		req = withUserID(req, 1)
 		rr := httptest.NewRecorder()
-		mockUC.On("CreateExpense", mock.Anything, 1, 1, "meal", "u").Return((*domain.Expense)(nil), domain.ErrInvalidAmount).Once()
+		rangeErr := &domain.AmountRangeError{Category: "Meals", Min: 10000, Max: 500000}
+		mockUC.On("CreateExpense", mock.Anything, mock.AnythingOfType("*domain.Expense"), false).Return((*domain.Expense)(nil), rangeErr).Once()
 
 		h.CreateExpense(rr, req)
 		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), "Meals expenses must be between 10000 and 500000 IDR")
 	})
 
 	t.Run("success", func(t *testing.T) {
 		mockUC := new(mocks.ExpenseUseCase)
 		h := NewExpenseHandler(mockUC)
-		req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(`{"amount_idr":10000,"description":"meal","receipt_url":"u"}`))
+		req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(`{"category_id":3,"amount_idr":10000,"description":"meal","merchant":"Warung Sunda","incurred_on":"2026-10-16T00:00:00Z","receipt_url":"u"}`))
 		req = withUserID(req, 1)
 		rr := httptest.NewRecorder()
 		exp := &domain.Expense{ID: 1, UserID: 1, AmountIDR: 10000, Description: "meal", ReceiptURL: "u"}
-		mockUC.On("CreateExpense", mock.Anything, 1, 10000, "meal", "u").Return(exp, nil).Once()
+		mockUC.On("CreateExpense", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.UserID == 1 && e.CategoryID == 3 && e.AmountIDR == 10000 && e.Description == "meal" && e.ReceiptURL == "u" &&
+				e.Merchant == "Warung Sunda" && e.IncurredOn.Equal(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+		}), false).Return(exp, nil).Once()
 
 		h.CreateExpense(rr, req)
 		require.Equal(t, http.StatusCreated, rr.Code)
 		require.Contains(t, rr.Body.String(), `"id":1`)
 	})
+
+	t.Run("inactive cost center", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(`{"category_id":3,"amount_idr":10000,"description":"meal","receipt_url":"u","allocations":[{"cost_center_code":"OLD","percent":100}]}`))
+		req = withUserID(req, 1)
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateExpense", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return len(e.Allocations) == 1 && e.Allocations[0].CostCenterCode == "OLD" && e.Allocations[0].Percent == 100
+		}), false).Return((*domain.Expense)(nil), &domain.AllocationCodeError{Kind: "cost center", Code: "OLD"}).Once()
+
+		h.CreateExpense(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), "cost center OLD does not exist or is not active")
+	})
+
+	t.Run("draft", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(`{"category_id":3,"amount_idr":10000,"description":"meal","receipt_url":"u","draft":true}`))
+		req = withUserID(req, 1)
+		rr := httptest.NewRecorder()
+		exp := &domain.Expense{ID: 2, UserID: 1, AmountIDR: 10000, Description: "meal", ReceiptURL: "u", Status: domain.ExpenseStatusDraft}
+		mockUC.On("CreateExpense", mock.Anything, mock.AnythingOfType("*domain.Expense"), true).Return(exp, nil).Once()
+
+		h.CreateExpense(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.Contains(t, rr.Body.String(), `"status":"draft"`)
+	})
+
+	t.Run("blocked by policy", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(`{"category_id":3,"amount_idr":10000,"description":"meal","receipt_url":"u"}`))
+		req = withUserID(req, 1)
+		rr := httptest.NewRecorder()
+		blocked := &domain.RuleBlockedError{Violations: []*domain.RuleViolation{{RuleName: "Meal cap", Action: domain.RuleActionBlock, Message: "amount exceeds IDR 5000"}}}
+		mockUC.On("CreateExpense", mock.Anything, mock.AnythingOfType("*domain.Expense"), false).Return((*domain.Expense)(nil), blocked).Once()
+
+		h.CreateExpense(rr, req)
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		require.Contains(t, rr.Body.String(), "Meal cap: amount exceeds IDR 5000")
+	})
+}
+
+func TestExpenseHandlerDraftEndpoints(t *testing.T) {
+	t.Run("update not editable", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPatch, "/expenses/5", strings.NewReader(`{"description":"taxi"}`))
+		req = withUserID(req, 1)
+		req = mux.SetURLVars(req, map[string]string{"id": "5"})
+		rr := httptest.NewRecorder()
+		mockUC.On("UpdateExpense", mock.Anything, 5, 1, mock.AnythingOfType("domain.ExpenseChanges")).
+			Return((*domain.Expense)(nil), domain.ErrExpenseNotEditable).Once()
+
+		h.UpdateExpense(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), domain.ErrExpenseNotEditable.Error())
+	})
+
+	t.Run("update success", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPatch, "/expenses/5", strings.NewReader(`{"description":"taxi"}`))
+		req = withUserID(req, 1)
+		req = mux.SetURLVars(req, map[string]string{"id": "5"})
+		rr := httptest.NewRecorder()
+		exp := &domain.Expense{ID: 5, UserID: 1, Description: "taxi", Status: domain.ExpenseStatusDraft}
+		mockUC.On("UpdateExpense", mock.Anything, 5, 1, mock.MatchedBy(func(c domain.ExpenseChanges) bool {
+			return c.Description != nil && *c.Description == "taxi" && c.AmountIDR == nil
+		})).Return(exp, nil).Once()
+
+		h.UpdateExpense(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"description":"taxi"`)
+	})
+
+	t.Run("submit non draft", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expenses/5/submit", nil)
+		req = withUserID(req, 1)
+		req = mux.SetURLVars(req, map[string]string{"id": "5"})
+		rr := httptest.NewRecorder()
+		mockUC.On("SubmitExpense", mock.Anything, 5, 1).Return((*domain.Expense)(nil), domain.ErrInvalidExpenseStatus).Once()
+
+		h.SubmitExpense(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("cancel after payment started", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expenses/5/cancel", nil)
+		req = withUserID(req, 1)
+		req = mux.SetURLVars(req, map[string]string{"id": "5"})
+		rr := httptest.NewRecorder()
+		transitionErr := &domain.TransitionError{From: domain.ExpenseStatusProcessing, To: domain.ExpenseStatusCancelled}
+		mockUC.On("CancelExpense", mock.Anything, 5, 1).Return((*domain.Expense)(nil), transitionErr).Once()
+
+		h.CancelExpense(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), "can no longer be cancelled")
+	})
+
+	t.Run("cancel success", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expenses/5/cancel", nil)
+		req = withUserID(req, 1)
+		req = mux.SetURLVars(req, map[string]string{"id": "5"})
+		rr := httptest.NewRecorder()
+		exp := &domain.Expense{ID: 5, UserID: 1, Status: domain.ExpenseStatusCancelled}
+		mockUC.On("CancelExpense", mock.Anything, 5, 1).Return(exp, nil).Once()
+
+		h.CancelExpense(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"status":"cancelled"`)
+	})
 }
 
 func TestExpenseHandlerReadEndpoints(t *testing.T) {
@@ -94,6 +220,37 @@ func TestExpenseHandlerReadEndpoints(t *testing.T) {
 	})
 }
 
+func TestExpenseHandlerGetExpenseHistory(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/expenses/9/history", nil)
+		req = withUserID(req, 7)
+		req = mux.SetURLVars(req, map[string]string{"id": "9"})
+		rr := httptest.NewRecorder()
+		entries := []*domain.StatusHistory{{ID: 1, ExpenseID: 9, ToStatus: domain.ExpenseStatusAutoApproved, ActorType: domain.ActorTypeUser}}
+		mockUC.On("GetExpenseHistory", mock.Anything, 9, 7, domain.RoleEmployee).Return(entries, nil).Once()
+
+		h.GetExpenseHistory(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"to_status":"auto_approved"`)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/expenses/9/history", nil)
+		req = withUserID(req, 7)
+		req = mux.SetURLVars(req, map[string]string{"id": "9"})
+		rr := httptest.NewRecorder()
+		mockUC.On("GetExpenseHistory", mock.Anything, 9, 7, domain.RoleEmployee).
+			Return(([]*domain.StatusHistory)(nil), domain.ErrUnauthorizedAction).Once()
+
+		h.GetExpenseHistory(rr, req)
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
 func TestExpenseHandlerApprovalEndpoints(t *testing.T) {
 	t.Run("approve invalid id", func(t *testing.T) {
 		mockUC := new(mocks.ExpenseUseCase)
@@ -120,6 +277,48 @@ func TestExpenseHandlerApprovalEndpoints(t *testing.T) {
 		require.Equal(t, http.StatusForbidden, rr.Code)
 	})
 
+	t.Run("approve blocked by segregation of duties", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expenses/10/approve", strings.NewReader(`{"notes":"ok"}`))
+		req = withUserID(req, 3)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		violation := &domain.PolicyViolationError{Reason: "approvers cannot approve their own expenses"}
+		mockUC.On("ApproveExpense", mock.Anything, 10, 3, "ok").Return(violation).Once()
+
+		h.ApproveExpense(rr, req)
+		require.Equal(t, http.StatusForbidden, rr.Code)
+		require.Contains(t, rr.Body.String(), "approvers cannot approve their own expenses")
+	})
+
+	t.Run("approve conflict", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expenses/10/approve", strings.NewReader(`{"notes":"ok"}`))
+		req = withUserID(req, 3)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("ApproveExpense", mock.Anything, 10, 3, "ok").Return(domain.ErrStatusConflict).Once()
+
+		h.ApproveExpense(rr, req)
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("reject invalid transition", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expenses/10/reject", strings.NewReader(`{"notes":"reject"}`))
+		req = withUserID(req, 3)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		transitionErr := &domain.TransitionError{From: domain.ExpenseStatusCompleted, To: domain.ExpenseStatusRejected}
+		mockUC.On("RejectExpense", mock.Anything, 10, 3, "reject").Return(transitionErr).Once()
+
+		h.RejectExpense(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
 	t.Run("reject success", func(t *testing.T) {
 		mockUC := new(mocks.ExpenseUseCase)
 		h := NewExpenseHandler(mockUC)
@@ -134,12 +333,75 @@ func TestExpenseHandlerApprovalEndpoints(t *testing.T) {
 	})
 }
 
+func TestExpenseHandlerRequestChanges(t *testing.T) {
+	t.Run("missing notes", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPut, "/expenses/10/request-changes", strings.NewReader(`{"notes":""}`))
+		req = withUserID(req, 3)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("RequestChanges", mock.Anything, 10, 3, "").Return(domain.ErrMissingNotes).Once()
+
+		h.RequestChanges(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), "notes are required")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPut, "/expenses/10/request-changes", strings.NewReader(`{"notes":"attach invoice"}`))
+		req = withUserID(req, 3)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("RequestChanges", mock.Anything, 10, 3, "attach invoice").Return(nil).Once()
+
+		h.RequestChanges(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestExpenseHandlerResubmitExpense(t *testing.T) {
+	t.Run("not returned for changes", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expenses/10/resubmit", strings.NewReader(`{}`))
+		req = withUserID(req, 1)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		transitionErr := &domain.TransitionError{From: domain.ExpenseStatusApproved, To: domain.ExpenseStatusAwaitingApproval}
+		mockUC.On("ResubmitExpense", mock.Anything, 10, 1, domain.ExpenseChanges{}).Return((*domain.Expense)(nil), transitionErr).Once()
+
+		h.ResubmitExpense(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.ExpenseUseCase)
+		h := NewExpenseHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expenses/10/resubmit", strings.NewReader(`{"category_id":3,"amount_idr":1500000}`))
+		req = withUserID(req, 1)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		exp := &domain.Expense{ID: 10, UserID: 1, AmountIDR: 1500000, Status: domain.ExpenseStatusAwaitingApproval}
+		mockUC.On("ResubmitExpense", mock.Anything, 10, 1, mock.MatchedBy(func(c domain.ExpenseChanges) bool {
+			return c.AmountIDR != nil && *c.AmountIDR == 1500000 && c.Description == nil
+		})).Return(exp, nil).Once()
+
+		h.ResubmitExpense(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"status":"awaiting_approval"`)
+	})
+}
+
 func TestExpenseHandlerGetPendingApproval(t *testing.T) {
 	mockUC := new(mocks.ExpenseUseCase)
 	h := NewExpenseHandler(mockUC)
 	req := httptest.NewRequest(http.MethodGet, "/expenses/pending-approval", nil).WithContext(context.Background())
+	req = withUserID(req, 3)
 	rr := httptest.NewRecorder()
-	mockUC.On("GetPendingApproval", mock.Anything).Return(([]*domain.Expense)(nil), errors.New("db error")).Once()
+	mockUC.On("GetPendingApproval", mock.Anything, 3).Return(([]*domain.Expense)(nil), errors.New("db error")).Once()
 
 	h.GetPendingApproval(rr, req)
 	require.Equal(t, http.StatusInternalServerError, rr.Code)
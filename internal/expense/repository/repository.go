@@ -10,6 +10,8 @@ import (
 
 	"github.com/evrintobing17/expense-management-backend/internal/domain"
 	"github.com/evrintobing17/expense-management-backend/internal/expense"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+	"github.com/lib/pq"
 )
 
 type expenseRepository struct {
@@ -22,61 +24,78 @@ func NewExpenseRepository(db *sql.DB) expense.ExpenseRepository {
 
 func (r *expenseRepository) Create(ctx context.Context, expense *domain.Expense) error {
 	query := `
-		INSERT INTO expenses (user_id, amount_idr, description, receipt_url, status, requires_approval, auto_approved)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO expenses (user_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, requires_approval, auto_approved,
+			requires_finance_approval, advance_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		RETURNING id, submitted_at
 	`
 
-	requiresApproval := expense.AmountIDR >= domain.ApprovalThreshold
-	autoApproved := !requiresApproval
-	initialStatus := domain.ExpenseStatusPending
-
-	if autoApproved {
-		initialStatus = domain.ExpenseStatusAutoApproved
-	} else if requiresApproval {
-		initialStatus = domain.ExpenseStatusAwaitingApproval
+	// Expenses that arrive without a status are routed on the default policy here. Drafts
+	// and expenses the caller has already routed are stored as they are.
+	if expense.Status == "" {
+		expense.Route(domain.ApprovalPolicy{})
 	}
 
-	err := r.db.QueryRowContext(ctx, query,
+	return database.Conn(ctx, r.db).QueryRowContext(ctx, query,
 		expense.UserID,
+		expense.CategoryID,
 		expense.AmountIDR,
+		expense.Currency,
+		expense.OriginalAmount,
+		expense.FXRate,
+		expense.NetAmountIDR,
+		expense.TaxAmountIDR,
+		expense.TaxRatePercent,
+		expense.EFakturNumber,
 		expense.Description,
+		expense.Merchant,
+		expense.IncurredOn,
 		expense.ReceiptURL,
-		initialStatus,
-		requiresApproval,
-		autoApproved,
+		expense.Status,
+		expense.RequiresApproval,
+		expense.AutoApproved,
+		expense.RequiresFinanceApproval,
+		expense.AdvanceID,
 	).Scan(&expense.ID, &expense.SubmittedAt)
-
-	if err != nil {
-		return err
-	}
-
-	expense.Status = initialStatus
-	expense.RequiresApproval = requiresApproval
-	expense.AutoApproved = autoApproved
-
-	return nil
 }
 
 func (r *expenseRepository) FindByID(ctx context.Context, id int) (*domain.Expense, error) {
 	query := `
-		SELECT id, user_id, amount_idr, description, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
 		FROM expenses
 		WHERE id = $1
 	`
 
 	expense := &domain.Expense{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&expense.ID,
 		&expense.UserID,
+		&expense.ReportID,
+		&expense.CategoryID,
 		&expense.AmountIDR,
+		&expense.Currency,
+		&expense.OriginalAmount,
+		&expense.FXRate,
+		&expense.NetAmountIDR,
+		&expense.TaxAmountIDR,
+		&expense.TaxRatePercent,
+		&expense.EFakturNumber,
 		&expense.Description,
+		&expense.Merchant,
+		&expense.IncurredOn,
 		&expense.ReceiptURL,
 		&expense.Status,
 		&expense.SubmittedAt,
 		&expense.ProcessedAt,
 		&expense.RequiresApproval,
 		&expense.AutoApproved,
+		&expense.RequiresFinanceApproval,
+		&expense.AdvanceID,
 	)
 
 	if err != nil {
@@ -91,7 +110,10 @@ func (r *expenseRepository) FindByID(ctx context.Context, id int) (*domain.Expen
 
 func (r *expenseRepository) FindByUserID(ctx context.Context, userID int, status domain.ExpenseStatus, limit, offset int) ([]*domain.Expense, error) {
 	query := `
-		SELECT id, user_id, amount_idr, description, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
 		FROM expenses
 		WHERE user_id = $1
 	`
@@ -118,7 +140,7 @@ func (r *expenseRepository) FindByUserID(ctx context.Context, userID int, status
 		args = append(args, offset)
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -130,14 +152,27 @@ func (r *expenseRepository) FindByUserID(ctx context.Context, userID int, status
 		err := rows.Scan(
 			&expense.ID,
 			&expense.UserID,
+			&expense.ReportID,
+			&expense.CategoryID,
 			&expense.AmountIDR,
+			&expense.Currency,
+			&expense.OriginalAmount,
+			&expense.FXRate,
+			&expense.NetAmountIDR,
+			&expense.TaxAmountIDR,
+			&expense.TaxRatePercent,
+			&expense.EFakturNumber,
 			&expense.Description,
+			&expense.Merchant,
+			&expense.IncurredOn,
 			&expense.ReceiptURL,
 			&expense.Status,
 			&expense.SubmittedAt,
 			&expense.ProcessedAt,
 			&expense.RequiresApproval,
 			&expense.AutoApproved,
+			&expense.RequiresFinanceApproval,
+			&expense.AdvanceID,
 		)
 		if err != nil {
 			return nil, err
@@ -148,26 +183,224 @@ func (r *expenseRepository) FindByUserID(ctx context.Context, userID int, status
 	return expenses, nil
 }
 
-func (r *expenseRepository) UpdateStatus(ctx context.Context, id int, status domain.ExpenseStatus, processedAt *time.Time) error {
+// UpdateStatus moves an expense from one status to another. The update only applies
+// while the row is still in the expected from status, so concurrent callers racing on
+// the same transition cannot both succeed.
+func (r *expenseRepository) UpdateStatus(ctx context.Context, id int, from, to domain.ExpenseStatus, processedAt *time.Time) error {
+	if err := domain.ValidateTransition(from, to); err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE expenses
 		SET status = $1, processed_at = $2
-		WHERE id = $3
+		WHERE id = $3 AND status = $4
+	`
+
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, to, processedAt, id, from)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrStatusConflict
+	}
+
+	return nil
+}
+
+// Update saves the submitter-editable fields of expense together with its status,
+// submission time and approval flags, provided the stored status is still from.
+func (r *expenseRepository) Update(ctx context.Context, expense *domain.Expense, from domain.ExpenseStatus) error {
+	if expense.Status != from {
+		if err := domain.ValidateTransition(from, expense.Status); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		UPDATE expenses
+		SET category_id = $1, amount_idr = $2, currency = $3, original_amount = $4, fx_rate = $5,
+			net_amount_idr = $6, tax_amount_idr = $7, tax_rate_percent = $8, efaktur_number = $9,
+			description = $10, merchant = $11, incurred_on = $12, receipt_url = $13, status = $14,
+			submitted_at = $15, requires_approval = $16, auto_approved = $17, requires_finance_approval = $18,
+			advance_id = $19
+		WHERE id = $20 AND status = $21
 	`
 
-	_, err := r.db.ExecContext(ctx, query, status, processedAt, id)
-	return err
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query,
+		expense.CategoryID,
+		expense.AmountIDR,
+		expense.Currency,
+		expense.OriginalAmount,
+		expense.FXRate,
+		expense.NetAmountIDR,
+		expense.TaxAmountIDR,
+		expense.TaxRatePercent,
+		expense.EFakturNumber,
+		expense.Description,
+		expense.Merchant,
+		expense.IncurredOn,
+		expense.ReceiptURL,
+		expense.Status,
+		expense.SubmittedAt,
+		expense.RequiresApproval,
+		expense.AutoApproved,
+		expense.RequiresFinanceApproval,
+		expense.AdvanceID,
+		expense.ID,
+		from,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrStatusConflict
+	}
+
+	return nil
 }
 
 func (r *expenseRepository) FindPendingApproval(ctx context.Context) ([]*domain.Expense, error) {
 	query := `
-		SELECT id, user_id, amount_idr, description, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
+		FROM expenses
+		WHERE status = $1 AND requires_approval = true AND report_id IS NULL
+		ORDER BY submitted_at ASC
+	`
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, domain.ExpenseStatusAwaitingApproval)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []*domain.Expense
+	for rows.Next() {
+		expense := &domain.Expense{}
+		err := rows.Scan(
+			&expense.ID,
+			&expense.UserID,
+			&expense.ReportID,
+			&expense.CategoryID,
+			&expense.AmountIDR,
+			&expense.Currency,
+			&expense.OriginalAmount,
+			&expense.FXRate,
+			&expense.NetAmountIDR,
+			&expense.TaxAmountIDR,
+			&expense.TaxRatePercent,
+			&expense.EFakturNumber,
+			&expense.Description,
+			&expense.Merchant,
+			&expense.IncurredOn,
+			&expense.ReceiptURL,
+			&expense.Status,
+			&expense.SubmittedAt,
+			&expense.ProcessedAt,
+			&expense.RequiresApproval,
+			&expense.AutoApproved,
+			&expense.RequiresFinanceApproval,
+			&expense.AdvanceID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, expense)
+	}
+
+	return expenses, nil
+}
+
+// FindPendingApprovalByUserIDs is FindPendingApproval restricted to expenses submitted by
+// the given users.
+func (r *expenseRepository) FindPendingApprovalByUserIDs(ctx context.Context, userIDs []int) ([]*domain.Expense, error) {
+	query := `
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
 		FROM expenses
-		WHERE status = $1 AND requires_approval = true
+		WHERE status = $1 AND requires_approval = true AND user_id = ANY($2) AND report_id IS NULL
 		ORDER BY submitted_at ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, domain.ExpenseStatusAwaitingApproval)
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, domain.ExpenseStatusAwaitingApproval, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []*domain.Expense
+	for rows.Next() {
+		expense := &domain.Expense{}
+		err := rows.Scan(
+			&expense.ID,
+			&expense.UserID,
+			&expense.ReportID,
+			&expense.CategoryID,
+			&expense.AmountIDR,
+			&expense.Currency,
+			&expense.OriginalAmount,
+			&expense.FXRate,
+			&expense.NetAmountIDR,
+			&expense.TaxAmountIDR,
+			&expense.TaxRatePercent,
+			&expense.EFakturNumber,
+			&expense.Description,
+			&expense.Merchant,
+			&expense.IncurredOn,
+			&expense.ReceiptURL,
+			&expense.Status,
+			&expense.SubmittedAt,
+			&expense.ProcessedAt,
+			&expense.RequiresApproval,
+			&expense.AutoApproved,
+			&expense.RequiresFinanceApproval,
+			&expense.AdvanceID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, expense)
+	}
+
+	return expenses, nil
+}
+
+// FindSameDay returns the user's other expenses incurred on the given date, leaving out
+// excludeID and expenses in any of the skipped statuses.
+func (r *expenseRepository) FindSameDay(ctx context.Context, userID int, incurredOn time.Time, excludeID int, skip ...domain.ExpenseStatus) ([]*domain.Expense, error) {
+	query := `
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
+		FROM expenses
+		WHERE user_id = $1 AND incurred_on = $2 AND id <> $3 AND NOT (status = ANY($4))
+		ORDER BY id ASC
+	`
+
+	statuses := make([]string, len(skip))
+	for i, status := range skip {
+		statuses[i] = string(status)
+	}
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, userID, incurredOn, excludeID, pq.Array(statuses))
 	if err != nil {
 		return nil, err
 	}
@@ -179,14 +412,27 @@ func (r *expenseRepository) FindPendingApproval(ctx context.Context) ([]*domain.
 		err := rows.Scan(
 			&expense.ID,
 			&expense.UserID,
+			&expense.ReportID,
+			&expense.CategoryID,
 			&expense.AmountIDR,
+			&expense.Currency,
+			&expense.OriginalAmount,
+			&expense.FXRate,
+			&expense.NetAmountIDR,
+			&expense.TaxAmountIDR,
+			&expense.TaxRatePercent,
+			&expense.EFakturNumber,
 			&expense.Description,
+			&expense.Merchant,
+			&expense.IncurredOn,
 			&expense.ReceiptURL,
 			&expense.Status,
 			&expense.SubmittedAt,
 			&expense.ProcessedAt,
 			&expense.RequiresApproval,
 			&expense.AutoApproved,
+			&expense.RequiresFinanceApproval,
+			&expense.AdvanceID,
 		)
 		if err != nil {
 			return nil, err
@@ -197,13 +443,191 @@ func (r *expenseRepository) FindPendingApproval(ctx context.Context) ([]*domain.
 	return expenses, nil
 }
 
+// FindIncurredBetween returns the user's other expenses incurred between from and to,
+// both inclusive, leaving out excludeID and expenses in any of the skipped statuses.
+func (r *expenseRepository) FindIncurredBetween(ctx context.Context, userID int, from, to time.Time, excludeID int, skip ...domain.ExpenseStatus) ([]*domain.Expense, error) {
+	query := `
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
+		FROM expenses
+		WHERE user_id = $1 AND incurred_on BETWEEN $2 AND $3 AND id <> $4 AND NOT (status = ANY($5))
+		ORDER BY incurred_on ASC, id ASC
+	`
+
+	statuses := make([]string, len(skip))
+	for i, status := range skip {
+		statuses[i] = string(status)
+	}
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, userID, from, to, excludeID, pq.Array(statuses))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []*domain.Expense
+	for rows.Next() {
+		expense := &domain.Expense{}
+		err := rows.Scan(
+			&expense.ID,
+			&expense.UserID,
+			&expense.ReportID,
+			&expense.CategoryID,
+			&expense.AmountIDR,
+			&expense.Currency,
+			&expense.OriginalAmount,
+			&expense.FXRate,
+			&expense.NetAmountIDR,
+			&expense.TaxAmountIDR,
+			&expense.TaxRatePercent,
+			&expense.EFakturNumber,
+			&expense.Description,
+			&expense.Merchant,
+			&expense.IncurredOn,
+			&expense.ReceiptURL,
+			&expense.Status,
+			&expense.SubmittedAt,
+			&expense.ProcessedAt,
+			&expense.RequiresApproval,
+			&expense.AutoApproved,
+			&expense.RequiresFinanceApproval,
+			&expense.AdvanceID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, expense)
+	}
+
+	return expenses, nil
+}
+
+// FindByReportID returns the line items of an expense report in the order they were
+// incurred.
+func (r *expenseRepository) FindByReportID(ctx context.Context, reportID int) ([]*domain.Expense, error) {
+	query := `
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
+		FROM expenses
+		WHERE report_id = $1
+		ORDER BY incurred_on ASC, id ASC
+	`
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, reportID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []*domain.Expense
+	for rows.Next() {
+		expense := &domain.Expense{}
+		err := rows.Scan(
+			&expense.ID,
+			&expense.UserID,
+			&expense.ReportID,
+			&expense.CategoryID,
+			&expense.AmountIDR,
+			&expense.Currency,
+			&expense.OriginalAmount,
+			&expense.FXRate,
+			&expense.NetAmountIDR,
+			&expense.TaxAmountIDR,
+			&expense.TaxRatePercent,
+			&expense.EFakturNumber,
+			&expense.Description,
+			&expense.Merchant,
+			&expense.IncurredOn,
+			&expense.ReceiptURL,
+			&expense.Status,
+			&expense.SubmittedAt,
+			&expense.ProcessedAt,
+			&expense.RequiresApproval,
+			&expense.AutoApproved,
+			&expense.RequiresFinanceApproval,
+			&expense.AdvanceID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, expense)
+	}
+
+	return expenses, nil
+}
+
+// FindByAdvanceID returns the expenses filed against a cash advance in the order they
+// were incurred.
+func (r *expenseRepository) FindByAdvanceID(ctx context.Context, advanceID int) ([]*domain.Expense, error) {
+	query := `
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
+		FROM expenses
+		WHERE advance_id = $1
+		ORDER BY incurred_on ASC, id ASC
+	`
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, advanceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []*domain.Expense
+	for rows.Next() {
+		expense := &domain.Expense{}
+		err := rows.Scan(
+			&expense.ID,
+			&expense.UserID,
+			&expense.ReportID,
+			&expense.CategoryID,
+			&expense.AmountIDR,
+			&expense.Currency,
+			&expense.OriginalAmount,
+			&expense.FXRate,
+			&expense.NetAmountIDR,
+			&expense.TaxAmountIDR,
+			&expense.TaxRatePercent,
+			&expense.EFakturNumber,
+			&expense.Description,
+			&expense.Merchant,
+			&expense.IncurredOn,
+			&expense.ReceiptURL,
+			&expense.Status,
+			&expense.SubmittedAt,
+			&expense.ProcessedAt,
+			&expense.RequiresApproval,
+			&expense.AutoApproved,
+			&expense.RequiresFinanceApproval,
+			&expense.AdvanceID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, expense)
+	}
+
+	return expenses, nil
+}
+
+// FindByStatus returns the standalone expenses in any of the statuses. Lines of expense
+// reports are left out; they move with their report.
 func (r *expenseRepository) FindByStatus(ctx context.Context, statuses ...domain.ExpenseStatus) ([]*domain.Expense, error) {
 	if len(statuses) == 0 {
 		return nil, fmt.Errorf("at least one status must be provided")
 	}
 
 	query := `
-		SELECT id, user_id, amount_idr, description, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
 		FROM expenses
 		WHERE status IN (`
 
@@ -214,9 +638,9 @@ func (r *expenseRepository) FindByStatus(ctx context.Context, statuses ...domain
 		placeholders[i] = fmt.Sprintf("$%d", i+1)
 		args[i] = status
 	}
-	query += strings.Join(placeholders, ", ") + ")"
+	query += strings.Join(placeholders, ", ") + ") AND report_id IS NULL"
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -228,14 +652,27 @@ func (r *expenseRepository) FindByStatus(ctx context.Context, statuses ...domain
 		err := rows.Scan(
 			&expense.ID,
 			&expense.UserID,
+			&expense.ReportID,
+			&expense.CategoryID,
 			&expense.AmountIDR,
+			&expense.Currency,
+			&expense.OriginalAmount,
+			&expense.FXRate,
+			&expense.NetAmountIDR,
+			&expense.TaxAmountIDR,
+			&expense.TaxRatePercent,
+			&expense.EFakturNumber,
 			&expense.Description,
+			&expense.Merchant,
+			&expense.IncurredOn,
 			&expense.ReceiptURL,
 			&expense.Status,
 			&expense.SubmittedAt,
 			&expense.ProcessedAt,
 			&expense.RequiresApproval,
 			&expense.AutoApproved,
+			&expense.RequiresFinanceApproval,
+			&expense.AdvanceID,
 		)
 		if err != nil {
 			return nil, err
@@ -245,3 +682,81 @@ func (r *expenseRepository) FindByStatus(ctx context.Context, statuses ...domain
 
 	return expenses, nil
 }
+
+// ClaimForPayment moves up to limit approved and auto-approved standalone expenses to
+// processing and returns them, oldest submission first. Rows locked by another worker
+// are skipped rather than waited for, so replicas never claim the same expense. Expenses
+// claimed more than timeout ago and still processing, because their worker died
+// mid-payment, are claimed again, and so are expenses whose payment retry has come due.
+// Claim times come from the database clock so replicas agree on them. Expenses filed
+// against a cash advance are left out; they are offset when the advance is settled.
+// Each returned expense carries the status it was claimed from.
+func (r *expenseRepository) ClaimForPayment(ctx context.Context, limit int, timeout time.Duration) ([]*domain.Expense, error) {
+	query := `
+		UPDATE expenses e
+		SET status = $1, claimed_at = CURRENT_TIMESTAMP
+		FROM (
+			SELECT id, status
+			FROM expenses
+			WHERE report_id IS NULL AND advance_id IS NULL
+				AND (status = ANY($2)
+					OR (status = $1 AND claimed_at < CURRENT_TIMESTAMP - make_interval(secs => $3))
+					OR (status = $5 AND NOT EXISTS (
+						SELECT 1 FROM payments p
+						WHERE p.kind = $6 AND p.subject_id = expenses.id AND p.next_attempt_at > CURRENT_TIMESTAMP
+					)))
+			ORDER BY submitted_at ASC, id ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		) claimed
+		WHERE e.id = claimed.id
+		RETURNING e.id, e.user_id, e.report_id, e.category_id, e.amount_idr, e.currency, e.original_amount, e.fx_rate,
+			e.net_amount_idr, e.tax_amount_idr, e.tax_rate_percent, e.efaktur_number,
+			e.description, e.merchant, e.incurred_on, e.receipt_url, claimed.status, e.submitted_at, e.processed_at,
+			e.requires_approval, e.auto_approved, e.requires_finance_approval, e.advance_id
+	`
+
+	payable := pq.Array([]string{string(domain.ExpenseStatusApproved), string(domain.ExpenseStatusAutoApproved)})
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, domain.ExpenseStatusProcessing, payable, timeout.Seconds(), limit,
+		domain.ExpenseStatusRetryScheduled, domain.PaymentKindExpense)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []*domain.Expense
+	for rows.Next() {
+		expense := &domain.Expense{}
+		err := rows.Scan(
+			&expense.ID,
+			&expense.UserID,
+			&expense.ReportID,
+			&expense.CategoryID,
+			&expense.AmountIDR,
+			&expense.Currency,
+			&expense.OriginalAmount,
+			&expense.FXRate,
+			&expense.NetAmountIDR,
+			&expense.TaxAmountIDR,
+			&expense.TaxRatePercent,
+			&expense.EFakturNumber,
+			&expense.Description,
+			&expense.Merchant,
+			&expense.IncurredOn,
+			&expense.ReceiptURL,
+			&expense.Status,
+			&expense.SubmittedAt,
+			&expense.ProcessedAt,
+			&expense.RequiresApproval,
+			&expense.AutoApproved,
+			&expense.RequiresFinanceApproval,
+			&expense.AdvanceID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, expense)
+	}
+
+	return expenses, rows.Err()
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,17 +21,22 @@ func TestExpenseRepositoryCreate(t *testing.T) {
 	repo := &expenseRepository{db: db}
 
 	query := regexp.QuoteMeta(`
-		INSERT INTO expenses (user_id, amount_idr, description, receipt_url, status, requires_approval, auto_approved)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO expenses (user_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, requires_approval, auto_approved,
+			requires_finance_approval, advance_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		RETURNING id, submitted_at
 	`)
 	submittedAt := time.Now()
+	incurredOn := domain.DateOf(submittedAt)
 
 	t.Run("auto approved below threshold", func(t *testing.T) {
-		exp := &domain.Expense{UserID: 1, AmountIDR: 100_000, Description: "taxi", ReceiptURL: "url"}
+		exp := &domain.Expense{UserID: 1, CategoryID: 3, AmountIDR: 100_000, Currency: "SGD", OriginalAmount: 8.5, FXRate: 11764.7,
+			NetAmountIDR: 90_000, TaxAmountIDR: 10_000, TaxRatePercent: 11, EFakturNumber: "010.000-24.12345678", Description: "taxi", Merchant: "Bluebird", IncurredOn: incurredOn, ReceiptURL: "url"}
 		rows := sqlmock.NewRows([]string{"id", "submitted_at"}).AddRow(5, submittedAt)
 		mock.ExpectQuery(query).
-			WithArgs(1, 100_000, "taxi", "url", domain.ExpenseStatusAutoApproved, false, true).
+			WithArgs(1, 3, 100_000, "SGD", 8.5, 11764.7, 90_000, 10_000, 11.0, "010.000-24.12345678", "taxi", "Bluebird", incurredOn, "url", domain.ExpenseStatusAutoApproved, false, true, false, nil).
 			WillReturnRows(rows)
 
 		createErr := repo.Create(context.Background(), exp)
@@ -41,10 +47,10 @@ func TestExpenseRepositoryCreate(t *testing.T) {
 	})
 
 	t.Run("awaiting approval at threshold", func(t *testing.T) {
-		exp := &domain.Expense{UserID: 1, AmountIDR: domain.ApprovalThreshold, Description: "hotel", ReceiptURL: "url"}
+		exp := &domain.Expense{UserID: 1, CategoryID: 3, AmountIDR: domain.ApprovalThreshold, Currency: "IDR", OriginalAmount: domain.ApprovalThreshold, FXRate: 1, NetAmountIDR: domain.ApprovalThreshold, Description: "hotel", Merchant: "Bluebird", IncurredOn: incurredOn, ReceiptURL: "url"}
 		rows := sqlmock.NewRows([]string{"id", "submitted_at"}).AddRow(6, submittedAt)
 		mock.ExpectQuery(query).
-			WithArgs(1, domain.ApprovalThreshold, "hotel", "url", domain.ExpenseStatusAwaitingApproval, true, false).
+			WithArgs(1, 3, domain.ApprovalThreshold, "IDR", float64(domain.ApprovalThreshold), 1.0, domain.ApprovalThreshold, 0, 0.0, "", "hotel", "Bluebird", incurredOn, "url", domain.ExpenseStatusAwaitingApproval, true, false, false, nil).
 			WillReturnRows(rows)
 
 		createErr := repo.Create(context.Background(), exp)
@@ -54,6 +60,19 @@ func TestExpenseRepositoryCreate(t *testing.T) {
 		require.False(t, exp.AutoApproved)
 	})
 
+	t.Run("draft keeps its status", func(t *testing.T) {
+		exp := &domain.Expense{UserID: 1, CategoryID: 3, AmountIDR: domain.ApprovalThreshold, Currency: "IDR", OriginalAmount: domain.ApprovalThreshold, FXRate: 1, NetAmountIDR: domain.ApprovalThreshold, Description: "hotel", Merchant: "Bluebird", IncurredOn: incurredOn, ReceiptURL: "url", Status: domain.ExpenseStatusDraft}
+		rows := sqlmock.NewRows([]string{"id", "submitted_at"}).AddRow(7, submittedAt)
+		mock.ExpectQuery(query).
+			WithArgs(1, 3, domain.ApprovalThreshold, "IDR", float64(domain.ApprovalThreshold), 1.0, domain.ApprovalThreshold, 0, 0.0, "", "hotel", "Bluebird", incurredOn, "url", domain.ExpenseStatusDraft, false, false, false, nil).
+			WillReturnRows(rows)
+
+		createErr := repo.Create(context.Background(), exp)
+		require.NoError(t, createErr)
+		require.Equal(t, domain.ExpenseStatusDraft, exp.Status)
+		require.False(t, exp.RequiresApproval)
+	})
+
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -63,14 +82,18 @@ func TestExpenseRepositoryFindByID(t *testing.T) {
 	t.Cleanup(func() { _ = db.Close() })
 	repo := &expenseRepository{db: db}
 	query := regexp.QuoteMeta(`
-		SELECT id, user_id, amount_idr, description, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
 		FROM expenses
 		WHERE id = $1
 	`)
 	now := time.Now()
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "amount_idr", "description", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved"}).
-		AddRow(1, 2, 30000, "meal", "url", "pending", now, now, false, false)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "report_id", "category_id", "amount_idr", "currency", "original_amount", "fx_rate",
+		"net_amount_idr", "tax_amount_idr", "tax_rate_percent", "efaktur_number", "description", "merchant", "incurred_on", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved", "requires_finance_approval", "advance_id"}).
+		AddRow(1, 2, nil, 1, 30000, "IDR", 30000, 1, 30000, 0, 0, "", "meal", "", domain.DateOf(now), "url", "pending", now, now, false, false, false, nil)
 	mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
 	result, findErr := repo.FindByID(context.Background(), 1)
 	require.NoError(t, findErr)
@@ -94,12 +117,16 @@ func TestExpenseRepositoryFindByStatus(t *testing.T) {
 	require.Error(t, findErr)
 
 	query := regexp.QuoteMeta(`
-		SELECT id, user_id, amount_idr, description, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
 		FROM expenses
-		WHERE status IN ($1, $2)`)
+		WHERE status IN ($1, $2) AND report_id IS NULL`)
 	now := time.Now()
-	rows := sqlmock.NewRows([]string{"id", "user_id", "amount_idr", "description", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved"}).
-		AddRow(1, 2, 40000, "flight", "url", "approved", now, now, true, false)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "report_id", "category_id", "amount_idr", "currency", "original_amount", "fx_rate",
+		"net_amount_idr", "tax_amount_idr", "tax_rate_percent", "efaktur_number", "description", "merchant", "incurred_on", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved", "requires_finance_approval", "advance_id"}).
+		AddRow(1, 2, nil, 1, 40000, "IDR", 40000, 1, 40000, 0, 0, "", "flight", "", domain.DateOf(now), "url", "approved", now, now, true, false, false, nil)
 	mock.ExpectQuery(query).WithArgs(domain.ExpenseStatusApproved, domain.ExpenseStatusRejected).WillReturnRows(rows)
 
 	result, findErr := repo.FindByStatus(context.Background(), domain.ExpenseStatusApproved, domain.ExpenseStatusRejected)
@@ -108,6 +135,56 @@ func TestExpenseRepositoryFindByStatus(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestExpenseRepositoryClaimForPayment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	repo := &expenseRepository{db: db}
+
+	query := regexp.QuoteMeta(`
+		UPDATE expenses e
+		SET status = $1, claimed_at = CURRENT_TIMESTAMP
+		FROM (
+			SELECT id, status
+			FROM expenses
+			WHERE report_id IS NULL AND advance_id IS NULL
+				AND (status = ANY($2)
+					OR (status = $1 AND claimed_at < CURRENT_TIMESTAMP - make_interval(secs => $3))
+					OR (status = $5 AND NOT EXISTS (
+						SELECT 1 FROM payments p
+						WHERE p.kind = $6 AND p.subject_id = expenses.id AND p.next_attempt_at > CURRENT_TIMESTAMP
+					)))
+			ORDER BY submitted_at ASC, id ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		) claimed
+		WHERE e.id = claimed.id
+		RETURNING e.id, e.user_id, e.report_id, e.category_id, e.amount_idr, e.currency, e.original_amount, e.fx_rate,
+			e.net_amount_idr, e.tax_amount_idr, e.tax_rate_percent, e.efaktur_number,
+			e.description, e.merchant, e.incurred_on, e.receipt_url, claimed.status, e.submitted_at, e.processed_at,
+			e.requires_approval, e.auto_approved, e.requires_finance_approval, e.advance_id
+	`)
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "user_id", "report_id", "category_id", "amount_idr", "currency", "original_amount", "fx_rate",
+		"net_amount_idr", "tax_amount_idr", "tax_rate_percent", "efaktur_number", "description", "merchant", "incurred_on", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved", "requires_finance_approval", "advance_id"}).
+		AddRow(1, 2, nil, 1, 40000, "IDR", 40000, 1, 40000, 0, 0, "", "flight", "", domain.DateOf(now), "url", "auto_approved", now, nil, false, true, false, nil).
+		AddRow(2, 2, nil, 1, 60000, "IDR", 60000, 1, 60000, 0, 0, "", "hotel", "", domain.DateOf(now), "url", "processing", now, nil, true, false, false, nil)
+	mock.ExpectQuery(query).
+		WithArgs(domain.ExpenseStatusProcessing, pq.Array([]string{"approved", "auto_approved"}), 600.0, 50, domain.ExpenseStatusRetryScheduled, domain.PaymentKindExpense).
+		WillReturnRows(rows)
+
+	result, claimErr := repo.ClaimForPayment(context.Background(), 50, 10*time.Minute)
+	require.NoError(t, claimErr)
+	require.Len(t, result, 2)
+	require.Equal(t, domain.ExpenseStatusAutoApproved, result[0].Status)
+	require.Equal(t, domain.ExpenseStatusProcessing, result[1].Status)
+
+	mock.ExpectQuery(query).WillReturnError(errors.New("db down"))
+	_, claimErr = repo.ClaimForPayment(context.Background(), 50, 10*time.Minute)
+	require.Error(t, claimErr)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestExpenseRepositoryUpdateStatusAndPending(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -118,27 +195,108 @@ func TestExpenseRepositoryUpdateStatusAndPending(t *testing.T) {
 	updateQuery := regexp.QuoteMeta(`
 		UPDATE expenses
 		SET status = $1, processed_at = $2
-		WHERE id = $3
+		WHERE id = $3 AND status = $4
 	`)
 	mock.ExpectExec(updateQuery).
-		WithArgs(domain.ExpenseStatusApproved, &now, 10).
+		WithArgs(domain.ExpenseStatusApproved, &now, 10, domain.ExpenseStatusAwaitingApproval).
 		WillReturnResult(sqlmock.NewResult(0, 1))
-	updateErr := repo.UpdateStatus(context.Background(), 10, domain.ExpenseStatusApproved, &now)
+	updateErr := repo.UpdateStatus(context.Background(), 10, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusApproved, &now)
 	require.NoError(t, updateErr)
 
+	mock.ExpectExec(updateQuery).
+		WithArgs(domain.ExpenseStatusRejected, &now, 10, domain.ExpenseStatusAwaitingApproval).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	updateErr = repo.UpdateStatus(context.Background(), 10, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusRejected, &now)
+	require.ErrorIs(t, updateErr, domain.ErrStatusConflict)
+
+	updateErr = repo.UpdateStatus(context.Background(), 10, domain.ExpenseStatusRejected, domain.ExpenseStatusApproved, &now)
+	require.ErrorIs(t, updateErr, domain.ErrInvalidTransition)
+
 	pendingQuery := regexp.QuoteMeta(`
-		SELECT id, user_id, amount_idr, description, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
 		FROM expenses
-		WHERE status = $1 AND requires_approval = true
+		WHERE status = $1 AND requires_approval = true AND report_id IS NULL
 		ORDER BY submitted_at ASC
 	`)
-	rows := sqlmock.NewRows([]string{"id", "user_id", "amount_idr", "description", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved"}).
-		AddRow(1, 2, 3000000, "conference", "url", "awaiting_approval", now, nil, true, false)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "report_id", "category_id", "amount_idr", "currency", "original_amount", "fx_rate",
+		"net_amount_idr", "tax_amount_idr", "tax_rate_percent", "efaktur_number", "description", "merchant", "incurred_on", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved", "requires_finance_approval", "advance_id"}).
+		AddRow(1, 2, nil, 1, 3000000, "IDR", 3000000, 1, 3000000, 0, 0, "", "conference", "", domain.DateOf(now), "url", "awaiting_approval", now, nil, true, false, false, nil)
 	mock.ExpectQuery(pendingQuery).WithArgs(domain.ExpenseStatusAwaitingApproval).WillReturnRows(rows)
 	pending, findErr := repo.FindPendingApproval(context.Background())
 	require.NoError(t, findErr)
 	require.Len(t, pending, 1)
 
+	byUserQuery := regexp.QuoteMeta(`
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
+		FROM expenses
+		WHERE status = $1 AND requires_approval = true AND user_id = ANY($2) AND report_id IS NULL
+		ORDER BY submitted_at ASC
+	`)
+	rows = sqlmock.NewRows([]string{"id", "user_id", "report_id", "category_id", "amount_idr", "currency", "original_amount", "fx_rate",
+		"net_amount_idr", "tax_amount_idr", "tax_rate_percent", "efaktur_number", "description", "merchant", "incurred_on", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved", "requires_finance_approval", "advance_id"}).
+		AddRow(1, 2, nil, 1, 3000000, "IDR", 3000000, 1, 3000000, 0, 0, "", "conference", "", domain.DateOf(now), "url", "awaiting_approval", now, nil, true, false, false, nil)
+	mock.ExpectQuery(byUserQuery).WithArgs(domain.ExpenseStatusAwaitingApproval, pq.Array([]int{2, 3})).WillReturnRows(rows)
+	pending, findErr = repo.FindPendingApprovalByUserIDs(context.Background(), []int{2, 3})
+	require.NoError(t, findErr)
+	require.Len(t, pending, 1)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExpenseRepositoryUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	repo := &expenseRepository{db: db}
+	now := time.Now()
+
+	query := regexp.QuoteMeta(`
+		UPDATE expenses
+		SET category_id = $1, amount_idr = $2, currency = $3, original_amount = $4, fx_rate = $5,
+			net_amount_idr = $6, tax_amount_idr = $7, tax_rate_percent = $8, efaktur_number = $9,
+			description = $10, merchant = $11, incurred_on = $12, receipt_url = $13, status = $14,
+			submitted_at = $15, requires_approval = $16, auto_approved = $17, requires_finance_approval = $18,
+			advance_id = $19
+		WHERE id = $20 AND status = $21
+	`)
+	expense := &domain.Expense{
+		ID:               10,
+		CategoryID:       2,
+		AmountIDR:        2500000,
+		Currency:         "JPY",
+		OriginalAmount:   24000,
+		FXRate:           104.16,
+		NetAmountIDR:     2500000,
+		Description:      "hotel, corrected",
+		Merchant:         "Hotel Indonesia",
+		IncurredOn:       domain.DateOf(now),
+		ReceiptURL:       "url",
+		Status:           domain.ExpenseStatusAwaitingApproval,
+		SubmittedAt:      now,
+		RequiresApproval: true,
+	}
+
+	mock.ExpectExec(query).
+		WithArgs(2, 2500000, "JPY", 24000.0, 104.16, 2500000, 0, 0.0, "", "hotel, corrected", "Hotel Indonesia", domain.DateOf(now), "url", domain.ExpenseStatusAwaitingApproval, now, true, false, false, nil, 10, domain.ExpenseStatusChangesRequested).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	updateErr := repo.Update(context.Background(), expense, domain.ExpenseStatusChangesRequested)
+	require.NoError(t, updateErr)
+
+	mock.ExpectExec(query).
+		WithArgs(2, 2500000, "JPY", 24000.0, 104.16, 2500000, 0, 0.0, "", "hotel, corrected", "Hotel Indonesia", domain.DateOf(now), "url", domain.ExpenseStatusAwaitingApproval, now, true, false, false, nil, 10, domain.ExpenseStatusChangesRequested).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	updateErr = repo.Update(context.Background(), expense, domain.ExpenseStatusChangesRequested)
+	require.ErrorIs(t, updateErr, domain.ErrStatusConflict)
+
+	updateErr = repo.Update(context.Background(), expense, domain.ExpenseStatusRejected)
+	require.ErrorIs(t, updateErr, domain.ErrInvalidTransition)
+
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -150,12 +308,16 @@ func TestExpenseRepositoryFindByUserID(t *testing.T) {
 	now := time.Now()
 
 	queryWithFilter := regexp.QuoteMeta(`
-		SELECT id, user_id, amount_idr, description, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
 		FROM expenses
 		WHERE user_id = $1
 	 AND status = $2 ORDER BY submitted_at DESC LIMIT $3`)
-	rows := sqlmock.NewRows([]string{"id", "user_id", "amount_idr", "description", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved"}).
-		AddRow(1, 4, 50000, "parking", "url", "approved", now, nil, false, true)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "report_id", "category_id", "amount_idr", "currency", "original_amount", "fx_rate",
+		"net_amount_idr", "tax_amount_idr", "tax_rate_percent", "efaktur_number", "description", "merchant", "incurred_on", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved", "requires_finance_approval", "advance_id"}).
+		AddRow(1, 4, nil, 1, 50000, "IDR", 50000, 1, 50000, 0, 0, "", "parking", "", domain.DateOf(now), "url", "approved", now, nil, false, true, false, nil)
 	mock.ExpectQuery(queryWithFilter).WithArgs(4, domain.ExpenseStatusApproved, 10).WillReturnRows(rows)
 
 	result, findErr := repo.FindByUserID(context.Background(), 4, domain.ExpenseStatusApproved, 10, 0)
@@ -163,7 +325,10 @@ func TestExpenseRepositoryFindByUserID(t *testing.T) {
 	require.Len(t, result, 1)
 
 	queryNoFilter := regexp.QuoteMeta(`
-		SELECT id, user_id, amount_idr, description, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
 		FROM expenses
 		WHERE user_id = $1
 	 ORDER BY submitted_at DESC`)
@@ -173,3 +338,122 @@ func TestExpenseRepositoryFindByUserID(t *testing.T) {
 
 	require.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestExpenseRepositoryFindSameDay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	repo := &expenseRepository{db: db}
+	now := time.Now()
+	incurredOn := domain.DateOf(now)
+
+	query := regexp.QuoteMeta(`
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
+		FROM expenses
+		WHERE user_id = $1 AND incurred_on = $2 AND id <> $3 AND NOT (status = ANY($4))
+		ORDER BY id ASC
+	`)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "report_id", "category_id", "amount_idr", "currency", "original_amount", "fx_rate",
+		"net_amount_idr", "tax_amount_idr", "tax_rate_percent", "efaktur_number", "description", "merchant", "incurred_on", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved", "requires_finance_approval", "advance_id"}).
+		AddRow(3, 4, nil, 4, 150000, "IDR", 150000, 1, 150000, 0, 0, "", "lunch", "Warung Sunda", incurredOn, "url", "auto_approved", now, nil, false, true, false, nil)
+	mock.ExpectQuery(query).
+		WithArgs(4, incurredOn, 9, pq.Array([]string{"draft", "cancelled"})).
+		WillReturnRows(rows)
+
+	result, findErr := repo.FindSameDay(context.Background(), 4, incurredOn, 9, domain.ExpenseStatusDraft, domain.ExpenseStatusCancelled)
+	require.NoError(t, findErr)
+	require.Len(t, result, 1)
+	require.Equal(t, "Warung Sunda", result[0].Merchant)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExpenseRepositoryFindIncurredBetween(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	repo := &expenseRepository{db: db}
+	now := time.Now()
+	from := domain.DateOf(now).AddDate(0, 0, -3)
+	to := domain.DateOf(now).AddDate(0, 0, 3)
+
+	query := regexp.QuoteMeta(`
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
+		FROM expenses
+		WHERE user_id = $1 AND incurred_on BETWEEN $2 AND $3 AND id <> $4 AND NOT (status = ANY($5))
+		ORDER BY incurred_on ASC, id ASC
+	`)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "report_id", "category_id", "amount_idr", "currency", "original_amount", "fx_rate",
+		"net_amount_idr", "tax_amount_idr", "tax_rate_percent", "efaktur_number", "description", "merchant", "incurred_on", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved", "requires_finance_approval", "advance_id"}).
+		AddRow(3, 4, nil, 4, 150000, "IDR", 150000, 1, 150000, 0, 0, "", "taxi to airport", "Bluebird", from, "url", "auto_approved", now, nil, false, true, false, nil)
+	mock.ExpectQuery(query).
+		WithArgs(4, from, to, 9, pq.Array([]string{"draft"})).
+		WillReturnRows(rows)
+
+	result, findErr := repo.FindIncurredBetween(context.Background(), 4, from, to, 9, domain.ExpenseStatusDraft)
+	require.NoError(t, findErr)
+	require.Len(t, result, 1)
+	require.Equal(t, "taxi to airport", result[0].Description)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindByReportID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	repo := &expenseRepository{db: db}
+	now := time.Now()
+
+	query := regexp.QuoteMeta(`
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
+		FROM expenses
+		WHERE report_id = $1
+		ORDER BY incurred_on ASC, id ASC
+	`)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "report_id", "category_id", "amount_idr", "currency", "original_amount", "fx_rate",
+		"net_amount_idr", "tax_amount_idr", "tax_rate_percent", "efaktur_number", "description", "merchant", "incurred_on", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved", "requires_finance_approval", "advance_id"}).
+		AddRow(3, 4, 7, 4, 150000, "IDR", 150000, 1, 150000, 0, 0, "", "hotel", "Hotel Indonesia", domain.DateOf(now), "url", "draft", now, nil, false, false, false, nil)
+	mock.ExpectQuery(query).WithArgs(7).WillReturnRows(rows)
+
+	result, findErr := repo.FindByReportID(context.Background(), 7)
+	require.NoError(t, findErr)
+	require.Len(t, result, 1)
+	require.Equal(t, 7, *result[0].ReportID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindByAdvanceID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	repo := &expenseRepository{db: db}
+	now := time.Now()
+
+	query := regexp.QuoteMeta(`
+		SELECT id, user_id, report_id, category_id, amount_idr, currency, original_amount, fx_rate,
+			net_amount_idr, tax_amount_idr, tax_rate_percent, efaktur_number,
+			description, merchant, incurred_on, receipt_url, status, submitted_at, processed_at, requires_approval, auto_approved,
+			requires_finance_approval, advance_id
+		FROM expenses
+		WHERE advance_id = $1
+		ORDER BY incurred_on ASC, id ASC
+	`)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "report_id", "category_id", "amount_idr", "currency", "original_amount", "fx_rate",
+		"net_amount_idr", "tax_amount_idr", "tax_rate_percent", "efaktur_number", "description", "merchant", "incurred_on", "receipt_url", "status", "submitted_at", "processed_at", "requires_approval", "auto_approved", "requires_finance_approval", "advance_id"}).
+		AddRow(3, 4, nil, 4, 150000, "IDR", 150000, 1, 150000, 0, 0, "", "hotel", "Hotel Indonesia", domain.DateOf(now), "url", "approved", now, nil, false, false, false, 2)
+	mock.ExpectQuery(query).WithArgs(2).WillReturnRows(rows)
+
+	result, findErr := repo.FindByAdvanceID(context.Background(), 2)
+	require.NoError(t, findErr)
+	require.Len(t, result, 1)
+	require.Equal(t, 2, *result[0].AdvanceID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
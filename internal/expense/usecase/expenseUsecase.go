@@ -2,42 +2,164 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"sort"
 	"time"
 
+	"github.com/evrintobing17/expense-management-backend/internal/advance"
+	"github.com/evrintobing17/expense-management-backend/internal/allocation"
 	"github.com/evrintobing17/expense-management-backend/internal/approval"
+	"github.com/evrintobing17/expense-management-backend/internal/budget"
+	"github.com/evrintobing17/expense-management-backend/internal/category"
 	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/duplicate"
+	"github.com/evrintobing17/expense-management-backend/internal/escalation"
 	"github.com/evrintobing17/expense-management-backend/internal/expense"
+	"github.com/evrintobing17/expense-management-backend/internal/fraud"
+	"github.com/evrintobing17/expense-management-backend/internal/fx"
+	"github.com/evrintobing17/expense-management-backend/internal/history"
+	"github.com/evrintobing17/expense-management-backend/internal/rule"
+	"github.com/evrintobing17/expense-management-backend/internal/user"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
 )
 
 type expenseUseCase struct {
-	expenseRepo  expense.ExpenseRepository
-	approvalRepo approval.ApprovalRepository
+	expenseRepo    expense.ExpenseRepository
+	approvalRepo   approval.ApprovalRepository
+	historyRepo    history.HistoryRepository
+	userRepo       user.UserRepository
+	escalationRepo escalation.EscalationRepository
+	categoryRepo   category.CategoryRepository
+	violationRepo  rule.ViolationRepository
+	duplicateRepo  duplicate.DuplicateRepository
+	fraudRepo      fraud.FraudRepository
+	allocationRepo allocation.AllocationRepository
+	advanceRepo    advance.AdvanceRepository
+	approvalPolicy domain.ApprovalPolicy
+	scope          approval.ApproverScope
+	segregation    approval.SegregationPolicy
+	ruleEngine     rule.RuleEngine
+	duplicates     duplicate.DuplicateDetector
+	splits         fraud.SplitDetector
+	converter      fx.CurrencyConverter
+	allocations    allocation.AllocationChecker
+	budgets        budget.BudgetChecker
+	transactor     database.Transactor
 }
 
-func NewExpenseUseCase(expenseRepo expense.ExpenseRepository, approvalRepo approval.ApprovalRepository) expense.ExpenseUseCase {
+// Deps lists what the expense use case is built from.
+type Deps struct {
+	ExpenseRepo    expense.ExpenseRepository
+	ApprovalRepo   approval.ApprovalRepository
+	HistoryRepo    history.HistoryRepository
+	UserRepo       user.UserRepository
+	EscalationRepo escalation.EscalationRepository
+	CategoryRepo   category.CategoryRepository
+	ViolationRepo  rule.ViolationRepository
+	DuplicateRepo  duplicate.DuplicateRepository
+	FraudRepo      fraud.FraudRepository
+	AllocationRepo allocation.AllocationRepository
+	AdvanceRepo    advance.AdvanceRepository
+	ApprovalPolicy domain.ApprovalPolicy
+	Scope          approval.ApproverScope
+	Segregation    approval.SegregationPolicy
+	RuleEngine     rule.RuleEngine
+	Duplicates     duplicate.DuplicateDetector
+	Splits         fraud.SplitDetector
+	Converter      fx.CurrencyConverter
+	Allocations    allocation.AllocationChecker
+	Budgets        budget.BudgetChecker
+	Transactor     database.Transactor
+}
+
+func NewExpenseUseCase(deps Deps) expense.ExpenseUseCase {
 	return &expenseUseCase{
-		expenseRepo:  expenseRepo,
-		approvalRepo: approvalRepo,
+		expenseRepo:    deps.ExpenseRepo,
+		approvalRepo:   deps.ApprovalRepo,
+		historyRepo:    deps.HistoryRepo,
+		userRepo:       deps.UserRepo,
+		escalationRepo: deps.EscalationRepo,
+		categoryRepo:   deps.CategoryRepo,
+		violationRepo:  deps.ViolationRepo,
+		duplicateRepo:  deps.DuplicateRepo,
+		fraudRepo:      deps.FraudRepo,
+		allocationRepo: deps.AllocationRepo,
+		advanceRepo:    deps.AdvanceRepo,
+		approvalPolicy: deps.ApprovalPolicy,
+		scope:          deps.Scope,
+		segregation:    deps.Segregation,
+		ruleEngine:     deps.RuleEngine,
+		duplicates:     deps.Duplicates,
+		splits:         deps.Splits,
+		converter:      deps.Converter,
+		allocations:    deps.Allocations,
+		budgets:        deps.Budgets,
+		transactor:     deps.Transactor,
 	}
 }
 
-func (uc *expenseUseCase) CreateExpense(ctx context.Context, userID int, amountIDR int, description, receiptURL string) (*domain.Expense, error) {
-	if amountIDR < domain.MinExpenseAmount || amountIDR > domain.MaxExpenseAmount {
-		return nil, domain.ErrInvalidAmount
+// CreateExpense stores a new expense and, unless it is a draft, submits it.
+func (uc *expenseUseCase) CreateExpense(ctx context.Context, expense *domain.Expense, draft bool) (*domain.Expense, error) {
+	if expense.IncurredOn.IsZero() {
+		expense.IncurredOn = time.Now()
 	}
+	expense.IncurredOn = domain.DateOf(expense.IncurredOn)
 
-	if description == "" {
-		return nil, domain.ErrMissingDescription
+	if err := uc.converter.Convert(ctx, expense); err != nil {
+		return nil, err
 	}
 
-	expense := &domain.Expense{
-		UserID:      userID,
-		AmountIDR:   amountIDR,
-		Description: description,
-		ReceiptURL:  receiptURL,
+	reason := "submitted"
+	if draft {
+		expense.Status = domain.ExpenseStatusDraft
+		reason = "draft created"
+	} else {
+		expense.Route(uc.approvalPolicy)
 	}
 
-	err := uc.expenseRepo.Create(ctx, expense)
+	if err := uc.validateExpense(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkPolicy(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	if !draft {
+		if err := uc.checkSplitting(ctx, expense); err != nil {
+			return nil, err
+		}
+
+		if err := uc.checkBudget(ctx, expense); err != nil {
+			return nil, err
+		}
+	}
+
+	err := uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.expenseRepo.Create(ctx, expense); err != nil {
+			return err
+		}
+
+		if err := uc.allocationRepo.Replace(ctx, expense.ID, expense.Allocations); err != nil {
+			return err
+		}
+
+		if err := uc.violationRepo.Replace(ctx, expense.ID, expense.PolicyViolations); err != nil {
+			return err
+		}
+
+		if !draft {
+			if err := uc.fraudRepo.Replace(ctx, expense.ID, expense.FraudFlags); err != nil {
+				return err
+			}
+
+			if err := uc.flagDuplicates(ctx, expense); err != nil {
+				return err
+			}
+		}
+
+		return uc.historyRepo.Create(ctx, domain.UserStatusChange(expense.ID, "", expense.Status, expense.UserID, reason))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +167,182 @@ func (uc *expenseUseCase) CreateExpense(ctx context.Context, userID int, amountI
 	return expense, nil
 }
 
+// UpdateExpense applies the submitter's edits to an editable expense.
+func (uc *expenseUseCase) UpdateExpense(ctx context.Context, expenseID int, userID int, changes domain.ExpenseChanges) (*domain.Expense, error) {
+	expense, err := uc.ownExpense(ctx, expenseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !expense.IsEditable() {
+		return nil, domain.ErrExpenseNotEditable
+	}
+
+	if err := uc.attachAllocations(ctx, []*domain.Expense{expense}); err != nil {
+		return nil, err
+	}
+
+	changes.Apply(expense)
+	if err := uc.converter.Convert(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	if err := uc.validateExpense(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkPolicy(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	err = uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.expenseRepo.Update(ctx, expense, expense.Status); err != nil {
+			return err
+		}
+
+		if err := uc.allocationRepo.Replace(ctx, expense.ID, expense.Allocations); err != nil {
+			return err
+		}
+
+		return uc.violationRepo.Replace(ctx, expense.ID, expense.PolicyViolations)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return expense, nil
+}
+
+// SubmitExpense sends a draft on to approval or auto-approval.
+func (uc *expenseUseCase) SubmitExpense(ctx context.Context, expenseID int, userID int) (*domain.Expense, error) {
+	expense, err := uc.ownExpense(ctx, expenseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if expense.ReportID != nil {
+		return nil, domain.ErrExpenseInReport
+	}
+
+	if expense.Status != domain.ExpenseStatusDraft {
+		return nil, domain.ErrInvalidExpenseStatus
+	}
+
+	if err := uc.attachAllocations(ctx, []*domain.Expense{expense}); err != nil {
+		return nil, err
+	}
+
+	if err := uc.route(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	err = uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.expenseRepo.Update(ctx, expense, domain.ExpenseStatusDraft); err != nil {
+			return err
+		}
+
+		return uc.recordSubmission(ctx, expense, domain.ExpenseStatusDraft, userID, "submitted")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return expense, nil
+}
+
+// CancelExpense withdraws an expense on behalf of its submitter.
+func (uc *expenseUseCase) CancelExpense(ctx context.Context, expenseID int, userID int) (*domain.Expense, error) {
+	expense, err := uc.ownExpense(ctx, expenseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if expense.ReportID != nil {
+		return nil, domain.ErrExpenseInReport
+	}
+
+	from := expense.Status
+	if err := domain.ValidateTransition(from, domain.ExpenseStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.expenseRepo.UpdateStatus(ctx, expense.ID, from, domain.ExpenseStatusCancelled, &now); err != nil {
+			return err
+		}
+
+		return uc.historyRepo.Create(ctx, domain.UserStatusChange(expense.ID, from, domain.ExpenseStatusCancelled, userID, "cancelled by submitter"))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	expense.Status = domain.ExpenseStatusCancelled
+	expense.ProcessedAt = &now
+
+	return expense, nil
+}
+
+// GetExpenseByID returns one of the user's expenses with its flags.
 func (uc *expenseUseCase) GetExpenseByID(ctx context.Context, id int, userID int) (*domain.Expense, error) {
+	expense, err := uc.ownExpense(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.attachFlags(ctx, []*domain.Expense{expense}); err != nil {
+		return nil, err
+	}
+
+	return expense, nil
+}
+
+// CheckReportLine checks a report line as if it were submitted on its own against
+// policy rules, split claims and budgets, keeps what it found on the line and reports
+// whether any of them asks for manual approval.
+func (uc *expenseUseCase) CheckReportLine(ctx context.Context, expense *domain.Expense) (bool, error) {
+	if expense.Allocations == nil {
+		if err := uc.attachAllocations(ctx, []*domain.Expense{expense}); err != nil {
+			return false, err
+		}
+	}
+
+	line := *expense
+	line.Status = domain.ExpenseStatusAutoApproved
+	line.RequiresApproval = false
+	if err := uc.validateExpense(ctx, &line); err != nil {
+		return false, err
+	}
+
+	if err := uc.checkPolicy(ctx, &line); err != nil {
+		return false, err
+	}
+
+	if err := uc.checkSplitting(ctx, &line); err != nil {
+		return false, err
+	}
+
+	if err := uc.checkBudget(ctx, &line); err != nil {
+		return false, err
+	}
+
+	expense.PolicyViolations = line.PolicyViolations
+	expense.FraudFlags = line.FraudFlags
+	expense.BudgetWarnings = line.BudgetWarnings
+	expense.RequiresFinanceApproval = line.RequiresFinanceApproval
+
+	return line.RequiresApproval, nil
+}
+
+// RecordReportLine stores what CheckReportLine found on a submitted report line and
+// flags possible duplicates.
+func (uc *expenseUseCase) RecordReportLine(ctx context.Context, expense *domain.Expense) error {
+	return uc.recordFlags(ctx, expense)
+}
+
+// ownExpense loads the expense and checks that userID submitted it.
+func (uc *expenseUseCase) ownExpense(ctx context.Context, id int, userID int) (*domain.Expense, error) {
 	expense, err := uc.expenseRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -73,7 +370,16 @@ func (uc *expenseUseCase) GetUserExpenses(ctx context.Context, userID int, statu
 
 	offset := (page - 1) * limit
 
-	return uc.expenseRepo.FindByUserID(ctx, userID, status, limit, offset)
+	expenses, err := uc.expenseRepo.FindByUserID(ctx, userID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.attachFlags(ctx, expenses); err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
 }
 
 func (uc *expenseUseCase) ApproveExpense(ctx context.Context, expenseID int, approverID int, notes string) error {
@@ -84,6 +390,99 @@ func (uc *expenseUseCase) RejectExpense(ctx context.Context, expenseID int, appr
 	return uc.processExpenseApproval(ctx, expenseID, approverID, notes, domain.ApprovalStatusRejected, domain.ExpenseStatusRejected)
 }
 
+// RequestChanges sends the expense back to its submitter; notes are required.
+func (uc *expenseUseCase) RequestChanges(ctx context.Context, expenseID int, approverID int, notes string) error {
+	if notes == "" {
+		return domain.ErrMissingNotes
+	}
+
+	return uc.processExpenseApproval(ctx, expenseID, approverID, notes, domain.ApprovalStatusChangesRequested, domain.ExpenseStatusChangesRequested)
+}
+
+// ResubmitExpense applies the submitter's edits to an expense sent back to them and
+// submits it again. Every level signs the new round.
+func (uc *expenseUseCase) ResubmitExpense(ctx context.Context, expenseID int, userID int, changes domain.ExpenseChanges) (*domain.Expense, error) {
+	expense, err := uc.ownExpense(ctx, expenseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if expense.ReportID != nil {
+		return nil, domain.ErrExpenseInReport
+	}
+
+	from := expense.Status
+	if err := domain.ValidateTransition(from, domain.ExpenseStatusAwaitingApproval); err != nil {
+		return nil, err
+	}
+
+	if err := uc.attachAllocations(ctx, []*domain.Expense{expense}); err != nil {
+		return nil, err
+	}
+
+	changes.Apply(expense)
+	if err := uc.converter.Convert(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	// The amended expense is routed afresh, exactly like a first submission.
+	if err := uc.route(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	if err := domain.ValidateTransition(from, expense.Status); err != nil {
+		return nil, err
+	}
+
+	err = uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.expenseRepo.Update(ctx, expense, from); err != nil {
+			return err
+		}
+
+		if err := uc.allocationRepo.Replace(ctx, expense.ID, expense.Allocations); err != nil {
+			return err
+		}
+
+		return uc.recordSubmission(ctx, expense, from, userID, "resubmitted")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return expense, nil
+}
+
+// route decides how a submitted expense continues: it is validated, routed on the
+// approval policy and then checked against policy rules, split claims and budgets, any
+// of which can still send it to approval or block it.
+func (uc *expenseUseCase) route(ctx context.Context, expense *domain.Expense) error {
+	expense.Route(uc.approvalPolicy)
+	expense.SubmittedAt = time.Now()
+	if err := uc.validateExpense(ctx, expense); err != nil {
+		return err
+	}
+
+	if err := uc.checkPolicy(ctx, expense); err != nil {
+		return err
+	}
+
+	if err := uc.checkSplitting(ctx, expense); err != nil {
+		return err
+	}
+
+	return uc.checkBudget(ctx, expense)
+}
+
+// recordSubmission stores what routing found on the expense, flags possible duplicates
+// and records the transition from from.
+func (uc *expenseUseCase) recordSubmission(ctx context.Context, expense *domain.Expense, from domain.ExpenseStatus, userID int, reason string) error {
+	if err := uc.recordFlags(ctx, expense); err != nil {
+		return err
+	}
+
+	return uc.historyRepo.Create(ctx, domain.UserStatusChange(expense.ID, from, expense.Status, userID, reason))
+}
+
 func (uc *expenseUseCase) processExpenseApproval(
 	ctx context.Context,
 	expenseID int,
@@ -101,27 +500,510 @@ func (uc *expenseUseCase) processExpenseApproval(
 		return domain.ErrExpenseNotFound
 	}
 
-	if expense.Status != domain.ExpenseStatusAwaitingApproval {
+	if expense.ReportID != nil {
+		return domain.ErrExpenseInReport
+	}
+
+	if err := domain.ValidateTransition(expense.Status, expenseStatus); err != nil {
+		return err
+	}
+
+	approver, err := uc.userRepo.FindByID(ctx, approverID)
+	if err != nil {
+		return err
+	}
+
+	if approver == nil {
+		return domain.ErrUnauthorizedAction
+	}
+
+	required, chain, err := uc.approvalChain(ctx, expense)
+	if err != nil {
+		return err
+	}
+
+	level, ok := domain.NextApprovalLevel(required, chain)
+	if !ok {
 		return domain.ErrInvalidExpenseStatus
 	}
 
-	// Create approval record
+	onBehalfOf, allowed, err := uc.signingAuthority(ctx, approver, level, expense)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return domain.ErrUnauthorizedAction
+	}
+
+	if approvalStatus == domain.ApprovalStatusApproved {
+		// An advance settled while the expense was waiting would never offset it.
+		if err := uc.checkAdvance(ctx, expense); err != nil {
+			return err
+		}
+		if err := uc.segregation.CheckApprover(ctx, expense, level, approverID); err != nil {
+			return err
+		}
+	}
+
 	approval := &domain.Approval{
-		ExpenseID:  expenseID,
-		ApproverID: approverID,
-		Status:     approvalStatus,
-		Notes:      notes,
+		ExpenseID:    expenseID,
+		ApproverID:   approverID,
+		OnBehalfOfID: onBehalfOf,
+		Round:        domain.ApprovalRound(chain),
+		Level:        level,
+		Status:       approvalStatus,
+		Notes:        notes,
+	}
+
+	// Intermediate sign-offs only extend the chain.
+	_, outstanding := domain.NextApprovalLevel(required, append(chain, approval))
+	if approvalStatus == domain.ApprovalStatusApproved && outstanding {
+		return uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+			if err := uc.approvalRepo.Create(ctx, approval); err != nil {
+				return err
+			}
+
+			return uc.historyRepo.Create(ctx, domain.LevelApproved(expenseID, expense.Status, level, approverID, notes))
+		})
+	}
+
+	return uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.approvalRepo.Create(ctx, approval); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := uc.expenseRepo.UpdateStatus(ctx, expenseID, expense.Status, expenseStatus, &now); err != nil {
+			return err
+		}
+
+		return uc.historyRepo.Create(ctx, domain.UserStatusChange(expenseID, expense.Status, expenseStatus, approverID, notes))
+	})
+}
+
+// GetPendingApproval returns the expenses whose next outstanding level the approver may
+// sign, including those escalated to them.
+func (uc *expenseUseCase) GetPendingApproval(ctx context.Context, approverID int) ([]*domain.Expense, error) {
+	approver, err := uc.userRepo.FindByID(ctx, approverID)
+	if err != nil {
+		return nil, err
 	}
 
-	err = uc.approvalRepo.Create(ctx, approval)
+	if approver == nil {
+		return nil, domain.ErrUnauthorizedAction
+	}
+
+	scope, err := uc.scope.ManagerScope(ctx, approver)
+	if err != nil {
+		return nil, err
+	}
+
+	escalations, err := uc.escalationRepo.FindPendingByEscalatedToID(ctx, approver.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	escalated := make(map[int]map[domain.ApprovalLevel]bool)
+	for _, e := range escalations {
+		if escalated[e.ExpenseID] == nil {
+			escalated[e.ExpenseID] = make(map[domain.ApprovalLevel]bool)
+		}
+		escalated[e.ExpenseID][e.Level] = true
+	}
+
+	var expenses []*domain.Expense
+	if approver.Role == domain.RoleManager {
+		if len(scope) > 0 {
+			userIDs := make([]int, 0, len(scope))
+			for id := range scope {
+				userIDs = append(userIDs, id)
+			}
+			sort.Ints(userIDs)
+
+			expenses, err = uc.expenseRepo.FindPendingApprovalByUserIDs(ctx, userIDs)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		expenses, err = uc.expenseRepo.FindPendingApproval(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	expenses, err = uc.withEscalatedExpenses(ctx, expenses, escalations)
+	if err != nil {
+		return nil, err
+	}
+
+	// Approvers never see their own expenses; segregation of duties forbids signing them.
+	var candidates []*domain.Expense
+	var ids []int
+	for _, expense := range expenses {
+		if expense.UserID != approver.ID {
+			candidates = append(candidates, expense)
+			ids = append(ids, expense.ID)
+		}
+	}
+
+	chains := map[int][]*domain.Approval{}
+	if len(ids) > 0 {
+		chains, err = uc.approvalRepo.FindByExpenseIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var pending []*domain.Expense
+	for _, expense := range candidates {
+		level, ok := domain.NextApprovalLevel(uc.approvalPolicy.ExpenseLevels(expense), chains[expense.ID])
+		if !ok {
+			continue
+		}
+
+		if !escalated[expense.ID][level] {
+			if level == domain.ApprovalLevelManager {
+				if _, inScope := scope[expense.UserID]; !inScope {
+					continue
+				}
+			} else if level.Role() != approver.Role {
+				continue
+			}
+		}
+
+		// Leave out what segregation of duties would stop the approver from signing.
+		if err := uc.segregation.CheckApprover(ctx, expense, level, approver.ID); err != nil {
+			if errors.Is(err, domain.ErrUnauthorizedAction) {
+				continue
+			}
+			return nil, err
+		}
+
+		pending = append(pending, expense)
+	}
+
+	if err := uc.attachFlags(ctx, pending); err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+// withEscalatedExpenses appends escalated expenses missing from the regular queue.
+func (uc *expenseUseCase) withEscalatedExpenses(ctx context.Context, expenses []*domain.Expense, escalations []*domain.Escalation) ([]*domain.Expense, error) {
+	seen := make(map[int]bool, len(expenses))
+	for _, expense := range expenses {
+		seen[expense.ID] = true
+	}
+
+	for _, e := range escalations {
+		if seen[e.ExpenseID] {
+			continue
+		}
+		seen[e.ExpenseID] = true
+
+		expense, err := uc.expenseRepo.FindByID(ctx, e.ExpenseID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report lines are escalated with their report and signed there.
+		if expense != nil && expense.Status == domain.ExpenseStatusAwaitingApproval && expense.ReportID == nil {
+			expenses = append(expenses, expense)
+		}
+	}
+
+	return expenses, nil
+}
+
+// signingAuthority reports whether approver may sign level for the expense and, for a
+// delegated sign-off, on whose behalf.
+func (uc *expenseUseCase) signingAuthority(ctx context.Context, approver *domain.User, level domain.ApprovalLevel, expense *domain.Expense) (*int, bool, error) {
+	if level == domain.ApprovalLevelManager {
+		scope, err := uc.scope.ManagerScope(ctx, approver)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if onBehalfOf, ok := scope[expense.UserID]; ok {
+			return onBehalfOf, true, nil
+		}
+	} else if approver.Role == level.Role() {
+		return nil, true, nil
+	}
+
+	escalations, err := uc.escalationRepo.FindByExpenseID(ctx, expense.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, e := range escalations {
+		if e.Level == level && e.EscalatedToID == approver.ID {
+			return nil, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// approvalChain returns the required levels and the approvals recorded so far.
+func (uc *expenseUseCase) approvalChain(ctx context.Context, expense *domain.Expense) ([]domain.ApprovalLevel, []*domain.Approval, error) {
+	required := uc.approvalPolicy.ExpenseLevels(expense)
+
+	chain, err := uc.approvalRepo.FindByExpenseID(ctx, expense.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return required, chain, nil
+}
+
+func (uc *expenseUseCase) GetExpenseHistory(ctx context.Context, expenseID int, userID int, role domain.Role) ([]*domain.StatusHistory, error) {
+	expense, err := uc.expenseRepo.FindByID(ctx, expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if expense == nil {
+		return nil, domain.ErrExpenseNotFound
+	}
+
+	if expense.UserID != userID && !role.IsFinance() {
+		allowed, err := uc.inReportingLine(ctx, userID, expense.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowed {
+			return nil, domain.ErrUnauthorizedAction
+		}
+	}
+
+	return uc.historyRepo.FindByExpenseID(ctx, expenseID)
+}
+
+// inReportingLine reports whether the submitter is within the approver's manager scope.
+func (uc *expenseUseCase) inReportingLine(ctx context.Context, approverID int, submitterID int) (bool, error) {
+	approver, err := uc.userRepo.FindByID(ctx, approverID)
+	if err != nil || approver == nil {
+		return false, err
+	}
+
+	scope, err := uc.scope.ManagerScope(ctx, approver)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := scope[submitterID]
+	return ok, nil
+}
+
+// validateExpense checks the expense's category, allocations and cash advance. Drafts
+// may still lack a receipt.
+func (uc *expenseUseCase) validateExpense(ctx context.Context, expense *domain.Expense) error {
+	if expense.Description == "" {
+		return domain.ErrMissingDescription
+	}
+
+	category, err := uc.categoryRepo.FindByID(ctx, expense.CategoryID)
 	if err != nil {
 		return err
 	}
 
-	now := time.Now()
-	return uc.expenseRepo.UpdateStatus(ctx, expenseID, expenseStatus, &now)
+	if category == nil {
+		return domain.ErrCategoryNotFound
+	}
+
+	if !category.Active {
+		return domain.ErrInactiveCategory
+	}
+
+	if err := category.CheckAmount(expense.AmountIDR); err != nil {
+		return err
+	}
+
+	if err := expense.CheckTax(); err != nil {
+		return err
+	}
+
+	if err := uc.allocations.Check(ctx, expense); err != nil {
+		return err
+	}
+
+	if err := uc.checkAdvance(ctx, expense); err != nil {
+		return err
+	}
+
+	if expense.Status == domain.ExpenseStatusDraft {
+		return nil
+	}
+
+	return category.CheckReceipt(expense.ReceiptURL)
 }
 
-func (uc *expenseUseCase) GetPendingApproval(ctx context.Context) ([]*domain.Expense, error) {
-	return uc.expenseRepo.FindPendingApproval(ctx)
+// checkAdvance makes sure the cash advance an expense is filed against can take it.
+func (uc *expenseUseCase) checkAdvance(ctx context.Context, expense *domain.Expense) error {
+	if expense.AdvanceID == nil {
+		return nil
+	}
+
+	if expense.ReportID != nil {
+		return domain.ErrAdvanceNotLinkable
+	}
+
+	advance, err := uc.advanceRepo.FindByID(ctx, *expense.AdvanceID)
+	if err != nil {
+		return err
+	}
+
+	if advance == nil || advance.UserID != expense.UserID || !advance.AcceptsExpenses() {
+		return domain.ErrAdvanceNotLinkable
+	}
+
+	return nil
+}
+
+// checkPolicy records the policy violations on the expense and, once it is submitted,
+// applies their actions.
+func (uc *expenseUseCase) checkPolicy(ctx context.Context, expense *domain.Expense) error {
+	violations, err := uc.ruleEngine.Evaluate(ctx, expense)
+	if err != nil {
+		return err
+	}
+
+	expense.PolicyViolations = violations
+	if expense.Status == domain.ExpenseStatusDraft || expense.Status == domain.ExpenseStatusChangesRequested {
+		return nil
+	}
+
+	if domain.HasRuleAction(violations, domain.RuleActionBlock) {
+		return &domain.RuleBlockedError{Violations: violations}
+	}
+
+	if expense.Status == domain.ExpenseStatusAutoApproved && domain.HasRuleAction(violations, domain.RuleActionRequireApproval) {
+		expense.RequireApproval()
+	}
+
+	return nil
+}
+
+// checkSplitting sends a claim that looks split under the threshold to approval.
+func (uc *expenseUseCase) checkSplitting(ctx context.Context, expense *domain.Expense) error {
+	if expense.Status != domain.ExpenseStatusAutoApproved {
+		return nil
+	}
+
+	flag, err := uc.splits.Detect(ctx, expense)
+	if err != nil {
+		return err
+	}
+
+	if flag != nil {
+		expense.FraudFlags = []*domain.FraudFlag{flag}
+		expense.RequireApproval()
+	}
+
+	return nil
+}
+
+// checkBudget records budget warnings and, if the policy asks for it, sends an
+// over-budget expense to the finance director.
+func (uc *expenseUseCase) checkBudget(ctx context.Context, expense *domain.Expense) error {
+	warnings, err := uc.budgets.Check(ctx, expense)
+	if err != nil {
+		return err
+	}
+
+	expense.BudgetWarnings = warnings
+	expense.RequiresFinanceApproval = uc.approvalPolicy.FinanceApprovalOverBudget && domain.ExceedsBudget(warnings)
+	if expense.RequiresFinanceApproval && expense.Status == domain.ExpenseStatusAutoApproved {
+		expense.RequireApproval()
+	}
+
+	return nil
+}
+
+// recordFlags stores the expense's policy violations and fraud flags and flags
+// possible duplicates.
+func (uc *expenseUseCase) recordFlags(ctx context.Context, expense *domain.Expense) error {
+	if err := uc.violationRepo.Replace(ctx, expense.ID, expense.PolicyViolations); err != nil {
+		return err
+	}
+
+	if err := uc.fraudRepo.Replace(ctx, expense.ID, expense.FraudFlags); err != nil {
+		return err
+	}
+
+	return uc.flagDuplicates(ctx, expense)
+}
+
+// flagDuplicates records the earlier claims the expense may duplicate.
+func (uc *expenseUseCase) flagDuplicates(ctx context.Context, expense *domain.Expense) error {
+	matches, err := uc.duplicates.Detect(ctx, expense)
+	if err != nil {
+		return err
+	}
+
+	expense.PossibleDuplicates = matches
+	return uc.duplicateRepo.Replace(ctx, expense.ID, matches)
+}
+
+// attachFlags fills in the expenses' allocations, violations and flags.
+func (uc *expenseUseCase) attachFlags(ctx context.Context, expenses []*domain.Expense) error {
+	if len(expenses) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(expenses))
+	for i, expense := range expenses {
+		ids[i] = expense.ID
+	}
+
+	if err := uc.attachAllocations(ctx, expenses); err != nil {
+		return err
+	}
+
+	violations, err := uc.violationRepo.FindByExpenseIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	duplicates, err := uc.duplicateRepo.FindByExpenseIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	fraudFlags, err := uc.fraudRepo.FindByExpenseIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, expense := range expenses {
+		expense.PolicyViolations = violations[expense.ID]
+		expense.PossibleDuplicates = duplicates[expense.ID]
+		expense.FraudFlags = fraudFlags[expense.ID]
+	}
+
+	return nil
+}
+
+// attachAllocations fills in the expenses' allocations.
+func (uc *expenseUseCase) attachAllocations(ctx context.Context, expenses []*domain.Expense) error {
+	ids := make([]int, len(expenses))
+	for i, expense := range expenses {
+		ids[i] = expense.ID
+	}
+
+	allocations, err := uc.allocationRepo.FindByExpenseIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, expense := range expenses {
+		expense.Allocations = allocations[expense.ID]
+	}
+
+	return nil
 }
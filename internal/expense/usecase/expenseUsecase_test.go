@@ -4,77 +4,914 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/evrintobing17/expense-management-backend/internal/approval/scope"
 	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/expense"
 	"github.com/evrintobing17/expense-management-backend/mocks"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+// travel is the category the approval-flow fixtures are filed under.
+var travel = &domain.Category{ID: 2, Name: "Travel", MinAmountIDR: 10000, MaxAmountIDR: 50000000, Active: true}
+
+type useCaseMocks struct {
+	expense     *mocks.ExpenseRepository
+	approval    *mocks.ApprovalRepository
+	history     *mocks.HistoryRepository
+	user        *mocks.UserRepository
+	delegation  *mocks.DelegationRepository
+	escalation  *mocks.EscalationRepository
+	category    *mocks.CategoryRepository
+	violation   *mocks.ViolationRepository
+	duplicate   *mocks.DuplicateRepository
+	fraud       *mocks.FraudRepository
+	segregation *mocks.SegregationPolicy
+	rules       *mocks.RuleEngine
+	duplicates  *mocks.DuplicateDetector
+	splits      *mocks.SplitDetector
+	converter   *mocks.CurrencyConverter
+	allocation  *mocks.AllocationRepository
+	allocations *mocks.AllocationChecker
+	budgets     *mocks.BudgetChecker
+	advance     *mocks.AdvanceRepository
+	tx          *mocks.Transactor
+}
+
+func newUseCaseMocks() *useCaseMocks {
+	m := &useCaseMocks{
+		expense:     new(mocks.ExpenseRepository),
+		approval:    new(mocks.ApprovalRepository),
+		history:     new(mocks.HistoryRepository),
+		user:        new(mocks.UserRepository),
+		delegation:  new(mocks.DelegationRepository),
+		escalation:  new(mocks.EscalationRepository),
+		category:    new(mocks.CategoryRepository),
+		violation:   new(mocks.ViolationRepository),
+		duplicate:   new(mocks.DuplicateRepository),
+		fraud:       new(mocks.FraudRepository),
+		segregation: new(mocks.SegregationPolicy),
+		rules:       new(mocks.RuleEngine),
+		duplicates:  new(mocks.DuplicateDetector),
+		splits:      new(mocks.SplitDetector),
+		converter:   new(mocks.CurrencyConverter),
+		allocation:  new(mocks.AllocationRepository),
+		allocations: new(mocks.AllocationChecker),
+		budgets:     new(mocks.BudgetChecker),
+		advance:     new(mocks.AdvanceRepository),
+		tx:          new(mocks.Transactor),
+	}
+	m.tx.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}).Maybe()
+	m.violation.On("Replace", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.violation.On("FindByExpenseIDs", mock.Anything, mock.Anything).Return(map[int][]*domain.RuleViolation{}, nil).Maybe()
+	m.duplicate.On("Replace", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.duplicate.On("FindByExpenseIDs", mock.Anything, mock.Anything).Return(map[int][]*domain.DuplicateMatch{}, nil).Maybe()
+	m.duplicates.On("Detect", mock.Anything, mock.Anything).Return([]*domain.DuplicateMatch(nil), nil).Maybe()
+	m.fraud.On("Replace", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.fraud.On("FindByExpenseIDs", mock.Anything, mock.Anything).Return(map[int][]*domain.FraudFlag{}, nil).Maybe()
+	m.splits.On("Detect", mock.Anything, mock.Anything).Return((*domain.FraudFlag)(nil), nil).Maybe()
+	m.converter.On("Convert", mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.allocation.On("Replace", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.allocation.On("FindByExpenseIDs", mock.Anything, mock.Anything).Return(map[int][]*domain.Allocation{}, nil).Maybe()
+	m.allocations.On("Check", mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.budgets.On("Check", mock.Anything, mock.Anything).Return([]*domain.BudgetWarning(nil), nil).Maybe()
+	return m
+}
+
+func (m *useCaseMocks) useCase() expense.ExpenseUseCase {
+	return NewExpenseUseCase(m.deps())
+}
+
+func (m *useCaseMocks) deps() Deps {
+	return Deps{
+		ExpenseRepo:    m.expense,
+		ApprovalRepo:   m.approval,
+		HistoryRepo:    m.history,
+		UserRepo:       m.user,
+		EscalationRepo: m.escalation,
+		CategoryRepo:   m.category,
+		ViolationRepo:  m.violation,
+		DuplicateRepo:  m.duplicate,
+		FraudRepo:      m.fraud,
+		AllocationRepo: m.allocation,
+		AdvanceRepo:    m.advance,
+		ApprovalPolicy: domain.ApprovalPolicy{Tiers: domain.DefaultApprovalTiers},
+		Scope:          scope.NewApproverScope(m.user, m.delegation, false),
+		Segregation:    m.segregation,
+		RuleEngine:     m.rules,
+		Duplicates:     m.duplicates,
+		Splits:         m.splits,
+		Converter:      m.converter,
+		Allocations:    m.allocations,
+		Budgets:        m.budgets,
+		Transactor:     m.tx,
+	}
+}
+
+func (m *useCaseMocks) expectCategory(category *domain.Category) {
+	m.category.On("FindByID", mock.Anything, category.ID).Return(category, nil)
+}
+
+// expectViolations makes the rule engine report the given violations for any expense.
+func (m *useCaseMocks) expectViolations(violations ...*domain.RuleViolation) {
+	m.rules.On("Evaluate", mock.Anything, mock.Anything).Return(violations, nil)
+}
+
+func (m *useCaseMocks) allowSegregation() {
+	m.segregation.On("CheckApprover", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+}
+
+func (m *useCaseMocks) expectApprover(id int, role domain.Role) {
+	m.user.On("FindByID", mock.Anything, id).Return(&domain.User{ID: id, Role: role}, nil)
+}
+
+func (m *useCaseMocks) expectReports(managerID int, reportIDs ...int) {
+	m.user.On("FindReportIDs", mock.Anything, managerID, false).Return(reportIDs, nil)
+}
+
+func (m *useCaseMocks) expectDelegations(delegateID int, delegations ...*domain.Delegation) {
+	m.delegation.On("FindActiveByDelegateID", mock.Anything, delegateID, mock.AnythingOfType("time.Time")).Return(delegations, nil)
+}
+
+func (m *useCaseMocks) expectEscalations(userID int, escalations ...*domain.Escalation) {
+	m.escalation.On("FindPendingByEscalatedToID", mock.Anything, userID).Return(escalations, nil)
+}
+
+func (m *useCaseMocks) expectExpenseEscalations(expenseID int, escalations ...*domain.Escalation) {
+	m.escalation.On("FindByExpenseID", mock.Anything, expenseID).Return(escalations, nil)
+}
+
 func TestCreateExpense(t *testing.T) {
 	ctx := context.Background()
 	userID := 1
 	amountIDR := 10000
 	description := "test"
 	receiptURL := "test"
+	meals := &domain.Category{ID: 3, Name: "Meals", MinAmountIDR: 10000, MaxAmountIDR: 500000, ReceiptRequired: true, Active: true}
+	newExpense := func(categoryID, amountIDR int, description, receiptURL string) *domain.Expense {
+		return &domain.Expense{UserID: userID, CategoryID: categoryID, AmountIDR: amountIDR, Description: description, ReceiptURL: receiptURL}
+	}
 
 	t.Run("success", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
-
-		mockExpense.On("Create", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return(nil).Once()
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(meals)
+		m.expectViolations()
+		m.expense.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.CategoryID == meals.ID
+		})).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Expense).Status = domain.ExpenseStatusAutoApproved
+		}).Once()
+		m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.FromStatus == nil && h.ToStatus == domain.ExpenseStatusAutoApproved &&
+				h.ActorType == domain.ActorTypeUser && *h.ActorID == userID
+		})).Return(nil).Once()
 
-		result, err := uc.CreateExpense(ctx, userID, amountIDR, description, receiptURL)
+		result, err := uc.CreateExpense(ctx, newExpense(meals.ID, amountIDR, description, receiptURL), false)
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		require.Equal(t, userID, result.UserID)
+		require.Equal(t, domain.DateOf(time.Now()), result.IncurredOn)
+		m.history.AssertExpectations(t)
 	})
 
-	t.Run("invalid amount", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
+	t.Run("split claim needs approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		flag := &domain.FraudFlag{Kind: domain.FraudThresholdSplit, RelatedExpenseIDs: []int{4}, Explanation: "split"}
+		m.splits = new(mocks.SplitDetector)
+		m.splits.On("Detect", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return(flag, nil).Once()
+		uc := m.useCase()
+		m.expectCategory(meals)
+		m.expectViolations()
+		m.expense.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Status == domain.ExpenseStatusAwaitingApproval && e.RequiresApproval
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		result, err := uc.CreateExpense(ctx, newExpense(meals.ID, 400000, description, receiptURL), false)
+		require.NoError(t, err)
+		require.Equal(t, []*domain.FraudFlag{flag}, result.FraudFlags)
+	})
+
+	t.Run("budget warning does not hold the expense", func(t *testing.T) {
+		m := newUseCaseMocks()
+		warning := &domain.BudgetWarning{BudgetID: 7, Level: domain.BudgetNearLimit}
+		m.budgets = new(mocks.BudgetChecker)
+		m.budgets.On("Check", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return([]*domain.BudgetWarning{warning}, nil).Once()
+		uc := m.useCase()
+		m.expectCategory(meals)
+		m.expectViolations()
+		m.expense.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Status == domain.ExpenseStatusAutoApproved && !e.RequiresFinanceApproval
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		result, err := uc.CreateExpense(ctx, newExpense(meals.ID, 400000, description, receiptURL), false)
+		require.NoError(t, err)
+		require.Equal(t, []*domain.BudgetWarning{warning}, result.BudgetWarnings)
+	})
+
+	t.Run("over budget needs finance approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.budgets = new(mocks.BudgetChecker)
+		m.budgets.On("Check", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return([]*domain.BudgetWarning{{BudgetID: 7, Level: domain.BudgetExceeded}}, nil).Once()
+		deps := m.deps()
+		deps.ApprovalPolicy.FinanceApprovalOverBudget = true
+		uc := NewExpenseUseCase(deps)
+		m.expectCategory(meals)
+		m.expectViolations()
+		m.expense.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Status == domain.ExpenseStatusAwaitingApproval && e.RequiresApproval && e.RequiresFinanceApproval
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		_, err := uc.CreateExpense(ctx, newExpense(meals.ID, 400000, description, receiptURL), false)
+		require.NoError(t, err)
+		m.expense.AssertExpectations(t)
+	})
 
-		result, err := uc.CreateExpense(ctx, userID, domain.MinExpenseAmount-1, description, receiptURL)
+	t.Run("over budget only warns unless configured", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.budgets = new(mocks.BudgetChecker)
+		m.budgets.On("Check", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return([]*domain.BudgetWarning{{BudgetID: 7, Level: domain.BudgetExceeded}}, nil).Once()
+		uc := m.useCase()
+		m.expectCategory(meals)
+		m.expectViolations()
+		m.expense.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Status == domain.ExpenseStatusAutoApproved && !e.RequiresFinanceApproval
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		_, err := uc.CreateExpense(ctx, newExpense(meals.ID, 400000, description, receiptURL), false)
+		require.NoError(t, err)
+		m.expense.AssertExpectations(t)
+	})
+
+	t.Run("foreign currency routed on its IDR amount", func(t *testing.T) {
+		m := newUseCaseMocks()
+		lodging := &domain.Category{ID: 5, Name: "Lodging", MinAmountIDR: 10000, MaxAmountIDR: 10000000, Active: true}
+		m.converter = new(mocks.CurrencyConverter)
+		m.converter.On("Convert", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Currency == "USD" && !e.IncurredOn.IsZero()
+		})).Return(nil).Run(func(args mock.Arguments) {
+			e := args.Get(1).(*domain.Expense)
+			e.FXRate = 15600
+			e.AmountIDR = domain.ConvertToIDR(e.OriginalAmount, e.FXRate)
+		}).Once()
+		uc := m.useCase()
+		m.expectCategory(lodging)
+		m.expectViolations()
+		m.expense.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.AmountIDR == 1560000 && e.RequiresApproval
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		exp := &domain.Expense{UserID: userID, CategoryID: lodging.ID, Currency: "USD", OriginalAmount: 100, Description: "hotel", ReceiptURL: receiptURL}
+		result, err := uc.CreateExpense(ctx, exp, false)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, result.Status)
+	})
+
+	t.Run("split across cost centers", func(t *testing.T) {
+		m := newUseCaseMocks()
+		allocations := []*domain.Allocation{
+			{CostCenterCode: "FIN", Percent: 50},
+			{CostCenterCode: "MKT", ProjectCode: "PRJ-01", Percent: 50},
+		}
+		m.allocation = new(mocks.AllocationRepository)
+		m.allocation.On("Replace", mock.Anything, 12, allocations).Return(nil).Once()
+		uc := m.useCase()
+		m.expectCategory(meals)
+		m.expectViolations()
+		m.expense.On("Create", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Expense).ID = 12
+		}).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		exp := newExpense(meals.ID, 100000, description, receiptURL)
+		exp.Allocations = allocations
+		_, err := uc.CreateExpense(ctx, exp, false)
+		require.NoError(t, err)
+		m.allocation.AssertExpectations(t)
+	})
+
+	t.Run("inactive cost center", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.allocations = new(mocks.AllocationChecker)
+		m.allocations.On("Check", mock.Anything, mock.AnythingOfType("*domain.Expense")).
+			Return(&domain.AllocationCodeError{Kind: "cost center", Code: "OLD"}).Once()
+		uc := m.useCase()
+		m.expectCategory(meals)
+
+		exp := newExpense(meals.ID, amountIDR, description, receiptURL)
+		exp.Allocations = []*domain.Allocation{{CostCenterCode: "OLD", Percent: 100}}
+		result, err := uc.CreateExpense(ctx, exp, false)
+		require.ErrorIs(t, err, domain.ErrInactiveAllocationCode)
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("no exchange rate", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.converter = new(mocks.CurrencyConverter)
+		m.converter.On("Convert", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return(domain.ErrFXRateNotFound).Once()
+		uc := m.useCase()
+
+		exp := &domain.Expense{UserID: userID, CategoryID: meals.ID, Currency: "EUR", OriginalAmount: 20, Description: description, ReceiptURL: receiptURL}
+		result, err := uc.CreateExpense(ctx, exp, false)
+		require.ErrorIs(t, err, domain.ErrFXRateNotFound)
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("draft", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(meals)
+		m.expectViolations()
+		m.expense.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Status == domain.ExpenseStatusDraft
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.ToStatus == domain.ExpenseStatusDraft && h.Reason == "draft created"
+		})).Return(nil).Once()
+
+		result, err := uc.CreateExpense(ctx, newExpense(meals.ID, amountIDR, description, ""), true)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusDraft, result.Status)
+	})
+
+	t.Run("amount outside category limits", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(meals)
+
+		result, err := uc.CreateExpense(ctx, newExpense(meals.ID, meals.MaxAmountIDR+1, description, receiptURL), false)
 		require.ErrorIs(t, err, domain.ErrInvalidAmount)
+		require.EqualError(t, err, "Meals expenses must be between 10000 and 500000 IDR")
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ppn does not add up", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(meals)
+
+		exp := newExpense(meals.ID, 111000, description, receiptURL)
+		exp.NetAmountIDR, exp.TaxAmountIDR, exp.TaxRatePercent = 100000, 12000, 12
+		result, err := uc.CreateExpense(ctx, exp, false)
+		require.ErrorIs(t, err, domain.ErrTaxMismatch)
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing receipt", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(meals)
+
+		result, err := uc.CreateExpense(ctx, newExpense(meals.ID, amountIDR, description, ""), false)
+		require.ErrorIs(t, err, domain.ErrMissingReceipt)
+		require.Nil(t, result)
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.category.On("FindByID", mock.Anything, 42).Return((*domain.Category)(nil), nil).Once()
+
+		result, err := uc.CreateExpense(ctx, newExpense(42, amountIDR, description, receiptURL), false)
+		require.ErrorIs(t, err, domain.ErrCategoryNotFound)
+		require.Nil(t, result)
+	})
+
+	t.Run("inactive category", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(&domain.Category{ID: 4, Name: "Gifts", MinAmountIDR: 10000, MaxAmountIDR: 100000})
+
+		result, err := uc.CreateExpense(ctx, newExpense(4, amountIDR, description, receiptURL), false)
+		require.ErrorIs(t, err, domain.ErrInactiveCategory)
 		require.Nil(t, result)
-		mockExpense.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
 
 	t.Run("missing description", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
+		m := newUseCaseMocks()
+		uc := m.useCase()
 
-		result, err := uc.CreateExpense(ctx, userID, amountIDR, "", receiptURL)
+		result, err := uc.CreateExpense(ctx, newExpense(meals.ID, amountIDR, "", receiptURL), false)
 		require.ErrorIs(t, err, domain.ErrMissingDescription)
 		require.Nil(t, result)
-		mockExpense.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.expense.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("blocked by policy", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(meals)
+		m.expectViolations(&domain.RuleViolation{RuleID: 1, RuleName: "Meal cap", Action: domain.RuleActionBlock, Message: "amount exceeds IDR 5000"})
+
+		result, err := uc.CreateExpense(ctx, newExpense(meals.ID, amountIDR, description, receiptURL), false)
+		require.ErrorIs(t, err, domain.ErrPolicyBlocked)
+		require.EqualError(t, err, "expense blocked by policy: Meal cap: amount exceeds IDR 5000")
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("blocking rule only warns on drafts", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(meals)
+		m.expectViolations(&domain.RuleViolation{RuleID: 1, RuleName: "Meal cap", Action: domain.RuleActionBlock, Message: "amount exceeds IDR 5000"})
+		m.expense.On("Create", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := uc.CreateExpense(ctx, newExpense(meals.ID, amountIDR, description, ""), true)
+		require.NoError(t, err)
+		require.Len(t, result.PolicyViolations, 1)
+	})
+
+	t.Run("rule forces manual approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		weekend := &domain.RuleViolation{RuleID: 2, RuleName: "Weekend expenses", Action: domain.RuleActionRequireApproval, Message: "incurred on a Saturday"}
+		m.expectCategory(meals)
+		m.expectViolations(weekend)
+		m.expense.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Status == domain.ExpenseStatusAwaitingApproval && e.RequiresApproval && !e.AutoApproved
+		})).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Expense).ID = 12
+		}).Once()
+		m.history.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+		expense := newExpense(meals.ID, amountIDR, description, receiptURL)
+		expense.IncurredOn = time.Date(2026, 10, 17, 15, 30, 0, 0, time.UTC)
+		result, err := uc.CreateExpense(ctx, expense, false)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), result.IncurredOn)
+		m.violation.AssertCalled(t, "Replace", mock.Anything, 12, []*domain.RuleViolation{weekend})
+	})
+
+	t.Run("filed against an outstanding advance", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(meals)
+		m.expectViolations()
+		m.advance.On("FindByID", mock.Anything, 4).Return(&domain.CashAdvance{ID: 4, UserID: userID, Status: domain.AdvanceStatusOutstanding}, nil).Once()
+		m.expense.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return *e.AdvanceID == 4
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+		expense := newExpense(meals.ID, amountIDR, description, receiptURL)
+		advanceID := 4
+		expense.AdvanceID = &advanceID
+		_, err := uc.CreateExpense(ctx, expense, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("advance not outstanding", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(meals)
+		m.advance.On("FindByID", mock.Anything, 4).Return(&domain.CashAdvance{ID: 4, UserID: userID, Status: domain.AdvanceStatusRepaymentDue}, nil).Once()
+
+		expense := newExpense(meals.ID, amountIDR, description, receiptURL)
+		advanceID := 4
+		expense.AdvanceID = &advanceID
+		_, err := uc.CreateExpense(ctx, expense, false)
+		require.ErrorIs(t, err, domain.ErrAdvanceNotLinkable)
+		m.expense.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("someone else's advance", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(meals)
+		m.advance.On("FindByID", mock.Anything, 4).Return(&domain.CashAdvance{ID: 4, UserID: 2, Status: domain.AdvanceStatusOutstanding}, nil).Once()
+
+		expense := newExpense(meals.ID, amountIDR, description, receiptURL)
+		advanceID := 4
+		expense.AdvanceID = &advanceID
+		_, err := uc.CreateExpense(ctx, expense, false)
+		require.ErrorIs(t, err, domain.ErrAdvanceNotLinkable)
 	})
 
 	t.Run("repository error", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
+		m := newUseCaseMocks()
+		uc := m.useCase()
 		expectedErr := errors.New("db failed")
-		mockExpense.On("Create", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return(expectedErr).Once()
+		m.expectCategory(meals)
+		m.expectViolations()
+		m.expense.On("Create", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return(expectedErr).Once()
 
-		result, err := uc.CreateExpense(ctx, userID, amountIDR, description, receiptURL)
+		result, err := uc.CreateExpense(ctx, newExpense(meals.ID, amountIDR, description, receiptURL), false)
 		require.ErrorIs(t, err, expectedErr)
 		require.Nil(t, result)
 	})
 }
 
+func TestUpdateExpense(t *testing.T) {
+	ctx := context.Background()
+	expenseID := 5
+	userID := 1
+	description := "taxi to client office"
+
+	t.Run("draft", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 50000, Description: "taxi", Status: domain.ExpenseStatusDraft}, nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations()
+		m.expense.On("Update", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Description == description && e.AmountIDR == 50000
+		}), domain.ExpenseStatusDraft).Return(nil).Once()
+
+		result, err := uc.UpdateExpense(ctx, expenseID, userID, domain.ExpenseChanges{Description: &description})
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusDraft, result.Status)
+		m.tx.AssertCalled(t, "WithinTx", mock.Anything, mock.Anything)
+	})
+
+	t.Run("allocations fail to save", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.allocation = new(mocks.AllocationRepository)
+		m.allocation.On("FindByExpenseIDs", mock.Anything, []int{expenseID}).Return(map[int][]*domain.Allocation{}, nil).Once()
+		m.allocation.On("Replace", mock.Anything, expenseID, mock.Anything).Return(errors.New("db down")).Once()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 50000, Description: "taxi", Status: domain.ExpenseStatusDraft}, nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations()
+		m.expense.On("Update", mock.Anything, mock.Anything, domain.ExpenseStatusDraft).Return(nil).Once()
+
+		result, err := uc.UpdateExpense(ctx, expenseID, userID, domain.ExpenseChanges{Description: &description})
+		require.Error(t, err)
+		require.Nil(t, result)
+		m.violation.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("violations fail to save", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.violation = new(mocks.ViolationRepository)
+		m.violation.On("Replace", mock.Anything, expenseID, mock.Anything).Return(errors.New("db down")).Once()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 50000, Description: "taxi", Status: domain.ExpenseStatusDraft}, nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations(&domain.RuleViolation{RuleName: "weekend", Action: domain.RuleActionWarn})
+		m.expense.On("Update", mock.Anything, mock.Anything, domain.ExpenseStatusDraft).Return(nil).Once()
+
+		// The expense update is only committed along with its violations.
+		result, err := uc.UpdateExpense(ctx, expenseID, userID, domain.ExpenseChanges{Description: &description})
+		require.Error(t, err)
+		require.Nil(t, result)
+		m.tx.AssertCalled(t, "WithinTx", mock.Anything, mock.Anything)
+	})
+
+	t.Run("submitted expense", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 50000, Description: "taxi", Status: domain.ExpenseStatusAutoApproved}, nil).Once()
+
+		result, err := uc.UpdateExpense(ctx, expenseID, userID, domain.ExpenseChanges{Description: &description})
+		require.ErrorIs(t, err, domain.ErrExpenseNotEditable)
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("blank description", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		blank := ""
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 50000, Description: "taxi", Status: domain.ExpenseStatusChangesRequested}, nil).Once()
+
+		result, err := uc.UpdateExpense(ctx, expenseID, userID, domain.ExpenseChanges{Description: &blank})
+		require.ErrorIs(t, err, domain.ErrMissingDescription)
+		require.Nil(t, result)
+	})
+
+	t.Run("new amount no longer matches the split", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.allocation = new(mocks.AllocationRepository)
+		m.allocation.On("FindByExpenseIDs", mock.Anything, []int{expenseID}).Return(map[int][]*domain.Allocation{
+			expenseID: {{ExpenseID: expenseID, CostCenterCode: "FIN", AmountIDR: 50000}},
+		}, nil).Once()
+		m.allocations = new(mocks.AllocationChecker)
+		m.allocations.On("Check", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.AmountIDR == 75000 && len(e.Allocations) == 1
+		})).Return(domain.ErrInvalidAllocation).Once()
+		uc := m.useCase()
+		amount := 75000
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 50000, Description: "taxi", Status: domain.ExpenseStatusDraft}, nil).Once()
+		m.expectCategory(travel)
+
+		result, err := uc.UpdateExpense(ctx, expenseID, userID, domain.ExpenseChanges{AmountIDR: &amount})
+		require.ErrorIs(t, err, domain.ErrInvalidAllocation)
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCheckReportLine(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("policy asks for approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(travel)
+		m.expectViolations(&domain.RuleViolation{RuleName: "weekend", Action: domain.RuleActionRequireApproval})
+		line := &domain.Expense{ID: 5, CategoryID: travel.ID, AmountIDR: 50000, Description: "taxi", ReceiptURL: "u", Status: domain.ExpenseStatusDraft}
+
+		requireApproval, err := uc.CheckReportLine(ctx, line)
+		require.NoError(t, err)
+		require.True(t, requireApproval)
+		require.Equal(t, domain.ExpenseStatusDraft, line.Status)
+	})
+
+	t.Run("missing receipt", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectCategory(&domain.Category{ID: 9, Name: "Lodging", MinAmountIDR: 10000, MaxAmountIDR: 10000000, ReceiptRequired: true, Active: true})
+
+		_, err := uc.CheckReportLine(ctx, &domain.Expense{ID: 5, CategoryID: 9, AmountIDR: 500000, Description: "hotel", Status: domain.ExpenseStatusDraft})
+		require.ErrorIs(t, err, domain.ErrMissingReceipt)
+	})
+
+	t.Run("split claim asks for approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		flag := &domain.FraudFlag{Kind: domain.FraudThresholdSplit, RelatedExpenseIDs: []int{4}, Explanation: "split"}
+		m.splits = new(mocks.SplitDetector)
+		m.splits.On("Detect", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return(flag, nil).Once()
+		uc := m.useCase()
+		m.expectCategory(travel)
+		m.expectViolations()
+		line := &domain.Expense{ID: 5, CategoryID: travel.ID, AmountIDR: 400000, Description: "taxi", ReceiptURL: "u", Status: domain.ExpenseStatusDraft}
+
+		requireApproval, err := uc.CheckReportLine(ctx, line)
+		require.NoError(t, err)
+		require.True(t, requireApproval)
+		require.Equal(t, []*domain.FraudFlag{flag}, line.FraudFlags)
+		require.Equal(t, domain.ExpenseStatusDraft, line.Status)
+	})
+
+	t.Run("over budget needs finance approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		warning := &domain.BudgetWarning{BudgetID: 7, Level: domain.BudgetExceeded}
+		m.budgets = new(mocks.BudgetChecker)
+		m.budgets.On("Check", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return([]*domain.BudgetWarning{warning}, nil).Once()
+		deps := m.deps()
+		deps.ApprovalPolicy.FinanceApprovalOverBudget = true
+		uc := NewExpenseUseCase(deps)
+		m.expectCategory(travel)
+		m.expectViolations()
+		line := &domain.Expense{ID: 5, CategoryID: travel.ID, AmountIDR: 400000, Description: "taxi", ReceiptURL: "u", Status: domain.ExpenseStatusDraft}
+
+		requireApproval, err := uc.CheckReportLine(ctx, line)
+		require.NoError(t, err)
+		require.True(t, requireApproval)
+		require.True(t, line.RequiresFinanceApproval)
+		require.Equal(t, []*domain.BudgetWarning{warning}, line.BudgetWarnings)
+	})
+}
+
+func TestRecordReportLine(t *testing.T) {
+	m := newUseCaseMocks()
+	match := &domain.DuplicateMatch{ExpenseID: 5, DuplicateOfID: 3}
+	m.duplicates = new(mocks.DuplicateDetector)
+	m.duplicates.On("Detect", mock.Anything, mock.AnythingOfType("*domain.Expense")).Return([]*domain.DuplicateMatch{match}, nil).Once()
+	uc := m.useCase()
+	line := &domain.Expense{
+		ID:               5,
+		PolicyViolations: []*domain.RuleViolation{{RuleName: "weekend", Action: domain.RuleActionWarn}},
+		FraudFlags:       []*domain.FraudFlag{{Kind: domain.FraudThresholdSplit}},
+	}
+
+	require.NoError(t, uc.RecordReportLine(context.Background(), line))
+	require.Equal(t, []*domain.DuplicateMatch{match}, line.PossibleDuplicates)
+	m.violation.AssertCalled(t, "Replace", mock.Anything, 5, line.PolicyViolations)
+	m.fraud.AssertCalled(t, "Replace", mock.Anything, 5, line.FraudFlags)
+	m.duplicate.AssertCalled(t, "Replace", mock.Anything, 5, []*domain.DuplicateMatch{match})
+}
+
+func TestSubmitExpense(t *testing.T) {
+	ctx := context.Background()
+	expenseID := 5
+	userID := 1
+
+	t.Run("routes to approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 3000000, Description: "hotel", Status: domain.ExpenseStatusDraft}, nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations()
+		m.expense.On("Update", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Status == domain.ExpenseStatusAwaitingApproval && e.RequiresApproval && !e.SubmittedAt.IsZero()
+		}), domain.ExpenseStatusDraft).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return *h.FromStatus == domain.ExpenseStatusDraft && h.ToStatus == domain.ExpenseStatusAwaitingApproval && h.Reason == "submitted"
+		})).Return(nil).Once()
+
+		result, err := uc.SubmitExpense(ctx, expenseID, userID)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, result.Status)
+	})
+
+	t.Run("line of an expense report", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		reportID := 7
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, ReportID: &reportID, Status: domain.ExpenseStatusDraft}, nil).Once()
+
+		result, err := uc.SubmitExpense(ctx, expenseID, userID)
+		require.ErrorIs(t, err, domain.ErrExpenseInReport)
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("flags possible duplicates", func(t *testing.T) {
+		m := newUseCaseMocks()
+		matches := []*domain.DuplicateMatch{{DuplicateOfID: 4, Reason: domain.DuplicateReceipt, Detail: "receipt hotel.pdf was already uploaded to expense 4"}}
+		m.duplicates = new(mocks.DuplicateDetector)
+		m.duplicates.On("Detect", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.ID == expenseID
+		})).Return(matches, nil).Once()
+		m.duplicate = new(mocks.DuplicateRepository)
+		m.duplicate.On("Replace", mock.Anything, expenseID, matches).Return(nil).Once()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 3000000, Description: "hotel", Status: domain.ExpenseStatusDraft}, nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations()
+		m.expense.On("Update", mock.Anything, mock.AnythingOfType("*domain.Expense"), domain.ExpenseStatusDraft).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		result, err := uc.SubmitExpense(ctx, expenseID, userID)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, result.Status)
+		require.Equal(t, matches, result.PossibleDuplicates)
+		m.duplicate.AssertExpectations(t)
+	})
+
+	t.Run("split claim needs approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		flag := &domain.FraudFlag{Kind: domain.FraudThresholdSplit, RelatedExpenseIDs: []int{4}, Explanation: "together with expense 4 the claims add up to IDR 1500000, at or above the IDR 1000000 approval threshold"}
+		m.splits = new(mocks.SplitDetector)
+		m.splits.On("Detect", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.ID == expenseID
+		})).Return(flag, nil).Once()
+		m.fraud = new(mocks.FraudRepository)
+		m.fraud.On("Replace", mock.Anything, expenseID, []*domain.FraudFlag{flag}).Return(nil).Once()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 750000, Description: "hotel", Status: domain.ExpenseStatusDraft}, nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations()
+		m.expense.On("Update", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Status == domain.ExpenseStatusAwaitingApproval && e.RequiresApproval && !e.AutoApproved
+		}), domain.ExpenseStatusDraft).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		result, err := uc.SubmitExpense(ctx, expenseID, userID)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, result.Status)
+		require.Equal(t, []*domain.FraudFlag{flag}, result.FraudFlags)
+		m.fraud.AssertExpectations(t)
+	})
+
+	t.Run("small amount is auto approved", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 50000, Description: "taxi", Status: domain.ExpenseStatusDraft}, nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations()
+		m.expense.On("Update", mock.Anything, mock.AnythingOfType("*domain.Expense"), domain.ExpenseStatusDraft).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		result, err := uc.SubmitExpense(ctx, expenseID, userID)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAutoApproved, result.Status)
+		require.True(t, result.AutoApproved)
+	})
+
+	t.Run("blocked by policy", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 50000, Description: "taxi", Status: domain.ExpenseStatusDraft}, nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations(&domain.RuleViolation{RuleID: 3, RuleName: "Daily cap", Action: domain.RuleActionBlock, Message: "daily total of IDR 1200000 exceeds the IDR 1000000 per-diem cap"})
+
+		result, err := uc.SubmitExpense(ctx, expenseID, userID)
+		require.ErrorIs(t, err, domain.ErrPolicyBlocked)
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing receipt", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		lodging := &domain.Category{ID: 6, Name: "Lodging", MinAmountIDR: 100000, MaxAmountIDR: 20000000, ReceiptRequired: true, Active: true}
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: lodging.ID, AmountIDR: 3000000, Description: "hotel", Status: domain.ExpenseStatusDraft}, nil).Once()
+		m.expectCategory(lodging)
+
+		result, err := uc.SubmitExpense(ctx, expenseID, userID)
+		require.ErrorIs(t, err, domain.ErrMissingReceipt)
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("already submitted", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, CategoryID: travel.ID, AmountIDR: 50000, Status: domain.ExpenseStatusAutoApproved}, nil).Once()
+
+		result, err := uc.SubmitExpense(ctx, expenseID, userID)
+		require.ErrorIs(t, err, domain.ErrInvalidExpenseStatus)
+		require.Nil(t, result)
+	})
+}
+
+func TestCancelExpense(t *testing.T) {
+	ctx := context.Background()
+	expenseID := 5
+	userID := 1
+
+	t.Run("approved expense before payment", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, Status: domain.ExpenseStatusApproved}, nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, expenseID, domain.ExpenseStatusApproved, domain.ExpenseStatusCancelled, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return *h.FromStatus == domain.ExpenseStatusApproved && h.ToStatus == domain.ExpenseStatusCancelled && *h.ActorID == userID
+		})).Return(nil).Once()
+
+		result, err := uc.CancelExpense(ctx, expenseID, userID)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusCancelled, result.Status)
+	})
+
+	t.Run("payment already started", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, Status: domain.ExpenseStatusProcessing}, nil).Once()
+
+		result, err := uc.CancelExpense(ctx, expenseID, userID)
+		require.ErrorIs(t, err, domain.ErrInvalidExpenseStatus)
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("claimed by payment worker meanwhile", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, Status: domain.ExpenseStatusAutoApproved}, nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, expenseID, domain.ExpenseStatusAutoApproved, domain.ExpenseStatusCancelled, mock.AnythingOfType("*time.Time")).
+			Return(domain.ErrStatusConflict).Once()
+
+		result, err := uc.CancelExpense(ctx, expenseID, userID)
+		require.ErrorIs(t, err, domain.ErrStatusConflict)
+		require.Nil(t, result)
+		m.history.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: userID, Status: domain.ExpenseStatusDraft}, nil).Once()
+
+		result, err := uc.CancelExpense(ctx, expenseID, 99)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		require.Nil(t, result)
+	})
+}
+
 func TestGetExpenseByID(t *testing.T) {
 	ctx := context.Background()
 	userID := 1
 	expenseID := 1
 
 	t.Run("success", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
+		m := newUseCaseMocks()
+		uc := m.useCase()
 		resp := &domain.Expense{
 			ID:               expenseID,
 			UserID:           userID,
@@ -85,18 +922,30 @@ func TestGetExpenseByID(t *testing.T) {
 			RequiresApproval: false,
 			AutoApproved:     false,
 		}
-		mockExpense.On("FindByID", mock.Anything, expenseID).Return(resp, nil).Once()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(resp, nil).Once()
 
 		result, err := uc.GetExpenseByID(ctx, expenseID, userID)
 		require.NoError(t, err)
 		require.Equal(t, resp, result)
 	})
 
+	t.Run("with policy violations", func(t *testing.T) {
+		m := newUseCaseMocks()
+		violations := []*domain.RuleViolation{{ExpenseID: expenseID, RuleID: 1, RuleName: "Weekend expenses", Action: domain.RuleActionWarn, Message: "incurred on a Sunday"}}
+		m.violation = new(mocks.ViolationRepository)
+		m.violation.On("FindByExpenseIDs", mock.Anything, []int{expenseID}).Return(map[int][]*domain.RuleViolation{expenseID: violations}, nil).Once()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(&domain.Expense{ID: expenseID, UserID: userID, Status: domain.ExpenseStatusAutoApproved}, nil).Once()
+
+		result, err := uc.GetExpenseByID(ctx, expenseID, userID)
+		require.NoError(t, err)
+		require.Equal(t, violations, result.PolicyViolations)
+	})
+
 	t.Run("not found", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
-		mockExpense.On("FindByID", mock.Anything, expenseID).Return((*domain.Expense)(nil), nil).Once()
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return((*domain.Expense)(nil), nil).Once()
 
 		result, err := uc.GetExpenseByID(ctx, expenseID, userID)
 		require.ErrorIs(t, err, domain.ErrExpenseNotFound)
@@ -104,9 +953,8 @@ func TestGetExpenseByID(t *testing.T) {
 	})
 
 	t.Run("unauthorized", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
+		m := newUseCaseMocks()
+		uc := m.useCase()
 		resp := &domain.Expense{
 			ID:               expenseID,
 			UserID:           2,
@@ -117,7 +965,7 @@ func TestGetExpenseByID(t *testing.T) {
 			RequiresApproval: false,
 			AutoApproved:     false,
 		}
-		mockExpense.On("FindByID", mock.Anything, expenseID).Return(resp, nil).Once()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(resp, nil).Once()
 
 		result, err := uc.GetExpenseByID(ctx, expenseID, userID)
 		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
@@ -125,11 +973,10 @@ func TestGetExpenseByID(t *testing.T) {
 	})
 
 	t.Run("repository error", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
+		m := newUseCaseMocks()
+		uc := m.useCase()
 		expectedErr := errors.New("db failed")
-		mockExpense.On("FindByID", mock.Anything, expenseID).Return((*domain.Expense)(nil), expectedErr).Once()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return((*domain.Expense)(nil), expectedErr).Once()
 
 		result, err := uc.GetExpenseByID(ctx, expenseID, userID)
 		require.ErrorIs(t, err, expectedErr)
@@ -139,14 +986,13 @@ func TestGetExpenseByID(t *testing.T) {
 
 func TestGetUserExpenses(t *testing.T) {
 	ctx := context.Background()
-	mockExpense := new(mocks.ExpenseRepository)
-	mockApproval := new(mocks.ApprovalRepository)
-	uc := NewExpenseUseCase(mockExpense, mockApproval)
+	m := newUseCaseMocks()
+	uc := m.useCase()
 	userID := 10
 	status := domain.ExpenseStatusApproved
 	expected := []*domain.Expense{{ID: 1, UserID: userID, Status: status}}
 
-	mockExpense.On("FindByUserID", mock.Anything, userID, status, 10, 0).Return(expected, nil).Once()
+	m.expense.On("FindByUserID", mock.Anything, userID, status, 10, 0).Return(expected, nil).Once()
 
 	result, err := uc.GetUserExpenses(ctx, userID, status, 0, 0)
 	require.NoError(t, err)
@@ -165,6 +1011,7 @@ func testProcessApprovalFlow(t *testing.T, approve bool) {
 	ctx := context.Background()
 	expenseID := 9
 	approverID := 3
+	submitterID := 20
 	notes := "ok"
 	expenseStatus := domain.ExpenseStatusApproved
 	approvalStatus := domain.ApprovalStatusApproved
@@ -174,15 +1021,24 @@ func testProcessApprovalFlow(t *testing.T, approve bool) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
-		mockExpense.On("FindByID", mock.Anything, expenseID).
-			Return(&domain.Expense{ID: expenseID, Status: domain.ExpenseStatusAwaitingApproval}, nil).Once()
-		mockApproval.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Approval) bool {
-			return a.ExpenseID == expenseID && a.ApproverID == approverID && a.Status == approvalStatus
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: submitterID, AmountIDR: 2000000, Status: domain.ExpenseStatusAwaitingApproval}, nil).Once()
+		m.expectApprover(approverID, domain.RoleManager)
+		m.expectDelegations(approverID)
+		m.expectReports(approverID, submitterID)
+		m.allowSegregation()
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, expenseID, domain.ExpenseStatusAwaitingApproval, expenseStatus, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		m.approval.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Approval) bool {
+			return a.ExpenseID == expenseID && a.ApproverID == approverID && a.Status == approvalStatus &&
+				a.Level == domain.ApprovalLevelManager
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return *h.FromStatus == domain.ExpenseStatusAwaitingApproval && h.ToStatus == expenseStatus &&
+				*h.ActorID == approverID && h.Reason == notes
 		})).Return(nil).Once()
-		mockExpense.On("UpdateStatus", mock.Anything, expenseID, expenseStatus, mock.AnythingOfType("*time.Time")).Return(nil).Once()
 
 		var err error
 		if approve {
@@ -194,10 +1050,9 @@ func testProcessApprovalFlow(t *testing.T, approve bool) {
 	})
 
 	t.Run("expense not found", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
-		mockExpense.On("FindByID", mock.Anything, expenseID).Return((*domain.Expense)(nil), nil).Once()
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return((*domain.Expense)(nil), nil).Once()
 
 		var err error
 		if approve {
@@ -206,14 +1061,13 @@ func testProcessApprovalFlow(t *testing.T, approve bool) {
 			err = uc.RejectExpense(ctx, expenseID, approverID, notes)
 		}
 		require.ErrorIs(t, err, domain.ErrExpenseNotFound)
-		mockApproval.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.approval.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
 
 	t.Run("invalid expense status", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
-		mockExpense.On("FindByID", mock.Anything, expenseID).
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
 			Return(&domain.Expense{ID: expenseID, Status: domain.ExpenseStatusApproved}, nil).Once()
 
 		var err error
@@ -223,17 +1077,113 @@ func testProcessApprovalFlow(t *testing.T, approve bool) {
 			err = uc.RejectExpense(ctx, expenseID, approverID, notes)
 		}
 		require.ErrorIs(t, err, domain.ErrInvalidExpenseStatus)
-		mockApproval.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.approval.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("submitter outside reporting line", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: submitterID, Status: domain.ExpenseStatusAwaitingApproval}, nil).Once()
+		m.expectApprover(approverID, domain.RoleManager)
+		m.expectDelegations(approverID)
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.expectExpenseEscalations(expenseID)
+		m.expectReports(approverID, 21, 22)
+
+		var err error
+		if approve {
+			err = uc.ApproveExpense(ctx, expenseID, approverID, notes)
+		} else {
+			err = uc.RejectExpense(ctx, expenseID, approverID, notes)
+		}
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		m.expense.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		m.approval.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("segregation of duties violation", func(t *testing.T) {
+		if !approve {
+			t.Skip("segregation of duties only applies to approvals")
+		}
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: submitterID, Status: domain.ExpenseStatusAwaitingApproval}, nil).Once()
+		m.expectApprover(approverID, domain.RoleManager)
+		m.expectDelegations(approverID)
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.expectReports(approverID, submitterID)
+		violation := &domain.PolicyViolationError{Reason: "too many approvals in a row"}
+		m.segregation.On("CheckApprover", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.ID == expenseID
+		}), domain.ApprovalLevelManager, approverID).Return(violation).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, approverID, notes)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		require.ErrorAs(t, err, &violation)
+		m.expense.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		m.approval.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("advance settled while awaiting approval", func(t *testing.T) {
+		if !approve {
+			t.Skip("a settled advance only blocks approvals")
+		}
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		advanceID := 4
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: submitterID, AdvanceID: &advanceID, Status: domain.ExpenseStatusAwaitingApproval}, nil).Once()
+		m.expectApprover(approverID, domain.RoleManager)
+		m.expectDelegations(approverID)
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.expectReports(approverID, submitterID)
+		m.advance.On("FindByID", mock.Anything, advanceID).
+			Return(&domain.CashAdvance{ID: advanceID, UserID: submitterID, Status: domain.AdvanceStatusRepaymentDue}, nil).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, approverID, notes)
+		require.ErrorIs(t, err, domain.ErrAdvanceNotLinkable)
+		m.expense.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		m.approval.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("concurrent decision", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: submitterID, Status: domain.ExpenseStatusAwaitingApproval}, nil).Once()
+		m.expectApprover(approverID, domain.RoleManager)
+		m.expectDelegations(approverID)
+		m.expectReports(approverID, submitterID)
+		m.allowSegregation()
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.approval.On("Create", mock.Anything, mock.AnythingOfType("*domain.Approval")).Return(nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, expenseID, domain.ExpenseStatusAwaitingApproval, expenseStatus, mock.AnythingOfType("*time.Time")).
+			Return(domain.ErrStatusConflict).Once()
+
+		var err error
+		if approve {
+			err = uc.ApproveExpense(ctx, expenseID, approverID, notes)
+		} else {
+			err = uc.RejectExpense(ctx, expenseID, approverID, notes)
+		}
+		require.ErrorIs(t, err, domain.ErrStatusConflict)
+		m.history.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
 
 	t.Run("approval repository error", func(t *testing.T) {
-		mockExpense := new(mocks.ExpenseRepository)
-		mockApproval := new(mocks.ApprovalRepository)
-		uc := NewExpenseUseCase(mockExpense, mockApproval)
+		m := newUseCaseMocks()
+		uc := m.useCase()
 		expectedErr := errors.New("approval create failed")
-		mockExpense.On("FindByID", mock.Anything, expenseID).
-			Return(&domain.Expense{ID: expenseID, Status: domain.ExpenseStatusAwaitingApproval}, nil).Once()
-		mockApproval.On("Create", mock.Anything, mock.AnythingOfType("*domain.Approval")).Return(expectedErr).Once()
+		m.expense.On("FindByID", mock.Anything, expenseID).
+			Return(&domain.Expense{ID: expenseID, UserID: submitterID, Status: domain.ExpenseStatusAwaitingApproval}, nil).Once()
+		m.expectApprover(approverID, domain.RoleManager)
+		m.expectDelegations(approverID)
+		m.expectReports(approverID, submitterID)
+		m.allowSegregation()
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.approval.On("Create", mock.Anything, mock.AnythingOfType("*domain.Approval")).Return(expectedErr).Once()
 
 		var err error
 		if approve {
@@ -242,19 +1192,746 @@ func testProcessApprovalFlow(t *testing.T, approve bool) {
 			err = uc.RejectExpense(ctx, expenseID, approverID, notes)
 		}
 		require.ErrorIs(t, err, expectedErr)
-		mockExpense.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		m.expense.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestApproveExpenseMultiLevel(t *testing.T) {
+	ctx := context.Background()
+	expenseID := 12
+	managerID := 3
+	directorID := 4
+	submitterID := 20
+	amount := 12000000
+	awaiting := &domain.Expense{ID: expenseID, UserID: submitterID, AmountIDR: amount, Status: domain.ExpenseStatusAwaitingApproval}
+	managerApproval := &domain.Approval{ExpenseID: expenseID, ApproverID: managerID, Level: domain.ApprovalLevelManager, Status: domain.ApprovalStatusApproved}
+
+	t.Run("first level keeps expense awaiting", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(managerID, domain.RoleManager)
+		m.expectDelegations(managerID)
+		m.expectReports(managerID, submitterID)
+		m.allowSegregation()
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.approval.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Approval) bool {
+			return a.Level == domain.ApprovalLevelManager && a.Status == domain.ApprovalStatusApproved
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.Event == domain.HistoryEventLevelApproved && h.ToStatus == domain.ExpenseStatusAwaitingApproval
+		})).Return(nil).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, managerID, "ok")
+		require.NoError(t, err)
+		m.expense.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		m.history.AssertExpectations(t)
+	})
+
+	t.Run("manager cannot sign finance level", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(managerID, domain.RoleManager)
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{managerApproval}, nil).Once()
+		m.expectExpenseEscalations(expenseID)
+
+		err := uc.ApproveExpense(ctx, expenseID, managerID, "again")
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		m.approval.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("finance director cannot skip manager", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(directorID, domain.RoleFinanceDirector)
+		m.expectDelegations(directorID)
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.expectExpenseEscalations(expenseID)
+
+		err := uc.ApproveExpense(ctx, expenseID, directorID, "ok")
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+	})
+
+	t.Run("last level approves expense", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(directorID, domain.RoleFinanceDirector)
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{managerApproval}, nil).Once()
+		m.allowSegregation()
+		m.expense.On("UpdateStatus", mock.Anything, expenseID, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusApproved, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		m.approval.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Approval) bool {
+			return a.Level == domain.ApprovalLevelFinanceDirector && a.ApproverID == directorID
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, directorID, "ok")
+		require.NoError(t, err)
+		m.expense.AssertExpectations(t)
+	})
+
+	t.Run("over budget expense waits for finance director", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		overBudget := &domain.Expense{ID: expenseID, UserID: submitterID, AmountIDR: 1500000, Status: domain.ExpenseStatusAwaitingApproval, RequiresFinanceApproval: true}
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(overBudget, nil).Once()
+		m.expectApprover(managerID, domain.RoleManager)
+		m.expectDelegations(managerID)
+		m.expectReports(managerID, submitterID)
+		m.allowSegregation()
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.approval.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Approval) bool {
+			return a.Level == domain.ApprovalLevelManager
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, managerID, "ok")
+		require.NoError(t, err)
+		m.expense.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("same level signed concurrently", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(managerID, domain.RoleManager)
+		m.expectDelegations(managerID)
+		m.expectReports(managerID, submitterID)
+		m.allowSegregation()
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.approval.On("Create", mock.Anything, mock.AnythingOfType("*domain.Approval")).Return(domain.ErrApprovalConflict).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, managerID, "ok")
+		require.ErrorIs(t, err, domain.ErrApprovalConflict)
+	})
+}
+
+func TestApproveExpenseDelegated(t *testing.T) {
+	ctx := context.Background()
+	expenseID := 9
+	managerID := 3
+	delegateID := 7
+	submitterID := 20
+	awaiting := &domain.Expense{ID: expenseID, UserID: submitterID, AmountIDR: 2000000, Status: domain.ExpenseStatusAwaitingApproval}
+	delegation := &domain.Delegation{ID: 1, ManagerID: managerID, DelegateID: delegateID}
+
+	t.Run("delegate approves on behalf of manager", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(delegateID, domain.RoleManager)
+		m.expectDelegations(delegateID, delegation)
+		m.expectReports(managerID, submitterID)
+		m.expectReports(delegateID)
+		m.allowSegregation()
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, expenseID, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusApproved, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		m.approval.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Approval) bool {
+			return a.ApproverID == delegateID && a.IsDelegated() && *a.OnBehalfOfID == managerID &&
+				a.Level == domain.ApprovalLevelManager
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, delegateID, "covering")
+		require.NoError(t, err)
+		m.approval.AssertExpectations(t)
+	})
+
+	t.Run("finance director delegate rejects manager level", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(delegateID, domain.RoleFinanceDirector)
+		m.expectDelegations(delegateID, delegation)
+		m.expectReports(managerID, submitterID)
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, expenseID, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusRejected, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		m.approval.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Approval) bool {
+			return a.Status == domain.ApprovalStatusRejected && a.IsDelegated() && *a.OnBehalfOfID == managerID
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		err := uc.RejectExpense(ctx, expenseID, delegateID, "not in budget")
+		require.NoError(t, err)
+		m.approval.AssertExpectations(t)
+	})
+
+	t.Run("own report is not a delegated action", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(delegateID, domain.RoleManager)
+		m.expectDelegations(delegateID, delegation)
+		m.expectReports(managerID, submitterID)
+		m.expectReports(delegateID, submitterID)
+		m.allowSegregation()
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, expenseID, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusApproved, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		m.approval.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Approval) bool {
+			return !a.IsDelegated()
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, delegateID, "ok")
+		require.NoError(t, err)
+		m.approval.AssertExpectations(t)
+	})
+
+	t.Run("without delegation", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(delegateID, domain.RoleManager)
+		m.expectDelegations(delegateID)
+		m.expectReports(delegateID)
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.expectExpenseEscalations(expenseID)
+
+		err := uc.ApproveExpense(ctx, expenseID, delegateID, "ok")
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		m.approval.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("delegation lookup error", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		expectedErr := errors.New("db error")
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(delegateID, domain.RoleManager)
+		m.delegation.On("FindActiveByDelegateID", mock.Anything, delegateID, mock.AnythingOfType("time.Time")).Return(nil, expectedErr).Once()
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, delegateID, "ok")
+		require.ErrorIs(t, err, expectedErr)
+	})
+}
+
+func TestApproveExpenseEscalated(t *testing.T) {
+	ctx := context.Background()
+	expenseID := 9
+	seniorID := 8
+	submitterID := 20
+	awaiting := &domain.Expense{ID: expenseID, UserID: submitterID, AmountIDR: 2000000, Status: domain.ExpenseStatusAwaitingApproval}
+
+	t.Run("escalation target signs level", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(seniorID, domain.RoleManager)
+		m.expectDelegations(seniorID)
+		m.expectReports(seniorID, 3)
+		m.expectExpenseEscalations(expenseID, &domain.Escalation{ExpenseID: expenseID, Level: domain.ApprovalLevelManager, EscalatedToID: seniorID})
+		m.allowSegregation()
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, expenseID, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusApproved, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		m.approval.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Approval) bool {
+			return a.ApproverID == seniorID && a.Level == domain.ApprovalLevelManager && !a.IsDelegated()
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, seniorID, "ok")
+		require.NoError(t, err)
+		m.approval.AssertExpectations(t)
+	})
+
+	t.Run("escalation for another level", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(seniorID, domain.RoleManager)
+		m.expectDelegations(seniorID)
+		m.expectReports(seniorID, 3)
+		m.expectExpenseEscalations(expenseID, &domain.Escalation{ExpenseID: expenseID, Level: domain.ApprovalLevelFinanceDirector, EscalatedToID: seniorID})
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{}, nil).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, seniorID, "ok")
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		m.approval.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRequestChanges(t *testing.T) {
+	ctx := context.Background()
+	expenseID := 12
+	managerID := 3
+	directorID := 4
+	submitterID := 20
+	awaiting := &domain.Expense{ID: expenseID, UserID: submitterID, AmountIDR: 12000000, Status: domain.ExpenseStatusAwaitingApproval}
+
+	t.Run("returns expense to submitter", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		managerApproval := &domain.Approval{ExpenseID: expenseID, ApproverID: managerID, Round: 1, Level: domain.ApprovalLevelManager, Status: domain.ApprovalStatusApproved}
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(directorID, domain.RoleFinanceDirector)
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return([]*domain.Approval{managerApproval}, nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, expenseID, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusChangesRequested, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		m.approval.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Approval) bool {
+			return a.Level == domain.ApprovalLevelFinanceDirector && a.Round == 1 && a.Status == domain.ApprovalStatusChangesRequested
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.ToStatus == domain.ExpenseStatusChangesRequested && h.Reason == "attach the hotel invoice"
+		})).Return(nil).Once()
+
+		err := uc.RequestChanges(ctx, expenseID, directorID, "attach the hotel invoice")
+		require.NoError(t, err)
+		m.segregation.AssertNotCalled(t, "CheckApprover", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("resubmitted expense starts a new round", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		chain := []*domain.Approval{
+			{ExpenseID: expenseID, ApproverID: managerID, Round: 1, Level: domain.ApprovalLevelManager, Status: domain.ApprovalStatusApproved},
+			{ExpenseID: expenseID, ApproverID: directorID, Round: 1, Level: domain.ApprovalLevelFinanceDirector, Status: domain.ApprovalStatusChangesRequested},
+		}
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(awaiting, nil).Once()
+		m.expectApprover(managerID, domain.RoleManager)
+		m.expectDelegations(managerID)
+		m.expectReports(managerID, submitterID)
+		m.allowSegregation()
+		m.approval.On("FindByExpenseID", mock.Anything, expenseID).Return(chain, nil).Once()
+		m.approval.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Approval) bool {
+			return a.Level == domain.ApprovalLevelManager && a.Round == 2 && a.Status == domain.ApprovalStatusApproved
+		})).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		err := uc.ApproveExpense(ctx, expenseID, managerID, "ok")
+		require.NoError(t, err)
+		m.approval.AssertExpectations(t)
+	})
+
+	t.Run("notes are required", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+
+		err := uc.RequestChanges(ctx, expenseID, managerID, "")
+		require.ErrorIs(t, err, domain.ErrMissingNotes)
+		m.expense.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestResubmitExpense(t *testing.T) {
+	ctx := context.Background()
+	expenseID := 12
+	submitterID := 20
+	returned := func() *domain.Expense {
+		return &domain.Expense{ID: expenseID, UserID: submitterID, CategoryID: travel.ID, AmountIDR: 2000000, Description: "hotel", Status: domain.ExpenseStatusChangesRequested, RequiresApproval: true}
+	}
+	amount := 1800000
+	receipt := "https://receipts.example.com/hotel.pdf"
+
+	t.Run("success", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(returned(), nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations()
+		m.expense.On("Update", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.AmountIDR == amount && e.Description == "hotel" && e.ReceiptURL == receipt &&
+				e.Status == domain.ExpenseStatusAwaitingApproval && !e.SubmittedAt.IsZero()
+		}), domain.ExpenseStatusChangesRequested).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return *h.FromStatus == domain.ExpenseStatusChangesRequested && h.ToStatus == domain.ExpenseStatusAwaitingApproval &&
+				*h.ActorID == submitterID && h.Reason == "resubmitted"
+		})).Return(nil).Once()
+
+		result, err := uc.ResubmitExpense(ctx, expenseID, submitterID, domain.ExpenseChanges{AmountIDR: &amount, ReceiptURL: &receipt})
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, result.Status)
+	})
+
+	t.Run("amended below the threshold is auto-approved", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		lower := 500000
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(returned(), nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations()
+		m.expense.On("Update", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Status == domain.ExpenseStatusAutoApproved && e.AutoApproved && !e.RequiresApproval
+		}), domain.ExpenseStatusChangesRequested).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.ToStatus == domain.ExpenseStatusAutoApproved && h.Reason == "resubmitted"
+		})).Return(nil).Once()
+
+		result, err := uc.ResubmitExpense(ctx, expenseID, submitterID, domain.ExpenseChanges{AmountIDR: &lower})
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAutoApproved, result.Status)
+	})
+
+	t.Run("split claim still needs approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		lower := 750000
+		flag := &domain.FraudFlag{Kind: domain.FraudThresholdSplit, RelatedExpenseIDs: []int{4}}
+		m.splits = new(mocks.SplitDetector)
+		m.splits.On("Detect", mock.Anything, mock.Anything).Return(flag, nil).Once()
+		m.fraud = new(mocks.FraudRepository)
+		m.fraud.On("Replace", mock.Anything, expenseID, []*domain.FraudFlag{flag}).Return(nil).Once()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(returned(), nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations()
+		m.expense.On("Update", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool {
+			return e.Status == domain.ExpenseStatusAwaitingApproval && e.RequiresApproval
+		}), domain.ExpenseStatusChangesRequested).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		result, err := uc.ResubmitExpense(ctx, expenseID, submitterID, domain.ExpenseChanges{AmountIDR: &lower})
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, result.Status)
+		m.fraud.AssertExpectations(t)
+	})
+
+	t.Run("possible duplicates are flagged", func(t *testing.T) {
+		m := newUseCaseMocks()
+		matches := []*domain.DuplicateMatch{{ExpenseID: expenseID, DuplicateOfID: 7, Reason: domain.DuplicateSimilarExpense}}
+		m.duplicates = new(mocks.DuplicateDetector)
+		m.duplicates.On("Detect", mock.Anything, mock.Anything).Return(matches, nil).Once()
+		m.duplicate = new(mocks.DuplicateRepository)
+		m.duplicate.On("Replace", mock.Anything, expenseID, matches).Return(nil).Once()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(returned(), nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations()
+		m.expense.On("Update", mock.Anything, mock.AnythingOfType("*domain.Expense"), domain.ExpenseStatusChangesRequested).Return(nil).Once()
+		m.history.On("Create", mock.Anything, mock.AnythingOfType("*domain.StatusHistory")).Return(nil).Once()
+
+		result, err := uc.ResubmitExpense(ctx, expenseID, submitterID, domain.ExpenseChanges{})
+		require.NoError(t, err)
+		require.Equal(t, matches, result.PossibleDuplicates)
+		m.duplicate.AssertExpectations(t)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(returned(), nil).Once()
+
+		result, err := uc.ResubmitExpense(ctx, expenseID, 99, domain.ExpenseChanges{})
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		require.Nil(t, result)
+	})
+
+	t.Run("expense was not returned", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		expense := returned()
+		expense.Status = domain.ExpenseStatusApproved
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(expense, nil).Once()
+
+		result, err := uc.ResubmitExpense(ctx, expenseID, submitterID, domain.ExpenseChanges{})
+		require.ErrorIs(t, err, domain.ErrInvalidExpenseStatus)
+		require.Nil(t, result)
+		m.expense.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		tooSmall := 10
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(returned(), nil).Once()
+		m.expectCategory(travel)
+
+		result, err := uc.ResubmitExpense(ctx, expenseID, submitterID, domain.ExpenseChanges{AmountIDR: &tooSmall})
+		require.ErrorIs(t, err, domain.ErrInvalidAmount)
+		require.Nil(t, result)
+	})
+
+	t.Run("concurrent update", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(returned(), nil).Once()
+		m.expectCategory(travel)
+		m.expectViolations()
+		m.expense.On("Update", mock.Anything, mock.AnythingOfType("*domain.Expense"), domain.ExpenseStatusChangesRequested).Return(domain.ErrStatusConflict).Once()
+
+		result, err := uc.ResubmitExpense(ctx, expenseID, submitterID, domain.ExpenseChanges{})
+		require.ErrorIs(t, err, domain.ErrStatusConflict)
+		require.Nil(t, result)
+		m.history.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
 }
 
 func TestGetPendingApproval(t *testing.T) {
 	ctx := context.Background()
-	mockExpense := new(mocks.ExpenseRepository)
-	mockApproval := new(mocks.ApprovalRepository)
-	uc := NewExpenseUseCase(mockExpense, mockApproval)
-	expected := []*domain.Expense{{ID: 1, Status: domain.ExpenseStatusAwaitingApproval}}
-	mockExpense.On("FindPendingApproval", mock.Anything).Return(expected, nil).Once()
+	awaitingManager := &domain.Expense{ID: 1, UserID: 20, AmountIDR: 2000000, Status: domain.ExpenseStatusAwaitingApproval}
+	awaitingDirector := &domain.Expense{ID: 2, UserID: 21, AmountIDR: 12000000, Status: domain.ExpenseStatusAwaitingApproval}
+	signedByManager := []*domain.Approval{{ExpenseID: 2, Level: domain.ApprovalLevelManager, Status: domain.ApprovalStatusApproved}}
 
-	result, err := uc.GetPendingApproval(ctx)
-	require.NoError(t, err)
-	require.Equal(t, expected, result)
+	t.Run("manager sees own reports", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.allowSegregation()
+		uc := m.useCase()
+		m.expectApprover(5, domain.RoleManager)
+		m.expectDelegations(5)
+		m.expectEscalations(5)
+		m.expectReports(5, 20, 21)
+		m.expense.On("FindPendingApprovalByUserIDs", mock.Anything, []int{20, 21}).
+			Return([]*domain.Expense{awaitingManager, awaitingDirector}, nil).Once()
+		m.approval.On("FindByExpenseIDs", mock.Anything, []int{1, 2}).Return(map[int][]*domain.Approval{2: signedByManager}, nil).Once()
+
+		result, err := uc.GetPendingApproval(ctx, 5)
+		require.NoError(t, err)
+		require.Equal(t, []*domain.Expense{awaitingManager}, result)
+		m.expense.AssertNotCalled(t, "FindPendingApproval", mock.Anything)
+	})
+
+	t.Run("expenses blocked by segregation of duties are left out", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expectApprover(5, domain.RoleManager)
+		m.expectDelegations(5)
+		m.expectEscalations(5)
+		m.expectReports(5, 20, 22)
+		other := &domain.Expense{ID: 3, UserID: 22, AmountIDR: 2000000, Status: domain.ExpenseStatusAwaitingApproval}
+		m.expense.On("FindPendingApprovalByUserIDs", mock.Anything, []int{20, 22}).
+			Return([]*domain.Expense{awaitingManager, other}, nil).Once()
+		m.approval.On("FindByExpenseIDs", mock.Anything, []int{1, 3}).Return(map[int][]*domain.Approval{}, nil).Once()
+		m.segregation.On("CheckApprover", mock.Anything, awaitingManager, domain.ApprovalLevelManager, 5).
+			Return(&domain.PolicyViolationError{Reason: "too many approvals in a row"}).Once()
+		m.segregation.On("CheckApprover", mock.Anything, other, domain.ApprovalLevelManager, 5).Return(nil).Once()
+
+		result, err := uc.GetPendingApproval(ctx, 5)
+		require.NoError(t, err)
+		require.Equal(t, []*domain.Expense{other}, result)
+	})
+
+	t.Run("possible duplicates are shown", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.allowSegregation()
+		flagged := &domain.Expense{ID: 1, UserID: 20, AmountIDR: 2000000, Status: domain.ExpenseStatusAwaitingApproval}
+		matches := []*domain.DuplicateMatch{{ExpenseID: 1, DuplicateOfID: 7, Reason: domain.DuplicateSimilarExpense, Detail: "expense 7 has a similar amount, date and description"}}
+		m.duplicate = new(mocks.DuplicateRepository)
+		m.duplicate.On("FindByExpenseIDs", mock.Anything, []int{1}).Return(map[int][]*domain.DuplicateMatch{1: matches}, nil).Once()
+		uc := m.useCase()
+		m.expectApprover(5, domain.RoleManager)
+		m.expectDelegations(5)
+		m.expectEscalations(5)
+		m.expectReports(5, 20)
+		m.expense.On("FindPendingApprovalByUserIDs", mock.Anything, []int{20}).Return([]*domain.Expense{flagged}, nil).Once()
+		m.approval.On("FindByExpenseIDs", mock.Anything, []int{1}).Return(map[int][]*domain.Approval{}, nil).Once()
+
+		result, err := uc.GetPendingApproval(ctx, 5)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, matches, result[0].PossibleDuplicates)
+	})
+
+	t.Run("delegate sees delegating manager's team", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.allowSegregation()
+		uc := m.useCase()
+		teamExpense := &domain.Expense{ID: 3, UserID: 30, AmountIDR: 2000000, Status: domain.ExpenseStatusAwaitingApproval}
+		m.expectApprover(5, domain.RoleManager)
+		m.expectDelegations(5, &domain.Delegation{ManagerID: 9, DelegateID: 5})
+		m.expectEscalations(5)
+		m.expectReports(9, 30)
+		m.expectReports(5, 20)
+		m.expense.On("FindPendingApprovalByUserIDs", mock.Anything, []int{20, 30}).
+			Return([]*domain.Expense{awaitingManager, teamExpense}, nil).Once()
+		m.approval.On("FindByExpenseIDs", mock.Anything, []int{1, 3}).Return(map[int][]*domain.Approval{}, nil).Once()
+
+		result, err := uc.GetPendingApproval(ctx, 5)
+		require.NoError(t, err)
+		require.Equal(t, []*domain.Expense{awaitingManager, teamExpense}, result)
+	})
+
+	t.Run("finance director delegate sees manager level of delegated team", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.allowSegregation()
+		uc := m.useCase()
+		otherTeam := &domain.Expense{ID: 3, UserID: 40, AmountIDR: 2000000, Status: domain.ExpenseStatusAwaitingApproval}
+		m.expectApprover(5, domain.RoleFinanceDirector)
+		m.expectDelegations(5, &domain.Delegation{ManagerID: 9, DelegateID: 5})
+		m.expectEscalations(5)
+		m.expectReports(9, 20)
+		m.expense.On("FindPendingApproval", mock.Anything).Return([]*domain.Expense{awaitingManager, awaitingDirector, otherTeam}, nil).Once()
+		m.approval.On("FindByExpenseIDs", mock.Anything, []int{1, 2, 3}).Return(map[int][]*domain.Approval{2: signedByManager}, nil).Once()
+
+		result, err := uc.GetPendingApproval(ctx, 5)
+		require.NoError(t, err)
+		require.Equal(t, []*domain.Expense{awaitingManager, awaitingDirector}, result)
+	})
+
+	t.Run("escalated expenses outside the usual queue", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.allowSegregation()
+		uc := m.useCase()
+		escalatedExpense := &domain.Expense{ID: 7, UserID: 40, AmountIDR: 2000000, Status: domain.ExpenseStatusAwaitingApproval}
+		m.expectApprover(5, domain.RoleManager)
+		m.expectDelegations(5)
+		m.expectEscalations(5, &domain.Escalation{ExpenseID: 7, Level: domain.ApprovalLevelManager, EscalatedToID: 5})
+		m.expectReports(5, 20)
+		m.expense.On("FindPendingApprovalByUserIDs", mock.Anything, []int{20}).Return([]*domain.Expense{awaitingManager}, nil).Once()
+		m.expense.On("FindByID", mock.Anything, 7).Return(escalatedExpense, nil).Once()
+		m.approval.On("FindByExpenseIDs", mock.Anything, []int{1, 7}).Return(map[int][]*domain.Approval{}, nil).Once()
+
+		result, err := uc.GetPendingApproval(ctx, 5)
+		require.NoError(t, err)
+		require.Equal(t, []*domain.Expense{awaitingManager, escalatedExpense}, result)
+	})
+
+	t.Run("escalated report lines stay with their report", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.allowSegregation()
+		uc := m.useCase()
+		reportID := 10
+		reportLine := &domain.Expense{ID: 7, UserID: 40, ReportID: &reportID, AmountIDR: 2000000, Status: domain.ExpenseStatusAwaitingApproval}
+		m.expectApprover(5, domain.RoleManager)
+		m.expectDelegations(5)
+		m.expectEscalations(5, &domain.Escalation{ExpenseID: 7, Level: domain.ApprovalLevelManager, EscalatedToID: 5})
+		m.expectReports(5, 20)
+		m.expense.On("FindPendingApprovalByUserIDs", mock.Anything, []int{20}).Return([]*domain.Expense{awaitingManager}, nil).Once()
+		m.expense.On("FindByID", mock.Anything, 7).Return(reportLine, nil).Once()
+		m.approval.On("FindByExpenseIDs", mock.Anything, []int{1}).Return(map[int][]*domain.Approval{}, nil).Once()
+
+		result, err := uc.GetPendingApproval(ctx, 5)
+		require.NoError(t, err)
+		require.Equal(t, []*domain.Expense{awaitingManager}, result)
+	})
+
+	t.Run("own expenses are hidden", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.allowSegregation()
+		uc := m.useCase()
+		own := &domain.Expense{ID: 3, UserID: 5, AmountIDR: 12000000, Status: domain.ExpenseStatusAwaitingApproval}
+		m.expectApprover(5, domain.RoleFinanceDirector)
+		m.expectDelegations(5)
+		m.expectEscalations(5)
+		m.expense.On("FindPendingApproval", mock.Anything).Return([]*domain.Expense{own}, nil).Once()
+
+		result, err := uc.GetPendingApproval(ctx, 5)
+		require.NoError(t, err)
+		require.Empty(t, result)
+		m.approval.AssertNotCalled(t, "FindByExpenseIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("manager without reports", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.allowSegregation()
+		uc := m.useCase()
+		m.expectApprover(5, domain.RoleManager)
+		m.expectDelegations(5)
+		m.expectEscalations(5)
+		m.expectReports(5)
+
+		result, err := uc.GetPendingApproval(ctx, 5)
+		require.NoError(t, err)
+		require.Empty(t, result)
+		m.expense.AssertNotCalled(t, "FindPendingApprovalByUserIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("finance levels are company wide", func(t *testing.T) {
+		for role, want := range map[domain.Role][]*domain.Expense{
+			domain.RoleFinanceDirector: {awaitingDirector},
+			domain.RoleCFO:             nil,
+		} {
+			m := newUseCaseMocks()
+			m.allowSegregation()
+			uc := m.useCase()
+			m.expectApprover(5, role)
+			m.expectDelegations(5)
+			m.expectEscalations(5)
+			m.expense.On("FindPendingApproval", mock.Anything).Return([]*domain.Expense{awaitingManager, awaitingDirector}, nil).Once()
+			m.approval.On("FindByExpenseIDs", mock.Anything, []int{1, 2}).Return(map[int][]*domain.Approval{2: signedByManager}, nil).Once()
+
+			result, err := uc.GetPendingApproval(ctx, 5)
+			require.NoError(t, err)
+			require.Equal(t, want, result, role)
+			m.user.AssertNotCalled(t, "FindReportIDs", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("unknown approver", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.user.On("FindByID", mock.Anything, 5).Return((*domain.User)(nil), nil).Once()
+
+		result, err := uc.GetPendingApproval(ctx, 5)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		require.Nil(t, result)
+	})
+}
+
+func TestGetExpenseHistory(t *testing.T) {
+	ctx := context.Background()
+	expenseID := 4
+	ownerID := 1
+	entries := []*domain.StatusHistory{{ID: 1, ExpenseID: expenseID, ToStatus: domain.ExpenseStatusAwaitingApproval}}
+
+	t.Run("owner", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(&domain.Expense{ID: expenseID, UserID: ownerID}, nil).Once()
+		m.history.On("FindByExpenseID", mock.Anything, expenseID).Return(entries, nil).Once()
+
+		result, err := uc.GetExpenseHistory(ctx, expenseID, ownerID, domain.RoleEmployee)
+		require.NoError(t, err)
+		require.Equal(t, entries, result)
+	})
+
+	t.Run("submitter's manager", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(&domain.Expense{ID: expenseID, UserID: ownerID}, nil).Once()
+		m.expectApprover(99, domain.RoleManager)
+		m.expectDelegations(99)
+		m.expectReports(99, ownerID)
+		m.history.On("FindByExpenseID", mock.Anything, expenseID).Return(entries, nil).Once()
+
+		result, err := uc.GetExpenseHistory(ctx, expenseID, 99, domain.RoleManager)
+		require.NoError(t, err)
+		require.Equal(t, entries, result)
+	})
+
+	t.Run("manager outside the reporting line", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(&domain.Expense{ID: expenseID, UserID: ownerID}, nil).Once()
+		m.expectApprover(99, domain.RoleManager)
+		m.expectDelegations(99)
+		m.expectReports(99, 30)
+
+		result, err := uc.GetExpenseHistory(ctx, expenseID, 99, domain.RoleManager)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		require.Nil(t, result)
+		m.history.AssertNotCalled(t, "FindByExpenseID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("finance", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(&domain.Expense{ID: expenseID, UserID: ownerID}, nil).Once()
+		m.history.On("FindByExpenseID", mock.Anything, expenseID).Return(entries, nil).Once()
+
+		result, err := uc.GetExpenseHistory(ctx, expenseID, 50, domain.RoleFinanceDirector)
+		require.NoError(t, err)
+		require.Equal(t, entries, result)
+	})
+
+	t.Run("other employee", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return(&domain.Expense{ID: expenseID, UserID: ownerID}, nil).Once()
+		m.expectApprover(99, domain.RoleEmployee)
+		m.expectDelegations(99)
+
+		result, err := uc.GetExpenseHistory(ctx, expenseID, 99, domain.RoleEmployee)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		require.Nil(t, result)
+		m.history.AssertNotCalled(t, "FindByExpenseID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		m := newUseCaseMocks()
+		uc := m.useCase()
+		m.expense.On("FindByID", mock.Anything, expenseID).Return((*domain.Expense)(nil), nil).Once()
+
+		result, err := uc.GetExpenseHistory(ctx, expenseID, ownerID, domain.RoleEmployee)
+		require.ErrorIs(t, err, domain.ErrExpenseNotFound)
+		require.Nil(t, result)
+	})
 }
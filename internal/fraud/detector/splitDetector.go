@@ -0,0 +1,45 @@
+package detector
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/expense"
+	"github.com/evrintobing17/expense-management-backend/internal/fraud"
+)
+
+// unpaidStatuses are left out of the sum: drafts have not been claimed yet, and
+// rejected or cancelled expenses will never be paid.
+var unpaidStatuses = []domain.ExpenseStatus{
+	domain.ExpenseStatusDraft,
+	domain.ExpenseStatusRejected,
+	domain.ExpenseStatusCancelled,
+}
+
+type splitDetector struct {
+	expenseRepo expense.ExpenseRepository
+	policy      domain.SplitPolicy
+}
+
+func NewSplitDetector(expenseRepo expense.ExpenseRepository, policy domain.SplitPolicy) fraud.SplitDetector {
+	return &splitDetector{
+		expenseRepo: expenseRepo,
+		policy:      policy,
+	}
+}
+
+// Detect only looks at expenses that would otherwise be auto-approved.
+func (d *splitDetector) Detect(ctx context.Context, exp *domain.Expense) (*domain.FraudFlag, error) {
+	if exp.RequiresApproval {
+		return nil, nil
+	}
+
+	from := exp.IncurredOn.AddDate(0, 0, -d.policy.WindowDays)
+	to := exp.IncurredOn.AddDate(0, 0, d.policy.WindowDays)
+	recent, err := d.expenseRepo.FindIncurredBetween(ctx, exp.UserID, from, to, exp.ID, unpaidStatuses...)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.policy.DetectSplit(exp, recent), nil
+}
@@ -0,0 +1,41 @@
+package detector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitDetectorDetect(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("split claim", func(t *testing.T) {
+		mockExpense := new(mocks.ExpenseRepository)
+		d := NewSplitDetector(mockExpense, domain.DefaultSplitPolicy)
+		expense := &domain.Expense{ID: 10, UserID: 2, CategoryID: 2, AmountIDR: 750000, IncurredOn: day, AutoApproved: true}
+		mockExpense.On("FindIncurredBetween", mock.Anything, 2, day.AddDate(0, 0, -7), day.AddDate(0, 0, 7), 10,
+			unpaidStatuses[0], unpaidStatuses[1], unpaidStatuses[2]).
+			Return([]*domain.Expense{{ID: 8, CategoryID: 2, AmountIDR: 750000}}, nil).Once()
+
+		flag, err := d.Detect(ctx, expense)
+		require.NoError(t, err)
+		require.NotNil(t, flag)
+		require.Equal(t, []int{8}, flag.RelatedExpenseIDs)
+	})
+
+	t.Run("expense needs approval anyway", func(t *testing.T) {
+		mockExpense := new(mocks.ExpenseRepository)
+		d := NewSplitDetector(mockExpense, domain.DefaultSplitPolicy)
+
+		flag, err := d.Detect(ctx, &domain.Expense{ID: 10, AmountIDR: 2000000, RequiresApproval: true})
+		require.NoError(t, err)
+		require.Nil(t, flag)
+		mockExpense.AssertNotCalled(t, "FindIncurredBetween")
+	})
+}
@@ -0,0 +1,12 @@
+package fraud
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type FraudRepository interface {
+	Replace(ctx context.Context, expenseID int, flags []*domain.FraudFlag) error
+	FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.FraudFlag, error)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/fraud"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+	"github.com/lib/pq"
+)
+
+type fraudRepository struct {
+	db *sql.DB
+}
+
+func NewFraudRepository(db *sql.DB) fraud.FraudRepository {
+	return &fraudRepository{db: db}
+}
+
+// Replace swaps the expense's fraud flags for the result of its latest check.
+func (r *fraudRepository) Replace(ctx context.Context, expenseID int, flags []*domain.FraudFlag) error {
+	return database.WithinTx(ctx, r.db, func(ctx context.Context) error {
+		return r.replace(ctx, expenseID, flags)
+	})
+}
+
+func (r *fraudRepository) replace(ctx context.Context, expenseID int, flags []*domain.FraudFlag) error {
+	tx := database.Conn(ctx, r.db)
+
+	_, err := tx.ExecContext(ctx, `DELETE FROM expense_fraud_flags WHERE expense_id = $1`, expenseID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO expense_fraud_flags (expense_id, kind, related_expense_ids, explanation)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	for _, f := range flags {
+		_, err = tx.ExecContext(ctx, query, expenseID, f.Kind, pq.Array(f.RelatedExpenseIDs), f.Explanation)
+		if err != nil {
+			return err
+		}
+		f.ExpenseID = expenseID
+	}
+
+	return nil
+}
+
+// FindByExpenseIDs returns the fraud flags keyed by expense id. Expenses without flags
+// are left out of the map.
+func (r *fraudRepository) FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.FraudFlag, error) {
+	query := `
+		SELECT expense_id, kind, related_expense_ids, explanation
+		FROM expense_fraud_flags
+		WHERE expense_id = ANY($1)
+		ORDER BY expense_id ASC, id ASC
+	`
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, pq.Array(expenseIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	flags := make(map[int][]*domain.FraudFlag)
+	for rows.Next() {
+		f := &domain.FraudFlag{}
+		var related pq.Int64Array
+		err := rows.Scan(
+			&f.ExpenseID,
+			&f.Kind,
+			&related,
+			&f.Explanation,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range related {
+			f.RelatedExpenseIDs = append(f.RelatedExpenseIDs, int(id))
+		}
+		flags[f.ExpenseID] = append(flags[f.ExpenseID], f)
+	}
+
+	return flags, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFraudRepositoryReplace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &fraudRepository{db: db}
+	flags := []*domain.FraudFlag{{Kind: domain.FraudThresholdSplit, RelatedExpenseIDs: []int{8, 9}, Explanation: "split"}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM expense_fraud_flags WHERE expense_id = $1`)).WithArgs(10).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`
+		INSERT INTO expense_fraud_flags (expense_id, kind, related_expense_ids, explanation)
+		VALUES ($1, $2, $3, $4)
+	`)).WithArgs(10, domain.FraudThresholdSplit, pq.Array([]int{8, 9}), "split").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Replace(context.Background(), 10, flags))
+	require.Equal(t, 10, flags[0].ExpenseID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFraudRepositoryFindByExpenseIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &fraudRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT expense_id, kind, related_expense_ids, explanation
+		FROM expense_fraud_flags
+		WHERE expense_id = ANY($1)
+		ORDER BY expense_id ASC, id ASC
+	`)
+	mock.ExpectQuery(query).WithArgs(pq.Array([]int{10, 11})).
+		WillReturnRows(sqlmock.NewRows([]string{"expense_id", "kind", "related_expense_ids", "explanation"}).
+			AddRow(10, "threshold_split", "{8,9}", "split"))
+
+	flags, findErr := repo.FindByExpenseIDs(context.Background(), []int{10, 11})
+	require.NoError(t, findErr)
+	require.Len(t, flags[10], 1)
+	require.Equal(t, []int{8, 9}, flags[10][0].RelatedExpenseIDs)
+	require.Empty(t, flags[11])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
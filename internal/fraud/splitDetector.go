@@ -0,0 +1,13 @@
+package fraud
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+// SplitDetector looks for a claim split into parts that each stay under the approval
+// threshold.
+type SplitDetector interface {
+	Detect(ctx context.Context, expense *domain.Expense) (*domain.FraudFlag, error)
+}
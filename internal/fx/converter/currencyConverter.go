@@ -0,0 +1,60 @@
+package converter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/fx"
+)
+
+type currencyConverter struct {
+	fxRepo     fx.FXRateRepository
+	maxAgeDays int
+}
+
+// NewCurrencyConverter converts at the latest rate published on or before the day an
+// expense was incurred. Rates more than maxAgeDays older than that day are not used.
+func NewCurrencyConverter(fxRepo fx.FXRateRepository, maxAgeDays int) fx.CurrencyConverter {
+	return &currencyConverter{
+		fxRepo:     fxRepo,
+		maxAgeDays: maxAgeDays,
+	}
+}
+
+// Convert sets the expense's IDR amount and the rate used from its original amount.
+// Expenses without a currency are taken to be in IDR; for those an original amount of
+// zero means the IDR amount was given directly.
+func (c *currencyConverter) Convert(ctx context.Context, expense *domain.Expense) error {
+	currency := strings.ToUpper(strings.TrimSpace(expense.Currency))
+	if currency == "" {
+		currency = domain.CurrencyIDR
+	}
+
+	if !domain.ValidCurrency(currency) {
+		return domain.ErrInvalidCurrency
+	}
+	expense.Currency = currency
+
+	if currency == domain.CurrencyIDR {
+		if expense.OriginalAmount == 0 {
+			expense.OriginalAmount = float64(expense.AmountIDR)
+		}
+		expense.FXRate = 1
+		expense.AmountIDR = domain.ConvertToIDR(expense.OriginalAmount, 1)
+		return nil
+	}
+
+	rate, err := c.fxRepo.FindRate(ctx, currency, expense.IncurredOn)
+	if err != nil {
+		return err
+	}
+
+	if rate == nil || rate.RateDate.Before(expense.IncurredOn.AddDate(0, 0, -c.maxAgeDays)) {
+		return domain.ErrFXRateNotFound
+	}
+
+	expense.FXRate = rate.RateIDR
+	expense.AmountIDR = domain.ConvertToIDR(expense.OriginalAmount, rate.RateIDR)
+	return nil
+}
@@ -0,0 +1,68 @@
+package converter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyConverterConvert(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("idr amount only", func(t *testing.T) {
+		mockFX := new(mocks.FXRateRepository)
+		c := NewCurrencyConverter(mockFX, 7)
+		expense := &domain.Expense{AmountIDR: 150000, IncurredOn: day}
+
+		require.NoError(t, c.Convert(ctx, expense))
+		require.Equal(t, domain.CurrencyIDR, expense.Currency)
+		require.Equal(t, 150000.0, expense.OriginalAmount)
+		require.Equal(t, 1.0, expense.FXRate)
+		require.Equal(t, 150000, expense.AmountIDR)
+		mockFX.AssertNotCalled(t, "FindRate")
+	})
+
+	t.Run("foreign currency", func(t *testing.T) {
+		mockFX := new(mocks.FXRateRepository)
+		c := NewCurrencyConverter(mockFX, 7)
+		expense := &domain.Expense{Currency: "usd", OriginalAmount: 42.5, IncurredOn: day}
+		mockFX.On("FindRate", mock.Anything, "USD", day).
+			Return(&domain.FXRate{Currency: "USD", RateDate: day.AddDate(0, 0, -2), RateIDR: 15600.25}, nil).Once()
+
+		require.NoError(t, c.Convert(ctx, expense))
+		require.Equal(t, "USD", expense.Currency)
+		require.Equal(t, 15600.25, expense.FXRate)
+		require.Equal(t, 663011, expense.AmountIDR)
+	})
+
+	t.Run("rate too old", func(t *testing.T) {
+		mockFX := new(mocks.FXRateRepository)
+		c := NewCurrencyConverter(mockFX, 7)
+		expense := &domain.Expense{Currency: "EUR", OriginalAmount: 10, IncurredOn: day}
+		mockFX.On("FindRate", mock.Anything, "EUR", day).
+			Return(&domain.FXRate{Currency: "EUR", RateDate: day.AddDate(0, 0, -8), RateIDR: 17000}, nil).Once()
+
+		require.ErrorIs(t, c.Convert(ctx, expense), domain.ErrFXRateNotFound)
+	})
+
+	t.Run("no rate", func(t *testing.T) {
+		mockFX := new(mocks.FXRateRepository)
+		c := NewCurrencyConverter(mockFX, 7)
+		expense := &domain.Expense{Currency: "JPY", OriginalAmount: 1000, IncurredOn: day}
+		mockFX.On("FindRate", mock.Anything, "JPY", day).Return((*domain.FXRate)(nil), nil).Once()
+
+		require.ErrorIs(t, c.Convert(ctx, expense), domain.ErrFXRateNotFound)
+	})
+
+	t.Run("invalid currency", func(t *testing.T) {
+		c := NewCurrencyConverter(new(mocks.FXRateRepository), 7)
+
+		require.ErrorIs(t, c.Convert(ctx, &domain.Expense{Currency: "US$", OriginalAmount: 1}), domain.ErrInvalidCurrency)
+	})
+}
@@ -0,0 +1,12 @@
+package fx
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+// CurrencyConverter fills in an expense's IDR amount from the amount it was incurred in.
+type CurrencyConverter interface {
+	Convert(ctx context.Context, expense *domain.Expense) error
+}
@@ -0,0 +1,13 @@
+package fx
+
+import (
+	"context"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type FXRateRepository interface {
+	FindRate(ctx context.Context, currency string, on time.Time) (*domain.FXRate, error)
+	Upsert(ctx context.Context, rates []*domain.FXRate) error
+}
@@ -0,0 +1,78 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+// header is the first line every rates file must start with.
+var header = []string{"date", "currency", "rate_idr"}
+
+// ParseCSV reads exchange rates in the format
+//
+//	date,currency,rate_idr
+//	2026-10-16,USD,15610.50
+//
+// where rate_idr is the IDR value of one unit of the currency. Any malformed line fails
+// the whole file, so a partial import never reaches the database.
+func ParseCSV(r io.Reader) ([]*domain.FXRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(header)
+	reader.TrimLeadingSpace = true
+
+	first, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	for i, name := range header {
+		if strings.ToLower(strings.TrimSpace(first[i])) != name {
+			return nil, fmt.Errorf("header must be %s", strings.Join(header, ","))
+		}
+	}
+
+	var rates []*domain.FXRate
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		rate, err := parseRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+func parseRecord(record []string) (*domain.FXRate, error) {
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(record[0]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", record[0])
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(record[1]))
+	if !domain.ValidCurrency(currency) {
+		return nil, fmt.Errorf("invalid currency %q", record[1])
+	}
+
+	rateIDR, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+	if err != nil || rateIDR <= 0 {
+		return nil, fmt.Errorf("invalid rate %q", record[2])
+	}
+
+	return &domain.FXRate{Currency: currency, RateDate: date, RateIDR: rateIDR}, nil
+}
@@ -0,0 +1,37 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		rates, err := ParseCSV(strings.NewReader("date,currency,rate_idr\n2026-10-16,usd,15610.50\n2026-10-16, SGD ,12045\n"))
+		require.NoError(t, err)
+		day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, []*domain.FXRate{
+			{Currency: "USD", RateDate: day, RateIDR: 15610.5},
+			{Currency: "SGD", RateDate: day, RateIDR: 12045},
+		}, rates)
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		_, err := ParseCSV(strings.NewReader("2026-10-16,USD,15610.50\n"))
+		require.ErrorContains(t, err, "header must be date,currency,rate_idr")
+	})
+
+	t.Run("bad rate", func(t *testing.T) {
+		_, err := ParseCSV(strings.NewReader("date,currency,rate_idr\n2026-10-16,USD,15610.50\n2026-10-16,EUR,-1\n"))
+		require.ErrorContains(t, err, `line 3: invalid rate "-1"`)
+	})
+
+	t.Run("bad currency", func(t *testing.T) {
+		_, err := ParseCSV(strings.NewReader("date,currency,rate_idr\n2026-10-16,EURO,17000\n"))
+		require.ErrorContains(t, err, `line 2: invalid currency "EURO"`)
+	})
+}
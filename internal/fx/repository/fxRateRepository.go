@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/fx"
+)
+
+type fxRateRepository struct {
+	db *sql.DB
+}
+
+func NewFXRateRepository(db *sql.DB) fx.FXRateRepository {
+	return &fxRateRepository{db: db}
+}
+
+// FindRate returns the most recent rate for currency published on or before on.
+func (r *fxRateRepository) FindRate(ctx context.Context, currency string, on time.Time) (*domain.FXRate, error) {
+	query := `
+		SELECT currency, rate_date, rate_idr
+		FROM fx_rates
+		WHERE currency = $1 AND rate_date <= $2
+		ORDER BY rate_date DESC
+		LIMIT 1
+	`
+
+	rate := &domain.FXRate{}
+	err := r.db.QueryRowContext(ctx, query, currency, on).Scan(&rate.Currency, &rate.RateDate, &rate.RateIDR)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+// Upsert stores the rates in one transaction, overwriting any already loaded for the
+// same currency and date.
+func (r *fxRateRepository) Upsert(ctx context.Context, rates []*domain.FXRate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO fx_rates (currency, rate_date, rate_idr)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (currency, rate_date) DO UPDATE SET rate_idr = EXCLUDED.rate_idr
+	`
+
+	for _, rate := range rates {
+		_, err = tx.ExecContext(ctx, query, rate.Currency, rate.RateDate, rate.RateIDR)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+const findRateQuery = `
+		SELECT currency, rate_date, rate_idr
+		FROM fx_rates
+		WHERE currency = $1 AND rate_date <= $2
+		ORDER BY rate_date DESC
+		LIMIT 1
+	`
+
+func TestFXRateRepositoryFindRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &fxRateRepository{db: db}
+	on := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	rateDate := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(findRateQuery)).WithArgs("USD", on).
+		WillReturnRows(sqlmock.NewRows([]string{"currency", "rate_date", "rate_idr"}).AddRow("USD", rateDate, 15600.5))
+
+	rate, findErr := repo.FindRate(context.Background(), "USD", on)
+	require.NoError(t, findErr)
+	require.Equal(t, &domain.FXRate{Currency: "USD", RateDate: rateDate, RateIDR: 15600.5}, rate)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFXRateRepositoryFindRateMissing(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &fxRateRepository{db: db}
+	on := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(findRateQuery)).WithArgs("EUR", on).
+		WillReturnRows(sqlmock.NewRows([]string{"currency", "rate_date", "rate_idr"}))
+
+	rate, findErr := repo.FindRate(context.Background(), "EUR", on)
+	require.NoError(t, findErr)
+	require.Nil(t, rate)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFXRateRepositoryUpsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &fxRateRepository{db: db}
+	day := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	rates := []*domain.FXRate{
+		{Currency: "USD", RateDate: day, RateIDR: 15600.5},
+		{Currency: "SGD", RateDate: day, RateIDR: 11620},
+	}
+	query := regexp.QuoteMeta(`
+		INSERT INTO fx_rates (currency, rate_date, rate_idr)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (currency, rate_date) DO UPDATE SET rate_idr = EXCLUDED.rate_idr
+	`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs("USD", day, 15600.5).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(query).WithArgs("SGD", day, 11620.0).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Upsert(context.Background(), rates))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
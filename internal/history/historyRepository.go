@@ -0,0 +1,12 @@
+package history
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type HistoryRepository interface {
+	Create(ctx context.Context, entry *domain.StatusHistory) error
+	FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.StatusHistory, error)
+}
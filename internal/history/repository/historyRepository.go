@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/history"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+)
+
+type historyRepository struct {
+	db *sql.DB
+}
+
+func NewHistoryRepository(db *sql.DB) history.HistoryRepository {
+	return &historyRepository{db: db}
+}
+
+// Create appends entry to the expense timeline. Entries without an event are recorded as
+// status changes.
+func (r *historyRepository) Create(ctx context.Context, entry *domain.StatusHistory) error {
+	query := `
+		INSERT INTO expense_status_history (expense_id, event, from_status, to_status, actor_type, actor_id, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+
+	if entry.Event == "" {
+		entry.Event = domain.HistoryEventStatusChange
+	}
+
+	err := database.Conn(ctx, r.db).QueryRowContext(ctx, query,
+		entry.ExpenseID,
+		entry.Event,
+		entry.FromStatus,
+		entry.ToStatus,
+		entry.ActorType,
+		entry.ActorID,
+		entry.Reason,
+	).Scan(&entry.ID, &entry.CreatedAt)
+
+	return err
+}
+
+func (r *historyRepository) FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.StatusHistory, error) {
+	query := `
+		SELECT id, expense_id, event, from_status, to_status, actor_type, actor_id, reason, created_at
+		FROM expense_status_history
+		WHERE expense_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.StatusHistory
+	for rows.Next() {
+		entry := &domain.StatusHistory{}
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ExpenseID,
+			&entry.Event,
+			&entry.FromStatus,
+			&entry.ToStatus,
+			&entry.ActorType,
+			&entry.ActorID,
+			&entry.Reason,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
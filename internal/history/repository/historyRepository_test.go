@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &historyRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO expense_status_history (expense_id, event, from_status, to_status, actor_type, actor_id, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`)
+	createdAt := time.Now()
+	from := domain.ExpenseStatusProcessing
+
+	t.Run("worker entry", func(t *testing.T) {
+		entry := &domain.StatusHistory{
+			ExpenseID:  3,
+			FromStatus: &from,
+			ToStatus:   domain.ExpenseStatusFailed,
+			ActorType:  domain.ActorTypeWorker,
+			Reason:     "timeout",
+		}
+		rows := sqlmock.NewRows([]string{"id", "created_at"}).AddRow(11, createdAt)
+		mock.ExpectQuery(query).
+			WithArgs(3, domain.HistoryEventStatusChange, &from, domain.ExpenseStatusFailed, domain.ActorTypeWorker, nil, "timeout").
+			WillReturnRows(rows)
+
+		createErr := repo.Create(context.Background(), entry)
+		require.NoError(t, createErr)
+		require.Equal(t, 11, entry.ID)
+		require.Equal(t, createdAt, entry.CreatedAt)
+		require.Equal(t, domain.HistoryEventStatusChange, entry.Event)
+	})
+
+	t.Run("escalation entry", func(t *testing.T) {
+		awaiting := domain.ExpenseStatusAwaitingApproval
+		entry := &domain.StatusHistory{
+			ExpenseID:  3,
+			Event:      domain.HistoryEventEscalation,
+			FromStatus: &awaiting,
+			ToStatus:   awaiting,
+			ActorType:  domain.ActorTypeWorker,
+			Reason:     "escalated",
+		}
+		rows := sqlmock.NewRows([]string{"id", "created_at"}).AddRow(12, createdAt)
+		mock.ExpectQuery(query).
+			WithArgs(3, domain.HistoryEventEscalation, &awaiting, awaiting, domain.ActorTypeWorker, nil, "escalated").
+			WillReturnRows(rows)
+
+		createErr := repo.Create(context.Background(), entry)
+		require.NoError(t, createErr)
+		require.Equal(t, 12, entry.ID)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		expectedErr := errors.New("insert failed")
+		actorID := 2
+		entry := &domain.StatusHistory{ExpenseID: 3, ToStatus: domain.ExpenseStatusPending, ActorType: domain.ActorTypeUser, ActorID: &actorID}
+		mock.ExpectQuery(query).WillReturnError(expectedErr)
+
+		createErr := repo.Create(context.Background(), entry)
+		require.ErrorIs(t, createErr, expectedErr)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHistoryRepositoryFindByExpenseID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &historyRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id, expense_id, event, from_status, to_status, actor_type, actor_id, reason, created_at
+		FROM expense_status_history
+		WHERE expense_id = $1
+		ORDER BY created_at ASC, id ASC
+	`)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "expense_id", "event", "from_status", "to_status", "actor_type", "actor_id", "reason", "created_at"}).
+		AddRow(1, 5, "status_change", nil, "awaiting_approval", "user", 2, "submitted", now).
+		AddRow(2, 5, "sla_reminder", "awaiting_approval", "awaiting_approval", "worker", nil, "reminder sent", now).
+		AddRow(3, 5, "status_change", "awaiting_approval", "approved", "user", 1, "ok", now).
+		AddRow(4, 5, "status_change", "approved", "processing", "worker", nil, "payment started", now)
+	mock.ExpectQuery(query).WithArgs(5).WillReturnRows(rows)
+
+	result, findErr := repo.FindByExpenseID(context.Background(), 5)
+	require.NoError(t, findErr)
+	require.Len(t, result, 4)
+	require.Nil(t, result[0].FromStatus)
+	require.Equal(t, 2, *result[0].ActorID)
+	require.Equal(t, domain.HistoryEventReminder, result[1].Event)
+	require.Equal(t, domain.ExpenseStatusAwaitingApproval, *result[2].FromStatus)
+	require.Equal(t, domain.ActorTypeWorker, result[3].ActorType)
+	require.Nil(t, result[3].ActorID)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
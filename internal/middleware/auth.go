@@ -60,6 +60,45 @@ func ManagerOnlyMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// ApproverOnlyMiddleware ensures only users who sign off some approval level can access the endpoint
+func ApproverOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, ok := r.Context().Value(userRoleKey).(domain.Role)
+		if !ok || !role.IsApprover() {
+			http.Error(w, "Access denied. Approver role required.", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// FinanceOnlyMiddleware ensures only the finance director and CFO can access the endpoint
+func FinanceOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, ok := r.Context().Value(userRoleKey).(domain.Role)
+		if !ok || !role.IsFinance() {
+			http.Error(w, "Access denied. Finance role required.", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AdminOnlyMiddleware ensures only administrators can access the endpoint
+func AdminOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, ok := r.Context().Value(userRoleKey).(domain.Role)
+		if !ok || role != domain.RoleAdmin {
+			http.Error(w, "Access denied. Admin role required.", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // Helper functions to get values from context
 func GetUserIDFromContext(ctx context.Context) (int, bool) {
 	userID, ok := ctx.Value(userIDKey).(int)
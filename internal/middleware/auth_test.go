@@ -85,6 +85,65 @@ func TestManagerOnlyMiddleware(t *testing.T) {
 	require.True(t, nextCalled)
 }
 
+func TestApproverOnlyMiddleware(t *testing.T) {
+	handler := ApproverOnlyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for role, want := range map[domain.Role]int{
+		domain.RoleEmployee:        http.StatusForbidden,
+		domain.RoleManager:         http.StatusOK,
+		domain.RoleFinanceDirector: http.StatusOK,
+		domain.RoleCFO:             http.StatusOK,
+		domain.RoleAdmin:           http.StatusForbidden,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), userRoleKey, role))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, want, rr.Code, role)
+	}
+}
+
+func TestFinanceOnlyMiddleware(t *testing.T) {
+	handler := FinanceOnlyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for role, want := range map[domain.Role]int{
+		domain.RoleEmployee:        http.StatusForbidden,
+		domain.RoleManager:         http.StatusForbidden,
+		domain.RoleAdmin:           http.StatusForbidden,
+		domain.RoleFinanceDirector: http.StatusOK,
+		domain.RoleCFO:             http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), userRoleKey, role))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, want, rr.Code, role)
+	}
+}
+
+func TestAdminOnlyMiddleware(t *testing.T) {
+	handler := AdminOnlyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for role, want := range map[domain.Role]int{
+		domain.RoleEmployee: http.StatusForbidden,
+		domain.RoleManager:  http.StatusForbidden,
+		domain.RoleCFO:      http.StatusForbidden,
+		domain.RoleAdmin:    http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), userRoleKey, role))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, want, rr.Code, role)
+	}
+}
+
 func TestCORS(t *testing.T) {
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusCreated)
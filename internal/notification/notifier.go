@@ -0,0 +1,9 @@
+package notification
+
+import (
+	"context"
+)
+
+type Notifier interface {
+	Notify(ctx context.Context, userID int, subject, message string) error
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/evrintobing17/expense-management-backend/internal/notification"
+)
+
+type logNotifier struct{}
+
+// NewLogNotifier returns a Notifier that writes notifications to the log. It stands in
+// until an email or chat integration is configured.
+func NewLogNotifier() notification.Notifier {
+	return &logNotifier{}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, userID int, subject, message string) error {
+	log.Printf("Notification to user %d: %s: %s", userID, subject, message)
+	return nil
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/evrintobing17/expense-management-backend/internal/payment"
+	"github.com/gorilla/mux"
+)
+
+type PaymentHandler struct {
+	paymentUseCase payment.PaymentUseCase
+}
+
+func NewPaymentHandler(paymentUseCase payment.PaymentUseCase) *PaymentHandler {
+	return &PaymentHandler{paymentUseCase: paymentUseCase}
+}
+
+// GetDeadLetters lists the payments that ran out of retries for finance.
+func (h *PaymentHandler) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	deadLetters, err := h.paymentUseCase.GetDeadLetters(ctx)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deadLetters)
+}
+
+func (h *PaymentHandler) RequeueDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	deadLetter, err := h.paymentUseCase.RequeueDeadLetter(ctx, paymentKind(r), id, userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deadLetter)
+}
+
+func (h *PaymentHandler) AbandonDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	deadLetter, err := h.paymentUseCase.AbandonDeadLetter(ctx, paymentKind(r), id, userID, req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deadLetter)
+}
+
+// paymentKind reads the kind of the dead-lettered payment from the kind query parameter.
+// The id in the path is the expense's when it is left out.
+func paymentKind(r *http.Request) domain.PaymentKind {
+	if kind := r.URL.Query().Get("kind"); kind != "" {
+		return domain.PaymentKind(kind)
+	}
+	return domain.PaymentKindExpense
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPaymentKind):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrExpenseNotFound):
+		http.Error(w, "Expense not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrReportNotFound):
+		http.Error(w, "Expense report not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrAdvanceNotFound):
+		http.Error(w, "Cash advance not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidExpenseStatus), errors.Is(err, domain.ErrInvalidAdvanceStatus):
+		http.Error(w, "Payment is not dead-lettered", http.StatusBadRequest)
+	case errors.Is(err, domain.ErrStatusConflict):
+		http.Error(w, "Payment was updated by another request", http.StatusConflict)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
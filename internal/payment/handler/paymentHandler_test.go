@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func withUser(req *http.Request, userID int, role domain.Role) *http.Request {
+	// Reuse auth middleware to set the same context keys used by handlers.
+	mockAuth := new(mocks.AuthService)
+	mockAuth.On("ValidateToken", mock.Anything, "t").Return(userID, role, nil).Maybe()
+	rr := httptest.NewRecorder()
+	next := middleware.AuthMiddleware(mockAuth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+	}))
+	req.Header.Set("Authorization", "Bearer t")
+	next.ServeHTTP(rr, req)
+	return req
+}
+
+func TestPaymentHandlerGetDeadLetters(t *testing.T) {
+	mockUC := new(mocks.PaymentUseCase)
+	h := NewPaymentHandler(mockUC)
+	req := httptest.NewRequest(http.MethodGet, "/payments/dead-letter", nil)
+	req = withUser(req, 9, domain.RoleFinanceDirector)
+	rr := httptest.NewRecorder()
+	mockUC.On("GetDeadLetters", mock.Anything).Return([]*domain.DeadLetter{{
+		Expense: &domain.Expense{ID: 5, Status: domain.ExpenseStatusDeadLetter},
+		Payment: &domain.Payment{ID: 3, Attempts: 5, LastError: "payment failed: Service Unavailable"},
+	}}, nil).Once()
+
+	h.GetDeadLetters(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Body.String(), `"attempts":5`)
+}
+
+func TestPaymentHandlerRequeueDeadLetter(t *testing.T) {
+	t.Run("requeued", func(t *testing.T) {
+		mockUC := new(mocks.PaymentUseCase)
+		h := NewPaymentHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/payments/dead-letter/5/requeue", nil)
+		req = withUser(req, 9, domain.RoleFinanceDirector)
+		req = mux.SetURLVars(req, map[string]string{"id": "5"})
+		rr := httptest.NewRecorder()
+		mockUC.On("RequeueDeadLetter", mock.Anything, domain.PaymentKindExpense, 5, 9).Return(&domain.DeadLetter{
+			Expense: &domain.Expense{ID: 5, Status: domain.ExpenseStatusRetryScheduled},
+		}, nil).Once()
+
+		h.RequeueDeadLetter(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"status":"retry_scheduled"`)
+	})
+
+	t.Run("not dead-lettered", func(t *testing.T) {
+		mockUC := new(mocks.PaymentUseCase)
+		h := NewPaymentHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/payments/dead-letter/5/requeue", nil)
+		req = withUser(req, 9, domain.RoleFinanceDirector)
+		req = mux.SetURLVars(req, map[string]string{"id": "5"})
+		rr := httptest.NewRecorder()
+		mockUC.On("RequeueDeadLetter", mock.Anything, domain.PaymentKindExpense, 5, 9).Return((*domain.DeadLetter)(nil), domain.ErrInvalidExpenseStatus).Once()
+
+		h.RequeueDeadLetter(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("expense report", func(t *testing.T) {
+		mockUC := new(mocks.PaymentUseCase)
+		h := NewPaymentHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/payments/dead-letter/7/requeue?kind=expense_report", nil)
+		req = withUser(req, 9, domain.RoleFinanceDirector)
+		req = mux.SetURLVars(req, map[string]string{"id": "7"})
+		rr := httptest.NewRecorder()
+		mockUC.On("RequeueDeadLetter", mock.Anything, domain.PaymentKindExpenseReport, 7, 9).Return(&domain.DeadLetter{
+			Kind:   domain.PaymentKindExpenseReport,
+			Report: &domain.ExpenseReport{ID: 7, Status: domain.ExpenseStatusRetryScheduled},
+		}, nil).Once()
+
+		h.RequeueDeadLetter(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"kind":"expense_report"`)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		mockUC := new(mocks.PaymentUseCase)
+		h := NewPaymentHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/payments/dead-letter/7/requeue?kind=invoice", nil)
+		req = withUser(req, 9, domain.RoleFinanceDirector)
+		req = mux.SetURLVars(req, map[string]string{"id": "7"})
+		rr := httptest.NewRecorder()
+		mockUC.On("RequeueDeadLetter", mock.Anything, domain.PaymentKind("invoice"), 7, 9).Return((*domain.DeadLetter)(nil), domain.ErrInvalidPaymentKind).Once()
+
+		h.RequeueDeadLetter(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		h := NewPaymentHandler(new(mocks.PaymentUseCase))
+		req := httptest.NewRequest(http.MethodPost, "/payments/dead-letter/abc/requeue", nil)
+		req = withUser(req, 9, domain.RoleFinanceDirector)
+		req = mux.SetURLVars(req, map[string]string{"id": "abc"})
+		rr := httptest.NewRecorder()
+
+		h.RequeueDeadLetter(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestPaymentHandlerAbandonDeadLetter(t *testing.T) {
+	t.Run("abandoned", func(t *testing.T) {
+		mockUC := new(mocks.PaymentUseCase)
+		h := NewPaymentHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/payments/dead-letter/5/abandon", strings.NewReader(`{"reason":"bank account closed"}`))
+		req = withUser(req, 9, domain.RoleFinanceDirector)
+		req = mux.SetURLVars(req, map[string]string{"id": "5"})
+		rr := httptest.NewRecorder()
+		mockUC.On("AbandonDeadLetter", mock.Anything, domain.PaymentKindExpense, 5, 9, "bank account closed").Return(&domain.DeadLetter{
+			Expense: &domain.Expense{ID: 5, Status: domain.ExpenseStatusFailed},
+		}, nil).Once()
+
+		h.AbandonDeadLetter(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockUC := new(mocks.PaymentUseCase)
+		h := NewPaymentHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/payments/dead-letter/5/abandon", strings.NewReader(`{}`))
+		req = withUser(req, 9, domain.RoleFinanceDirector)
+		req = mux.SetURLVars(req, map[string]string{"id": "5"})
+		rr := httptest.NewRecorder()
+		mockUC.On("AbandonDeadLetter", mock.Anything, domain.PaymentKindExpense, 5, 9, "").Return((*domain.DeadLetter)(nil), domain.ErrExpenseNotFound).Once()
+
+		h.AbandonDeadLetter(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
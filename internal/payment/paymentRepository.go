@@ -0,0 +1,13 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type PaymentRepository interface {
+	Create(ctx context.Context, payment *domain.Payment) error
+	FindBySubject(ctx context.Context, kind domain.PaymentKind, subjectID int) (*domain.Payment, error)
+	Update(ctx context.Context, payment *domain.Payment) error
+}
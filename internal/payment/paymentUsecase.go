@@ -0,0 +1,13 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type PaymentUseCase interface {
+	GetDeadLetters(ctx context.Context) ([]*domain.DeadLetter, error)
+	RequeueDeadLetter(ctx context.Context, kind domain.PaymentKind, id int, userID int) (*domain.DeadLetter, error)
+	AbandonDeadLetter(ctx context.Context, kind domain.PaymentKind, id int, userID int, reason string) (*domain.DeadLetter, error)
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/payment"
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is the Postgres error code raised when a unique index rejects a row.
+const uniqueViolation = "23505"
+
+type paymentRepository struct {
+	db *sql.DB
+}
+
+func NewPaymentRepository(db *sql.DB) payment.PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+// Create stores the payment before it is sent to the provider. Every subject has at most
+// one payment; ErrStatusConflict is returned when another worker recorded it first.
+func (r *paymentRepository) Create(ctx context.Context, payment *domain.Payment) error {
+	query := `
+		INSERT INTO payments (kind, subject_id, external_id, amount_idr, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		payment.Kind,
+		payment.SubjectID,
+		payment.ExternalID,
+		payment.AmountIDR,
+		payment.Status,
+	).Scan(&payment.ID, &payment.CreatedAt, &payment.UpdatedAt)
+
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+		return domain.ErrStatusConflict
+	}
+
+	return err
+}
+
+// FindBySubject returns the payment made for the subject, or nil when it was never sent.
+func (r *paymentRepository) FindBySubject(ctx context.Context, kind domain.PaymentKind, subjectID int) (*domain.Payment, error) {
+	query := `
+		SELECT id, kind, subject_id, external_id, amount_idr, status, provider_id, raw_response,
+			attempts, next_attempt_at, last_error, created_at, updated_at
+		FROM payments
+		WHERE kind = $1 AND subject_id = $2
+	`
+
+	payment := &domain.Payment{}
+	err := r.db.QueryRowContext(ctx, query, kind, subjectID).Scan(
+		&payment.ID,
+		&payment.Kind,
+		&payment.SubjectID,
+		&payment.ExternalID,
+		&payment.AmountIDR,
+		&payment.Status,
+		&payment.ProviderID,
+		&payment.RawResponse,
+		&payment.Attempts,
+		&payment.NextAttemptAt,
+		&payment.LastError,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// Update saves the payment's external id, the provider's answer and its retry state.
+func (r *paymentRepository) Update(ctx context.Context, payment *domain.Payment) error {
+	query := `
+		UPDATE payments
+		SET external_id = $1, status = $2, provider_id = $3, raw_response = $4,
+			attempts = $5, next_attempt_at = $6, last_error = $7, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $8
+		RETURNING updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		payment.ExternalID,
+		payment.Status,
+		payment.ProviderID,
+		payment.RawResponse,
+		payment.Attempts,
+		payment.NextAttemptAt,
+		payment.LastError,
+		payment.ID,
+	).Scan(&payment.UpdatedAt)
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &paymentRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO payments (kind, subject_id, external_id, amount_idr, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`)
+	now := time.Now()
+	newPayment := func() *domain.Payment {
+		return &domain.Payment{
+			Kind:       domain.PaymentKindExpense,
+			SubjectID:  5,
+			ExternalID: "ext_1",
+			AmountIDR:  250000,
+			Status:     domain.PaymentStatusPending,
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		payment := newPayment()
+		mock.ExpectQuery(query).
+			WithArgs(payment.Kind, payment.SubjectID, payment.ExternalID, payment.AmountIDR, payment.Status).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(3, now, now))
+
+		require.NoError(t, repo.Create(context.Background(), payment))
+		require.Equal(t, 3, payment.ID)
+	})
+
+	t.Run("subject already has a payment", func(t *testing.T) {
+		payment := newPayment()
+		mock.ExpectQuery(query).
+			WithArgs(payment.Kind, payment.SubjectID, payment.ExternalID, payment.AmountIDR, payment.Status).
+			WillReturnError(&pq.Error{Code: uniqueViolation})
+
+		require.ErrorIs(t, repo.Create(context.Background(), payment), domain.ErrStatusConflict)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPaymentRepositoryFindBySubject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &paymentRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id, kind, subject_id, external_id, amount_idr, status, provider_id, raw_response,
+			attempts, next_attempt_at, last_error, created_at, updated_at
+		FROM payments
+		WHERE kind = $1 AND subject_id = $2
+	`)
+	now := time.Now()
+
+	t.Run("found", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "kind", "subject_id", "external_id", "amount_idr", "status", "provider_id", "raw_response", "attempts", "next_attempt_at", "last_error", "created_at", "updated_at"}).
+			AddRow(3, domain.PaymentKindExpense, 5, "ext_1", 250000, domain.PaymentStatusPending, "", "", 2, now, "context deadline exceeded", now, now)
+		mock.ExpectQuery(query).WithArgs(domain.PaymentKindExpense, 5).WillReturnRows(rows)
+
+		payment, findErr := repo.FindBySubject(context.Background(), domain.PaymentKindExpense, 5)
+		require.NoError(t, findErr)
+		require.Equal(t, "ext_1", payment.ExternalID)
+		require.Equal(t, domain.PaymentStatusPending, payment.Status)
+		require.Equal(t, 2, payment.Attempts)
+		require.NotNil(t, payment.NextAttemptAt)
+	})
+
+	t.Run("never sent", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs(domain.PaymentKindExpenseReport, 5).WillReturnError(sql.ErrNoRows)
+
+		payment, findErr := repo.FindBySubject(context.Background(), domain.PaymentKindExpenseReport, 5)
+		require.NoError(t, findErr)
+		require.Nil(t, payment)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPaymentRepositoryUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &paymentRepository{db: db}
+	query := regexp.QuoteMeta(`
+		UPDATE payments
+		SET external_id = $1, status = $2, provider_id = $3, raw_response = $4,
+			attempts = $5, next_attempt_at = $6, last_error = $7, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $8
+		RETURNING updated_at
+	`)
+	payment := &domain.Payment{ID: 3, ExternalID: "ext_1", Status: domain.PaymentStatusSucceeded, ProviderID: "pay_1", RawResponse: `{"data":{}}`, Attempts: 2}
+
+	mock.ExpectQuery(query).
+		WithArgs(payment.ExternalID, payment.Status, payment.ProviderID, payment.RawResponse, 2, nil, "", payment.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+
+	require.NoError(t, repo.Update(context.Background(), payment))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
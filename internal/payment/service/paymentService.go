@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"time"
@@ -40,7 +39,7 @@ func (s *paymentService) ProcessPayment(ctx context.Context, amount int, externa
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, err
 	}
@@ -58,14 +57,21 @@ func (s *paymentService) ProcessPayment(ctx context.Context, amount int, externa
 		return nil, err
 	}
 
+	// Error responses are not always JSON, for instance when a proxy in front of the
+	// provider answers; their status code is what matters.
 	var paymentResp domain.PaymentResponse
 	err = json.Unmarshal(body, &paymentResp)
-	if err != nil {
+	if err != nil && resp.StatusCode == http.StatusOK {
 		return nil, err
 	}
+	paymentResp.Raw = body
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("payment failed: %s", paymentResp.Message)
+		message := paymentResp.Message
+		if message == "" {
+			message = http.StatusText(resp.StatusCode)
+		}
+		return nil, &domain.PaymentError{StatusCode: resp.StatusCode, Message: message, Body: body}
 	}
 
 	return &paymentResp, nil
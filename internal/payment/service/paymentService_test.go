@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,6 +25,7 @@ func TestProcessPayment(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, resp)
 		require.Equal(t, "pay_1", resp.Data.ID)
+		require.JSONEq(t, `{"data":{"id":"pay_1","external_id":"ext_1","status":"success"}}`, string(resp.Raw))
 	})
 
 	t.Run("failed payment response", func(t *testing.T) {
@@ -37,6 +39,28 @@ func TestProcessPayment(t *testing.T) {
 		resp, err := svc.ProcessPayment(context.Background(), 12000, "ext_2")
 		require.Error(t, err)
 		require.Nil(t, resp)
+
+		var paymentErr *domain.PaymentError
+		require.ErrorAs(t, err, &paymentErr)
+		require.Equal(t, http.StatusBadRequest, paymentErr.StatusCode)
+		require.Equal(t, "insufficient funds", paymentErr.Message)
+		require.Equal(t, `{"message":"insufficient funds"}`, string(paymentErr.Body))
+	})
+
+	t.Run("server error without a json body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`<html>Bad Gateway</html>`))
+		}))
+		defer server.Close()
+
+		svc := NewPaymentService(server.URL)
+		_, err := svc.ProcessPayment(context.Background(), 12000, "ext_4")
+
+		var paymentErr *domain.PaymentError
+		require.ErrorAs(t, err, &paymentErr)
+		require.Equal(t, http.StatusBadGateway, paymentErr.StatusCode)
+		require.Equal(t, "Bad Gateway", paymentErr.Message)
 	})
 
 	t.Run("invalid json response", func(t *testing.T) {
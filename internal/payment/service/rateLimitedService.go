@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/payment"
+	"github.com/evrintobing17/expense-management-backend/pkg/ratelimit"
+)
+
+// rateLimitedService holds payments back so the provider is not sent more of them than
+// the limiter allows, however many the worker pays at once.
+type rateLimitedService struct {
+	next    payment.PaymentService
+	limiter *ratelimit.TokenBucket
+}
+
+func NewRateLimitedService(next payment.PaymentService, limiter *ratelimit.TokenBucket) payment.PaymentService {
+	return &rateLimitedService{
+		next:    next,
+		limiter: limiter,
+	}
+}
+
+func (s *rateLimitedService) ProcessPayment(ctx context.Context, amount int, externalID string) (*domain.PaymentResponse, error) {
+	if err := s.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.next.ProcessPayment(ctx, amount, externalID)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/evrintobing17/expense-management-backend/pkg/ratelimit"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitedServiceProcessPayment(t *testing.T) {
+	t.Run("passes payments through within the limit", func(t *testing.T) {
+		next := new(mocks.PaymentService)
+		next.On("ProcessPayment", mock.Anything, 12000, "ext_1").Return(&domain.PaymentResponse{}, nil).Once()
+
+		svc := NewRateLimitedService(next, ratelimit.NewTokenBucket(1, 1))
+		_, err := svc.ProcessPayment(context.Background(), 12000, "ext_1")
+		require.NoError(t, err)
+		next.AssertExpectations(t)
+	})
+
+	t.Run("gives up waiting when the context ends", func(t *testing.T) {
+		next := new(mocks.PaymentService)
+		svc := NewRateLimitedService(next, ratelimit.NewTokenBucket(0.001, 1))
+		next.On("ProcessPayment", mock.Anything, 12000, "ext_1").Return(&domain.PaymentResponse{}, nil).Once()
+		_, err := svc.ProcessPayment(context.Background(), 12000, "ext_1")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = svc.ProcessPayment(ctx, 12000, "ext_2")
+		require.ErrorIs(t, err, context.Canceled)
+		next.AssertNotCalled(t, "ProcessPayment", mock.Anything, 12000, "ext_2")
+	})
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/advance"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/expense"
+	"github.com/evrintobing17/expense-management-backend/internal/history"
+	"github.com/evrintobing17/expense-management-backend/internal/payment"
+	"github.com/evrintobing17/expense-management-backend/internal/report"
+)
+
+type paymentUseCase struct {
+	expenseRepo expense.ExpenseRepository
+	reportRepo  report.ReportRepository
+	advanceRepo advance.AdvanceRepository
+	paymentRepo payment.PaymentRepository
+	historyRepo history.HistoryRepository
+}
+
+func NewPaymentUseCase(
+	expenseRepo expense.ExpenseRepository,
+	reportRepo report.ReportRepository,
+	advanceRepo advance.AdvanceRepository,
+	paymentRepo payment.PaymentRepository,
+	historyRepo history.HistoryRepository,
+) payment.PaymentUseCase {
+	return &paymentUseCase{
+		expenseRepo: expenseRepo,
+		reportRepo:  reportRepo,
+		advanceRepo: advanceRepo,
+		paymentRepo: paymentRepo,
+		historyRepo: historyRepo,
+	}
+}
+
+// GetDeadLetters lists the expenses, expense reports and cash advances whose payment ran
+// out of retries.
+func (uc *paymentUseCase) GetDeadLetters(ctx context.Context) ([]*domain.DeadLetter, error) {
+	expenses, err := uc.expenseRepo.FindByStatus(ctx, domain.ExpenseStatusDeadLetter)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := uc.reportRepo.FindByStatus(ctx, domain.ExpenseStatusDeadLetter)
+	if err != nil {
+		return nil, err
+	}
+
+	advances, err := uc.advanceRepo.FindByStatus(ctx, domain.AdvanceStatusDeadLetter)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetters := make([]*domain.DeadLetter, 0, len(expenses)+len(reports)+len(advances))
+	for _, e := range expenses {
+		deadLetters = append(deadLetters, &domain.DeadLetter{Kind: domain.PaymentKindExpense, Expense: e})
+	}
+	for _, r := range reports {
+		deadLetters = append(deadLetters, &domain.DeadLetter{Kind: domain.PaymentKindExpenseReport, Report: r})
+	}
+	for _, a := range advances {
+		deadLetters = append(deadLetters, &domain.DeadLetter{Kind: a.PaymentKind(), Advance: a})
+	}
+
+	for _, deadLetter := range deadLetters {
+		deadLetter.Payment, err = uc.paymentRepo.FindBySubject(ctx, deadLetter.Kind, subjectID(deadLetter))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return deadLetters, nil
+}
+
+// RequeueDeadLetter hands a dead-lettered payment of the given kind back to the payment
+// worker with a fresh set of attempts. It is sent again under the same external id right
+// away.
+func (uc *paymentUseCase) RequeueDeadLetter(ctx context.Context, kind domain.PaymentKind, id int, userID int) (*domain.DeadLetter, error) {
+	deadLetter, err := uc.findDeadLetter(ctx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reset the attempts before the worker can see the subject again.
+	if p := deadLetter.Payment; p != nil {
+		p.Attempts = 0
+		p.NextAttemptAt = nil
+		if err := uc.paymentRepo.Update(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+
+	err = uc.transition(ctx, deadLetter, domain.ExpenseStatusRetryScheduled, domain.AdvanceStatusRetryScheduled, userID, "payment requeued")
+	if err != nil {
+		return nil, err
+	}
+
+	return deadLetter, nil
+}
+
+// AbandonDeadLetter gives up on a dead-lettered payment of the given kind; what it paid
+// for then fails.
+func (uc *paymentUseCase) AbandonDeadLetter(ctx context.Context, kind domain.PaymentKind, id int, userID int, reason string) (*domain.DeadLetter, error) {
+	deadLetter, err := uc.findDeadLetter(ctx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason == "" {
+		reason = "payment abandoned"
+	}
+
+	if err := uc.transition(ctx, deadLetter, domain.ExpenseStatusFailed, domain.AdvanceStatusFailed, userID, reason); err != nil {
+		return nil, err
+	}
+
+	return deadLetter, nil
+}
+
+// findDeadLetter loads the dead-lettered expense, report or advance that a payment of the
+// given kind pays for, together with the payment. The advance kinds must match the
+// payment the advance is waiting on.
+func (uc *paymentUseCase) findDeadLetter(ctx context.Context, kind domain.PaymentKind, id int) (*domain.DeadLetter, error) {
+	deadLetter := &domain.DeadLetter{Kind: kind}
+
+	switch kind {
+	case domain.PaymentKindExpense:
+		e, err := uc.expenseRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if e == nil {
+			return nil, domain.ErrExpenseNotFound
+		}
+		if e.Status != domain.ExpenseStatusDeadLetter {
+			return nil, domain.ErrInvalidExpenseStatus
+		}
+		deadLetter.Expense = e
+	case domain.PaymentKindExpenseReport:
+		r, err := uc.reportRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if r == nil {
+			return nil, domain.ErrReportNotFound
+		}
+		if r.Status != domain.ExpenseStatusDeadLetter {
+			return nil, domain.ErrInvalidExpenseStatus
+		}
+		deadLetter.Report = r
+	case domain.PaymentKindAdvance, domain.PaymentKindAdvanceReimbursement:
+		a, err := uc.advanceRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if a == nil {
+			return nil, domain.ErrAdvanceNotFound
+		}
+		if a.Status != domain.AdvanceStatusDeadLetter || a.PaymentKind() != kind {
+			return nil, domain.ErrInvalidAdvanceStatus
+		}
+		deadLetter.Advance = a
+	default:
+		return nil, domain.ErrInvalidPaymentKind
+	}
+
+	p, err := uc.paymentRepo.FindBySubject(ctx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	deadLetter.Payment = p
+
+	return deadLetter, nil
+}
+
+// transition moves what the dead letter pays for to its next status, to for an expense
+// or report and toAdvance for a cash advance, and appends the change, made by the
+// finance user, to the history of the expense or of every report line that moved.
+func (uc *paymentUseCase) transition(ctx context.Context, deadLetter *domain.DeadLetter, to domain.ExpenseStatus, toAdvance domain.AdvanceStatus, userID int, reason string) error {
+	switch {
+	case deadLetter.Report != nil:
+		r := deadLetter.Report
+		lines, err := uc.expenseRepo.FindByReportID(ctx, r.ID)
+		if err != nil {
+			return err
+		}
+
+		from := r.Status
+		r.Status = to
+		r.Lines = lines
+		moved, err := uc.reportRepo.UpdateStatus(ctx, r, from)
+		if err != nil {
+			r.Status = from
+			return err
+		}
+
+		for _, id := range moved {
+			if err := uc.historyRepo.Create(ctx, domain.UserStatusChange(id, from, to, userID, reason)); err != nil {
+				return err
+			}
+			if line, ok := r.Line(id); ok {
+				line.Status = to
+			}
+		}
+		return nil
+	case deadLetter.Advance != nil:
+		a := deadLetter.Advance
+		from := a.Status
+		a.Status = toAdvance
+		if err := uc.advanceRepo.UpdateStatus(ctx, a, from); err != nil {
+			a.Status = from
+			return err
+		}
+		return nil
+	default:
+		e := deadLetter.Expense
+		from := e.Status
+		if err := uc.expenseRepo.UpdateStatus(ctx, e.ID, from, to, nil); err != nil {
+			return err
+		}
+		e.Status = to
+
+		return uc.historyRepo.Create(ctx, domain.UserStatusChange(e.ID, from, to, userID, reason))
+	}
+}
+
+// subjectID returns the id of what the dead letter pays for.
+func subjectID(deadLetter *domain.DeadLetter) int {
+	switch {
+	case deadLetter.Report != nil:
+		return deadLetter.Report.ID
+	case deadLetter.Advance != nil:
+		return deadLetter.Advance.ID
+	default:
+		return deadLetter.Expense.ID
+	}
+}
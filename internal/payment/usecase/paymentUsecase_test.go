@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/payment"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type useCaseMocks struct {
+	expense *mocks.ExpenseRepository
+	report  *mocks.ReportRepository
+	advance *mocks.AdvanceRepository
+	payment *mocks.PaymentRepository
+	history *mocks.HistoryRepository
+}
+
+func newUseCaseMocks() *useCaseMocks {
+	m := &useCaseMocks{
+		expense: new(mocks.ExpenseRepository),
+		report:  new(mocks.ReportRepository),
+		advance: new(mocks.AdvanceRepository),
+		payment: new(mocks.PaymentRepository),
+		history: new(mocks.HistoryRepository),
+	}
+	m.history.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *useCaseMocks) useCase() payment.PaymentUseCase {
+	return NewPaymentUseCase(m.expense, m.report, m.advance, m.payment, m.history)
+}
+
+func (m *useCaseMocks) expectDeadLetter(status domain.ExpenseStatus) {
+	m.expense.On("FindByID", mock.Anything, 5).Return(&domain.Expense{ID: 5, AmountIDR: 250000, Status: status}, nil)
+	m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+		Return(&domain.Payment{ID: 3, ExternalID: "ext_1", Status: domain.PaymentStatusPending, Attempts: 5, LastError: "503 Service Unavailable"}, nil).Maybe()
+}
+
+func TestGetDeadLetters(t *testing.T) {
+	m := newUseCaseMocks()
+	paidAt := time.Now()
+	m.expense.On("FindByStatus", mock.Anything, domain.ExpenseStatusDeadLetter).Return([]*domain.Expense{{ID: 5, Status: domain.ExpenseStatusDeadLetter}}, nil)
+	m.report.On("FindByStatus", mock.Anything, domain.ExpenseStatusDeadLetter).Return([]*domain.ExpenseReport{{ID: 7, Status: domain.ExpenseStatusDeadLetter}}, nil)
+	m.advance.On("FindByStatus", mock.Anything, domain.AdvanceStatusDeadLetter).
+		Return([]*domain.CashAdvance{{ID: 4, Status: domain.AdvanceStatusDeadLetter, PaidAt: &paidAt}}, nil)
+	m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).Return(&domain.Payment{ID: 3, Attempts: 5}, nil)
+	m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpenseReport, 7).Return(&domain.Payment{ID: 8, Attempts: 5}, nil)
+	m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindAdvanceReimbursement, 4).Return(&domain.Payment{ID: 9, Attempts: 5}, nil)
+
+	deadLetters, err := m.useCase().GetDeadLetters(context.Background())
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 3)
+	require.Equal(t, 5, deadLetters[0].Payment.Attempts)
+	require.Equal(t, domain.PaymentKindExpenseReport, deadLetters[1].Kind)
+	require.Equal(t, 8, deadLetters[1].Payment.ID)
+	require.Equal(t, domain.PaymentKindAdvanceReimbursement, deadLetters[2].Kind)
+	require.Equal(t, 9, deadLetters[2].Payment.ID)
+}
+
+func TestRequeueDeadLetter(t *testing.T) {
+	t.Run("attempts start over", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectDeadLetter(domain.ExpenseStatusDeadLetter)
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Attempts == 0 && p.NextAttemptAt == nil && p.ExternalID == "ext_1"
+		})).Return(nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, 5, domain.ExpenseStatusDeadLetter, domain.ExpenseStatusRetryScheduled, mock.Anything).Return(nil).Once()
+
+		deadLetter, err := m.useCase().RequeueDeadLetter(context.Background(), domain.PaymentKindExpense, 5, 9)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusRetryScheduled, deadLetter.Expense.Status)
+		m.history.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.ActorType == domain.ActorTypeUser && *h.ActorID == 9 && h.Reason == "payment requeued"
+		}))
+	})
+
+	t.Run("not dead-lettered", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectDeadLetter(domain.ExpenseStatusRetryScheduled)
+
+		_, err := m.useCase().RequeueDeadLetter(context.Background(), domain.PaymentKindExpense, 5, 9)
+		require.ErrorIs(t, err, domain.ErrInvalidExpenseStatus)
+		m.payment.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expense.On("FindByID", mock.Anything, 5).Return((*domain.Expense)(nil), nil)
+
+		_, err := m.useCase().RequeueDeadLetter(context.Background(), domain.PaymentKindExpense, 5, 9)
+		require.ErrorIs(t, err, domain.ErrExpenseNotFound)
+	})
+
+	t.Run("expense report moves with its lines", func(t *testing.T) {
+		m := newUseCaseMocks()
+		reportID := 7
+		m.report.On("FindByID", mock.Anything, reportID).Return(&domain.ExpenseReport{ID: reportID, Status: domain.ExpenseStatusDeadLetter}, nil).Once()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpenseReport, reportID).
+			Return(&domain.Payment{ID: 8, ExternalID: "ext_7", Status: domain.PaymentStatusPending, Attempts: 5}, nil).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Attempts == 0 && p.ExternalID == "ext_7"
+		})).Return(nil).Once()
+		m.expense.On("FindByReportID", mock.Anything, reportID).Return([]*domain.Expense{
+			{ID: 11, ReportID: &reportID, Status: domain.ExpenseStatusDeadLetter},
+			{ID: 12, ReportID: &reportID, Status: domain.ExpenseStatusRejected},
+		}, nil).Once()
+		m.report.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(r *domain.ExpenseReport) bool {
+			return r.Status == domain.ExpenseStatusRetryScheduled
+		}), domain.ExpenseStatusDeadLetter).Return([]int{11}, nil).Once()
+
+		deadLetter, err := m.useCase().RequeueDeadLetter(context.Background(), domain.PaymentKindExpenseReport, reportID, 9)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusRetryScheduled, deadLetter.Report.Status)
+		m.history.AssertNumberOfCalls(t, "Create", 1)
+		m.history.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.ExpenseID == 11 && h.ToStatus == domain.ExpenseStatusRetryScheduled && *h.ActorID == 9
+		}))
+	})
+
+	t.Run("cash advance reimbursement", func(t *testing.T) {
+		m := newUseCaseMocks()
+		paidAt := time.Now()
+		m.advance.On("FindByID", mock.Anything, 4).Return(&domain.CashAdvance{ID: 4, Status: domain.AdvanceStatusDeadLetter, PaidAt: &paidAt}, nil).Once()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindAdvanceReimbursement, 4).
+			Return(&domain.Payment{ID: 9, ExternalID: "ext_r4", Status: domain.PaymentStatusPending, Attempts: 5}, nil).Once()
+		m.payment.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		m.advance.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(a *domain.CashAdvance) bool {
+			return a.Status == domain.AdvanceStatusRetryScheduled
+		}), domain.AdvanceStatusDeadLetter).Return(nil).Once()
+
+		deadLetter, err := m.useCase().RequeueDeadLetter(context.Background(), domain.PaymentKindAdvanceReimbursement, 4, 9)
+		require.NoError(t, err)
+		require.Equal(t, domain.AdvanceStatusRetryScheduled, deadLetter.Advance.Status)
+	})
+
+	t.Run("cash advance waiting on another payment", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.advance.On("FindByID", mock.Anything, 4).Return(&domain.CashAdvance{ID: 4, Status: domain.AdvanceStatusDeadLetter}, nil).Once()
+
+		_, err := m.useCase().RequeueDeadLetter(context.Background(), domain.PaymentKindAdvanceReimbursement, 4, 9)
+		require.ErrorIs(t, err, domain.ErrInvalidAdvanceStatus)
+		m.payment.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		m := newUseCaseMocks()
+
+		_, err := m.useCase().RequeueDeadLetter(context.Background(), domain.PaymentKind("invoice"), 4, 9)
+		require.ErrorIs(t, err, domain.ErrInvalidPaymentKind)
+	})
+}
+
+func TestAbandonDeadLetter(t *testing.T) {
+	t.Run("fails the expense", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectDeadLetter(domain.ExpenseStatusDeadLetter)
+		m.expense.On("UpdateStatus", mock.Anything, 5, domain.ExpenseStatusDeadLetter, domain.ExpenseStatusFailed, mock.Anything).Return(nil).Once()
+
+		deadLetter, err := m.useCase().AbandonDeadLetter(context.Background(), domain.PaymentKindExpense, 5, 9, "bank account closed")
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusFailed, deadLetter.Expense.Status)
+		m.payment.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		m.history.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.Reason == "bank account closed"
+		}))
+	})
+
+	t.Run("requeued by someone else meanwhile", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectDeadLetter(domain.ExpenseStatusDeadLetter)
+		m.expense.On("UpdateStatus", mock.Anything, 5, domain.ExpenseStatusDeadLetter, domain.ExpenseStatusFailed, mock.Anything).Return(domain.ErrStatusConflict).Once()
+
+		_, err := m.useCase().AbandonDeadLetter(context.Background(), domain.PaymentKindExpense, 5, 9, "")
+		require.ErrorIs(t, err, domain.ErrStatusConflict)
+		m.history.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails the cash advance", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.advance.On("FindByID", mock.Anything, 4).Return(&domain.CashAdvance{ID: 4, Status: domain.AdvanceStatusDeadLetter}, nil).Once()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindAdvance, 4).Return(&domain.Payment{ID: 5, Attempts: 5}, nil).Once()
+		m.advance.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(a *domain.CashAdvance) bool {
+			return a.Status == domain.AdvanceStatusFailed
+		}), domain.AdvanceStatusDeadLetter).Return(nil).Once()
+
+		deadLetter, err := m.useCase().AbandonDeadLetter(context.Background(), domain.PaymentKindAdvance, 4, 9, "")
+		require.NoError(t, err)
+		require.Equal(t, domain.AdvanceStatusFailed, deadLetter.Advance.Status)
+		m.payment.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
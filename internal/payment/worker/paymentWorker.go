@@ -2,39 +2,77 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
-	"strings"
+	"math/rand"
+	"net"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/evrintobing17/expense-management-backend/internal/advance"
 	"github.com/evrintobing17/expense-management-backend/internal/domain"
 	"github.com/evrintobing17/expense-management-backend/internal/expense"
+	"github.com/evrintobing17/expense-management-backend/internal/history"
 	"github.com/evrintobing17/expense-management-backend/internal/payment"
+	"github.com/evrintobing17/expense-management-backend/internal/report"
 	"github.com/evrintobing17/expense-management-backend/pkg/utils"
 )
 
+// Options tune how the payment worker claims its work.
+type Options struct {
+	// Interval is the time between two sweeps for payable work.
+	Interval time.Duration
+	// BatchSize caps how many expenses, reports and advances each sweep claims.
+	BatchSize int
+	// Concurrency is how many payments are sent to the provider at once.
+	Concurrency int
+	// ClaimTimeout is how long a claim may stay unfinished before a later sweep takes
+	// it over, for when a worker dies mid-payment.
+	ClaimTimeout time.Duration
+	// Retry decides when a payment that failed for a transient reason is sent again.
+	Retry domain.RetryPolicy
+	// Wake, when set, starts a sweep as soon as it receives a value, such as when new
+	// work is approved, instead of waiting for the next interval.
+	Wake <-chan struct{}
+}
+
 type PaymentWorker struct {
 	expenseRepo    expense.ExpenseRepository
+	reportRepo     report.ReportRepository
+	advanceRepo    advance.AdvanceRepository
+	historyRepo    history.HistoryRepository
+	paymentRepo    payment.PaymentRepository
 	paymentService payment.PaymentService
-	interval       time.Duration
+	opts           Options
 }
 
-func NewPaymentWorker(expenseRepo expense.ExpenseRepository, paymentService payment.PaymentService, interval time.Duration) *PaymentWorker {
+func NewPaymentWorker(expenseRepo expense.ExpenseRepository, reportRepo report.ReportRepository, advanceRepo advance.AdvanceRepository, historyRepo history.HistoryRepository, paymentRepo payment.PaymentRepository, paymentService payment.PaymentService, opts Options) *PaymentWorker {
 	return &PaymentWorker{
 		expenseRepo:    expenseRepo,
+		reportRepo:     reportRepo,
+		advanceRepo:    advanceRepo,
+		historyRepo:    historyRepo,
+		paymentRepo:    paymentRepo,
 		paymentService: paymentService,
-		interval:       interval,
+		opts:           opts,
 	}
 }
 
+// Start sweeps for payable work every interval and whenever Options.Wake is signalled,
+// until ctx is done. The interval sweep picks up whatever a missed signal would have.
 func (w *PaymentWorker) Start(ctx context.Context) {
-	ticker := time.NewTicker(w.interval)
+	ticker := time.NewTicker(w.opts.Interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
 			w.processPayments(ctx)
+		case <-w.opts.Wake:
+			w.processPayments(ctx)
 		case <-ctx.Done():
 			log.Println("Payment worker stopped")
 			return
@@ -42,42 +80,380 @@ func (w *PaymentWorker) Start(ctx context.Context) {
 	}
 }
 
+// processPayments claims each kind of payable work and pays it through a pool of
+// Options.Concurrency goroutines, so one slow payment holds up a single slot rather than
+// the whole queue. Claiming marks the rows as being paid in the same statement that
+// selects them, so several worker replicas can run side by side without paying anything
+// twice. Work is claimed in chunks no larger than the pool, and the next chunk only once
+// the pool has taken the last, so nothing sits claimed long behind slow payments while
+// other replicas could have paid it. The sweep returns once everything it claimed has
+// been paid.
 func (w *PaymentWorker) processPayments(ctx context.Context) {
-	expenses, err := w.expenseRepo.FindByStatus(ctx, domain.ExpenseStatusApproved, domain.ExpenseStatusAutoApproved)
-	if err != nil {
-		log.Printf("Error fetching expenses for payment processing: %v", err)
-		return
+	concurrency := max(w.opts.Concurrency, 1)
+	jobs := make(chan func())
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				job()
+			}
+		}()
 	}
+	defer wg.Wait()
+	defer close(jobs)
 
-	for _, expense := range expenses {
-		err := w.processPayment(ctx, expense)
-		if err != nil {
+	feed(ctx, w, jobs, concurrency, "expenses", w.expenseRepo.ClaimForPayment, func(expense *domain.Expense) {
+		if err := w.processPayment(ctx, expense); err != nil {
 			log.Printf("Error processing payment for expense %d: %v", expense.ID, err)
-			w.expenseRepo.UpdateStatus(ctx, expense.ID, domain.ExpenseStatusFailed, nil)
+		}
+	})
+
+	feed(ctx, w, jobs, concurrency, "expense reports", w.reportRepo.ClaimForPayment, func(report *domain.ExpenseReport) {
+		if err := w.processReportPayment(ctx, report); err != nil {
+			log.Printf("Error processing payment for expense report %d: %v", report.ID, err)
+		}
+	})
+
+	feed(ctx, w, jobs, concurrency, "cash advances", w.advanceRepo.ClaimForPayment, func(advance *domain.CashAdvance) {
+		if err := w.processAdvancePayment(ctx, advance); err != nil {
+			log.Printf("Error processing payment for cash advance %d: %v", advance.ID, err)
+		}
+	})
+}
+
+// feed claims up to Options.BatchSize items of one kind, chunk at a time, and hands them
+// to the pool through jobs. Sending on jobs blocks while every slot is busy, which keeps
+// the next chunk from being claimed before the pool can take it.
+func feed[T any](ctx context.Context, w *PaymentWorker, jobs chan<- func(), chunk int, what string, claim func(context.Context, int, time.Duration) ([]T, error), process func(T)) {
+	for claimed := 0; claimed < w.opts.BatchSize && ctx.Err() == nil; {
+		limit := min(chunk, w.opts.BatchSize-claimed)
+		items, err := claim(ctx, limit, w.opts.ClaimTimeout)
+		if err != nil {
+			log.Printf("Error claiming %s for payment processing: %v", what, err)
+			return
+		}
+
+		for _, item := range items {
+			jobs <- func() { process(item) }
+		}
+
+		claimed += len(items)
+		if len(items) < limit {
+			return
 		}
 	}
 }
 
+// processPayment pays a claimed expense, which carries the status it was claimed from.
 func (w *PaymentWorker) processPayment(ctx context.Context, expense *domain.Expense) error {
-	idempotencyKey := utils.GenerateID()
+	from := expense.Status
+	expense.Status = domain.ExpenseStatusProcessing
+	switch from {
+	case domain.ExpenseStatusProcessing:
+		log.Printf("Retrying payment for expense %d after its claim timed out", expense.ID)
+	case domain.ExpenseStatusRetryScheduled:
+		w.recordHistory(ctx, expense.ID, from, domain.ExpenseStatusProcessing, "payment retried")
+	default:
+		w.recordHistory(ctx, expense.ID, from, domain.ExpenseStatusProcessing, "payment started")
+	}
+
+	payment, err := w.pay(ctx, domain.PaymentKindExpense, expense.ID, expense.AmountIDR, fmt.Sprintf("expense %d", expense.ID))
+	if err != nil {
+		if payment == nil || ctx.Err() != nil {
+			// The provider was never called, or the worker is shutting down; the claim
+			// times out and is picked up again.
+			return err
+		}
+		to, reason := failPayment(payment, err)
+		if updateErr := w.transition(ctx, expense.ID, domain.ExpenseStatusProcessing, to, nil, reason); updateErr != nil {
+			log.Printf("Error marking expense %d as %s: %v", expense.ID, to, updateErr)
+		}
+		return err
+	}
 
-	paymentResp, err := w.paymentService.ProcessPayment(ctx, expense.AmountIDR, idempotencyKey)
+	now := time.Now()
+	return w.transition(ctx, expense.ID, domain.ExpenseStatusProcessing, domain.ExpenseStatusCompleted, &now, "payment completed")
+}
+
+// failPayment decides what becomes of an expense, report or advance whose payment did
+// not go through, and returns the status to move it to with the reason. After a
+// transient error it waits for the retry pay scheduled, or is dead-lettered for finance
+// to requeue or abandon once the retry policy has run out of attempts. Any other error
+// fails it.
+func failPayment(payment *domain.Payment, err error) (domain.ExpenseStatus, string) {
+	switch {
+	case !isTransient(err):
+		return domain.ExpenseStatusFailed, err.Error()
+	case payment.NextAttemptAt == nil:
+		return domain.ExpenseStatusDeadLetter, fmt.Sprintf("payment failed after %d attempts: %v", payment.Attempts, err)
+	default:
+		return domain.ExpenseStatusRetryScheduled, fmt.Sprintf("payment attempt %d failed, retrying at %s: %v",
+			payment.Attempts, payment.NextAttemptAt.Format(time.RFC3339), err)
+	}
+}
+
+// processReportPayment pays a claimed expense report's total in a single payment. The
+// report carries the status it was claimed from; its lines, apart from the ones an
+// approver rejected, were claimed along with it. A failed payment is retried or
+// dead-lettered like an expense's.
+func (w *PaymentWorker) processReportPayment(ctx context.Context, report *domain.ExpenseReport) error {
+	lines, err := w.expenseRepo.FindByReportID(ctx, report.ID)
+	if err != nil {
+		return err
+	}
+	report.Lines = lines
+
+	from := report.Status
+	report.Status = domain.ExpenseStatusProcessing
+	if from == domain.ExpenseStatusProcessing {
+		log.Printf("Retrying payment for expense report %d after its claim timed out", report.ID)
+	} else {
+		reason := "payment started"
+		if from == domain.ExpenseStatusRetryScheduled {
+			reason = "payment retried"
+		}
+		for _, line := range report.Lines {
+			if line.Status == domain.ExpenseStatusProcessing {
+				w.recordHistory(ctx, line.ID, from, domain.ExpenseStatusProcessing, reason)
+			}
+		}
+	}
+
+	label := fmt.Sprintf("expense report %d", report.ID)
+	payment, err := w.pay(ctx, domain.PaymentKindExpenseReport, report.ID, report.TotalIDR, label)
 	if err != nil {
-		if isIdempotencyError(err) {
-			now := time.Now()
-			return w.expenseRepo.UpdateStatus(ctx, expense.ID, domain.ExpenseStatusCompleted, &now)
+		if payment == nil || ctx.Err() != nil {
+			return err
+		}
+		to, reason := failPayment(payment, err)
+		if updateErr := w.transitionReport(ctx, report, to, nil, reason); updateErr != nil {
+			log.Printf("Error marking expense report %d as %s: %v", report.ID, to, updateErr)
 		}
 		return err
 	}
 
-	if paymentResp.Data.Status == "success" {
-		now := time.Now()
-		return w.expenseRepo.UpdateStatus(ctx, expense.ID, domain.ExpenseStatusCompleted, &now)
+	now := time.Now()
+	return w.transitionReport(ctx, report, domain.ExpenseStatusCompleted, &now, "payment completed")
+}
+
+// advanceFailures maps the status failPayment picks to the matching cash advance status.
+var advanceFailures = map[domain.ExpenseStatus]domain.AdvanceStatus{
+	domain.ExpenseStatusRetryScheduled: domain.AdvanceStatusRetryScheduled,
+	domain.ExpenseStatusDeadLetter:     domain.AdvanceStatusDeadLetter,
+	domain.ExpenseStatusFailed:         domain.AdvanceStatusFailed,
+}
+
+// processAdvancePayment pays out a claimed cash advance, or the difference owed to the
+// employee after settling one. A failed payment is retried or dead-lettered like an
+// expense's.
+func (w *PaymentWorker) processAdvancePayment(ctx context.Context, advance *domain.CashAdvance) error {
+	paid := domain.AdvanceStatusOutstanding
+	kind, amount, label := domain.PaymentKindAdvance, advance.AmountIDR, fmt.Sprintf("cash advance %d", advance.ID)
+	if advance.Status == domain.AdvanceStatusReimbursing {
+		paid = domain.AdvanceStatusSettled
+		kind, amount, label = domain.PaymentKindAdvanceReimbursement, advance.ReimbursementIDR, fmt.Sprintf("cash advance %d reimbursement", advance.ID)
 	}
 
-	return fmt.Errorf("payment processing failed for expense %d", expense.ID)
+	payment, err := w.pay(ctx, kind, advance.ID, amount, label)
+	if err != nil {
+		if payment == nil || ctx.Err() != nil {
+			return err
+		}
+		// Advances keep no status history; the payment records the attempts and error.
+		failed, _ := failPayment(payment, err)
+		to := advanceFailures[failed]
+		if updateErr := w.transitionAdvance(ctx, advance, to); updateErr != nil {
+			log.Printf("Error marking cash advance %d as %s: %v", advance.ID, to, updateErr)
+		}
+		return err
+	}
+
+	now := time.Now()
+	if paid == domain.AdvanceStatusOutstanding {
+		advance.PaidAt = &now
+	} else {
+		advance.SettledAt = &now
+	}
+	return w.transitionAdvance(ctx, advance, paid)
 }
 
-func isIdempotencyError(err error) bool {
-	return strings.Contains(err.Error(), "external id already exists")
+// transitionAdvance moves a cash advance to its next status.
+func (w *PaymentWorker) transitionAdvance(ctx context.Context, advance *domain.CashAdvance, to domain.AdvanceStatus) error {
+	from := advance.Status
+	advance.Status = to
+	if err := w.advanceRepo.UpdateStatus(ctx, advance, from); err != nil {
+		advance.Status = from
+		return err
+	}
+	return nil
+}
+
+// transitionReport moves a report and its lines to the next status and appends the
+// change to the history of every line that moved.
+func (w *PaymentWorker) transitionReport(ctx context.Context, report *domain.ExpenseReport, to domain.ExpenseStatus, processedAt *time.Time, reason string) error {
+	from := report.Status
+	report.Status = to
+	report.ProcessedAt = processedAt
+	moved, err := w.reportRepo.UpdateStatus(ctx, report, from)
+	if err != nil {
+		report.Status = from
+		return err
+	}
+
+	for _, id := range moved {
+		if line, ok := report.Line(id); ok {
+			line.Status = to
+		}
+		w.recordHistory(ctx, id, from, to, reason)
+	}
+
+	return nil
+}
+
+// transition moves an expense to its next status and appends the change to its history.
+func (w *PaymentWorker) transition(ctx context.Context, expenseID int, from, to domain.ExpenseStatus, processedAt *time.Time, reason string) error {
+	err := w.expenseRepo.UpdateStatus(ctx, expenseID, from, to, processedAt)
+	if err != nil {
+		return err
+	}
+
+	w.recordHistory(ctx, expenseID, from, to, reason)
+	return nil
+}
+
+// recordHistory appends a status change made by the worker to an expense's history. A
+// failure is logged rather than returned, since the status change itself has already
+// been committed.
+func (w *PaymentWorker) recordHistory(ctx context.Context, expenseID int, from, to domain.ExpenseStatus, reason string) {
+	if err := w.historyRepo.Create(ctx, domain.WorkerStatusChange(expenseID, from, to, reason)); err != nil {
+		log.Printf("Error recording history for expense %d: %v", expenseID, err)
+	}
+}
+
+// errPaymentUndecided is returned when the provider accepted a payment without deciding
+// it yet. It is transient: the payment is asked for again under the same external id.
+var errPaymentUndecided = errors.New("payment not decided by the provider yet")
+
+// pay sends amount to the payment provider for the subject and returns the payment. The
+// payment and its external id are stored before the provider is called. A payment whose
+// outcome is unknown, after a timeout, a dropped connection, a server error at the
+// provider or an undecided answer, is sent again under the same external id so the
+// provider's duplicate check stops a second payout; only a payment the provider confirmed
+// as failed gets a new one. After a transient failure the next attempt is scheduled by
+// the retry policy, and left unset once it runs out of attempts. Every attempt is saved
+// before pay returns, apart from one cut short by ctx. label names what is being paid in
+// errors.
+func (w *PaymentWorker) pay(ctx context.Context, kind domain.PaymentKind, subjectID int, amount int, label string) (*domain.Payment, error) {
+	payment, err := w.paymentRepo.FindBySubject(ctx, kind, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case payment == nil:
+		payment = &domain.Payment{
+			Kind:       kind,
+			SubjectID:  subjectID,
+			ExternalID: utils.GenerateID(),
+			AmountIDR:  amount,
+			Status:     domain.PaymentStatusPending,
+		}
+		if err := w.paymentRepo.Create(ctx, payment); err != nil {
+			return nil, err
+		}
+	case payment.Status == domain.PaymentStatusSucceeded:
+		return payment, nil
+	case payment.Status == domain.PaymentStatusFailed:
+		// The provider confirmed the last attempt failed, so nothing was paid under its
+		// external id and a new one is safe.
+		payment.ExternalID = utils.GenerateID()
+		payment.Status = domain.PaymentStatusPending
+		payment.ProviderID, payment.RawResponse = "", ""
+		if err := w.paymentRepo.Update(ctx, payment); err != nil {
+			return nil, err
+		}
+	}
+
+	payment.Attempts++
+	payment.NextAttemptAt = nil
+	paymentResp, err := w.paymentService.ProcessPayment(ctx, payment.AmountIDR, payment.ExternalID)
+
+	var paymentErr *domain.PaymentError
+	switch {
+	case err == nil:
+		payment.ProviderID = paymentResp.Data.ID
+		payment.RawResponse = string(paymentResp.Raw)
+		switch paymentResp.Data.Status {
+		case domain.ProviderStatusSuccess:
+			payment.Status = domain.PaymentStatusSucceeded
+		case domain.ProviderStatusFailed:
+			payment.Status = domain.PaymentStatusFailed
+			payment.LastError = fmt.Sprintf("payment status %s", paymentResp.Data.Status)
+		default:
+			err = fmt.Errorf("%w: payment status %s", errPaymentUndecided, paymentResp.Data.Status)
+			payment.LastError = err.Error()
+		}
+	case errors.Is(err, domain.ErrDuplicatePayment):
+		// An earlier attempt under this external id reached the provider.
+		payment.Status = domain.PaymentStatusSucceeded
+		if errors.As(err, &paymentErr) {
+			payment.RawResponse = string(paymentErr.Body)
+		}
+	case errors.As(err, &paymentErr) && !isTransient(err):
+		payment.Status = domain.PaymentStatusFailed
+		payment.RawResponse = string(paymentErr.Body)
+		payment.LastError = err.Error()
+	default:
+		if ctx.Err() != nil {
+			// The worker is shutting down; the attempt is left to the claim timeout.
+			return payment, err
+		}
+		payment.LastError = err.Error()
+	}
+
+	if payment.Status == domain.PaymentStatusPending && isTransient(err) && w.opts.Retry.CanRetry(payment.Attempts) {
+		next := time.Now().Add(w.opts.Retry.Delay(payment.Attempts, rand.Float64()))
+		payment.NextAttemptAt = &next
+	}
+
+	// The provider has answered or the attempt has been counted by now, so a failure to
+	// record it must not change the outcome; a payment left pending is resent under its
+	// external id.
+	if updateErr := w.paymentRepo.Update(ctx, payment); updateErr != nil {
+		log.Printf("Error recording payment %s for %s: %v", payment.ExternalID, label, updateErr)
+	}
+
+	if payment.Status == domain.PaymentStatusSucceeded {
+		return payment, nil
+	}
+
+	if err != nil {
+		return payment, err
+	}
+
+	return payment, fmt.Errorf("payment processing failed for %s", label)
+}
+
+// isTransient reports whether a payment error may clear up if the payment is sent again:
+// a timeout, a refused or reset connection, or a server error or rate limit at the
+// provider.
+func isTransient(err error) bool {
+	var paymentErr *domain.PaymentError
+	if errors.As(err, &paymentErr) {
+		return paymentErr.StatusCode >= 500 || paymentErr.StatusCode == 429
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, errPaymentUndecided) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
 }
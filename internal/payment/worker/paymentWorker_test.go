@@ -0,0 +1,592 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type paymentMocks struct {
+	expense *mocks.ExpenseRepository
+	report  *mocks.ReportRepository
+	advance *mocks.AdvanceRepository
+	history *mocks.HistoryRepository
+	payment *mocks.PaymentRepository
+	service *mocks.PaymentService
+}
+
+func newPaymentMocks() *paymentMocks {
+	m := &paymentMocks{
+		expense: new(mocks.ExpenseRepository),
+		report:  new(mocks.ReportRepository),
+		advance: new(mocks.AdvanceRepository),
+		history: new(mocks.HistoryRepository),
+		payment: new(mocks.PaymentRepository),
+		service: new(mocks.PaymentService),
+	}
+	m.history.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *paymentMocks) worker() *PaymentWorker {
+	return NewPaymentWorker(m.expense, m.report, m.advance, m.history, m.payment, m.service, Options{
+		Interval:     time.Minute,
+		BatchSize:    10,
+		Concurrency:  10,
+		ClaimTimeout: 10 * time.Minute,
+		Retry:        domain.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour},
+	})
+}
+
+func (m *paymentMocks) expectOutcome(expenseID int, to domain.ExpenseStatus) {
+	m.expense.On("UpdateStatus", mock.Anything, expenseID, domain.ExpenseStatusProcessing, to, mock.Anything).Return(nil).Once()
+}
+
+func paid(id, externalID string) *domain.PaymentResponse {
+	resp := &domain.PaymentResponse{Raw: []byte(`{"data":{"status":"success"}}`)}
+	resp.Data.ID = id
+	resp.Data.ExternalID = externalID
+	resp.Data.Status = "success"
+	return resp
+}
+
+func isHistory(expenseID int, from, to domain.ExpenseStatus) interface{} {
+	return mock.MatchedBy(func(h *domain.StatusHistory) bool {
+		return h.ExpenseID == expenseID && *h.FromStatus == from && h.ToStatus == to
+	})
+}
+
+func TestPaymentWorkerProcessPayment(t *testing.T) {
+	ctx := context.Background()
+	claimed := func() *domain.Expense {
+		return &domain.Expense{ID: 5, AmountIDR: 250000, Status: domain.ExpenseStatusApproved}
+	}
+	isStatus := func(status domain.PaymentStatus) interface{} {
+		return mock.MatchedBy(func(p *domain.Payment) bool { return p.Status == status })
+	}
+
+	t.Run("first attempt stores the external id before paying", func(t *testing.T) {
+		m := newPaymentMocks()
+		var externalID string
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).Return((*domain.Payment)(nil), nil).Once()
+		m.payment.On("Create", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.ExternalID != "" && p.AmountIDR == 250000 && p.Status == domain.PaymentStatusPending
+		})).Return(nil).Run(func(args mock.Arguments) {
+			externalID = args.Get(1).(*domain.Payment).ExternalID
+		}).Once()
+		m.service.On("ProcessPayment", mock.Anything, 250000, mock.Anything).Return(paid("pay_1", "ext"), nil).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Status == domain.PaymentStatusSucceeded && p.ProviderID == "pay_1" && p.RawResponse != ""
+		})).Return(nil).Once()
+		m.expectOutcome(5, domain.ExpenseStatusCompleted)
+
+		require.NoError(t, m.worker().processPayment(ctx, claimed()))
+		m.service.AssertCalled(t, "ProcessPayment", mock.Anything, 250000, externalID)
+		m.expense.AssertExpectations(t)
+	})
+
+	t.Run("retry reuses the stored external id", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+			Return(&domain.Payment{ID: 3, ExternalID: "ext_1", AmountIDR: 250000, Status: domain.PaymentStatusPending}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 250000, "ext_1").
+			Return((*domain.PaymentResponse)(nil), &domain.PaymentError{StatusCode: 409, Message: "external id already exists"}).Once()
+		m.payment.On("Update", mock.Anything, isStatus(domain.PaymentStatusSucceeded)).Return(nil).Once()
+		m.expectOutcome(5, domain.ExpenseStatusCompleted)
+
+		require.NoError(t, m.worker().processPayment(ctx, claimed()))
+		m.payment.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.expense.AssertExpectations(t)
+	})
+
+	t.Run("timeout schedules a retry under the same external id", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+			Return(&domain.Payment{ID: 3, ExternalID: "ext_1", AmountIDR: 250000, Status: domain.PaymentStatusPending}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 250000, "ext_1").Return((*domain.PaymentResponse)(nil), context.DeadlineExceeded).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Status == domain.PaymentStatusPending && p.ExternalID == "ext_1" && p.Attempts == 1 &&
+				p.NextAttemptAt != nil && p.NextAttemptAt.After(time.Now()) && p.LastError == context.DeadlineExceeded.Error()
+		})).Return(nil).Once()
+		m.expectOutcome(5, domain.ExpenseStatusRetryScheduled)
+
+		require.Error(t, m.worker().processPayment(ctx, claimed()))
+		m.payment.AssertExpectations(t)
+		m.expense.AssertExpectations(t)
+	})
+
+	t.Run("server error on the last attempt is dead-lettered", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+			Return(&domain.Payment{ID: 3, ExternalID: "ext_1", AmountIDR: 250000, Status: domain.PaymentStatusPending, Attempts: 2}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 250000, "ext_1").
+			Return((*domain.PaymentResponse)(nil), &domain.PaymentError{StatusCode: 503, Message: "Service Unavailable"}).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Status == domain.PaymentStatusPending && p.Attempts == 3 && p.NextAttemptAt == nil
+		})).Return(nil).Once()
+		m.expectOutcome(5, domain.ExpenseStatusDeadLetter)
+
+		require.Error(t, m.worker().processPayment(ctx, claimed()))
+		m.payment.AssertExpectations(t)
+		m.expense.AssertExpectations(t)
+	})
+
+	t.Run("due retry is sent again", func(t *testing.T) {
+		m := newPaymentMocks()
+		next := time.Now()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+			Return(&domain.Payment{ID: 3, ExternalID: "ext_1", AmountIDR: 250000, Status: domain.PaymentStatusPending, Attempts: 1, NextAttemptAt: &next}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 250000, "ext_1").Return(paid("pay_1", "ext_1"), nil).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Status == domain.PaymentStatusSucceeded && p.Attempts == 2 && p.NextAttemptAt == nil
+		})).Return(nil).Once()
+		m.expectOutcome(5, domain.ExpenseStatusCompleted)
+
+		expense := claimed()
+		expense.Status = domain.ExpenseStatusRetryScheduled
+		require.NoError(t, m.worker().processPayment(ctx, expense))
+		m.history.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return *h.FromStatus == domain.ExpenseStatusRetryScheduled && h.ToStatus == domain.ExpenseStatusProcessing && h.Reason == "payment retried"
+		}))
+	})
+
+	t.Run("payment could not be recorded", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).Return((*domain.Payment)(nil), errors.New("db down")).Once()
+
+		require.Error(t, m.worker().processPayment(ctx, claimed()))
+		m.service.AssertNotCalled(t, "ProcessPayment", mock.Anything, mock.Anything, mock.Anything)
+		m.expense.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("shutting down leaves the claim to time out", func(t *testing.T) {
+		m := newPaymentMocks()
+		ctx, cancel := context.WithCancel(context.Background())
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+			Return(&domain.Payment{ID: 3, ExternalID: "ext_1", AmountIDR: 250000, Status: domain.PaymentStatusPending}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 250000, "ext_1").
+			Return((*domain.PaymentResponse)(nil), context.Canceled).Run(func(mock.Arguments) { cancel() }).Once()
+
+		require.ErrorIs(t, m.worker().processPayment(ctx, claimed()), context.Canceled)
+		m.payment.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		m.expense.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("refused payment is recorded as failed", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+			Return(&domain.Payment{ID: 3, ExternalID: "ext_1", AmountIDR: 250000, Status: domain.PaymentStatusPending}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 250000, "ext_1").
+			Return((*domain.PaymentResponse)(nil), &domain.PaymentError{StatusCode: 400, Message: "insufficient funds", Body: []byte(`{"message":"insufficient funds"}`)}).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Status == domain.PaymentStatusFailed && p.RawResponse == `{"message":"insufficient funds"}` && p.LastError == "payment failed: insufficient funds"
+		})).Return(nil).Once()
+		m.expectOutcome(5, domain.ExpenseStatusFailed)
+
+		require.Error(t, m.worker().processPayment(ctx, claimed()))
+		m.payment.AssertExpectations(t)
+	})
+
+	t.Run("duplicate is recognised by its status code", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+			Return(&domain.Payment{ID: 3, ExternalID: "ext_1", AmountIDR: 250000, Status: domain.PaymentStatusPending, Attempts: 1}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 250000, "ext_1").
+			Return((*domain.PaymentResponse)(nil), &domain.PaymentError{StatusCode: 409, Message: "Conflict"}).Once()
+		m.payment.On("Update", mock.Anything, isStatus(domain.PaymentStatusSucceeded)).Return(nil).Once()
+		m.expectOutcome(5, domain.ExpenseStatusCompleted)
+
+		require.NoError(t, m.worker().processPayment(ctx, claimed()))
+		m.expense.AssertExpectations(t)
+	})
+
+	t.Run("undecided payment is retried under the same external id", func(t *testing.T) {
+		m := newPaymentMocks()
+		undecided := paid("pay_1", "ext_1")
+		undecided.Data.Status = "pending"
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+			Return(&domain.Payment{ID: 3, ExternalID: "ext_1", AmountIDR: 250000, Status: domain.PaymentStatusPending}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 250000, "ext_1").Return(undecided, nil).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Status == domain.PaymentStatusPending && p.ExternalID == "ext_1" && p.ProviderID == "pay_1" &&
+				p.Attempts == 1 && p.NextAttemptAt != nil
+		})).Return(nil).Once()
+		m.expectOutcome(5, domain.ExpenseStatusRetryScheduled)
+
+		require.ErrorIs(t, m.worker().processPayment(ctx, claimed()), errPaymentUndecided)
+		m.payment.AssertExpectations(t)
+		m.expense.AssertExpectations(t)
+	})
+
+	t.Run("payment reported failed is recorded as failed", func(t *testing.T) {
+		m := newPaymentMocks()
+		declined := paid("pay_1", "ext_1")
+		declined.Data.Status = domain.ProviderStatusFailed
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+			Return(&domain.Payment{ID: 3, ExternalID: "ext_1", AmountIDR: 250000, Status: domain.PaymentStatusPending}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 250000, "ext_1").Return(declined, nil).Once()
+		m.payment.On("Update", mock.Anything, isStatus(domain.PaymentStatusFailed)).Return(nil).Once()
+		m.expectOutcome(5, domain.ExpenseStatusFailed)
+
+		require.Error(t, m.worker().processPayment(ctx, claimed()))
+		m.payment.AssertExpectations(t)
+	})
+
+	t.Run("refused payment is sent again under a new external id", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+			Return(&domain.Payment{ID: 3, ExternalID: "ext_1", AmountIDR: 250000, Status: domain.PaymentStatusFailed}, nil).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Status == domain.PaymentStatusPending && p.ExternalID != "ext_1"
+		})).Return(nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 250000, mock.MatchedBy(func(id string) bool { return id != "ext_1" })).Return(paid("pay_2", "ext_2"), nil).Once()
+		m.payment.On("Update", mock.Anything, isStatus(domain.PaymentStatusSucceeded)).Return(nil).Once()
+		m.expectOutcome(5, domain.ExpenseStatusCompleted)
+
+		require.NoError(t, m.worker().processPayment(ctx, claimed()))
+		m.service.AssertExpectations(t)
+	})
+
+	t.Run("already paid", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).
+			Return(&domain.Payment{ID: 3, ExternalID: "ext_1", Status: domain.PaymentStatusSucceeded}, nil).Once()
+		m.expectOutcome(5, domain.ExpenseStatusCompleted)
+
+		require.NoError(t, m.worker().processPayment(ctx, claimed()))
+		m.service.AssertNotCalled(t, "ProcessPayment", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPaymentWorkerProcessReportPayment(t *testing.T) {
+	ctx := context.Background()
+	reportID := 7
+	claimed := func(status domain.ExpenseStatus) *domain.ExpenseReport {
+		return &domain.ExpenseReport{ID: reportID, TotalIDR: 1500000, Status: status}
+	}
+	expectLines := func(m *paymentMocks) {
+		m.expense.On("FindByReportID", mock.Anything, reportID).Return([]*domain.Expense{
+			{ID: 11, ReportID: &reportID, Status: domain.ExpenseStatusProcessing},
+			{ID: 12, ReportID: &reportID, Status: domain.ExpenseStatusRejected},
+		}, nil).Once()
+	}
+	expectReportOutcome := func(m *paymentMocks, to domain.ExpenseStatus) {
+		m.report.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(r *domain.ExpenseReport) bool {
+			return r.Status == to
+		}), domain.ExpenseStatusProcessing).Return([]int{11}, nil).Once()
+	}
+
+	t.Run("transient error schedules a retry", func(t *testing.T) {
+		m := newPaymentMocks()
+		expectLines(m)
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpenseReport, reportID).
+			Return(&domain.Payment{ID: 4, ExternalID: "ext_7", AmountIDR: 1500000, Status: domain.PaymentStatusPending}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 1500000, "ext_7").
+			Return((*domain.PaymentResponse)(nil), &domain.PaymentError{StatusCode: 502, Message: "Bad Gateway"}).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Status == domain.PaymentStatusPending && p.ExternalID == "ext_7" && p.Attempts == 1 &&
+				p.NextAttemptAt != nil && p.NextAttemptAt.After(time.Now())
+		})).Return(nil).Once()
+		expectReportOutcome(m, domain.ExpenseStatusRetryScheduled)
+
+		require.Error(t, m.worker().processReportPayment(ctx, claimed(domain.ExpenseStatusApproved)))
+		m.payment.AssertExpectations(t)
+		m.report.AssertExpectations(t)
+		m.history.AssertCalled(t, "Create", mock.Anything, isHistory(11, domain.ExpenseStatusProcessing, domain.ExpenseStatusRetryScheduled))
+		m.history.AssertNotCalled(t, "Create", mock.Anything, isHistory(12, domain.ExpenseStatusProcessing, domain.ExpenseStatusRetryScheduled))
+	})
+
+	t.Run("transient error on the last attempt is dead-lettered", func(t *testing.T) {
+		m := newPaymentMocks()
+		expectLines(m)
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpenseReport, reportID).
+			Return(&domain.Payment{ID: 4, ExternalID: "ext_7", AmountIDR: 1500000, Status: domain.PaymentStatusPending, Attempts: 2}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 1500000, "ext_7").Return((*domain.PaymentResponse)(nil), context.DeadlineExceeded).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Attempts == 3 && p.NextAttemptAt == nil
+		})).Return(nil).Once()
+		expectReportOutcome(m, domain.ExpenseStatusDeadLetter)
+
+		report := claimed(domain.ExpenseStatusRetryScheduled)
+		require.Error(t, m.worker().processReportPayment(ctx, report))
+		require.Equal(t, domain.ExpenseStatusDeadLetter, report.Status)
+		m.report.AssertExpectations(t)
+		m.history.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.ExpenseID == 11 && *h.FromStatus == domain.ExpenseStatusRetryScheduled && h.Reason == "payment retried"
+		}))
+	})
+
+	t.Run("refused payment fails the report", func(t *testing.T) {
+		m := newPaymentMocks()
+		expectLines(m)
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpenseReport, reportID).
+			Return(&domain.Payment{ID: 4, ExternalID: "ext_7", AmountIDR: 1500000, Status: domain.PaymentStatusPending}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 1500000, "ext_7").
+			Return((*domain.PaymentResponse)(nil), &domain.PaymentError{StatusCode: 400, Message: "invalid account"}).Once()
+		m.payment.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		expectReportOutcome(m, domain.ExpenseStatusFailed)
+
+		require.Error(t, m.worker().processReportPayment(ctx, claimed(domain.ExpenseStatusApproved)))
+		m.report.AssertExpectations(t)
+	})
+}
+
+func TestPaymentWorkerProcessAdvancePayment(t *testing.T) {
+	ctx := context.Background()
+	expectAdvanceOutcome := func(m *paymentMocks, from, to domain.AdvanceStatus) {
+		m.advance.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(a *domain.CashAdvance) bool {
+			return a.Status == to
+		}), from).Return(nil).Once()
+	}
+
+	t.Run("transient payout error schedules a retry", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindAdvance, 4).
+			Return(&domain.Payment{ID: 5, ExternalID: "ext_4", AmountIDR: 3000000, Status: domain.PaymentStatusPending}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 3000000, "ext_4").Return((*domain.PaymentResponse)(nil), syscall.ECONNRESET).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Attempts == 1 && p.NextAttemptAt != nil && p.NextAttemptAt.After(time.Now())
+		})).Return(nil).Once()
+		expectAdvanceOutcome(m, domain.AdvanceStatusDisbursing, domain.AdvanceStatusRetryScheduled)
+
+		advance := &domain.CashAdvance{ID: 4, AmountIDR: 3000000, Status: domain.AdvanceStatusDisbursing}
+		require.Error(t, m.worker().processAdvancePayment(ctx, advance))
+		require.Nil(t, advance.PaidAt)
+		m.payment.AssertExpectations(t)
+		m.advance.AssertExpectations(t)
+	})
+
+	t.Run("transient reimbursement error on the last attempt is dead-lettered", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindAdvanceReimbursement, 4).
+			Return(&domain.Payment{ID: 6, ExternalID: "ext_r4", AmountIDR: 500000, Status: domain.PaymentStatusPending, Attempts: 2}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 500000, "ext_r4").
+			Return((*domain.PaymentResponse)(nil), &domain.PaymentError{StatusCode: 429, Message: "Too Many Requests"}).Once()
+		m.payment.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Attempts == 3 && p.NextAttemptAt == nil
+		})).Return(nil).Once()
+		expectAdvanceOutcome(m, domain.AdvanceStatusReimbursing, domain.AdvanceStatusDeadLetter)
+
+		paidAt := time.Now()
+		advance := &domain.CashAdvance{ID: 4, AmountIDR: 3000000, ReimbursementIDR: 500000, PaidAt: &paidAt, Status: domain.AdvanceStatusReimbursing}
+		require.Error(t, m.worker().processAdvancePayment(ctx, advance))
+		require.Nil(t, advance.SettledAt)
+		m.advance.AssertExpectations(t)
+	})
+
+	t.Run("refused payout fails the advance", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindAdvance, 4).
+			Return(&domain.Payment{ID: 5, ExternalID: "ext_4", AmountIDR: 3000000, Status: domain.PaymentStatusPending}, nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 3000000, "ext_4").
+			Return((*domain.PaymentResponse)(nil), &domain.PaymentError{StatusCode: 400, Message: "invalid account"}).Once()
+		m.payment.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		expectAdvanceOutcome(m, domain.AdvanceStatusDisbursing, domain.AdvanceStatusFailed)
+
+		require.Error(t, m.worker().processAdvancePayment(ctx, &domain.CashAdvance{ID: 4, AmountIDR: 3000000, Status: domain.AdvanceStatusDisbursing}))
+		m.advance.AssertExpectations(t)
+	})
+}
+
+func TestPaymentWorkerProcessPayments(t *testing.T) {
+	ctx := context.Background()
+	pending := func(kind domain.PaymentKind, subjectID int) *domain.Payment {
+		return &domain.Payment{ID: subjectID, Kind: kind, SubjectID: subjectID, ExternalID: "ext", AmountIDR: 100000, Status: domain.PaymentStatusPending}
+	}
+	t.Run("pays each claimed batch", func(t *testing.T) {
+		m := newPaymentMocks()
+		reportID := 8
+		m.expense.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).
+			Return([]*domain.Expense{{ID: 5, AmountIDR: 100000, Status: domain.ExpenseStatusAutoApproved}}, nil).Once()
+		m.report.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).
+			Return([]*domain.ExpenseReport{{ID: reportID, TotalIDR: 100000, Status: domain.ExpenseStatusApproved}}, nil).Once()
+		m.advance.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).
+			Return([]*domain.CashAdvance{{ID: 4, ReimbursementIDR: 100000, Status: domain.AdvanceStatusReimbursing}}, nil).Once()
+		m.expense.On("FindByReportID", mock.Anything, reportID).Return([]*domain.Expense{
+			{ID: 11, ReportID: &reportID, Status: domain.ExpenseStatusProcessing},
+			{ID: 12, ReportID: &reportID, Status: domain.ExpenseStatusRejected},
+		}, nil).Once()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).Return(pending(domain.PaymentKindExpense, 5), nil).Once()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpenseReport, reportID).Return(pending(domain.PaymentKindExpenseReport, reportID), nil).Once()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindAdvanceReimbursement, 4).Return(pending(domain.PaymentKindAdvanceReimbursement, 4), nil).Once()
+		m.payment.On("Update", mock.Anything, mock.Anything).Return(nil)
+		m.service.On("ProcessPayment", mock.Anything, 100000, "ext").Return(paid("pay", "ext"), nil).Times(3)
+		m.expense.On("UpdateStatus", mock.Anything, 5, domain.ExpenseStatusProcessing, domain.ExpenseStatusCompleted, mock.Anything).Return(nil).Once()
+		m.report.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(r *domain.ExpenseReport) bool {
+			return r.Status == domain.ExpenseStatusCompleted
+		}), domain.ExpenseStatusProcessing).Return([]int{11}, nil).Once()
+		m.advance.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(a *domain.CashAdvance) bool {
+			return a.Status == domain.AdvanceStatusSettled && a.SettledAt != nil
+		}), domain.AdvanceStatusReimbursing).Return(nil).Once()
+
+		m.worker().processPayments(ctx)
+
+		m.service.AssertExpectations(t)
+		m.expense.AssertExpectations(t)
+		m.report.AssertExpectations(t)
+		m.advance.AssertExpectations(t)
+		m.history.AssertCalled(t, "Create", mock.Anything, isHistory(5, domain.ExpenseStatusAutoApproved, domain.ExpenseStatusProcessing))
+		m.history.AssertCalled(t, "Create", mock.Anything, isHistory(11, domain.ExpenseStatusApproved, domain.ExpenseStatusProcessing))
+		m.history.AssertCalled(t, "Create", mock.Anything, isHistory(11, domain.ExpenseStatusProcessing, domain.ExpenseStatusCompleted))
+		m.history.AssertNotCalled(t, "Create", mock.Anything, isHistory(12, domain.ExpenseStatusApproved, domain.ExpenseStatusProcessing))
+	})
+
+	t.Run("timed out claim is paid again", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.expense.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).
+			Return([]*domain.Expense{{ID: 5, AmountIDR: 100000, Status: domain.ExpenseStatusProcessing}}, nil).Once()
+		m.report.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).Return([]*domain.ExpenseReport(nil), nil).Once()
+		m.advance.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).Return([]*domain.CashAdvance(nil), nil).Once()
+		m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, 5).Return(pending(domain.PaymentKindExpense, 5), nil).Once()
+		m.payment.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		m.service.On("ProcessPayment", mock.Anything, 100000, "ext").
+			Return((*domain.PaymentResponse)(nil), &domain.PaymentError{StatusCode: 409, Message: "external id already exists"}).Once()
+		m.expense.On("UpdateStatus", mock.Anything, 5, domain.ExpenseStatusProcessing, domain.ExpenseStatusCompleted, mock.Anything).Return(nil).Once()
+
+		m.worker().processPayments(ctx)
+
+		m.expense.AssertExpectations(t)
+		m.history.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("claims in chunks no larger than the pool", func(t *testing.T) {
+		m := newPaymentMocks()
+		expenses := func(ids ...int) []*domain.Expense {
+			var claimed []*domain.Expense
+			for _, id := range ids {
+				claimed = append(claimed, &domain.Expense{ID: id, AmountIDR: 100000, Status: domain.ExpenseStatusApproved})
+			}
+			return claimed
+		}
+		m.expense.On("ClaimForPayment", mock.Anything, 2, 10*time.Minute).Return(expenses(1, 2), nil).Once()
+		m.expense.On("ClaimForPayment", mock.Anything, 2, 10*time.Minute).Return(expenses(3, 4), nil).Once()
+		m.expense.On("ClaimForPayment", mock.Anything, 1, 10*time.Minute).Return(expenses(5), nil).Once()
+		m.report.On("ClaimForPayment", mock.Anything, 2, 10*time.Minute).Return([]*domain.ExpenseReport(nil), nil).Once()
+		m.advance.On("ClaimForPayment", mock.Anything, 2, 10*time.Minute).Return([]*domain.CashAdvance(nil), nil).Once()
+		for id := 1; id <= 5; id++ {
+			m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, id).Return(pending(domain.PaymentKindExpense, id), nil).Once()
+			m.expectOutcome(id, domain.ExpenseStatusCompleted)
+		}
+		m.payment.On("Update", mock.Anything, mock.Anything).Return(nil)
+		m.service.On("ProcessPayment", mock.Anything, 100000, "ext").Return(paid("pay", "ext"), nil).Times(5)
+
+		w := m.worker()
+		w.opts.BatchSize, w.opts.Concurrency = 5, 2
+		w.processPayments(ctx)
+
+		m.expense.AssertExpectations(t)
+		m.service.AssertExpectations(t)
+	})
+
+	t.Run("pays several at once", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.expense.On("ClaimForPayment", mock.Anything, 2, 10*time.Minute).Return([]*domain.Expense{
+			{ID: 1, AmountIDR: 100000, Status: domain.ExpenseStatusApproved},
+			{ID: 2, AmountIDR: 100000, Status: domain.ExpenseStatusApproved},
+		}, nil).Once()
+		m.expense.On("ClaimForPayment", mock.Anything, 2, 10*time.Minute).Return([]*domain.Expense(nil), nil).Once()
+		m.report.On("ClaimForPayment", mock.Anything, 2, 10*time.Minute).Return([]*domain.ExpenseReport(nil), nil).Once()
+		m.advance.On("ClaimForPayment", mock.Anything, 2, 10*time.Minute).Return([]*domain.CashAdvance(nil), nil).Once()
+		for id := 1; id <= 2; id++ {
+			m.payment.On("FindBySubject", mock.Anything, domain.PaymentKindExpense, id).Return(pending(domain.PaymentKindExpense, id), nil).Once()
+			m.expectOutcome(id, domain.ExpenseStatusCompleted)
+		}
+		m.payment.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		// Each payment waits for the other to start, so paying them one at a time would
+		// never finish.
+		started, release := make(chan struct{}), make(chan struct{})
+		m.service.On("ProcessPayment", mock.Anything, 100000, "ext").Return(paid("pay", "ext"), nil).Run(func(mock.Arguments) {
+			started <- struct{}{}
+			<-release
+		}).Times(2)
+
+		done := make(chan struct{})
+		go func() {
+			w := m.worker()
+			w.opts.BatchSize, w.opts.Concurrency = 4, 2
+			w.processPayments(ctx)
+			close(done)
+		}()
+
+		for range 2 {
+			select {
+			case <-started:
+			case <-time.After(time.Second):
+				t.Fatal("payments were not sent concurrently")
+			}
+		}
+		close(release)
+		<-done
+
+		m.expense.AssertExpectations(t)
+	})
+
+	t.Run("a failed claim does not stop the other batches", func(t *testing.T) {
+		m := newPaymentMocks()
+		m.expense.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).Return([]*domain.Expense(nil), errors.New("db down")).Once()
+		m.report.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).Return([]*domain.ExpenseReport(nil), nil).Once()
+		m.advance.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).Return([]*domain.CashAdvance(nil), nil).Once()
+
+		m.worker().processPayments(ctx)
+
+		m.report.AssertExpectations(t)
+		m.advance.AssertExpectations(t)
+	})
+}
+
+func TestPaymentWorkerStart(t *testing.T) {
+	t.Run("a wake-up sweeps without waiting for the interval", func(t *testing.T) {
+		m := newPaymentMocks()
+		ctx, cancel := context.WithCancel(context.Background())
+		m.expense.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).Return([]*domain.Expense(nil), nil).Once()
+		m.report.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).Return([]*domain.ExpenseReport(nil), nil).Once()
+		m.advance.On("ClaimForPayment", mock.Anything, 10, 10*time.Minute).Return([]*domain.CashAdvance(nil), nil).Once().
+			Run(func(mock.Arguments) { cancel() })
+
+		wake := make(chan struct{}, 1)
+		wake <- struct{}{}
+		w := m.worker()
+		w.opts.Interval, w.opts.Wake = time.Hour, wake
+
+		done := make(chan struct{})
+		go func() {
+			w.Start(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			cancel()
+			t.Fatal("the wake-up did not start a sweep")
+		}
+		m.expense.AssertExpectations(t)
+		m.report.AssertExpectations(t)
+		m.advance.AssertExpectations(t)
+	})
+}
+
+func TestIsTransient(t *testing.T) {
+	reset := &url.Error{Op: "Post", URL: "https://pay", Err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}
+	timeout := &url.Error{Op: "Post", URL: "https://pay", Err: context.DeadlineExceeded}
+
+	require.True(t, isTransient(reset))
+	require.True(t, isTransient(timeout))
+	require.True(t, isTransient(&domain.PaymentError{StatusCode: 502}))
+	require.True(t, isTransient(&domain.PaymentError{StatusCode: 429}))
+	require.False(t, isTransient(&domain.PaymentError{StatusCode: 400}))
+	require.False(t, isTransient(errors.New("invalid character 'n' looking for beginning of value")))
+	require.True(t, isTransient(fmt.Errorf("%w: payment status pending", errPaymentUndecided)))
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/evrintobing17/expense-management-backend/internal/receipt"
+	"github.com/gorilla/mux"
+)
+
+// multipartOverhead is allowed on top of the file size for the form's boundaries and
+// part headers.
+const multipartOverhead = 64 << 10
+
+type ReceiptHandler struct {
+	receiptUseCase receipt.ReceiptUseCase
+	maxUploadBytes int64
+}
+
+func NewReceiptHandler(receiptUseCase receipt.ReceiptUseCase, maxUploadBytes int64) *ReceiptHandler {
+	return &ReceiptHandler{
+		receiptUseCase: receiptUseCase,
+		maxUploadBytes: maxUploadBytes,
+	}
+}
+
+// UploadReceipt takes a multipart/form-data body with the receipt in its "file" field.
+func (h *ReceiptHandler) UploadReceipt(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid expense ID", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadBytes+multipartOverhead)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, domain.ErrReceiptTooLarge.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "A receipt file is required in the \"file\" form field", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	rec, err := h.receiptUseCase.UploadReceipt(ctx, id, userID, header.Filename, file)
+	if err != nil {
+		writeReceiptError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(rec)
+}
+
+func (h *ReceiptHandler) ListReceipts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	role, _ := middleware.GetUserRoleFromContext(ctx)
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid expense ID", http.StatusBadRequest)
+		return
+	}
+
+	receipts, err := h.receiptUseCase.ListReceipts(ctx, id, userID, role)
+	if err != nil {
+		writeReceiptError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(receipts)
+}
+
+// GetReceipt returns the receipt's metadata with a short-lived download_url.
+func (h *ReceiptHandler) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	role, _ := middleware.GetUserRoleFromContext(ctx)
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid receipt ID", http.StatusBadRequest)
+		return
+	}
+
+	rec, err := h.receiptUseCase.GetReceipt(ctx, id, userID, role)
+	if err != nil {
+		writeReceiptError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rec)
+}
+
+func writeReceiptError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrReceiptTooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, domain.ErrUnsupportedReceipt):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	case errors.Is(err, domain.ErrExpenseNotEditable):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrExpenseNotFound):
+		http.Error(w, "Expense not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrReceiptNotFound):
+		http.Error(w, "Receipt not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrUnauthorizedAction):
+		http.Error(w, "Access denied", http.StatusForbidden)
+	case errors.Is(err, domain.ErrStatusConflict):
+		http.Error(w, "Expense was updated by another request", http.StatusConflict)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
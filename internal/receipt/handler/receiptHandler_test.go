@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func withRole(req *http.Request, userID int, role domain.Role) *http.Request {
+	mockAuth := new(mocks.AuthService)
+	mockAuth.On("ValidateToken", mock.Anything, "t").Return(userID, role, nil).Maybe()
+	rr := httptest.NewRecorder()
+	next := middleware.AuthMiddleware(mockAuth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+	}))
+	req.Header.Set("Authorization", "Bearer t")
+	next.ServeHTTP(rr, req)
+	return req
+}
+
+func uploadRequest(t *testing.T, field string, content []byte) *http.Request {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile(field, "taxi.pdf")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/expenses/7/receipts", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return mux.SetURLVars(req, map[string]string{"id": "7"})
+}
+
+func TestReceiptHandlerUploadReceipt(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.ReceiptUseCase)
+		h := NewReceiptHandler(mockUC, 1024)
+		req := withRole(uploadRequest(t, "file", []byte("%PDF-1.4")), 2, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+		mockUC.On("UploadReceipt", mock.Anything, 7, 2, "taxi.pdf", mock.Anything).
+			Return(&domain.Receipt{ID: 3, ExpenseID: 7, ContentType: "application/pdf"}, nil).Once()
+
+		h.UploadReceipt(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.Contains(t, rr.Body.String(), `"id":3`)
+	})
+
+	t.Run("missing file field", func(t *testing.T) {
+		mockUC := new(mocks.ReceiptUseCase)
+		h := NewReceiptHandler(mockUC, 1024)
+		req := withRole(uploadRequest(t, "attachment", []byte("%PDF-1.4")), 2, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+
+		h.UploadReceipt(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUC.AssertNotCalled(t, "UploadReceipt", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("body over the limit", func(t *testing.T) {
+		mockUC := new(mocks.ReceiptUseCase)
+		h := NewReceiptHandler(mockUC, 1024)
+		req := withRole(uploadRequest(t, "file", make([]byte, 128<<10)), 2, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+
+		h.UploadReceipt(rr, req)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		mockUC := new(mocks.ReceiptUseCase)
+		h := NewReceiptHandler(mockUC, 1024)
+		req := withRole(uploadRequest(t, "file", []byte("hello")), 2, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+		mockUC.On("UploadReceipt", mock.Anything, 7, 2, "taxi.pdf", mock.Anything).
+			Return((*domain.Receipt)(nil), domain.ErrUnsupportedReceipt).Once()
+
+		h.UploadReceipt(rr, req)
+		require.Equal(t, http.StatusUnsupportedMediaType, rr.Code)
+	})
+}
+
+func TestReceiptHandlerGetReceipt(t *testing.T) {
+	t.Run("forbidden", func(t *testing.T) {
+		mockUC := new(mocks.ReceiptUseCase)
+		h := NewReceiptHandler(mockUC, 1024)
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/receipts/3", nil), map[string]string{"id": "3"})
+		req = withRole(req, 5, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+		mockUC.On("GetReceipt", mock.Anything, 3, 5, domain.RoleEmployee).Return((*domain.Receipt)(nil), domain.ErrUnauthorizedAction).Once()
+
+		h.GetReceipt(rr, req)
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.ReceiptUseCase)
+		h := NewReceiptHandler(mockUC, 1024)
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/receipts/3", nil), map[string]string{"id": "3"})
+		req = withRole(req, 1, domain.RoleManager)
+		rr := httptest.NewRecorder()
+		mockUC.On("GetReceipt", mock.Anything, 3, 1, domain.RoleManager).
+			Return(&domain.Receipt{ID: 3, DownloadURL: "https://files/signed"}, nil).Once()
+
+		h.GetReceipt(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"download_url":"https://files/signed"`)
+	})
+}
@@ -0,0 +1,14 @@
+package receipt
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type ReceiptRepository interface {
+	Create(ctx context.Context, receipt *domain.Receipt) error
+	FindByID(ctx context.Context, id int) (*domain.Receipt, error)
+	FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.Receipt, error)
+	FindBySHA256(ctx context.Context, hashes []string, excludeExpenseID int) ([]*domain.Receipt, error)
+}
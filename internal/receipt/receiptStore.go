@@ -0,0 +1,13 @@
+package receipt
+
+import (
+	"context"
+	"time"
+)
+
+// ReceiptStore keeps receipt files. Files are never served directly; callers hand out
+// signed URLs that stop working after ttl.
+type ReceiptStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
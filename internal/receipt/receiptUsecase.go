@@ -0,0 +1,14 @@
+package receipt
+
+import (
+	"context"
+	"io"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type ReceiptUseCase interface {
+	UploadReceipt(ctx context.Context, expenseID int, userID int, fileName string, file io.Reader) (*domain.Receipt, error)
+	ListReceipts(ctx context.Context, expenseID int, userID int, role domain.Role) ([]*domain.Receipt, error)
+	GetReceipt(ctx context.Context, receiptID int, userID int, role domain.Role) (*domain.Receipt, error)
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/receipt"
+	"github.com/lib/pq"
+)
+
+type receiptRepository struct {
+	db *sql.DB
+}
+
+func NewReceiptRepository(db *sql.DB) receipt.ReceiptRepository {
+	return &receiptRepository{db: db}
+}
+
+func (r *receiptRepository) Create(ctx context.Context, receipt *domain.Receipt) error {
+	query := `
+		INSERT INTO receipts (expense_id, storage_key, file_name, content_type, size_bytes, sha256, uploaded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		receipt.ExpenseID,
+		receipt.StorageKey,
+		receipt.FileName,
+		receipt.ContentType,
+		receipt.SizeBytes,
+		receipt.SHA256,
+		receipt.UploadedBy,
+	).Scan(&receipt.ID, &receipt.CreatedAt)
+}
+
+func (r *receiptRepository) FindByID(ctx context.Context, id int) (*domain.Receipt, error) {
+	query := `
+		SELECT id, expense_id, storage_key, file_name, content_type, size_bytes, sha256, uploaded_by, created_at
+		FROM receipts
+		WHERE id = $1
+	`
+
+	receipt := &domain.Receipt{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&receipt.ID,
+		&receipt.ExpenseID,
+		&receipt.StorageKey,
+		&receipt.FileName,
+		&receipt.ContentType,
+		&receipt.SizeBytes,
+		&receipt.SHA256,
+		&receipt.UploadedBy,
+		&receipt.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+func (r *receiptRepository) FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.Receipt, error) {
+	query := `
+		SELECT id, expense_id, storage_key, file_name, content_type, size_bytes, sha256, uploaded_by, created_at
+		FROM receipts
+		WHERE expense_id = $1
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var receipts []*domain.Receipt
+	for rows.Next() {
+		receipt := &domain.Receipt{}
+		err := rows.Scan(
+			&receipt.ID,
+			&receipt.ExpenseID,
+			&receipt.StorageKey,
+			&receipt.FileName,
+			&receipt.ContentType,
+			&receipt.SizeBytes,
+			&receipt.SHA256,
+			&receipt.UploadedBy,
+			&receipt.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
+
+// FindBySHA256 returns the receipts with any of the given digests that belong to other
+// expenses than excludeExpenseID.
+func (r *receiptRepository) FindBySHA256(ctx context.Context, hashes []string, excludeExpenseID int) ([]*domain.Receipt, error) {
+	query := `
+		SELECT id, expense_id, storage_key, file_name, content_type, size_bytes, sha256, uploaded_by, created_at
+		FROM receipts
+		WHERE sha256 = ANY($1) AND expense_id <> $2
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(hashes), excludeExpenseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var receipts []*domain.Receipt
+	for rows.Next() {
+		receipt := &domain.Receipt{}
+		err := rows.Scan(
+			&receipt.ID,
+			&receipt.ExpenseID,
+			&receipt.StorageKey,
+			&receipt.FileName,
+			&receipt.ContentType,
+			&receipt.SizeBytes,
+			&receipt.SHA256,
+			&receipt.UploadedBy,
+			&receipt.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var receiptColumns = []string{"id", "expense_id", "storage_key", "file_name", "content_type", "size_bytes", "sha256", "uploaded_by", "created_at"}
+
+func TestReceiptRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &receiptRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO receipts (expense_id, storage_key, file_name, content_type, size_bytes, sha256, uploaded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`)
+	now := time.Now()
+
+	receipt := &domain.Receipt{ExpenseID: 7, StorageKey: "expenses/7/abc.pdf", FileName: "taxi.pdf", ContentType: "application/pdf", SizeBytes: 2048, SHA256: "abc", UploadedBy: 2}
+	mock.ExpectQuery(query).
+		WithArgs(7, "expenses/7/abc.pdf", "taxi.pdf", "application/pdf", int64(2048), "abc", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, now))
+
+	createErr := repo.Create(context.Background(), receipt)
+	require.NoError(t, createErr)
+	require.Equal(t, 5, receipt.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceiptRepositoryFind(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &receiptRepository{db: db}
+	now := time.Now()
+
+	byID := regexp.QuoteMeta(`
+		SELECT id, expense_id, storage_key, file_name, content_type, size_bytes, sha256, uploaded_by, created_at
+		FROM receipts
+		WHERE id = $1
+	`)
+	mock.ExpectQuery(byID).WithArgs(5).
+		WillReturnRows(sqlmock.NewRows(receiptColumns).AddRow(5, 7, "expenses/7/abc.pdf", "taxi.pdf", "application/pdf", 2048, "abc", 2, now))
+	receipt, findErr := repo.FindByID(context.Background(), 5)
+	require.NoError(t, findErr)
+	require.Equal(t, "expenses/7/abc.pdf", receipt.StorageKey)
+
+	mock.ExpectQuery(byID).WithArgs(6).WillReturnRows(sqlmock.NewRows(receiptColumns))
+	missing, findErr := repo.FindByID(context.Background(), 6)
+	require.NoError(t, findErr)
+	require.Nil(t, missing)
+
+	byExpense := regexp.QuoteMeta(`
+		SELECT id, expense_id, storage_key, file_name, content_type, size_bytes, sha256, uploaded_by, created_at
+		FROM receipts
+		WHERE expense_id = $1
+		ORDER BY id ASC
+	`)
+	mock.ExpectQuery(byExpense).WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(receiptColumns).
+			AddRow(5, 7, "expenses/7/abc.pdf", "taxi.pdf", "application/pdf", 2048, "abc", 2, now).
+			AddRow(8, 7, "expenses/7/def.png", "hotel.png", "image/png", 4096, "def", 2, now))
+	receipts, findErr := repo.FindByExpenseID(context.Background(), 7)
+	require.NoError(t, findErr)
+	require.Len(t, receipts, 2)
+
+	bySHA := regexp.QuoteMeta(`
+		SELECT id, expense_id, storage_key, file_name, content_type, size_bytes, sha256, uploaded_by, created_at
+		FROM receipts
+		WHERE sha256 = ANY($1) AND expense_id <> $2
+		ORDER BY id ASC
+	`)
+	mock.ExpectQuery(bySHA).WithArgs(pq.Array([]string{"abc", "def"}), 9).
+		WillReturnRows(sqlmock.NewRows(receiptColumns).
+			AddRow(5, 7, "expenses/7/abc.pdf", "taxi.pdf", "application/pdf", 2048, "abc", 2, now))
+	receipts, findErr = repo.FindBySHA256(context.Background(), []string{"abc", "def"}, 9)
+	require.NoError(t, findErr)
+	require.Len(t, receipts, 1)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
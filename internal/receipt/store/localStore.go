@@ -0,0 +1,148 @@
+package store
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/receipt"
+)
+
+// LocalFilesPath is the URL prefix the local store's signed URLs point at. The API mounts
+// NewLocalFileServer under it.
+const LocalFilesPath = "/receipt-files/"
+
+type localStore struct {
+	dir     string
+	baseURL string
+	secret  []byte
+	now     func() time.Time
+}
+
+// NewLocalStore keeps receipts under dir. Signed URLs are built on baseURL, the address
+// the API is reachable at, and carry an HMAC of the key and expiry made with secret.
+func NewLocalStore(dir, baseURL, secret string) receipt.ReceiptStore {
+	return &localStore{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  []byte(secret),
+		now:     time.Now,
+	}
+}
+
+// Put writes the file to a temporary name first so that a reader never sees a partial
+// receipt.
+func (s *localStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	target, err := localPath(s.dir, key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), target)
+}
+
+func (s *localStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	expires := s.now().Add(ttl).Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", signLocal(s.secret, key, expires))
+
+	return s.baseURL + LocalFilesPath + key + "?" + query.Encode(), nil
+}
+
+type localFileServer struct {
+	dir    string
+	secret []byte
+	now    func() time.Time
+}
+
+// NewLocalFileServer serves the files of a local store to holders of a valid signed URL.
+// It expects the request path to be the storage key, so mount it with http.StripPrefix.
+func NewLocalFileServer(dir, secret string) http.Handler {
+	return &localFileServer{
+		dir:    dir,
+		secret: []byte(secret),
+		now:    time.Now,
+	}
+}
+
+func (s *localFileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil || s.now().Unix() > expires ||
+		!hmac.Equal([]byte(signLocal(s.secret, key, expires)), []byte(r.URL.Query().Get("signature"))) {
+		http.Error(w, domain.ErrInvalidReceiptLink.Error(), http.StatusForbidden)
+		return
+	}
+
+	target, err := localPath(s.dir, key)
+	if err != nil {
+		http.Error(w, "Receipt not found", http.StatusNotFound)
+		return
+	}
+
+	file, err := os.Open(target)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			http.Error(w, "Receipt not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-store")
+	http.ServeContent(w, r, path.Base(key), info.ModTime(), file)
+}
+
+// localPath resolves key inside dir, refusing keys that would escape it.
+func localPath(dir, key string) (string, error) {
+	if key == "" || path.Clean("/"+key) != "/"+key {
+		return "", domain.ErrReceiptNotFound
+	}
+	return filepath.Join(dir, filepath.FromSlash(key)), nil
+}
+
+func signLocal(secret []byte, key string, expires int64) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
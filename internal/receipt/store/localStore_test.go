@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore_PutAndServe(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalStore(dir, "http://localhost:8080/", "secret")
+	server := http.StripPrefix(LocalFilesPath, NewLocalFileServer(dir, "secret"))
+
+	err := s.Put(context.Background(), "expenses/1/abc.pdf", []byte("%PDF-1.4"), "application/pdf")
+	require.NoError(t, err)
+
+	signed, err := s.SignedURL(context.Background(), "expenses/1/abc.pdf", time.Minute)
+	require.NoError(t, err)
+	assert.Contains(t, signed, "http://localhost:8080/receipt-files/expenses/1/abc.pdf?")
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, signed, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "%PDF-1.4", rec.Body.String())
+	assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+}
+
+func TestLocalFileServer_RejectsBadLinks(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalStore(dir, "http://localhost:8080", "secret").(*localStore)
+	server := http.StripPrefix(LocalFilesPath, NewLocalFileServer(dir, "secret"))
+	require.NoError(t, s.Put(context.Background(), "expenses/1/abc.png", []byte("png"), "image/png"))
+
+	valid, err := s.SignedURL(context.Background(), "expenses/1/abc.png", time.Minute)
+	require.NoError(t, err)
+
+	tampered, _ := url.Parse(valid)
+	tampered.Path = "/receipt-files/expenses/2/abc.png"
+
+	s.now = func() time.Time { return time.Now().Add(-time.Hour) }
+	expired, err := s.SignedURL(context.Background(), "expenses/1/abc.png", time.Minute)
+	require.NoError(t, err)
+
+	for name, link := range map[string]string{
+		"tampered key": tampered.String(),
+		"expired":      expired,
+		"unsigned":     "http://localhost:8080/receipt-files/expenses/1/abc.png",
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, link, nil))
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+		})
+	}
+}
+
+func TestLocalStore_RejectsEscapingKeys(t *testing.T) {
+	s := NewLocalStore(t.TempDir(), "http://localhost:8080", "secret")
+
+	err := s.Put(context.Background(), "../outside.pdf", []byte("x"), "application/pdf")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/receipt"
+)
+
+const (
+	s3Algorithm      = "AWS4-HMAC-SHA256"
+	s3TimeFormat     = "20060102T150405Z"
+	s3DateFormat     = "20060102"
+	s3UnsignedBody   = "UNSIGNED-PAYLOAD"
+	s3MaxPresignTime = 7 * 24 * time.Hour
+)
+
+// S3Config points the S3 store at a bucket on AWS S3 or any S3-compatible service such
+// as MinIO.
+type S3Config struct {
+	// Endpoint is the base URL the API uploads through, e.g. http://minio:9000.
+	Endpoint string
+	// PublicEndpoint is the base URL put into signed download links. It defaults to
+	// Endpoint and only differs when clients reach the service under another address.
+	PublicEndpoint string
+	Region         string
+	Bucket         string
+	AccessKey      string
+	SecretKey      string
+	// PathStyle addresses the bucket as endpoint/bucket/key instead of as a subdomain of
+	// the endpoint. MinIO needs it.
+	PathStyle bool
+}
+
+type s3Store struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Store signs its requests with AWS Signature Version 4.
+func NewS3Store(cfg S3Config, client *http.Client) receipt.ReceiptStore {
+	if cfg.PublicEndpoint == "" {
+		cfg.PublicEndpoint = cfg.Endpoint
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &s3Store{cfg: cfg, client: client, now: time.Now}
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	target, err := s.objectURL(s.cfg.Endpoint, key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(data)
+	payloadHash := hex.EncodeToString(digest[:])
+	now := s.now().UTC()
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+
+	headers := map[string]string{
+		"content-type":         contentType,
+		"host":                 target.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           now.Format(s3TimeFormat),
+	}
+	signedHeaders, canonicalHeaders := canonicalS3Headers(headers)
+
+	canonical := strings.Join([]string{
+		http.MethodPut,
+		target.EscapedPath(),
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := s.scope(now)
+	signature := s.sign(now, scope, canonical)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.cfg.AccessKey, scope, signedHeaders, signature))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("s3 put %s: %s: %s", key, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// SignedURL builds a presigned GET link on the public endpoint.
+func (s *s3Store) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if ttl <= 0 || ttl > s3MaxPresignTime {
+		return "", fmt.Errorf("s3 presigned URLs must expire within %s", s3MaxPresignTime)
+	}
+
+	target, err := s.objectURL(s.cfg.PublicEndpoint, key)
+	if err != nil {
+		return "", err
+	}
+
+	now := s.now().UTC()
+	scope := s.scope(now)
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     s3Algorithm,
+		"X-Amz-Credential":    s.cfg.AccessKey + "/" + scope,
+		"X-Amz-Date":          now.Format(s3TimeFormat),
+		"X-Amz-Expires":       strconv.FormatInt(int64(ttl/time.Second), 10),
+		"X-Amz-SignedHeaders": "host",
+	}
+	canonicalQuery := canonicalS3Query(query)
+
+	canonical := strings.Join([]string{
+		http.MethodGet,
+		target.EscapedPath(),
+		canonicalQuery,
+		"host:" + target.Host + "\n",
+		"host",
+		s3UnsignedBody,
+	}, "\n")
+
+	signature := s.sign(now, scope, canonical)
+	target.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+
+	return target.String(), nil
+}
+
+// objectURL addresses key in the bucket on the given endpoint.
+func (s *s3Store) objectURL(endpoint, key string) (*url.URL, error) {
+	base, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	objectPath := "/" + key
+	if s.cfg.PathStyle {
+		objectPath = "/" + s.cfg.Bucket + objectPath
+	} else {
+		base.Host = s.cfg.Bucket + "." + base.Host
+	}
+
+	base.Path += objectPath
+	base.RawPath = s3Escape(base.Path, false)
+
+	return base, nil
+}
+
+func (s *s3Store) scope(now time.Time) string {
+	return now.Format(s3DateFormat) + "/" + s.cfg.Region + "/s3/aws4_request"
+}
+
+func (s *s3Store) sign(now time.Time, scope, canonicalRequest string) string {
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		now.Format(s3TimeFormat),
+		scope,
+		hex.EncodeToString(hashed[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), now.Format(s3DateFormat))
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func canonicalS3Headers(headers map[string]string) (string, string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+
+	return strings.Join(names, ";"), canonical.String()
+}
+
+func canonicalS3Query(query map[string]string) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = s3Escape(name, true) + "=" + s3Escape(query[name], true)
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape percent-encodes everything but the unreserved characters, as SigV4 requires.
+// Slashes are kept in paths and encoded in query values.
+func s3Escape(value string, encodeSlash bool) string {
+	var escaped strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			escaped.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			escaped.WriteByte(b)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+	return escaped.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
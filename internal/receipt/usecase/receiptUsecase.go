@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/expense"
+	"github.com/evrintobing17/expense-management-backend/internal/receipt"
+)
+
+type receiptUseCase struct {
+	receiptRepo    receipt.ReceiptRepository
+	store          receipt.ReceiptStore
+	expenseRepo    expense.ExpenseRepository
+	expenseUseCase expense.ExpenseUseCase
+	maxBytes       int64
+	urlTTL         time.Duration
+}
+
+// NewReceiptUseCase accepts receipts of up to maxBytes and hands out download links that
+// stay valid for urlTTL.
+func NewReceiptUseCase(
+	receiptRepo receipt.ReceiptRepository,
+	store receipt.ReceiptStore,
+	expenseRepo expense.ExpenseRepository,
+	expenseUseCase expense.ExpenseUseCase,
+	maxBytes int64,
+	urlTTL time.Duration,
+) receipt.ReceiptUseCase {
+	return &receiptUseCase{
+		receiptRepo:    receiptRepo,
+		store:          store,
+		expenseRepo:    expenseRepo,
+		expenseUseCase: expenseUseCase,
+		maxBytes:       maxBytes,
+		urlTTL:         urlTTL,
+	}
+}
+
+// UploadReceipt stores the file and points the expense's receipt_url at it. Only the
+// submitter can attach receipts, and only while the expense is still editable. The
+// content type is sniffed from the file itself rather than trusted from the client.
+func (uc *receiptUseCase) UploadReceipt(ctx context.Context, expenseID int, userID int, fileName string, file io.Reader) (*domain.Receipt, error) {
+	exp, err := uc.expenseRepo.FindByID(ctx, expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if exp == nil {
+		return nil, domain.ErrExpenseNotFound
+	}
+
+	if exp.UserID != userID {
+		return nil, domain.ErrUnauthorizedAction
+	}
+
+	if !exp.IsEditable() {
+		return nil, domain.ErrExpenseNotEditable
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, uc.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > uc.maxBytes {
+		return nil, domain.ErrReceiptTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if _, ok := domain.ReceiptExtension(contentType); !ok || len(data) == 0 {
+		return nil, domain.ErrUnsupportedReceipt
+	}
+
+	digest := sha256.Sum256(data)
+	sum := hex.EncodeToString(digest[:])
+
+	rec := &domain.Receipt{
+		ExpenseID:   expenseID,
+		FileName:    filepath.Base(fileName),
+		ContentType: contentType,
+		SizeBytes:   int64(len(data)),
+		SHA256:      sum,
+		StorageKey:  domain.ReceiptStorageKey(expenseID, sum, contentType),
+		UploadedBy:  userID,
+	}
+
+	if err := uc.store.Put(ctx, rec.StorageKey, data, contentType); err != nil {
+		return nil, err
+	}
+
+	if err := uc.receiptRepo.Create(ctx, rec); err != nil {
+		return nil, err
+	}
+
+	// Going through the expense use case re-runs validation and the policy rules, so a
+	// missing_receipt violation clears as soon as the receipt is attached.
+	receiptURL := domain.ReceiptPath(rec.ID)
+	_, err = uc.expenseUseCase.UpdateExpense(ctx, expenseID, userID, domain.ExpenseChanges{ReceiptURL: &receiptURL})
+	if err != nil {
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+// ListReceipts returns the expense's receipts without download links. The submitter and
+// approvers may see them.
+func (uc *receiptUseCase) ListReceipts(ctx context.Context, expenseID int, userID int, role domain.Role) ([]*domain.Receipt, error) {
+	if _, err := uc.visibleExpense(ctx, expenseID, userID, role); err != nil {
+		return nil, err
+	}
+
+	return uc.receiptRepo.FindByExpenseID(ctx, expenseID)
+}
+
+// GetReceipt returns the receipt with a freshly signed download link.
+func (uc *receiptUseCase) GetReceipt(ctx context.Context, receiptID int, userID int, role domain.Role) (*domain.Receipt, error) {
+	rec, err := uc.receiptRepo.FindByID(ctx, receiptID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec == nil {
+		return nil, domain.ErrReceiptNotFound
+	}
+
+	if _, err := uc.visibleExpense(ctx, rec.ExpenseID, userID, role); err != nil {
+		return nil, err
+	}
+
+	expires := time.Now().Add(uc.urlTTL)
+	rec.DownloadURL, err = uc.store.SignedURL(ctx, rec.StorageKey, uc.urlTTL)
+	if err != nil {
+		return nil, err
+	}
+	rec.URLExpires = &expires
+
+	return rec, nil
+}
+
+// visibleExpense loads the expense and checks that the caller submitted it or is an
+// approver, the same rule the expense history follows.
+func (uc *receiptUseCase) visibleExpense(ctx context.Context, expenseID int, userID int, role domain.Role) (*domain.Expense, error) {
+	exp, err := uc.expenseRepo.FindByID(ctx, expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if exp == nil {
+		return nil, domain.ErrExpenseNotFound
+	}
+
+	if exp.UserID != userID && !role.IsApprover() {
+		return nil, domain.ErrUnauthorizedAction
+	}
+
+	return exp, nil
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type receiptMocks struct {
+	receipt *mocks.ReceiptRepository
+	store   *mocks.ReceiptStore
+	expense *mocks.ExpenseRepository
+	usecase *mocks.ExpenseUseCase
+}
+
+func newReceiptMocks() *receiptMocks {
+	return &receiptMocks{
+		receipt: new(mocks.ReceiptRepository),
+		store:   new(mocks.ReceiptStore),
+		expense: new(mocks.ExpenseRepository),
+		usecase: new(mocks.ExpenseUseCase),
+	}
+}
+
+func (m *receiptMocks) useCase() *receiptUseCase {
+	return NewReceiptUseCase(m.receipt, m.store, m.expense, m.usecase, 64, 5*time.Minute).(*receiptUseCase)
+}
+
+func TestUploadReceipt(t *testing.T) {
+	ctx := context.Background()
+	draft := &domain.Expense{ID: 7, UserID: 2, Status: domain.ExpenseStatusDraft}
+
+	t.Run("success", func(t *testing.T) {
+		m := newReceiptMocks()
+		m.expense.On("FindByID", mock.Anything, 7).Return(draft, nil).Once()
+		m.store.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "expenses/7/") && strings.HasSuffix(key, ".png")
+		}), pngHeader, "image/png").Return(nil).Once()
+		m.receipt.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.Receipt) bool {
+			return r.ExpenseID == 7 && r.FileName == "taxi.png" && len(r.SHA256) == 64 && r.SizeBytes == int64(len(pngHeader))
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Receipt).ID = 3
+		}).Return(nil).Once()
+		m.usecase.On("UpdateExpense", mock.Anything, 7, 2, mock.MatchedBy(func(c domain.ExpenseChanges) bool {
+			return c.ReceiptURL != nil && *c.ReceiptURL == "/api/receipts/3"
+		})).Return(draft, nil).Once()
+
+		result, err := m.useCase().UploadReceipt(ctx, 7, 2, "../../taxi.png", bytes.NewReader(pngHeader))
+		require.NoError(t, err)
+		require.Equal(t, "image/png", result.ContentType)
+		m.usecase.AssertExpectations(t)
+	})
+
+	t.Run("not the submitter", func(t *testing.T) {
+		m := newReceiptMocks()
+		m.expense.On("FindByID", mock.Anything, 7).Return(draft, nil).Once()
+
+		_, err := m.useCase().UploadReceipt(ctx, 7, 5, "taxi.png", bytes.NewReader(pngHeader))
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+	})
+
+	t.Run("not editable", func(t *testing.T) {
+		m := newReceiptMocks()
+		m.expense.On("FindByID", mock.Anything, 7).Return(&domain.Expense{ID: 7, UserID: 2, Status: domain.ExpenseStatusApproved}, nil).Once()
+
+		_, err := m.useCase().UploadReceipt(ctx, 7, 2, "taxi.png", bytes.NewReader(pngHeader))
+		require.ErrorIs(t, err, domain.ErrExpenseNotEditable)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		m := newReceiptMocks()
+		m.expense.On("FindByID", mock.Anything, 7).Return(draft, nil).Once()
+
+		_, err := m.useCase().UploadReceipt(ctx, 7, 2, "scan.png", bytes.NewReader(append(pngHeader, make([]byte, 64)...)))
+		require.ErrorIs(t, err, domain.ErrReceiptTooLarge)
+		m.store.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		m := newReceiptMocks()
+		m.expense.On("FindByID", mock.Anything, 7).Return(draft, nil).Once()
+
+		_, err := m.useCase().UploadReceipt(ctx, 7, 2, "taxi.png", strings.NewReader("plain text, not an image"))
+		require.ErrorIs(t, err, domain.ErrUnsupportedReceipt)
+		m.store.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetReceipt(t *testing.T) {
+	ctx := context.Background()
+	stored := &domain.Receipt{ID: 3, ExpenseID: 7, StorageKey: "expenses/7/abc.png"}
+
+	t.Run("approver gets a signed link", func(t *testing.T) {
+		m := newReceiptMocks()
+		m.receipt.On("FindByID", mock.Anything, 3).Return(stored, nil).Once()
+		m.expense.On("FindByID", mock.Anything, 7).Return(&domain.Expense{ID: 7, UserID: 2}, nil).Once()
+		m.store.On("SignedURL", mock.Anything, "expenses/7/abc.png", 5*time.Minute).Return("https://files/signed", nil).Once()
+
+		result, err := m.useCase().GetReceipt(ctx, 3, 1, domain.RoleManager)
+		require.NoError(t, err)
+		require.Equal(t, "https://files/signed", result.DownloadURL)
+		require.NotNil(t, result.URLExpires)
+	})
+
+	t.Run("other employee", func(t *testing.T) {
+		m := newReceiptMocks()
+		m.receipt.On("FindByID", mock.Anything, 3).Return(stored, nil).Once()
+		m.expense.On("FindByID", mock.Anything, 7).Return(&domain.Expense{ID: 7, UserID: 2}, nil).Once()
+
+		_, err := m.useCase().GetReceipt(ctx, 3, 5, domain.RoleEmployee)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+	})
+
+	t.Run("missing receipt", func(t *testing.T) {
+		m := newReceiptMocks()
+		m.receipt.On("FindByID", mock.Anything, 4).Return((*domain.Receipt)(nil), nil).Once()
+
+		_, err := m.useCase().GetReceipt(ctx, 4, 2, domain.RoleEmployee)
+		require.ErrorIs(t, err, domain.ErrReceiptNotFound)
+	})
+}
@@ -0,0 +1,425 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/evrintobing17/expense-management-backend/internal/report"
+	"github.com/gorilla/mux"
+)
+
+type ReportHandler struct {
+	reportUseCase report.ReportUseCase
+}
+
+func NewReportHandler(reportUseCase report.ReportUseCase) *ReportHandler {
+	return &ReportHandler{reportUseCase: reportUseCase}
+}
+
+type reportRequest struct {
+	Title     string    `json:"title"`
+	Purpose   string    `json:"purpose"`
+	TripStart time.Time `json:"trip_start"`
+	TripEnd   time.Time `json:"trip_end"`
+}
+
+func (h *ReportHandler) CreateReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req reportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	report, err := h.reportUseCase.CreateReport(ctx, &domain.ExpenseReport{
+		UserID:    userID,
+		Title:     req.Title,
+		Purpose:   req.Purpose,
+		TripStart: req.TripStart,
+		TripEnd:   req.TripEnd,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidReport):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(report)
+}
+
+func (h *ReportHandler) GetReports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	reports, err := h.reportUseCase.GetUserReports(ctx, userID)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reports)
+}
+
+func (h *ReportHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	role, _ := middleware.GetUserRoleFromContext(ctx)
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid report ID", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.reportUseCase.GetReport(ctx, id, userID, role)
+	if err != nil {
+		writeError(w, err, "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func (h *ReportHandler) UpdateReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid report ID", http.StatusBadRequest)
+		return
+	}
+
+	var req reportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.reportUseCase.UpdateReport(ctx, &domain.ExpenseReport{
+		ID:        id,
+		UserID:    userID,
+		Title:     req.Title,
+		Purpose:   req.Purpose,
+		TripStart: req.TripStart,
+		TripEnd:   req.TripEnd,
+	})
+	if err != nil {
+		writeError(w, err, "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func (h *ReportHandler) AddLine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid report ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		ExpenseID int `json:"expense_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.reportUseCase.AddLine(ctx, id, req.ExpenseID, userID)
+	if err != nil {
+		writeError(w, err, "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func (h *ReportHandler) RemoveLine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, expenseID, ok := lineVars(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportUseCase.RemoveLine(ctx, id, expenseID, userID)
+	if err != nil {
+		writeError(w, err, "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func (h *ReportHandler) SubmitReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid report ID", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.reportUseCase.SubmitReport(ctx, id, userID)
+	if err != nil {
+		writeError(w, err, "Only draft expense reports can be submitted")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func (h *ReportHandler) CancelReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid report ID", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.reportUseCase.CancelReport(ctx, id, userID)
+	if err != nil {
+		writeError(w, err, "Expense report can no longer be cancelled")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func (h *ReportHandler) GetPendingApproval(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	approverID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	reports, err := h.reportUseCase.GetPendingApproval(ctx, approverID)
+	if err != nil {
+		writeError(w, err, "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reports)
+}
+
+func (h *ReportHandler) ApproveReport(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.reportUseCase.ApproveReport, "Expense report cannot be approved")
+}
+
+func (h *ReportHandler) RejectReport(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.reportUseCase.RejectReport, "Expense report cannot be rejected")
+}
+
+// decide handles the approve and reject endpoints, which only differ in the use case
+// they call.
+func (h *ReportHandler) decide(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(ctx context.Context, id int, approverID int, notes string) error,
+	invalidStatus string,
+) {
+	ctx := r.Context()
+
+	approverID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid report ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Notes string `json:"notes"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := action(ctx, id, approverID, req.Notes); err != nil {
+		writeError(w, err, invalidStatus)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *ReportHandler) RejectLine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	approverID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, expenseID, ok := lineVars(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Notes string `json:"notes"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.reportUseCase.RejectLine(ctx, id, expenseID, approverID, req.Notes)
+	if err != nil {
+		writeError(w, err, "Line cannot be rejected")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// lineVars parses the report and expense ids of a line route, writing a 400 when either
+// is malformed.
+func lineVars(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid report ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	expenseID, err := strconv.Atoi(vars["expenseId"])
+	if err != nil {
+		http.Error(w, "Invalid expense ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	return id, expenseID, true
+}
+
+// writeError maps a report use case error to its response. invalidStatus is the message
+// shown when the report is not in a status that allows the action.
+func writeError(w http.ResponseWriter, err error, invalidStatus string) {
+	switch {
+	case isValidationError(err):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrPolicyBlocked):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, domain.ErrReportNotFound):
+		http.Error(w, "Expense report not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrExpenseNotFound):
+		http.Error(w, "Expense not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidExpenseStatus):
+		if invalidStatus == "" {
+			invalidStatus = err.Error()
+		}
+		http.Error(w, invalidStatus, http.StatusBadRequest)
+	case errors.Is(err, domain.ErrReportNotEditable), errors.Is(err, domain.ErrLineNotAttachable):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrStatusConflict):
+		http.Error(w, "Expense report was updated by another request", http.StatusConflict)
+	case errors.Is(err, domain.ErrApprovalConflict):
+		http.Error(w, "Approval level has already been decided", http.StatusConflict)
+	case errors.Is(err, domain.ErrUnauthorizedAction):
+		http.Error(w, "Access denied", http.StatusForbidden)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// isValidationError reports whether err rejects the report's details or one of its
+// lines, so that the message can be shown to the submitter as it is.
+func isValidationError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidReport) ||
+		errors.Is(err, domain.ErrEmptyReport) ||
+		errors.Is(err, domain.ErrInvalidAmount) ||
+		errors.Is(err, domain.ErrMissingDescription) ||
+		errors.Is(err, domain.ErrMissingReceipt) ||
+		errors.Is(err, domain.ErrCategoryNotFound) ||
+		errors.Is(err, domain.ErrInactiveCategory) ||
+		errors.Is(err, domain.ErrInvalidCurrency) ||
+		errors.Is(err, domain.ErrFXRateNotFound) ||
+		errors.Is(err, domain.ErrTaxMismatch) ||
+		errors.Is(err, domain.ErrInvalidTax) ||
+		errors.Is(err, domain.ErrInvalidEFaktur) ||
+		errors.Is(err, domain.ErrInvalidAllocation) ||
+		errors.Is(err, domain.ErrInactiveAllocationCode)
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/middleware"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func withUser(req *http.Request, userID int, role domain.Role) *http.Request {
+	// Reuse auth middleware to set the same context keys used by handlers.
+	mockAuth := new(mocks.AuthService)
+	mockAuth.On("ValidateToken", mock.Anything, "t").Return(userID, role, nil).Maybe()
+	rr := httptest.NewRecorder()
+	next := middleware.AuthMiddleware(mockAuth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+	}))
+	req.Header.Set("Authorization", "Bearer t")
+	next.ServeHTTP(rr, req)
+	return req
+}
+
+func TestReportHandlerCreateReport(t *testing.T) {
+	t.Run("unauthorized", func(t *testing.T) {
+		h := NewReportHandler(new(mocks.ReportUseCase))
+		req := httptest.NewRequest(http.MethodPost, "/expense-reports", strings.NewReader(`{"title":"Trip"}`))
+		rr := httptest.NewRecorder()
+
+		h.CreateReport(rr, req)
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("invalid details", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expense-reports", strings.NewReader(`{"title":""}`))
+		req = withUser(req, 1, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateReport", mock.Anything, mock.Anything).Return((*domain.ExpenseReport)(nil), domain.ErrInvalidReport).Once()
+
+		h.CreateReport(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expense-reports", strings.NewReader(`{"title":"Surabaya visit","purpose":"client onboarding","trip_start":"2026-10-12T00:00:00Z","trip_end":"2026-10-16T00:00:00Z"}`))
+		req = withUser(req, 1, domain.RoleEmployee)
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateReport", mock.Anything, mock.MatchedBy(func(r *domain.ExpenseReport) bool {
+			return r.UserID == 1 && r.Title == "Surabaya visit" && r.Purpose == "client onboarding" &&
+				r.TripStart.Equal(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)) &&
+				r.TripEnd.Equal(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+		})).Return(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusDraft}, nil).Once()
+
+		h.CreateReport(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.Contains(t, rr.Body.String(), `"id":10`)
+	})
+}
+
+func TestReportHandlerGetReport(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/expense-reports/10", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("GetReport", mock.Anything, 10, 1, domain.RoleEmployee).Return((*domain.ExpenseReport)(nil), domain.ErrReportNotFound).Once()
+
+		h.GetReport(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("approver", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/expense-reports/10", nil)
+		req = withUser(req, 2, domain.RoleManager)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("GetReport", mock.Anything, 10, 2, domain.RoleManager).Return(&domain.ExpenseReport{ID: 10, UserID: 1}, nil).Once()
+
+		h.GetReport(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestReportHandlerLines(t *testing.T) {
+	t.Run("add line not attachable", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expense-reports/10/lines", strings.NewReader(`{"expense_id":3}`))
+		req = withUser(req, 1, domain.RoleEmployee)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("AddLine", mock.Anything, 10, 3, 1).Return((*domain.ExpenseReport)(nil), domain.ErrLineNotAttachable).Once()
+
+		h.AddLine(rr, req)
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("remove line invalid expense id", func(t *testing.T) {
+		h := NewReportHandler(new(mocks.ReportUseCase))
+		req := httptest.NewRequest(http.MethodDelete, "/expense-reports/10/lines/x", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		req = mux.SetURLVars(req, map[string]string{"id": "10", "expenseId": "x"})
+		rr := httptest.NewRecorder()
+
+		h.RemoveLine(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("remove line success", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodDelete, "/expense-reports/10/lines/3", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		req = mux.SetURLVars(req, map[string]string{"id": "10", "expenseId": "3"})
+		rr := httptest.NewRecorder()
+		mockUC.On("RemoveLine", mock.Anything, 10, 3, 1).Return(&domain.ExpenseReport{ID: 10}, nil).Once()
+
+		h.RemoveLine(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestReportHandlerSubmitReport(t *testing.T) {
+	t.Run("empty report", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expense-reports/10/submit", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("SubmitReport", mock.Anything, 10, 1).Return((*domain.ExpenseReport)(nil), domain.ErrEmptyReport).Once()
+
+		h.SubmitReport(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), domain.ErrEmptyReport.Error())
+	})
+
+	t.Run("blocked by policy", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expense-reports/10/submit", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("SubmitReport", mock.Anything, 10, 1).Return((*domain.ExpenseReport)(nil), domain.ErrPolicyBlocked).Once()
+
+		h.SubmitReport(rr, req)
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/expense-reports/10/submit", nil)
+		req = withUser(req, 1, domain.RoleEmployee)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("SubmitReport", mock.Anything, 10, 1).
+			Return(&domain.ExpenseReport{ID: 10, Status: domain.ExpenseStatusAwaitingApproval, TotalIDR: 1500000}, nil).Once()
+
+		h.SubmitReport(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"total_idr":1500000`)
+	})
+}
+
+func TestReportHandlerApprovalEndpoints(t *testing.T) {
+	t.Run("approve forbidden", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPut, "/expense-reports/10/approve", strings.NewReader(`{"notes":"ok"}`))
+		req = withUser(req, 3, domain.RoleManager)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("ApproveReport", mock.Anything, 10, 3, "ok").Return(domain.ErrUnauthorizedAction).Once()
+
+		h.ApproveReport(rr, req)
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("approve level already decided", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPut, "/expense-reports/10/approve", strings.NewReader(`{"notes":"ok"}`))
+		req = withUser(req, 3, domain.RoleManager)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("ApproveReport", mock.Anything, 10, 3, "ok").Return(domain.ErrApprovalConflict).Once()
+
+		h.ApproveReport(rr, req)
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("reject success", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPut, "/expense-reports/10/reject", strings.NewReader(`{"notes":"no"}`))
+		req = withUser(req, 3, domain.RoleManager)
+		req = mux.SetURLVars(req, map[string]string{"id": "10"})
+		rr := httptest.NewRecorder()
+		mockUC.On("RejectReport", mock.Anything, 10, 3, "no").Return(nil).Once()
+
+		h.RejectReport(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("reject line", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPut, "/expense-reports/10/lines/4/reject", strings.NewReader(`{"notes":"personal"}`))
+		req = withUser(req, 3, domain.RoleManager)
+		req = mux.SetURLVars(req, map[string]string{"id": "10", "expenseId": "4"})
+		rr := httptest.NewRecorder()
+		mockUC.On("RejectLine", mock.Anything, 10, 4, 3, "personal").
+			Return(&domain.ExpenseReport{ID: 10, TotalIDR: 1200000}, nil).Once()
+
+		h.RejectLine(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"total_idr":1200000`)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		mockUC := new(mocks.ReportUseCase)
+		h := NewReportHandler(mockUC)
+		req := httptest.NewRequest(http.MethodGet, "/expense-reports-pending", nil)
+		req = withUser(req, 3, domain.RoleManager)
+		rr := httptest.NewRecorder()
+		mockUC.On("GetPendingApproval", mock.Anything, 3).Return([]*domain.ExpenseReport{{ID: 10}}, nil).Once()
+
+		h.GetPendingApproval(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
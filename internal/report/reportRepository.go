@@ -0,0 +1,22 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type ReportRepository interface {
+	Create(ctx context.Context, report *domain.ExpenseReport) error
+	FindByID(ctx context.Context, id int) (*domain.ExpenseReport, error)
+	FindByUserID(ctx context.Context, userID int) ([]*domain.ExpenseReport, error)
+	FindByStatus(ctx context.Context, statuses ...domain.ExpenseStatus) ([]*domain.ExpenseReport, error)
+	ClaimForPayment(ctx context.Context, limit int, timeout time.Duration) ([]*domain.ExpenseReport, error)
+	Update(ctx context.Context, report *domain.ExpenseReport) error
+	UpdateStatus(ctx context.Context, report *domain.ExpenseReport, from domain.ExpenseStatus) ([]int, error)
+	AddLine(ctx context.Context, reportID, expenseID, userID int) error
+	RemoveLine(ctx context.Context, reportID, expenseID int) error
+	CreateApproval(ctx context.Context, approval *domain.ReportApproval) error
+	FindApprovals(ctx context.Context, reportID int) ([]*domain.ReportApproval, error)
+}
@@ -0,0 +1,22 @@
+package report
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type ReportUseCase interface {
+	CreateReport(ctx context.Context, report *domain.ExpenseReport) (*domain.ExpenseReport, error)
+	UpdateReport(ctx context.Context, report *domain.ExpenseReport) (*domain.ExpenseReport, error)
+	GetReport(ctx context.Context, id int, userID int, role domain.Role) (*domain.ExpenseReport, error)
+	GetUserReports(ctx context.Context, userID int) ([]*domain.ExpenseReport, error)
+	AddLine(ctx context.Context, reportID, expenseID, userID int) (*domain.ExpenseReport, error)
+	RemoveLine(ctx context.Context, reportID, expenseID, userID int) (*domain.ExpenseReport, error)
+	SubmitReport(ctx context.Context, id int, userID int) (*domain.ExpenseReport, error)
+	CancelReport(ctx context.Context, id int, userID int) (*domain.ExpenseReport, error)
+	GetPendingApproval(ctx context.Context, approverID int) ([]*domain.ExpenseReport, error)
+	ApproveReport(ctx context.Context, id int, approverID int, notes string) error
+	RejectReport(ctx context.Context, id int, approverID int, notes string) error
+	RejectLine(ctx context.Context, reportID, expenseID, approverID int, notes string) (*domain.ExpenseReport, error)
+}
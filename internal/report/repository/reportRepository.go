@@ -0,0 +1,416 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/report"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is the Postgres error code raised when a unique index rejects a row.
+const uniqueViolation = "23505"
+
+type reportRepository struct {
+	db *sql.DB
+}
+
+func NewReportRepository(db *sql.DB) report.ReportRepository {
+	return &reportRepository{db: db}
+}
+
+func (r *reportRepository) Create(ctx context.Context, report *domain.ExpenseReport) error {
+	query := `
+		INSERT INTO expense_reports (user_id, title, purpose, trip_start, trip_end, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	return database.Conn(ctx, r.db).QueryRowContext(ctx, query,
+		report.UserID,
+		report.Title,
+		report.Purpose,
+		report.TripStart,
+		report.TripEnd,
+		report.Status,
+	).Scan(&report.ID, &report.CreatedAt)
+}
+
+func (r *reportRepository) FindByID(ctx context.Context, id int) (*domain.ExpenseReport, error) {
+	query := `
+		SELECT id, user_id, title, purpose, trip_start, trip_end, status, total_idr,
+			requires_approval, auto_approved, requires_finance_approval, submitted_at, processed_at, created_at
+		FROM expense_reports
+		WHERE id = $1
+	`
+
+	report := &domain.ExpenseReport{}
+	err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&report.ID,
+		&report.UserID,
+		&report.Title,
+		&report.Purpose,
+		&report.TripStart,
+		&report.TripEnd,
+		&report.Status,
+		&report.TotalIDR,
+		&report.RequiresApproval,
+		&report.AutoApproved,
+		&report.RequiresFinanceApproval,
+		&report.SubmittedAt,
+		&report.ProcessedAt,
+		&report.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (r *reportRepository) FindByUserID(ctx context.Context, userID int) ([]*domain.ExpenseReport, error) {
+	query := `
+		SELECT id, user_id, title, purpose, trip_start, trip_end, status, total_idr,
+			requires_approval, auto_approved, requires_finance_approval, submitted_at, processed_at, created_at
+		FROM expense_reports
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	return r.query(ctx, query, userID)
+}
+
+// FindByStatus returns the reports in any of the statuses, oldest submission first.
+func (r *reportRepository) FindByStatus(ctx context.Context, statuses ...domain.ExpenseStatus) ([]*domain.ExpenseReport, error) {
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("at least one status must be provided")
+	}
+
+	query := `
+		SELECT id, user_id, title, purpose, trip_start, trip_end, status, total_idr,
+			requires_approval, auto_approved, requires_finance_approval, submitted_at, processed_at, created_at
+		FROM expense_reports
+		WHERE status = ANY($1)
+		ORDER BY submitted_at ASC, id ASC
+	`
+
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+
+	return r.query(ctx, query, pq.Array(names))
+}
+
+// ClaimForPayment moves up to limit approved and auto-approved reports, and the lines
+// that moved with them, to processing and returns them, oldest submission first. Rows
+// locked by another worker are skipped rather than waited for. Reports claimed more than
+// timeout ago and still processing are claimed again, and so are reports whose payment
+// retry has come due. Each returned report carries the status it was claimed from.
+func (r *reportRepository) ClaimForPayment(ctx context.Context, limit int, timeout time.Duration) ([]*domain.ExpenseReport, error) {
+	query := `
+		WITH claimed AS (
+			SELECT id, status
+			FROM expense_reports
+			WHERE status = ANY($2)
+				OR (status = $1 AND claimed_at < CURRENT_TIMESTAMP - make_interval(secs => $3))
+				OR (status = $5 AND NOT EXISTS (
+					SELECT 1 FROM payments p
+					WHERE p.kind = $6 AND p.subject_id = expense_reports.id AND p.next_attempt_at > CURRENT_TIMESTAMP
+				))
+			ORDER BY submitted_at ASC, id ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		), lines AS (
+			UPDATE expenses e
+			SET status = $1
+			FROM claimed
+			WHERE e.report_id = claimed.id AND e.status = claimed.status
+		)
+		UPDATE expense_reports r
+		SET status = $1, claimed_at = CURRENT_TIMESTAMP
+		FROM claimed
+		WHERE r.id = claimed.id
+		RETURNING r.id, r.user_id, r.title, r.purpose, r.trip_start, r.trip_end, claimed.status, r.total_idr,
+			r.requires_approval, r.auto_approved, r.requires_finance_approval, r.submitted_at, r.processed_at, r.created_at
+	`
+
+	payable := pq.Array([]string{string(domain.ExpenseStatusApproved), string(domain.ExpenseStatusAutoApproved)})
+	return r.query(ctx, query, domain.ExpenseStatusProcessing, payable, timeout.Seconds(), limit,
+		domain.ExpenseStatusRetryScheduled, domain.PaymentKindExpenseReport)
+}
+
+func (r *reportRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.ExpenseReport, error) {
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*domain.ExpenseReport
+	for rows.Next() {
+		report := &domain.ExpenseReport{}
+		err := rows.Scan(
+			&report.ID,
+			&report.UserID,
+			&report.Title,
+			&report.Purpose,
+			&report.TripStart,
+			&report.TripEnd,
+			&report.Status,
+			&report.TotalIDR,
+			&report.RequiresApproval,
+			&report.AutoApproved,
+			&report.RequiresFinanceApproval,
+			&report.SubmittedAt,
+			&report.ProcessedAt,
+			&report.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, rows.Err()
+}
+
+// Update saves the report's details. Only drafts can be changed; ErrStatusConflict is
+// returned when the report was submitted in the meantime.
+func (r *reportRepository) Update(ctx context.Context, report *domain.ExpenseReport) error {
+	query := `
+		UPDATE expense_reports
+		SET title = $1, purpose = $2, trip_start = $3, trip_end = $4
+		WHERE id = $5 AND status = $6
+	`
+
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query,
+		report.Title,
+		report.Purpose,
+		report.TripStart,
+		report.TripEnd,
+		report.ID,
+		domain.ExpenseStatusDraft,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result)
+}
+
+// UpdateStatus moves the report from status from to its current status, saving its total
+// and routing, and moves the lines still in from along with it, returning the ids of the
+// lines that moved. Lines that were rejected on their own keep their status.
+// ErrStatusConflict is returned when the report is no longer in from.
+func (r *reportRepository) UpdateStatus(ctx context.Context, report *domain.ExpenseReport, from domain.ExpenseStatus) ([]int, error) {
+	if report.Status != from {
+		if err := domain.ValidateTransition(from, report.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	var moved []int
+	err := database.WithinTx(ctx, r.db, func(ctx context.Context) error {
+		var err error
+		moved, err = r.updateStatus(ctx, report, from)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return moved, nil
+}
+
+func (r *reportRepository) updateStatus(ctx context.Context, report *domain.ExpenseReport, from domain.ExpenseStatus) ([]int, error) {
+	tx := database.Conn(ctx, r.db)
+
+	query := `
+		UPDATE expense_reports
+		SET status = $1, total_idr = $2, requires_approval = $3, auto_approved = $4,
+			requires_finance_approval = $5, submitted_at = $6, processed_at = $7
+		WHERE id = $8 AND status = $9
+	`
+
+	result, err := tx.ExecContext(ctx, query,
+		report.Status,
+		report.TotalIDR,
+		report.RequiresApproval,
+		report.AutoApproved,
+		report.RequiresFinanceApproval,
+		report.SubmittedAt,
+		report.ProcessedAt,
+		report.ID,
+		from,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkAffected(result); err != nil {
+		return nil, err
+	}
+
+	if report.Status == from {
+		return nil, nil
+	}
+
+	query = `
+		UPDATE expenses
+		SET status = $1, processed_at = $2
+		WHERE report_id = $3 AND status = $4
+		RETURNING id
+	`
+
+	rows, err := tx.QueryContext(ctx, query, report.Status, report.ProcessedAt, report.ID, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moved []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		moved = append(moved, id)
+	}
+
+	return moved, rows.Err()
+}
+
+// AddLine attaches one of the user's draft expenses to the report. ErrLineNotAttachable
+// is returned when the expense is someone else's, no longer a draft, already in a report
+// or filed against a cash advance.
+func (r *reportRepository) AddLine(ctx context.Context, reportID, expenseID, userID int) error {
+	query := `
+		UPDATE expenses
+		SET report_id = $1
+		WHERE id = $2 AND user_id = $3 AND status = $4 AND report_id IS NULL
+			AND advance_id IS NULL
+	`
+
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, reportID, expenseID, userID, domain.ExpenseStatusDraft)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrLineNotAttachable
+	}
+
+	return nil
+}
+
+// RemoveLine detaches the expense from the report, leaving it as a standalone draft.
+func (r *reportRepository) RemoveLine(ctx context.Context, reportID, expenseID int) error {
+	query := `
+		UPDATE expenses
+		SET report_id = NULL
+		WHERE id = $1 AND report_id = $2
+	`
+
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, expenseID, reportID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrExpenseNotFound
+	}
+
+	return nil
+}
+
+func (r *reportRepository) CreateApproval(ctx context.Context, approval *domain.ReportApproval) error {
+	query := `
+		INSERT INTO expense_report_approvals (report_id, approver_id, on_behalf_of_id, level, status, notes)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	err := database.Conn(ctx, r.db).QueryRowContext(ctx, query,
+		approval.ReportID,
+		approval.ApproverID,
+		approval.OnBehalfOfID,
+		approval.Level,
+		approval.Status,
+		approval.Notes,
+	).Scan(&approval.ID, &approval.CreatedAt)
+
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+		return domain.ErrApprovalConflict
+	}
+
+	return err
+}
+
+// FindApprovals returns the report's approval chain in the order it was signed.
+func (r *reportRepository) FindApprovals(ctx context.Context, reportID int) ([]*domain.ReportApproval, error) {
+	query := `
+		SELECT id, report_id, approver_id, on_behalf_of_id, level, status, notes, created_at
+		FROM expense_report_approvals
+		WHERE report_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, reportID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var approvals []*domain.ReportApproval
+	for rows.Next() {
+		approval := &domain.ReportApproval{}
+		err := rows.Scan(
+			&approval.ID,
+			&approval.ReportID,
+			&approval.ApproverID,
+			&approval.OnBehalfOfID,
+			&approval.Level,
+			&approval.Status,
+			&approval.Notes,
+			&approval.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, rows.Err()
+}
+
+// checkAffected turns an update that matched no row into ErrStatusConflict.
+func checkAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrStatusConflict
+	}
+
+	return nil
+}
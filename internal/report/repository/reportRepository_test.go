@@ -0,0 +1,397 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var reportColumns = []string{
+	"id", "user_id", "title", "purpose", "trip_start", "trip_end", "status", "total_idr",
+	"requires_approval", "auto_approved", "requires_finance_approval", "submitted_at", "processed_at", "created_at",
+}
+
+func TestReportRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &reportRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO expense_reports (user_id, title, purpose, trip_start, trip_end, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`)
+	createdAt := time.Now()
+	report := &domain.ExpenseReport{
+		UserID:    1,
+		Title:     "Surabaya visit",
+		Purpose:   "client onboarding",
+		TripStart: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		TripEnd:   time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC),
+		Status:    domain.ExpenseStatusDraft,
+	}
+
+	mock.ExpectQuery(query).
+		WithArgs(report.UserID, report.Title, report.Purpose, report.TripStart, report.TripEnd, report.Status).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, createdAt))
+
+	require.NoError(t, repo.Create(context.Background(), report))
+	require.Equal(t, 7, report.ID)
+	require.Equal(t, createdAt, report.CreatedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepositoryFindByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &reportRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id, user_id, title, purpose, trip_start, trip_end, status, total_idr,
+			requires_approval, auto_approved, requires_finance_approval, submitted_at, processed_at, created_at
+		FROM expense_reports
+		WHERE id = $1
+	`)
+	now := time.Now()
+
+	t.Run("found", func(t *testing.T) {
+		rows := sqlmock.NewRows(reportColumns).
+			AddRow(7, 1, "Surabaya visit", "client onboarding", now, now, domain.ExpenseStatusAwaitingApproval, 1500000, true, false, false, now, nil, now)
+		mock.ExpectQuery(query).WithArgs(7).WillReturnRows(rows)
+
+		report, findErr := repo.FindByID(context.Background(), 7)
+		require.NoError(t, findErr)
+		require.Equal(t, 7, report.ID)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, report.Status)
+		require.Equal(t, 1500000, report.TotalIDR)
+		require.NotNil(t, report.SubmittedAt)
+		require.Nil(t, report.ProcessedAt)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs(8).WillReturnError(sql.ErrNoRows)
+
+		report, findErr := repo.FindByID(context.Background(), 8)
+		require.NoError(t, findErr)
+		require.Nil(t, report)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepositoryFindByStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &reportRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id, user_id, title, purpose, trip_start, trip_end, status, total_idr,
+			requires_approval, auto_approved, requires_finance_approval, submitted_at, processed_at, created_at
+		FROM expense_reports
+		WHERE status = ANY($1)
+		ORDER BY submitted_at ASC, id ASC
+	`)
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows(reportColumns).
+			AddRow(7, 1, "Surabaya visit", "", now, now, domain.ExpenseStatusApproved, 1500000, true, false, false, now, now, now)
+		mock.ExpectQuery(query).
+			WithArgs(pq.Array([]string{"approved", "auto_approved"})).
+			WillReturnRows(rows)
+
+		reports, findErr := repo.FindByStatus(context.Background(), domain.ExpenseStatusApproved, domain.ExpenseStatusAutoApproved)
+		require.NoError(t, findErr)
+		require.Len(t, reports, 1)
+		require.Equal(t, domain.ExpenseStatusApproved, reports[0].Status)
+	})
+
+	t.Run("no statuses", func(t *testing.T) {
+		_, findErr := repo.FindByStatus(context.Background())
+		require.Error(t, findErr)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepositoryClaimForPayment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &reportRepository{db: db}
+	query := regexp.QuoteMeta(`
+		WITH claimed AS (
+			SELECT id, status
+			FROM expense_reports
+			WHERE status = ANY($2)
+				OR (status = $1 AND claimed_at < CURRENT_TIMESTAMP - make_interval(secs => $3))
+				OR (status = $5 AND NOT EXISTS (
+					SELECT 1 FROM payments p
+					WHERE p.kind = $6 AND p.subject_id = expense_reports.id AND p.next_attempt_at > CURRENT_TIMESTAMP
+				))
+			ORDER BY submitted_at ASC, id ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		), lines AS (
+			UPDATE expenses e
+			SET status = $1
+			FROM claimed
+			WHERE e.report_id = claimed.id AND e.status = claimed.status
+		)
+		UPDATE expense_reports r
+		SET status = $1, claimed_at = CURRENT_TIMESTAMP
+		FROM claimed
+		WHERE r.id = claimed.id
+		RETURNING r.id, r.user_id, r.title, r.purpose, r.trip_start, r.trip_end, claimed.status, r.total_idr,
+			r.requires_approval, r.auto_approved, r.requires_finance_approval, r.submitted_at, r.processed_at, r.created_at
+	`)
+	now := time.Now()
+	rows := sqlmock.NewRows(reportColumns).
+		AddRow(7, 1, "Surabaya visit", "", now, now, domain.ExpenseStatusApproved, 1500000, true, false, false, now, nil, now)
+	mock.ExpectQuery(query).
+		WithArgs(domain.ExpenseStatusProcessing, pq.Array([]string{"approved", "auto_approved"}), 30.0, 10,
+			domain.ExpenseStatusRetryScheduled, domain.PaymentKindExpenseReport).
+		WillReturnRows(rows)
+
+	reports, claimErr := repo.ClaimForPayment(context.Background(), 10, 30*time.Second)
+	require.NoError(t, claimErr)
+	require.Len(t, reports, 1)
+	require.Equal(t, domain.ExpenseStatusApproved, reports[0].Status)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepositoryUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &reportRepository{db: db}
+	query := regexp.QuoteMeta(`
+		UPDATE expense_reports
+		SET title = $1, purpose = $2, trip_start = $3, trip_end = $4
+		WHERE id = $5 AND status = $6
+	`)
+	report := &domain.ExpenseReport{ID: 7, Title: "Surabaya visit", TripStart: time.Now(), TripEnd: time.Now()}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectExec(query).
+			WithArgs(report.Title, report.Purpose, report.TripStart, report.TripEnd, report.ID, domain.ExpenseStatusDraft).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.Update(context.Background(), report))
+	})
+
+	t.Run("no longer a draft", func(t *testing.T) {
+		mock.ExpectExec(query).
+			WithArgs(report.Title, report.Purpose, report.TripStart, report.TripEnd, report.ID, domain.ExpenseStatusDraft).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		require.ErrorIs(t, repo.Update(context.Background(), report), domain.ErrStatusConflict)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepositoryUpdateStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &reportRepository{db: db}
+	reportQuery := regexp.QuoteMeta(`
+		UPDATE expense_reports
+		SET status = $1, total_idr = $2, requires_approval = $3, auto_approved = $4,
+			requires_finance_approval = $5, submitted_at = $6, processed_at = $7
+		WHERE id = $8 AND status = $9
+	`)
+	linesQuery := regexp.QuoteMeta(`
+		UPDATE expenses
+		SET status = $1, processed_at = $2
+		WHERE report_id = $3 AND status = $4
+		RETURNING id
+	`)
+	submittedAt := time.Now()
+	report := &domain.ExpenseReport{
+		ID:               7,
+		Status:           domain.ExpenseStatusAwaitingApproval,
+		TotalIDR:         1500000,
+		RequiresApproval: true,
+		SubmittedAt:      &submittedAt,
+	}
+	args := []driver.Value{report.Status, report.TotalIDR, report.RequiresApproval, report.AutoApproved, report.RequiresFinanceApproval, report.SubmittedAt, report.ProcessedAt, report.ID}
+
+	t.Run("moves the lines along", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(reportQuery).
+			WithArgs(append(args, domain.ExpenseStatusDraft)...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(linesQuery).
+			WithArgs(report.Status, report.ProcessedAt, report.ID, domain.ExpenseStatusDraft).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4).AddRow(5))
+		mock.ExpectCommit()
+
+		moved, err := repo.UpdateStatus(context.Background(), report, domain.ExpenseStatusDraft)
+		require.NoError(t, err)
+		require.Equal(t, []int{3, 4, 5}, moved)
+	})
+
+	t.Run("same status only saves the report", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(reportQuery).
+			WithArgs(append(args, domain.ExpenseStatusAwaitingApproval)...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		moved, err := repo.UpdateStatus(context.Background(), report, domain.ExpenseStatusAwaitingApproval)
+		require.NoError(t, err)
+		require.Empty(t, moved)
+	})
+
+	t.Run("illegal transition", func(t *testing.T) {
+		_, err := repo.UpdateStatus(context.Background(), report, domain.ExpenseStatusCompleted)
+		require.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("status changed concurrently", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(reportQuery).
+			WithArgs(append(args, domain.ExpenseStatusDraft)...).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := repo.UpdateStatus(context.Background(), report, domain.ExpenseStatusDraft)
+		require.ErrorIs(t, err, domain.ErrStatusConflict)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepositoryAddLine(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &reportRepository{db: db}
+	query := regexp.QuoteMeta(`
+		UPDATE expenses
+		SET report_id = $1
+		WHERE id = $2 AND user_id = $3 AND status = $4 AND report_id IS NULL
+			AND advance_id IS NULL
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectExec(query).WithArgs(7, 3, 1, domain.ExpenseStatusDraft).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.AddLine(context.Background(), 7, 3, 1))
+	})
+
+	t.Run("not attachable", func(t *testing.T) {
+		mock.ExpectExec(query).WithArgs(7, 4, 1, domain.ExpenseStatusDraft).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		require.ErrorIs(t, repo.AddLine(context.Background(), 7, 4, 1), domain.ErrLineNotAttachable)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepositoryRemoveLine(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &reportRepository{db: db}
+	query := regexp.QuoteMeta(`
+		UPDATE expenses
+		SET report_id = NULL
+		WHERE id = $1 AND report_id = $2
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectExec(query).WithArgs(3, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.RemoveLine(context.Background(), 7, 3))
+	})
+
+	t.Run("not a line of the report", func(t *testing.T) {
+		mock.ExpectExec(query).WithArgs(4, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		require.ErrorIs(t, repo.RemoveLine(context.Background(), 7, 4), domain.ErrExpenseNotFound)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepositoryCreateApproval(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &reportRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO expense_report_approvals (report_id, approver_id, on_behalf_of_id, level, status, notes)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`)
+	approval := &domain.ReportApproval{
+		ReportID:   7,
+		ApproverID: 2,
+		Level:      domain.ApprovalLevelManager,
+		Status:     domain.ApprovalStatusApproved,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(approval.ReportID, approval.ApproverID, approval.OnBehalfOfID, approval.Level, approval.Status, approval.Notes).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, time.Now()))
+
+		require.NoError(t, repo.CreateApproval(context.Background(), approval))
+		require.Equal(t, 5, approval.ID)
+	})
+
+	t.Run("level already signed", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(approval.ReportID, approval.ApproverID, approval.OnBehalfOfID, approval.Level, approval.Status, approval.Notes).
+			WillReturnError(&pq.Error{Code: "23505"})
+
+		require.ErrorIs(t, repo.CreateApproval(context.Background(), approval), domain.ErrApprovalConflict)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepositoryFindApprovals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &reportRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id, report_id, approver_id, on_behalf_of_id, level, status, notes, created_at
+		FROM expense_report_approvals
+		WHERE report_id = $1
+		ORDER BY created_at ASC, id ASC
+	`)
+	rows := sqlmock.NewRows([]string{"id", "report_id", "approver_id", "on_behalf_of_id", "level", "status", "notes", "created_at"}).
+		AddRow(5, 7, 2, nil, domain.ApprovalLevelManager, domain.ApprovalStatusApproved, "", time.Now()).
+		AddRow(6, 7, 9, 4, domain.ApprovalLevelFinanceDirector, domain.ApprovalStatusApproved, "ok", time.Now())
+	mock.ExpectQuery(query).WithArgs(7).WillReturnRows(rows)
+
+	approvals, err := repo.FindApprovals(context.Background(), 7)
+	require.NoError(t, err)
+	require.Len(t, approvals, 2)
+	require.Nil(t, approvals[0].OnBehalfOfID)
+	require.Equal(t, 4, *approvals[1].OnBehalfOfID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
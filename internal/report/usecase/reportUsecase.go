@@ -0,0 +1,685 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/allocation"
+	"github.com/evrintobing17/expense-management-backend/internal/approval"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/escalation"
+	"github.com/evrintobing17/expense-management-backend/internal/expense"
+	"github.com/evrintobing17/expense-management-backend/internal/history"
+	"github.com/evrintobing17/expense-management-backend/internal/report"
+	"github.com/evrintobing17/expense-management-backend/internal/user"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+)
+
+type reportUseCase struct {
+	reportRepo     report.ReportRepository
+	expenseRepo    expense.ExpenseRepository
+	historyRepo    history.HistoryRepository
+	userRepo       user.UserRepository
+	scope          approval.ApproverScope
+	segregation    approval.SegregationPolicy
+	expenseUseCase expense.ExpenseUseCase
+	approvalPolicy domain.ApprovalPolicy
+	allocationRepo allocation.AllocationRepository
+	escalationRepo escalation.EscalationRepository
+	transactor     database.Transactor
+}
+
+func NewReportUseCase(
+	reportRepo report.ReportRepository,
+	expenseRepo expense.ExpenseRepository,
+	historyRepo history.HistoryRepository,
+	userRepo user.UserRepository,
+	scope approval.ApproverScope,
+	segregation approval.SegregationPolicy,
+	expenseUseCase expense.ExpenseUseCase,
+	approvalPolicy domain.ApprovalPolicy,
+	allocationRepo allocation.AllocationRepository,
+	escalationRepo escalation.EscalationRepository,
+	transactor database.Transactor,
+) report.ReportUseCase {
+	return &reportUseCase{
+		reportRepo:     reportRepo,
+		expenseRepo:    expenseRepo,
+		historyRepo:    historyRepo,
+		userRepo:       userRepo,
+		scope:          scope,
+		segregation:    segregation,
+		expenseUseCase: expenseUseCase,
+		approvalPolicy: approvalPolicy,
+		allocationRepo: allocationRepo,
+		escalationRepo: escalationRepo,
+		transactor:     transactor,
+	}
+}
+
+// CreateReport stores a new, empty draft report.
+func (uc *reportUseCase) CreateReport(ctx context.Context, report *domain.ExpenseReport) (*domain.ExpenseReport, error) {
+	if err := report.Validate(); err != nil {
+		return nil, err
+	}
+
+	report.Status = domain.ExpenseStatusDraft
+	if err := uc.reportRepo.Create(ctx, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// UpdateReport changes the details of one of the user's draft reports.
+func (uc *reportUseCase) UpdateReport(ctx context.Context, changes *domain.ExpenseReport) (*domain.ExpenseReport, error) {
+	report, err := uc.ownReport(ctx, changes.ID, changes.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !report.IsEditable() {
+		return nil, domain.ErrReportNotEditable
+	}
+
+	report.Title = changes.Title
+	report.Purpose = changes.Purpose
+	report.TripStart = changes.TripStart
+	report.TripEnd = changes.TripEnd
+	if err := report.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.reportRepo.Update(ctx, report); err != nil {
+		return nil, err
+	}
+
+	return uc.withDetails(ctx, report)
+}
+
+// GetReport returns a report with its lines and approval chain to its submitter, finance
+// or a manager whose reporting line includes the submitter.
+func (uc *reportUseCase) GetReport(ctx context.Context, id int, userID int, role domain.Role) (*domain.ExpenseReport, error) {
+	report, err := uc.findReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.UserID != userID && !role.IsFinance() {
+		allowed, err := uc.inReportingLine(ctx, userID, report.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowed {
+			return nil, domain.ErrUnauthorizedAction
+		}
+	}
+
+	return uc.withDetails(ctx, report)
+}
+
+// inReportingLine reports whether the submitter is within the approver's manager scope.
+func (uc *reportUseCase) inReportingLine(ctx context.Context, approverID int, submitterID int) (bool, error) {
+	approver, err := uc.userRepo.FindByID(ctx, approverID)
+	if err != nil || approver == nil {
+		return false, err
+	}
+
+	scope, err := uc.scope.ManagerScope(ctx, approver)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := scope[submitterID]
+	return ok, nil
+}
+
+func (uc *reportUseCase) GetUserReports(ctx context.Context, userID int) ([]*domain.ExpenseReport, error) {
+	return uc.reportRepo.FindByUserID(ctx, userID)
+}
+
+// AddLine attaches one of the submitter's draft expenses to their draft report.
+func (uc *reportUseCase) AddLine(ctx context.Context, reportID, expenseID, userID int) (*domain.ExpenseReport, error) {
+	report, err := uc.ownReport(ctx, reportID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !report.IsEditable() {
+		return nil, domain.ErrReportNotEditable
+	}
+
+	if err := uc.reportRepo.AddLine(ctx, report.ID, expenseID, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.withDetails(ctx, report)
+}
+
+// RemoveLine detaches a line, which stays a standalone draft.
+func (uc *reportUseCase) RemoveLine(ctx context.Context, reportID, expenseID, userID int) (*domain.ExpenseReport, error) {
+	report, err := uc.ownReport(ctx, reportID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !report.IsEditable() {
+		return nil, domain.ErrReportNotEditable
+	}
+
+	if err := uc.reportRepo.RemoveLine(ctx, report.ID, expenseID); err != nil {
+		return nil, err
+	}
+
+	return uc.withDetails(ctx, report)
+}
+
+// SubmitReport checks every line and routes the report on its total.
+func (uc *reportUseCase) SubmitReport(ctx context.Context, id int, userID int) (*domain.ExpenseReport, error) {
+	report, err := uc.ownReport(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !report.IsEditable() {
+		return nil, domain.ErrInvalidExpenseStatus
+	}
+
+	report.Lines, err = uc.expenseRepo.FindByReportID(ctx, report.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(report.Lines) == 0 {
+		return nil, domain.ErrEmptyReport
+	}
+
+	requireApproval := false
+	report.RequiresFinanceApproval = false
+	for _, line := range report.Lines {
+		required, err := uc.expenseUseCase.CheckReportLine(ctx, line)
+		if err != nil {
+			return nil, err
+		}
+		requireApproval = requireApproval || required
+		report.RequiresFinanceApproval = report.RequiresFinanceApproval || line.RequiresFinanceApproval
+	}
+
+	now := time.Now()
+	report.UpdateTotal()
+	report.Route(uc.approvalPolicy, requireApproval)
+	report.SubmittedAt = &now
+
+	err = uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		moved, err := uc.reportRepo.UpdateStatus(ctx, report, domain.ExpenseStatusDraft)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range report.Lines {
+			if err := uc.expenseUseCase.RecordReportLine(ctx, line); err != nil {
+				return err
+			}
+		}
+
+		return uc.recordLinesHistory(ctx, report, domain.ExpenseStatusDraft, moved, userID, "submitted with expense report")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// CancelReport withdraws a report and its lines on behalf of the submitter.
+func (uc *reportUseCase) CancelReport(ctx context.Context, id int, userID int) (*domain.ExpenseReport, error) {
+	report, err := uc.ownReport(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := report.Status
+	if err := domain.ValidateTransition(from, domain.ExpenseStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	report.Lines, err = uc.expenseRepo.FindByReportID(ctx, report.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	report.Status = domain.ExpenseStatusCancelled
+	report.ProcessedAt = &now
+
+	err = uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		moved, err := uc.reportRepo.UpdateStatus(ctx, report, from)
+		if err != nil {
+			return err
+		}
+
+		return uc.recordLinesHistory(ctx, report, from, moved, userID, "expense report cancelled by submitter")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// GetPendingApproval returns the reports whose next outstanding level the approver may
+// sign, with their lines.
+func (uc *reportUseCase) GetPendingApproval(ctx context.Context, approverID int) ([]*domain.ExpenseReport, error) {
+	approver, err := uc.userRepo.FindByID(ctx, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	if approver == nil {
+		return nil, domain.ErrUnauthorizedAction
+	}
+
+	scope, err := uc.scope.ManagerScope(ctx, approver)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := uc.reportRepo.FindByStatus(ctx, domain.ExpenseStatusAwaitingApproval)
+	if err != nil {
+		return nil, err
+	}
+
+	// The SLA job escalates a report by escalating its awaiting lines.
+	escalations, err := uc.escalationRepo.FindPendingByEscalatedToID(ctx, approver.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	escalated := make(map[int]map[domain.ApprovalLevel]bool)
+	for _, e := range escalations {
+		if escalated[e.ExpenseID] == nil {
+			escalated[e.ExpenseID] = make(map[domain.ApprovalLevel]bool)
+		}
+		escalated[e.ExpenseID][e.Level] = true
+	}
+
+	var pending []*domain.ExpenseReport
+	for _, report := range reports {
+		// Segregation of duties: approvers never sign their own reports.
+		if report.UserID == approver.ID {
+			continue
+		}
+
+		report.Approvals, err = uc.reportRepo.FindApprovals(ctx, report.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		level, ok := report.NextApprovalLevel(uc.approvalPolicy)
+		if !ok {
+			continue
+		}
+
+		allowed := level.Role() == approver.Role
+		if level == domain.ApprovalLevelManager {
+			_, allowed = scope[report.UserID]
+		}
+
+		if !allowed && len(escalated) == 0 {
+			continue
+		}
+
+		if err := uc.loadLines(ctx, report); err != nil {
+			return nil, err
+		}
+
+		if !allowed && !escalatedReport(report, level, escalated) {
+			continue
+		}
+
+		if err := uc.segregation.CheckReportApprover(ctx, report, level, approver.ID); err != nil {
+			if errors.Is(err, domain.ErrUnauthorizedAction) {
+				continue
+			}
+			return nil, err
+		}
+
+		pending = append(pending, report)
+	}
+
+	return pending, nil
+}
+
+// ApproveReport signs the report's next outstanding level.
+func (uc *reportUseCase) ApproveReport(ctx context.Context, id int, approverID int, notes string) error {
+	report, approval, err := uc.nextApproval(ctx, id, approverID, domain.ApprovalStatusApproved, notes)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.segregation.CheckReportApprover(ctx, report, approval.Level, approverID); err != nil {
+		return err
+	}
+
+	if report.Lines, err = uc.expenseRepo.FindByReportID(ctx, report.ID); err != nil {
+		return err
+	}
+
+	// Intermediate sign-offs only extend the chain.
+	report.Approvals = append(report.Approvals, approval)
+	if _, outstanding := report.NextApprovalLevel(uc.approvalPolicy); outstanding {
+		return uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+			if err := uc.reportRepo.CreateApproval(ctx, approval); err != nil {
+				return err
+			}
+
+			for _, line := range report.Lines {
+				if line.Status != report.Status {
+					continue
+				}
+				if err := uc.historyRepo.Create(ctx, domain.LevelApproved(line.ID, line.Status, approval.Level, approverID, notes)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	return uc.decide(ctx, report, approval, domain.ExpenseStatusApproved, approverID, notes)
+}
+
+// RejectReport rejects the report as a whole together with its remaining lines.
+func (uc *reportUseCase) RejectReport(ctx context.Context, id int, approverID int, notes string) error {
+	report, approval, err := uc.nextApproval(ctx, id, approverID, domain.ApprovalStatusRejected, notes)
+	if err != nil {
+		return err
+	}
+
+	if report.Lines, err = uc.expenseRepo.FindByReportID(ctx, report.ID); err != nil {
+		return err
+	}
+
+	return uc.decide(ctx, report, approval, domain.ExpenseStatusRejected, approverID, notes)
+}
+
+// RejectLine rejects a single line and takes it out of the report total, which may
+// decide the report.
+func (uc *reportUseCase) RejectLine(ctx context.Context, reportID, expenseID, approverID int, notes string) (*domain.ExpenseReport, error) {
+	report, approval, err := uc.nextApproval(ctx, reportID, approverID, domain.ApprovalStatusRejected, notes)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Lines, err = uc.expenseRepo.FindByReportID(ctx, report.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	line, ok := report.Line(expenseID)
+	if !ok {
+		return nil, domain.ErrExpenseNotFound
+	}
+
+	if err := domain.ValidateTransition(line.Status, domain.ExpenseStatusRejected); err != nil {
+		return nil, err
+	}
+
+	err = uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		if err := uc.expenseRepo.UpdateStatus(ctx, line.ID, line.Status, domain.ExpenseStatusRejected, &now); err != nil {
+			return err
+		}
+
+		if err := uc.historyRepo.Create(ctx, domain.UserStatusChange(line.ID, line.Status, domain.ExpenseStatusRejected, approverID, notes)); err != nil {
+			return err
+		}
+
+		line.Status = domain.ExpenseStatusRejected
+		line.ProcessedAt = &now
+		report.UpdateTotal()
+
+		if len(report.ClaimedLines()) == 0 {
+			return uc.decide(ctx, report, approval, domain.ExpenseStatusRejected, approverID, "all lines rejected")
+		}
+
+		if _, outstanding := report.NextApprovalLevel(uc.approvalPolicy); !outstanding {
+			return uc.decide(ctx, report, nil, domain.ExpenseStatusApproved, approverID, "approved after line rejection")
+		}
+
+		_, err := uc.reportRepo.UpdateStatus(ctx, report, report.Status)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// nextApproval prepares the approver's entry for the report's next outstanding level.
+func (uc *reportUseCase) nextApproval(
+	ctx context.Context,
+	id int,
+	approverID int,
+	status domain.ApprovalStatus,
+	notes string,
+) (*domain.ExpenseReport, *domain.ReportApproval, error) {
+	report, err := uc.findReport(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if report.Status != domain.ExpenseStatusAwaitingApproval {
+		return nil, nil, domain.ErrInvalidExpenseStatus
+	}
+
+	approver, err := uc.userRepo.FindByID(ctx, approverID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if approver == nil || approver.ID == report.UserID {
+		return nil, nil, domain.ErrUnauthorizedAction
+	}
+
+	report.Approvals, err = uc.reportRepo.FindApprovals(ctx, report.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	level, ok := report.NextApprovalLevel(uc.approvalPolicy)
+	if !ok {
+		return nil, nil, domain.ErrInvalidExpenseStatus
+	}
+
+	approval := &domain.ReportApproval{
+		ReportID:   report.ID,
+		ApproverID: approverID,
+		Level:      level,
+		Status:     status,
+		Notes:      notes,
+	}
+
+	allowed := approver.Role == level.Role()
+	if level == domain.ApprovalLevelManager {
+		scope, err := uc.scope.ManagerScope(ctx, approver)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		approval.OnBehalfOfID, allowed = scope[report.UserID]
+	}
+
+	if !allowed {
+		allowed, err = uc.escalatedTo(ctx, report, level, approverID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if !allowed {
+		return nil, nil, domain.ErrUnauthorizedAction
+	}
+
+	return report, approval, nil
+}
+
+// escalatedTo reports whether the SLA job escalated level of the report to approverID.
+func (uc *reportUseCase) escalatedTo(ctx context.Context, report *domain.ExpenseReport, level domain.ApprovalLevel, approverID int) (bool, error) {
+	lines, err := uc.expenseRepo.FindByReportID(ctx, report.ID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, line := range lines {
+		if line.Status != domain.ExpenseStatusAwaitingApproval {
+			continue
+		}
+
+		escalations, err := uc.escalationRepo.FindByExpenseID(ctx, line.ID)
+		if err != nil {
+			return false, err
+		}
+
+		for _, e := range escalations {
+			if e.Level == level && e.EscalatedToID == approverID {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// escalatedReport reports whether one of the report's loaded lines carries an
+// escalation of level.
+func escalatedReport(report *domain.ExpenseReport, level domain.ApprovalLevel, escalated map[int]map[domain.ApprovalLevel]bool) bool {
+	for _, line := range report.Lines {
+		if escalated[line.ID][level] {
+			return true
+		}
+	}
+	return false
+}
+
+// decide moves a report awaiting approval, and its lines, to its final decision. A nil
+// approval records no sign-off, e.g. when rejecting a line leaves no level outstanding.
+func (uc *reportUseCase) decide(
+	ctx context.Context,
+	report *domain.ExpenseReport,
+	approval *domain.ReportApproval,
+	to domain.ExpenseStatus,
+	actorID int,
+	reason string,
+) error {
+	from := report.Status
+	now := time.Now()
+	report.Status = to
+	report.ProcessedAt = &now
+
+	return uc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if approval != nil {
+			if err := uc.reportRepo.CreateApproval(ctx, approval); err != nil {
+				return err
+			}
+		}
+
+		moved, err := uc.reportRepo.UpdateStatus(ctx, report, from)
+		if err != nil {
+			return err
+		}
+
+		return uc.recordLinesHistory(ctx, report, from, moved, actorID, reason)
+	})
+}
+
+// findReport loads the report with the given id.
+func (uc *reportUseCase) findReport(ctx context.Context, id int) (*domain.ExpenseReport, error) {
+	report, err := uc.reportRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if report == nil {
+		return nil, domain.ErrReportNotFound
+	}
+
+	return report, nil
+}
+
+// ownReport loads the report and checks that userID submitted it.
+func (uc *reportUseCase) ownReport(ctx context.Context, id int, userID int) (*domain.ExpenseReport, error) {
+	report, err := uc.findReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.UserID != userID {
+		return nil, domain.ErrUnauthorizedAction
+	}
+
+	return report, nil
+}
+
+// withDetails attaches the report's lines and approval chain.
+func (uc *reportUseCase) withDetails(ctx context.Context, report *domain.ExpenseReport) (*domain.ExpenseReport, error) {
+	if err := uc.loadLines(ctx, report); err != nil {
+		return nil, err
+	}
+
+	var err error
+	report.Approvals, err = uc.reportRepo.FindApprovals(ctx, report.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// loadLines attaches the report's lines and their allocation totals.
+func (uc *reportUseCase) loadLines(ctx context.Context, report *domain.ExpenseReport) error {
+	var err error
+	report.Lines, err = uc.expenseRepo.FindByReportID(ctx, report.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(report.Lines) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(report.Lines))
+	for i, line := range report.Lines {
+		ids[i] = line.ID
+	}
+
+	allocations, err := uc.allocationRepo.FindByExpenseIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range report.Lines {
+		line.Allocations = allocations[line.ID]
+	}
+	report.AllocationTotals = domain.SumAllocations(report.ClaimedLines())
+
+	return nil
+}
+
+// recordLinesHistory records the report's transition on every line that moved with it.
+func (uc *reportUseCase) recordLinesHistory(ctx context.Context, report *domain.ExpenseReport, from domain.ExpenseStatus, moved []int, actorID int, reason string) error {
+	for _, id := range moved {
+		if err := uc.historyRepo.Create(ctx, domain.UserStatusChange(id, from, report.Status, actorID, reason)); err != nil {
+			return err
+		}
+
+		if line, ok := report.Line(id); ok {
+			line.Status = report.Status
+			line.ProcessedAt = report.ProcessedAt
+		}
+	}
+
+	return nil
+}
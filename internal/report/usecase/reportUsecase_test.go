@@ -0,0 +1,602 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/approval/scope"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/report"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type useCaseMocks struct {
+	report      *mocks.ReportRepository
+	expense     *mocks.ExpenseRepository
+	history     *mocks.HistoryRepository
+	user        *mocks.UserRepository
+	delegation  *mocks.DelegationRepository
+	segregation *mocks.SegregationPolicy
+	expenses    *mocks.ExpenseUseCase
+	allocation  *mocks.AllocationRepository
+	escalation  *mocks.EscalationRepository
+	tx          *mocks.Transactor
+}
+
+func newUseCaseMocks() *useCaseMocks {
+	m := &useCaseMocks{
+		report:      new(mocks.ReportRepository),
+		expense:     new(mocks.ExpenseRepository),
+		history:     new(mocks.HistoryRepository),
+		user:        new(mocks.UserRepository),
+		delegation:  new(mocks.DelegationRepository),
+		segregation: new(mocks.SegregationPolicy),
+		expenses:    new(mocks.ExpenseUseCase),
+		allocation:  new(mocks.AllocationRepository),
+		escalation:  new(mocks.EscalationRepository),
+		tx:          new(mocks.Transactor),
+	}
+	m.tx.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}).Maybe()
+	m.history.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.segregation.On("CheckReportApprover", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.delegation.On("FindActiveByDelegateID", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Delegation(nil), nil).Maybe()
+	m.report.On("FindApprovals", mock.Anything, mock.Anything).Return([]*domain.ReportApproval(nil), nil).Maybe()
+	m.allocation.On("FindByExpenseIDs", mock.Anything, mock.Anything).Return(map[int][]*domain.Allocation{}, nil).Maybe()
+	m.escalation.On("FindPendingByEscalatedToID", mock.Anything, mock.Anything).Return([]*domain.Escalation(nil), nil).Maybe()
+	m.escalation.On("FindByExpenseID", mock.Anything, mock.Anything).Return([]*domain.Escalation(nil), nil).Maybe()
+	m.expenses.On("RecordReportLine", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *useCaseMocks) useCase() report.ReportUseCase {
+	return NewReportUseCase(m.report, m.expense, m.history, m.user, scope.NewApproverScope(m.user, m.delegation, false), m.segregation, m.expenses, domain.ApprovalPolicy{Tiers: domain.DefaultApprovalTiers}, m.allocation, m.escalation, m.tx)
+}
+
+func (m *useCaseMocks) expectReport(report *domain.ExpenseReport) {
+	m.report.On("FindByID", mock.Anything, report.ID).Return(report, nil)
+}
+
+func (m *useCaseMocks) expectLines(reportID int, lines ...*domain.Expense) {
+	m.expense.On("FindByReportID", mock.Anything, reportID).Return(lines, nil)
+}
+
+func (m *useCaseMocks) expectApprover(id int, role domain.Role) {
+	m.user.On("FindByID", mock.Anything, id).Return(&domain.User{ID: id, Role: role}, nil)
+}
+
+func (m *useCaseMocks) expectReports(managerID int, reportIDs ...int) {
+	m.user.On("FindReportIDs", mock.Anything, managerID, false).Return(reportIDs, nil)
+}
+
+func line(id int, amount int, status domain.ExpenseStatus) *domain.Expense {
+	reportID := 10
+	return &domain.Expense{ID: id, UserID: 1, ReportID: &reportID, AmountIDR: amount, Status: status}
+}
+
+func TestCreateReport(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	t.Run("stored as draft", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.report.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		report, err := m.useCase().CreateReport(context.Background(), &domain.ExpenseReport{
+			UserID:    1,
+			Title:     "Surabaya visit",
+			TripStart: start,
+			TripEnd:   start.AddDate(0, 0, 4),
+		})
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusDraft, report.Status)
+		require.Equal(t, domain.DateOf(start), report.TripStart)
+	})
+
+	t.Run("trip ends before it starts", func(t *testing.T) {
+		m := newUseCaseMocks()
+
+		_, err := m.useCase().CreateReport(context.Background(), &domain.ExpenseReport{
+			UserID:    1,
+			Title:     "Surabaya visit",
+			TripStart: start,
+			TripEnd:   start.AddDate(0, 0, -1),
+		})
+		require.ErrorIs(t, err, domain.ErrInvalidReport)
+		m.report.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAddLine(t *testing.T) {
+	t.Run("draft report", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusDraft})
+		m.report.On("AddLine", mock.Anything, 10, 3, 1).Return(nil)
+		m.expectLines(10, line(3, 400000, domain.ExpenseStatusDraft))
+
+		report, err := m.useCase().AddLine(context.Background(), 10, 3, 1)
+		require.NoError(t, err)
+		require.Len(t, report.Lines, 1)
+	})
+
+	t.Run("submitted report", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval})
+
+		_, err := m.useCase().AddLine(context.Background(), 10, 3, 1)
+		require.ErrorIs(t, err, domain.ErrReportNotEditable)
+	})
+
+	t.Run("someone else's report", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 2, Status: domain.ExpenseStatusDraft})
+
+		_, err := m.useCase().AddLine(context.Background(), 10, 3, 1)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+	})
+}
+
+func TestGetReportAllocationTotals(t *testing.T) {
+	m := newUseCaseMocks()
+	m.allocation = new(mocks.AllocationRepository)
+	m.allocation.On("FindByExpenseIDs", mock.Anything, []int{3, 4, 5}).Return(map[int][]*domain.Allocation{
+		3: {{ExpenseID: 3, CostCenterCode: "FIN", AmountIDR: 100000}, {ExpenseID: 3, CostCenterCode: "MKT", ProjectCode: "PRJ-01", AmountIDR: 300000}},
+		5: {{ExpenseID: 5, CostCenterCode: "MKT", ProjectCode: "PRJ-01", AmountIDR: 50000}},
+	}, nil).Once()
+	m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval})
+	m.expectLines(10,
+		line(3, 400000, domain.ExpenseStatusAwaitingApproval),
+		line(4, 200000, domain.ExpenseStatusAwaitingApproval),
+		line(5, 50000, domain.ExpenseStatusRejected),
+	)
+
+	report, err := m.useCase().GetReport(context.Background(), 10, 1, domain.RoleEmployee)
+	require.NoError(t, err)
+	require.Len(t, report.Lines[0].Allocations, 2)
+	require.Equal(t, []*domain.AllocationTotal{
+		{ExpenseCount: 1, AmountIDR: 200000},
+		{CostCenterCode: "FIN", ExpenseCount: 1, AmountIDR: 100000},
+		{CostCenterCode: "MKT", ProjectCode: "PRJ-01", ExpenseCount: 1, AmountIDR: 300000},
+	}, report.AllocationTotals)
+}
+
+func TestGetReport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("manager of the submitter", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval})
+		m.expectLines(10, line(3, 400000, domain.ExpenseStatusAwaitingApproval))
+		m.expectApprover(2, domain.RoleManager)
+		m.expectReports(2, 1)
+
+		report, err := m.useCase().GetReport(ctx, 10, 2, domain.RoleManager)
+		require.NoError(t, err)
+		require.Len(t, report.Lines, 1)
+	})
+
+	t.Run("manager outside the reporting line", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval})
+		m.expectApprover(2, domain.RoleManager)
+		m.expectReports(2, 7)
+
+		_, err := m.useCase().GetReport(ctx, 10, 2, domain.RoleManager)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		m.expense.AssertNotCalled(t, "FindByReportID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("finance", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval})
+		m.expectLines(10, line(3, 400000, domain.ExpenseStatusAwaitingApproval))
+
+		_, err := m.useCase().GetReport(ctx, 10, 4, domain.RoleFinanceDirector)
+		require.NoError(t, err)
+		m.user.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("another employee", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval})
+		m.expectApprover(5, domain.RoleEmployee)
+
+		_, err := m.useCase().GetReport(ctx, 10, 5, domain.RoleEmployee)
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+	})
+}
+
+func TestSubmitReport(t *testing.T) {
+	t.Run("total above threshold needs approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusDraft})
+		m.expectLines(10, line(3, 600000, domain.ExpenseStatusDraft), line(4, 500000, domain.ExpenseStatusDraft))
+		m.expenses.On("CheckReportLine", mock.Anything, mock.Anything).Return(false, nil)
+		m.report.On("UpdateStatus", mock.Anything, mock.Anything, domain.ExpenseStatusDraft).Return([]int{3, 4}, nil)
+
+		report, err := m.useCase().SubmitReport(context.Background(), 10, 1)
+		require.NoError(t, err)
+		require.Equal(t, 1100000, report.TotalIDR)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, report.Status)
+		require.NotNil(t, report.SubmittedAt)
+		for _, l := range report.Lines {
+			require.Equal(t, domain.ExpenseStatusAwaitingApproval, l.Status)
+		}
+		m.history.AssertNumberOfCalls(t, "Create", 2)
+	})
+
+	t.Run("history only records the lines that moved", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusDraft})
+		m.expectLines(10, line(3, 600000, domain.ExpenseStatusDraft), line(4, 500000, domain.ExpenseStatusDraft))
+		m.expenses.On("CheckReportLine", mock.Anything, mock.Anything).Return(false, nil)
+		// Line 4 was detached after the lines were loaded.
+		m.report.On("UpdateStatus", mock.Anything, mock.Anything, domain.ExpenseStatusDraft).Return([]int{3}, nil)
+
+		report, err := m.useCase().SubmitReport(context.Background(), 10, 1)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, report.Lines[0].Status)
+		require.Equal(t, domain.ExpenseStatusDraft, report.Lines[1].Status)
+		m.history.AssertNumberOfCalls(t, "Create", 1)
+		m.history.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.ExpenseID == 3 && *h.FromStatus == domain.ExpenseStatusDraft && h.ToStatus == domain.ExpenseStatusAwaitingApproval
+		}))
+	})
+
+	t.Run("small total is auto-approved", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusDraft})
+		m.expectLines(10, line(3, 200000, domain.ExpenseStatusDraft))
+		m.expenses.On("CheckReportLine", mock.Anything, mock.Anything).Return(false, nil)
+		m.report.On("UpdateStatus", mock.Anything, mock.Anything, domain.ExpenseStatusDraft).Return([]int{3}, nil)
+
+		report, err := m.useCase().SubmitReport(context.Background(), 10, 1)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAutoApproved, report.Status)
+		require.True(t, report.AutoApproved)
+	})
+
+	t.Run("policy rule on a line forces approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusDraft})
+		m.expectLines(10, line(3, 200000, domain.ExpenseStatusDraft))
+		m.expenses.On("CheckReportLine", mock.Anything, mock.Anything).Return(true, nil)
+		m.report.On("UpdateStatus", mock.Anything, mock.Anything, domain.ExpenseStatusDraft).Return([]int{3}, nil)
+
+		report, err := m.useCase().SubmitReport(context.Background(), 10, 1)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, report.Status)
+	})
+
+	t.Run("over-budget line sends the report to the finance director", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusDraft})
+		m.expectLines(10, line(3, 200000, domain.ExpenseStatusDraft), line(4, 100000, domain.ExpenseStatusDraft))
+		m.expenses.On("CheckReportLine", mock.Anything, mock.MatchedBy(func(e *domain.Expense) bool { return e.ID == 3 })).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(*domain.Expense).RequiresFinanceApproval = true
+			}).Return(true, nil)
+		m.expenses.On("CheckReportLine", mock.Anything, mock.Anything).Return(false, nil)
+		m.report.On("UpdateStatus", mock.Anything, mock.Anything, domain.ExpenseStatusDraft).Return([]int{3, 4}, nil)
+
+		report, err := m.useCase().SubmitReport(context.Background(), 10, 1)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, report.Status)
+		require.True(t, report.RequiresFinanceApproval)
+		level, ok := report.NextApprovalLevel(domain.ApprovalPolicy{Tiers: domain.DefaultApprovalTiers})
+		require.True(t, ok)
+		require.Equal(t, domain.ApprovalLevelManager, level)
+		require.Equal(t, []domain.ApprovalLevel{domain.ApprovalLevelManager, domain.ApprovalLevelFinanceDirector},
+			domain.ApprovalPolicy{Tiers: domain.DefaultApprovalTiers}.ReportLevels(report))
+		m.expenses.AssertNumberOfCalls(t, "RecordReportLine", 2)
+	})
+
+	t.Run("line flags fail to save", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expenses = new(mocks.ExpenseUseCase)
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusDraft})
+		m.expectLines(10, line(3, 200000, domain.ExpenseStatusDraft))
+		m.expenses.On("CheckReportLine", mock.Anything, mock.Anything).Return(false, nil)
+		m.expenses.On("RecordReportLine", mock.Anything, mock.Anything).Return(errors.New("db down"))
+		m.report.On("UpdateStatus", mock.Anything, mock.Anything, domain.ExpenseStatusDraft).Return([]int{3}, nil)
+
+		_, err := m.useCase().SubmitReport(context.Background(), 10, 1)
+		require.EqualError(t, err, "db down")
+		m.history.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid line blocks submission", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusDraft})
+		m.expectLines(10, line(3, 200000, domain.ExpenseStatusDraft))
+		m.expenses.On("CheckReportLine", mock.Anything, mock.Anything).Return(false, domain.ErrMissingReceipt)
+
+		_, err := m.useCase().SubmitReport(context.Background(), 10, 1)
+		require.ErrorIs(t, err, domain.ErrMissingReceipt)
+		m.report.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("empty report", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusDraft})
+		m.expectLines(10)
+
+		_, err := m.useCase().SubmitReport(context.Background(), 10, 1)
+		require.ErrorIs(t, err, domain.ErrEmptyReport)
+	})
+}
+
+func TestApproveReport(t *testing.T) {
+	awaiting := func(total int) *domain.ExpenseReport {
+		return &domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval, TotalIDR: total}
+	}
+
+	t.Run("last level approves report and lines", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(awaiting(1500000))
+		m.expectApprover(2, domain.RoleManager)
+		m.expectReports(2, 1)
+		m.expectLines(10, line(3, 1500000, domain.ExpenseStatusAwaitingApproval))
+		m.report.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(r *domain.ExpenseReport) bool {
+			return r.Status == domain.ExpenseStatusApproved
+		}), domain.ExpenseStatusAwaitingApproval).Return([]int{3}, nil)
+		m.report.On("CreateApproval", mock.Anything, mock.MatchedBy(func(a *domain.ReportApproval) bool {
+			return a.Level == domain.ApprovalLevelManager && a.Status == domain.ApprovalStatusApproved
+		})).Return(nil)
+
+		require.NoError(t, m.useCase().ApproveReport(context.Background(), 10, 2, "ok"))
+		m.history.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("outstanding level only extends the chain", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(awaiting(12000000))
+		m.expectApprover(2, domain.RoleManager)
+		m.expectReports(2, 1)
+		m.expectLines(10,
+			line(3, 11000000, domain.ExpenseStatusAwaitingApproval),
+			line(4, 1000000, domain.ExpenseStatusRejected),
+		)
+		m.report.On("CreateApproval", mock.Anything, mock.Anything).Return(nil)
+
+		require.NoError(t, m.useCase().ApproveReport(context.Background(), 10, 2, "ok"))
+		m.report.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+		m.history.AssertNumberOfCalls(t, "Create", 1)
+		m.history.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(h *domain.StatusHistory) bool {
+			return h.ExpenseID == 3 && h.Event == domain.HistoryEventLevelApproved
+		}))
+	})
+
+	t.Run("segregation of duties violation", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.segregation = new(mocks.SegregationPolicy)
+		m.segregation.On("CheckReportApprover", mock.Anything, mock.Anything, domain.ApprovalLevelManager, 2).
+			Return(&domain.PolicyViolationError{Reason: "too many approvals in a row"}).Once()
+		m.expectReport(awaiting(1500000))
+		m.expectApprover(2, domain.RoleManager)
+		m.expectReports(2, 1)
+
+		err := m.useCase().ApproveReport(context.Background(), 10, 2, "ok")
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+		m.report.AssertNotCalled(t, "CreateApproval", mock.Anything, mock.Anything)
+	})
+
+	t.Run("delegate signs on behalf of manager", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.delegation = new(mocks.DelegationRepository)
+		m.delegation.On("FindActiveByDelegateID", mock.Anything, 5, mock.Anything).
+			Return([]*domain.Delegation{{ManagerID: 2, DelegateID: 5}}, nil)
+		m.expectReport(awaiting(12000000))
+		m.expectApprover(5, domain.RoleEmployee)
+		m.expectReports(2, 1)
+		m.report.On("CreateApproval", mock.Anything, mock.MatchedBy(func(a *domain.ReportApproval) bool {
+			return a.OnBehalfOfID != nil && *a.OnBehalfOfID == 2
+		})).Return(nil)
+		m.expectLines(10, line(3, 12000000, domain.ExpenseStatusAwaitingApproval))
+
+		require.NoError(t, m.useCase().ApproveReport(context.Background(), 10, 5, ""))
+	})
+
+	t.Run("manager outside the team", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(awaiting(1500000))
+		m.expectApprover(2, domain.RoleManager)
+		m.expectReports(2, 7)
+		m.expectLines(10, line(3, 1500000, domain.ExpenseStatusAwaitingApproval))
+
+		err := m.useCase().ApproveReport(context.Background(), 10, 2, "")
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+	})
+
+	t.Run("level escalated to the approver", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.escalation = new(mocks.EscalationRepository)
+		m.escalation.On("FindByExpenseID", mock.Anything, 3).Return([]*domain.Escalation{
+			{ExpenseID: 3, Level: domain.ApprovalLevelManager, EscalatedToID: 8},
+		}, nil).Once()
+		m.expectReport(awaiting(1500000))
+		m.expectApprover(8, domain.RoleManager)
+		m.expectReports(8)
+		m.expectLines(10, line(3, 1500000, domain.ExpenseStatusAwaitingApproval))
+		m.report.On("CreateApproval", mock.Anything, mock.MatchedBy(func(a *domain.ReportApproval) bool {
+			return a.ApproverID == 8 && a.Level == domain.ApprovalLevelManager && a.OnBehalfOfID == nil
+		})).Return(nil).Once()
+		m.report.On("UpdateStatus", mock.Anything, mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]int{3}, nil).Once()
+		m.expense.On("UpdateStatus", mock.Anything, 3, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusApproved, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, m.useCase().ApproveReport(context.Background(), 10, 8, "covering"))
+		m.report.AssertExpectations(t)
+	})
+
+	t.Run("own report", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(awaiting(1500000))
+		m.expectApprover(1, domain.RoleManager)
+
+		err := m.useCase().ApproveReport(context.Background(), 10, 1, "")
+		require.ErrorIs(t, err, domain.ErrUnauthorizedAction)
+	})
+
+	t.Run("not awaiting approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusDraft})
+
+		err := m.useCase().ApproveReport(context.Background(), 10, 2, "")
+		require.ErrorIs(t, err, domain.ErrInvalidExpenseStatus)
+	})
+}
+
+func TestRejectLine(t *testing.T) {
+	t.Run("remaining lines stay in the report", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval, TotalIDR: 1700000})
+		m.expectApprover(2, domain.RoleManager)
+		m.expectReports(2, 1)
+		m.expectLines(10, line(3, 1200000, domain.ExpenseStatusAwaitingApproval), line(4, 500000, domain.ExpenseStatusAwaitingApproval))
+		m.expense.On("UpdateStatus", mock.Anything, 4, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusRejected, mock.Anything).Return(nil)
+		m.report.On("UpdateStatus", mock.Anything, mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]int(nil), nil)
+
+		report, err := m.useCase().RejectLine(context.Background(), 10, 4, 2, "personal")
+		require.NoError(t, err)
+		require.Equal(t, 1200000, report.TotalIDR)
+		require.Equal(t, domain.ExpenseStatusAwaitingApproval, report.Status)
+		m.report.AssertNotCalled(t, "CreateApproval", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejecting the last line rejects the report", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval, TotalIDR: 1500000})
+		m.expectApprover(2, domain.RoleManager)
+		m.expectReports(2, 1)
+		m.expectLines(10, line(3, 1500000, domain.ExpenseStatusAwaitingApproval))
+		m.expense.On("UpdateStatus", mock.Anything, 3, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusRejected, mock.Anything).Return(nil)
+		m.report.On("UpdateStatus", mock.Anything, mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]int(nil), nil)
+		m.report.On("CreateApproval", mock.Anything, mock.MatchedBy(func(a *domain.ReportApproval) bool {
+			return a.Status == domain.ApprovalStatusRejected
+		})).Return(nil)
+
+		report, err := m.useCase().RejectLine(context.Background(), 10, 3, 2, "personal")
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusRejected, report.Status)
+		require.Zero(t, report.TotalIDR)
+	})
+
+	t.Run("lower total needs no further level", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.report = new(mocks.ReportRepository)
+		m.report.On("FindApprovals", mock.Anything, 10).Return([]*domain.ReportApproval{
+			{Level: domain.ApprovalLevelManager, Status: domain.ApprovalStatusApproved},
+		}, nil)
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval, TotalIDR: 11000000})
+		m.expectApprover(9, domain.RoleFinanceDirector)
+		m.expectLines(10, line(3, 2000000, domain.ExpenseStatusAwaitingApproval), line(4, 9000000, domain.ExpenseStatusAwaitingApproval))
+		m.expense.On("UpdateStatus", mock.Anything, 4, domain.ExpenseStatusAwaitingApproval, domain.ExpenseStatusRejected, mock.Anything).Return(nil)
+		m.report.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(r *domain.ExpenseReport) bool {
+			return r.Status == domain.ExpenseStatusApproved
+		}), domain.ExpenseStatusAwaitingApproval).Return([]int{3}, nil)
+
+		report, err := m.useCase().RejectLine(context.Background(), 10, 4, 9, "")
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusApproved, report.Status)
+		require.Equal(t, domain.ExpenseStatusApproved, report.Lines[0].Status)
+		require.Equal(t, domain.ExpenseStatusRejected, report.Lines[1].Status)
+	})
+
+	t.Run("not a line of the report", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval, TotalIDR: 1500000})
+		m.expectApprover(2, domain.RoleManager)
+		m.expectReports(2, 1)
+		m.expectLines(10, line(3, 1500000, domain.ExpenseStatusAwaitingApproval))
+
+		_, err := m.useCase().RejectLine(context.Background(), 10, 99, 2, "")
+		require.ErrorIs(t, err, domain.ErrExpenseNotFound)
+	})
+}
+
+func TestCancelReport(t *testing.T) {
+	t.Run("awaiting approval", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusAwaitingApproval})
+		m.expectLines(10, line(3, 1500000, domain.ExpenseStatusAwaitingApproval))
+		m.report.On("UpdateStatus", mock.Anything, mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]int{3}, nil)
+
+		report, err := m.useCase().CancelReport(context.Background(), 10, 1)
+		require.NoError(t, err)
+		require.Equal(t, domain.ExpenseStatusCancelled, report.Status)
+		require.Equal(t, domain.ExpenseStatusCancelled, report.Lines[0].Status)
+	})
+
+	t.Run("already being paid", func(t *testing.T) {
+		m := newUseCaseMocks()
+		m.expectReport(&domain.ExpenseReport{ID: 10, UserID: 1, Status: domain.ExpenseStatusProcessing})
+
+		_, err := m.useCase().CancelReport(context.Background(), 10, 1)
+		require.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+}
+
+func TestGetPendingReports(t *testing.T) {
+	m := newUseCaseMocks()
+	m.expectApprover(2, domain.RoleManager)
+	m.expectReports(2, 1)
+	m.report.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.ExpenseReport{
+		{ID: 10, UserID: 1, TotalIDR: 1500000},
+		{ID: 11, UserID: 7, TotalIDR: 1500000},
+		{ID: 12, UserID: 2, TotalIDR: 1500000},
+	}, nil)
+	m.expectLines(10, line(3, 1500000, domain.ExpenseStatusAwaitingApproval))
+
+	reports, err := m.useCase().GetPendingApproval(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, 10, reports[0].ID)
+	require.Len(t, reports[0].Lines, 1)
+}
+
+func TestGetPendingReportsLeavesOutSegregationBlocks(t *testing.T) {
+	m := newUseCaseMocks()
+	m.segregation = new(mocks.SegregationPolicy)
+	m.expectApprover(2, domain.RoleManager)
+	m.expectReports(2, 1, 7)
+	m.report.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.ExpenseReport{
+		{ID: 10, UserID: 1, TotalIDR: 1500000},
+		{ID: 11, UserID: 7, TotalIDR: 1500000},
+	}, nil)
+	m.segregation.On("CheckReportApprover", mock.Anything, mock.MatchedBy(func(r *domain.ExpenseReport) bool { return r.ID == 10 }), domain.ApprovalLevelManager, 2).
+		Return(&domain.PolicyViolationError{Reason: "too many approvals in a row"}).Once()
+	m.segregation.On("CheckReportApprover", mock.Anything, mock.MatchedBy(func(r *domain.ExpenseReport) bool { return r.ID == 11 }), domain.ApprovalLevelManager, 2).
+		Return(nil).Once()
+	m.expectLines(10, line(3, 1500000, domain.ExpenseStatusAwaitingApproval))
+	m.expectLines(11, line(4, 1500000, domain.ExpenseStatusAwaitingApproval))
+
+	reports, err := m.useCase().GetPendingApproval(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, 11, reports[0].ID)
+}
+
+func TestGetPendingReportsIncludesEscalations(t *testing.T) {
+	m := newUseCaseMocks()
+	m.escalation = new(mocks.EscalationRepository)
+	m.escalation.On("FindPendingByEscalatedToID", mock.Anything, 8).Return([]*domain.Escalation{
+		{ExpenseID: 3, Level: domain.ApprovalLevelManager, EscalatedToID: 8},
+	}, nil).Once()
+	m.expectApprover(8, domain.RoleManager)
+	m.expectReports(8)
+	m.report.On("FindByStatus", mock.Anything, domain.ExpenseStatusAwaitingApproval).Return([]*domain.ExpenseReport{
+		{ID: 10, UserID: 1, TotalIDR: 1500000},
+		{ID: 11, UserID: 7, TotalIDR: 1500000},
+	}, nil)
+	m.expectLines(10, line(3, 1500000, domain.ExpenseStatusAwaitingApproval))
+	m.expectLines(11, line(4, 1500000, domain.ExpenseStatusAwaitingApproval))
+
+	reports, err := m.useCase().GetPendingApproval(context.Background(), 8)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, 10, reports[0].ID)
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/expense"
+	"github.com/evrintobing17/expense-management-backend/internal/rule"
+)
+
+// settledStatuses are left out when rules compare an expense with the submitter's other
+// expenses from the same day: drafts have not been claimed yet, and rejected or
+// cancelled expenses will never be paid.
+var settledStatuses = []domain.ExpenseStatus{
+	domain.ExpenseStatusDraft,
+	domain.ExpenseStatusRejected,
+	domain.ExpenseStatusCancelled,
+}
+
+type ruleEngine struct {
+	ruleRepo    rule.RuleRepository
+	expenseRepo expense.ExpenseRepository
+}
+
+// NewRuleEngine evaluates the active rules stored in ruleRepo. The submitter's other
+// expenses from the same day are only loaded when a rule needs them.
+func NewRuleEngine(ruleRepo rule.RuleRepository, expenseRepo expense.ExpenseRepository) rule.RuleEngine {
+	return &ruleEngine{
+		ruleRepo:    ruleRepo,
+		expenseRepo: expenseRepo,
+	}
+}
+
+func (e *ruleEngine) Evaluate(ctx context.Context, exp *domain.Expense) ([]*domain.RuleViolation, error) {
+	rules, err := e.ruleRepo.FindAll(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sameDay    []*domain.Expense
+		loaded     bool
+		violations []*domain.RuleViolation
+	)
+	for _, r := range rules {
+		if r.NeedsSameDayExpenses() && !loaded {
+			sameDay, err = e.expenseRepo.FindSameDay(ctx, exp.UserID, exp.IncurredOn, exp.ID, settledStatuses...)
+			if err != nil {
+				return nil, err
+			}
+			loaded = true
+		}
+
+		if v := r.Check(exp, sameDay); v != nil {
+			violations = append(violations, v)
+		}
+	}
+
+	return violations, nil
+}
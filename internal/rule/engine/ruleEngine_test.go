@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleEngineEvaluate(t *testing.T) {
+	ctx := context.Background()
+	saturday := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	expense := &domain.Expense{ID: 10, UserID: 2, CategoryID: 4, AmountIDR: 200000, Merchant: "Warung Sunda", IncurredOn: saturday}
+	weekend := &domain.PolicyRule{ID: 1, Name: "Weekend expenses", Type: domain.RuleWeekendDate, Action: domain.RuleActionWarn, Active: true}
+	duplicate := &domain.PolicyRule{ID: 2, Name: "Duplicate merchant", Type: domain.RuleDuplicateMerchantDay, Action: domain.RuleActionRequireApproval, Active: true}
+	perDiem := &domain.PolicyRule{ID: 3, Name: "Daily cap", Type: domain.RulePerDiemCap, Threshold: 1000000, Action: domain.RuleActionBlock, Active: true}
+
+	t.Run("rules without same-day lookups", func(t *testing.T) {
+		mockRules := new(mocks.RuleRepository)
+		mockExpense := new(mocks.ExpenseRepository)
+		e := NewRuleEngine(mockRules, mockExpense)
+		mockRules.On("FindAll", mock.Anything, false).Return([]*domain.PolicyRule{weekend}, nil).Once()
+
+		violations, err := e.Evaluate(ctx, expense)
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		require.Equal(t, "incurred on a Saturday", violations[0].Message)
+		mockExpense.AssertNotCalled(t, "FindSameDay", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("same-day expenses are loaded once", func(t *testing.T) {
+		mockRules := new(mocks.RuleRepository)
+		mockExpense := new(mocks.ExpenseRepository)
+		e := NewRuleEngine(mockRules, mockExpense)
+		mockRules.On("FindAll", mock.Anything, false).Return([]*domain.PolicyRule{duplicate, perDiem}, nil).Once()
+		mockExpense.On("FindSameDay", mock.Anything, 2, saturday, 10, settledStatuses[0], settledStatuses[1], settledStatuses[2]).
+			Return([]*domain.Expense{{ID: 9, CategoryID: 4, AmountIDR: 150000, Merchant: "warung sunda"}}, nil).Once()
+
+		violations, err := e.Evaluate(ctx, expense)
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		require.Equal(t, duplicate.ID, violations[0].RuleID)
+		mockExpense.AssertExpectations(t)
+	})
+
+	t.Run("rule lookup error", func(t *testing.T) {
+		mockRules := new(mocks.RuleRepository)
+		e := NewRuleEngine(mockRules, new(mocks.ExpenseRepository))
+		mockRules.On("FindAll", mock.Anything, false).Return(nil, errors.New("db down")).Once()
+
+		violations, err := e.Evaluate(ctx, expense)
+		require.Error(t, err)
+		require.Nil(t, violations)
+	})
+}
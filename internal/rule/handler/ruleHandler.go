@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/rule"
+	"github.com/gorilla/mux"
+)
+
+type RuleHandler struct {
+	ruleUseCase rule.RuleUseCase
+}
+
+func NewRuleHandler(ruleUseCase rule.RuleUseCase) *RuleHandler {
+	return &RuleHandler{ruleUseCase: ruleUseCase}
+}
+
+type ruleRequest struct {
+	Name         string            `json:"name"`
+	Type         domain.RuleType   `json:"type"`
+	CategoryID   *int              `json:"category_id"`
+	ThresholdIDR int               `json:"threshold_idr"`
+	Action       domain.RuleAction `json:"action"`
+	Active       *bool             `json:"active"`
+}
+
+func (h *RuleHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req ruleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	rule, err := h.ruleUseCase.CreateRule(ctx, &domain.PolicyRule{
+		Name:       req.Name,
+		Type:       req.Type,
+		CategoryID: req.CategoryID,
+		Threshold:  req.ThresholdIDR,
+		Action:     req.Action,
+	})
+	if err != nil {
+		writeRuleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(rule)
+}
+
+// ListRules returns the active rules, or every rule with include_inactive=true.
+func (h *RuleHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	includeInactive := r.URL.Query().Get("include_inactive") == "true"
+
+	rules, err := h.ruleUseCase.ListRules(ctx, includeInactive)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rules)
+}
+
+func (h *RuleHandler) GetRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid rule ID", http.StatusBadRequest)
+		return
+	}
+
+	rule, err := h.ruleUseCase.GetRule(ctx, id)
+	if err != nil {
+		writeRuleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rule)
+}
+
+func (h *RuleHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid rule ID", http.StatusBadRequest)
+		return
+	}
+
+	var req ruleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	rule, err := h.ruleUseCase.UpdateRule(ctx, &domain.PolicyRule{
+		ID:         id,
+		Name:       req.Name,
+		Type:       req.Type,
+		CategoryID: req.CategoryID,
+		Threshold:  req.ThresholdIDR,
+		Action:     req.Action,
+		Active:     active,
+	})
+	if err != nil {
+		writeRuleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rule)
+}
+
+// DeleteRule deactivates the rule; violations it already recorded stay on the expenses.
+func (h *RuleHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid rule ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.ruleUseCase.DeactivateRule(ctx, id)
+	if err != nil {
+		writeRuleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeRuleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRule), errors.Is(err, domain.ErrCategoryNotFound):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrRuleNotFound):
+		http.Error(w, "Policy rule not found", http.StatusNotFound)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
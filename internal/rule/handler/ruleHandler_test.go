@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleHandlerCreateRule(t *testing.T) {
+	t.Run("invalid rule", func(t *testing.T) {
+		mockUC := new(mocks.RuleUseCase)
+		h := NewRuleHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/policy-rules", strings.NewReader(`{"name":"Cap","type":"max_amount","action":"block"}`))
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateRule", mock.Anything, mock.AnythingOfType("*domain.PolicyRule")).Return((*domain.PolicyRule)(nil), domain.ErrInvalidRule).Once()
+
+		h.CreateRule(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.RuleUseCase)
+		h := NewRuleHandler(mockUC)
+		req := httptest.NewRequest(http.MethodPost, "/policy-rules", strings.NewReader(`{"name":"Meal per diem","type":"per_diem_cap","category_id":4,"threshold_idr":300000,"action":"require_approval"}`))
+		rr := httptest.NewRecorder()
+		mockUC.On("CreateRule", mock.Anything, mock.MatchedBy(func(r *domain.PolicyRule) bool {
+			return r.Type == domain.RulePerDiemCap && *r.CategoryID == 4 && r.Threshold == 300000 && r.Action == domain.RuleActionRequireApproval
+		})).Return(&domain.PolicyRule{ID: 5, Name: "Meal per diem", Active: true}, nil).Once()
+
+		h.CreateRule(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.Contains(t, rr.Body.String(), `"id":5`)
+	})
+}
+
+func TestRuleHandlerListRules(t *testing.T) {
+	mockUC := new(mocks.RuleUseCase)
+	h := NewRuleHandler(mockUC)
+	req := httptest.NewRequest(http.MethodGet, "/policy-rules?include_inactive=true", nil)
+	rr := httptest.NewRecorder()
+	mockUC.On("ListRules", mock.Anything, true).Return([]*domain.PolicyRule{{ID: 1, Name: "Weekend expenses"}}, nil).Once()
+
+	h.ListRules(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	mockUC.AssertExpectations(t)
+}
+
+func TestRuleHandlerUpdateRule(t *testing.T) {
+	mockUC := new(mocks.RuleUseCase)
+	h := NewRuleHandler(mockUC)
+	req := httptest.NewRequest(http.MethodPut, "/policy-rules/9", strings.NewReader(`{"name":"Weekend","type":"weekend_date","action":"warn"}`))
+	req = mux.SetURLVars(req, map[string]string{"id": "9"})
+	rr := httptest.NewRecorder()
+	mockUC.On("UpdateRule", mock.Anything, mock.MatchedBy(func(r *domain.PolicyRule) bool {
+		return r.ID == 9 && r.Active
+	})).Return((*domain.PolicyRule)(nil), domain.ErrRuleNotFound).Once()
+
+	h.UpdateRule(rr, req)
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestRuleHandlerDeleteRule(t *testing.T) {
+	mockUC := new(mocks.RuleUseCase)
+	h := NewRuleHandler(mockUC)
+	req := httptest.NewRequest(http.MethodDelete, "/policy-rules/1", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	rr := httptest.NewRecorder()
+	mockUC.On("DeactivateRule", mock.Anything, 1).Return(nil).Once()
+
+	h.DeleteRule(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/rule"
+)
+
+type ruleRepository struct {
+	db *sql.DB
+}
+
+func NewRuleRepository(db *sql.DB) rule.RuleRepository {
+	return &ruleRepository{db: db}
+}
+
+func (r *ruleRepository) Create(ctx context.Context, rule *domain.PolicyRule) error {
+	query := `
+		INSERT INTO policy_rules (name, rule_type, category_id, threshold_idr, action, active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		rule.Name,
+		rule.Type,
+		rule.CategoryID,
+		rule.Threshold,
+		rule.Action,
+		rule.Active,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+}
+
+func (r *ruleRepository) FindByID(ctx context.Context, id int) (*domain.PolicyRule, error) {
+	query := `
+		SELECT id, name, rule_type, category_id, threshold_idr, action, active, created_at, updated_at
+		FROM policy_rules
+		WHERE id = $1
+	`
+
+	rule := &domain.PolicyRule{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Type,
+		&rule.CategoryID,
+		&rule.Threshold,
+		&rule.Action,
+		&rule.Active,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (r *ruleRepository) FindAll(ctx context.Context, includeInactive bool) ([]*domain.PolicyRule, error) {
+	query := `
+		SELECT id, name, rule_type, category_id, threshold_idr, action, active, created_at, updated_at
+		FROM policy_rules
+		WHERE active = true OR $1
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*domain.PolicyRule
+	for rows.Next() {
+		rule := &domain.PolicyRule{}
+		err := rows.Scan(
+			&rule.ID,
+			&rule.Name,
+			&rule.Type,
+			&rule.CategoryID,
+			&rule.Threshold,
+			&rule.Action,
+			&rule.Active,
+			&rule.CreatedAt,
+			&rule.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Update overwrites the stored rule and returns domain.ErrRuleNotFound when no row has
+// its id.
+func (r *ruleRepository) Update(ctx context.Context, rule *domain.PolicyRule) error {
+	query := `
+		UPDATE policy_rules
+		SET name = $1, rule_type = $2, category_id = $3, threshold_idr = $4, action = $5, active = $6,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $7
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		rule.Name,
+		rule.Type,
+		rule.CategoryID,
+		rule.Threshold,
+		rule.Action,
+		rule.Active,
+		rule.ID,
+	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domain.ErrRuleNotFound
+	}
+
+	return err
+}
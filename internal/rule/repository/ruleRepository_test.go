@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+var ruleColumns = []string{"id", "name", "rule_type", "category_id", "threshold_idr", "action", "active", "created_at", "updated_at"}
+
+func TestRuleRepositoryCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &ruleRepository{db: db}
+	query := regexp.QuoteMeta(`
+		INSERT INTO policy_rules (name, rule_type, category_id, threshold_idr, action, active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`)
+	now := time.Now()
+	meals := 4
+
+	rule := &domain.PolicyRule{Name: "Meal per diem", Type: domain.RulePerDiemCap, CategoryID: &meals, Threshold: 300000, Action: domain.RuleActionRequireApproval, Active: true}
+	mock.ExpectQuery(query).
+		WithArgs("Meal per diem", domain.RulePerDiemCap, 4, 300000, domain.RuleActionRequireApproval, true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(3, now, now))
+
+	createErr := repo.Create(context.Background(), rule)
+	require.NoError(t, createErr)
+	require.Equal(t, 3, rule.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRuleRepositoryFind(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &ruleRepository{db: db}
+	now := time.Now()
+
+	byID := regexp.QuoteMeta(`
+		SELECT id, name, rule_type, category_id, threshold_idr, action, active, created_at, updated_at
+		FROM policy_rules
+		WHERE id = $1
+	`)
+	mock.ExpectQuery(byID).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(ruleColumns).AddRow(1, "Weekend expenses", "weekend_date", nil, 0, "warn", true, now, now))
+	rule, findErr := repo.FindByID(context.Background(), 1)
+	require.NoError(t, findErr)
+	require.Equal(t, domain.RuleWeekendDate, rule.Type)
+	require.Nil(t, rule.CategoryID)
+
+	mock.ExpectQuery(byID).WithArgs(9).WillReturnError(sql.ErrNoRows)
+	rule, findErr = repo.FindByID(context.Background(), 9)
+	require.NoError(t, findErr)
+	require.Nil(t, rule)
+
+	all := regexp.QuoteMeta(`
+		SELECT id, name, rule_type, category_id, threshold_idr, action, active, created_at, updated_at
+		FROM policy_rules
+		WHERE active = true OR $1
+		ORDER BY id ASC
+	`)
+	mock.ExpectQuery(all).WithArgs(false).
+		WillReturnRows(sqlmock.NewRows(ruleColumns).
+			AddRow(1, "Weekend expenses", "weekend_date", nil, 0, "warn", true, now, now).
+			AddRow(2, "Meal per diem", "per_diem_cap", 4, 300000, "require_approval", true, now, now))
+	rules, findErr := repo.FindAll(context.Background(), false)
+	require.NoError(t, findErr)
+	require.Len(t, rules, 2)
+	require.Equal(t, 4, *rules[1].CategoryID)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRuleRepositoryUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &ruleRepository{db: db}
+	query := regexp.QuoteMeta(`
+		UPDATE policy_rules
+		SET name = $1, rule_type = $2, category_id = $3, threshold_idr = $4, action = $5, active = $6,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $7
+		RETURNING created_at, updated_at
+	`)
+	now := time.Now()
+	rule := &domain.PolicyRule{ID: 1, Name: "Weekend expenses", Type: domain.RuleWeekendDate, Action: domain.RuleActionBlock}
+
+	mock.ExpectQuery(query).
+		WithArgs("Weekend expenses", domain.RuleWeekendDate, nil, 0, domain.RuleActionBlock, false, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	require.NoError(t, repo.Update(context.Background(), rule))
+
+	mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
+	require.ErrorIs(t, repo.Update(context.Background(), rule), domain.ErrRuleNotFound)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/rule"
+	"github.com/evrintobing17/expense-management-backend/pkg/database"
+	"github.com/lib/pq"
+)
+
+type violationRepository struct {
+	db *sql.DB
+}
+
+func NewViolationRepository(db *sql.DB) rule.ViolationRepository {
+	return &violationRepository{db: db}
+}
+
+// Replace swaps the expense's recorded violations for the result of its latest
+// evaluation. The rule name and action are copied so that later edits to the rule do not
+// rewrite what the approver was shown.
+func (r *violationRepository) Replace(ctx context.Context, expenseID int, violations []*domain.RuleViolation) error {
+	return database.WithinTx(ctx, r.db, func(ctx context.Context) error {
+		return r.replace(ctx, expenseID, violations)
+	})
+}
+
+func (r *violationRepository) replace(ctx context.Context, expenseID int, violations []*domain.RuleViolation) error {
+	tx := database.Conn(ctx, r.db)
+
+	_, err := tx.ExecContext(ctx, `DELETE FROM expense_policy_violations WHERE expense_id = $1`, expenseID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO expense_policy_violations (expense_id, rule_id, rule_name, action, message)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	for _, v := range violations {
+		_, err = tx.ExecContext(ctx, query, expenseID, v.RuleID, v.RuleName, v.Action, v.Message)
+		if err != nil {
+			return err
+		}
+		v.ExpenseID = expenseID
+	}
+
+	return nil
+}
+
+// FindByExpenseIDs returns the recorded violations keyed by expense id. Expenses without
+// violations are left out of the map.
+func (r *violationRepository) FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.RuleViolation, error) {
+	query := `
+		SELECT expense_id, rule_id, rule_name, action, message
+		FROM expense_policy_violations
+		WHERE expense_id = ANY($1)
+		ORDER BY expense_id ASC, id ASC
+	`
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, pq.Array(expenseIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	violations := make(map[int][]*domain.RuleViolation)
+	for rows.Next() {
+		v := &domain.RuleViolation{}
+		err := rows.Scan(
+			&v.ExpenseID,
+			&v.RuleID,
+			&v.RuleName,
+			&v.Action,
+			&v.Message,
+		)
+		if err != nil {
+			return nil, err
+		}
+		violations[v.ExpenseID] = append(violations[v.ExpenseID], v)
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViolationRepositoryReplace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &violationRepository{db: db}
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM expense_policy_violations WHERE expense_id = $1`)
+	insertQuery := regexp.QuoteMeta(`
+		INSERT INTO expense_policy_violations (expense_id, rule_id, rule_name, action, message)
+		VALUES ($1, $2, $3, $4, $5)
+	`)
+	violations := []*domain.RuleViolation{
+		{RuleID: 1, RuleName: "Weekend expenses", Action: domain.RuleActionWarn, Message: "incurred on a Saturday"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(insertQuery).
+			WithArgs(10, 1, "Weekend expenses", domain.RuleActionWarn, "incurred on a Saturday").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Replace(context.Background(), 10, violations))
+		require.Equal(t, 10, violations[0].ExpenseID)
+	})
+
+	t.Run("insert fails", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(insertQuery).WillReturnError(errors.New("insert failed"))
+		mock.ExpectRollback()
+
+		require.Error(t, repo.Replace(context.Background(), 10, violations))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestViolationRepositoryFindByExpenseIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &violationRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT expense_id, rule_id, rule_name, action, message
+		FROM expense_policy_violations
+		WHERE expense_id = ANY($1)
+		ORDER BY expense_id ASC, id ASC
+	`)
+	mock.ExpectQuery(query).WithArgs(pq.Array([]int{10, 11})).
+		WillReturnRows(sqlmock.NewRows([]string{"expense_id", "rule_id", "rule_name", "action", "message"}).
+			AddRow(10, 1, "Weekend expenses", "warn", "incurred on a Saturday").
+			AddRow(10, 2, "Duplicate merchant", "require_approval", "expense 9 is from the same merchant on the same day"))
+
+	violations, findErr := repo.FindByExpenseIDs(context.Background(), []int{10, 11})
+	require.NoError(t, findErr)
+	require.Len(t, violations[10], 2)
+	require.Empty(t, violations[11])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,12 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+// RuleEngine evaluates the active policy rules against an expense.
+type RuleEngine interface {
+	Evaluate(ctx context.Context, expense *domain.Expense) ([]*domain.RuleViolation, error)
+}
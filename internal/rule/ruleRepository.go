@@ -0,0 +1,14 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type RuleRepository interface {
+	Create(ctx context.Context, rule *domain.PolicyRule) error
+	FindByID(ctx context.Context, id int) (*domain.PolicyRule, error)
+	FindAll(ctx context.Context, includeInactive bool) ([]*domain.PolicyRule, error)
+	Update(ctx context.Context, rule *domain.PolicyRule) error
+}
@@ -0,0 +1,15 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type RuleUseCase interface {
+	CreateRule(ctx context.Context, rule *domain.PolicyRule) (*domain.PolicyRule, error)
+	GetRule(ctx context.Context, id int) (*domain.PolicyRule, error)
+	ListRules(ctx context.Context, includeInactive bool) ([]*domain.PolicyRule, error)
+	UpdateRule(ctx context.Context, rule *domain.PolicyRule) (*domain.PolicyRule, error)
+	DeactivateRule(ctx context.Context, id int) error
+}
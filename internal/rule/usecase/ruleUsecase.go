@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/category"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/rule"
+)
+
+type ruleUseCase struct {
+	ruleRepo     rule.RuleRepository
+	categoryRepo category.CategoryRepository
+}
+
+func NewRuleUseCase(ruleRepo rule.RuleRepository, categoryRepo category.CategoryRepository) rule.RuleUseCase {
+	return &ruleUseCase{
+		ruleRepo:     ruleRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// CreateRule adds an active rule. It applies to every submission from then on.
+func (uc *ruleUseCase) CreateRule(ctx context.Context, rule *domain.PolicyRule) (*domain.PolicyRule, error) {
+	if err := uc.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	rule.Active = true
+	err := uc.ruleRepo.Create(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (uc *ruleUseCase) GetRule(ctx context.Context, id int) (*domain.PolicyRule, error) {
+	rule, err := uc.ruleRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule == nil {
+		return nil, domain.ErrRuleNotFound
+	}
+
+	return rule, nil
+}
+
+func (uc *ruleUseCase) ListRules(ctx context.Context, includeInactive bool) ([]*domain.PolicyRule, error) {
+	return uc.ruleRepo.FindAll(ctx, includeInactive)
+}
+
+// UpdateRule replaces a rule's definition. Violations already recorded on expenses are
+// kept until those expenses are evaluated again.
+func (uc *ruleUseCase) UpdateRule(ctx context.Context, rule *domain.PolicyRule) (*domain.PolicyRule, error) {
+	if err := uc.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	err := uc.ruleRepo.Update(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// DeactivateRule stops the rule from being evaluated.
+func (uc *ruleUseCase) DeactivateRule(ctx context.Context, id int) error {
+	rule, err := uc.GetRule(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !rule.Active {
+		return nil
+	}
+
+	rule.Active = false
+	return uc.ruleRepo.Update(ctx, rule)
+}
+
+// validateRule checks the rule definition and that a category-scoped rule points at a
+// known category.
+func (uc *ruleUseCase) validateRule(ctx context.Context, rule *domain.PolicyRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	if rule.CategoryID == nil {
+		return nil
+	}
+
+	category, err := uc.categoryRepo.FindByID(ctx, *rule.CategoryID)
+	if err != nil {
+		return err
+	}
+
+	if category == nil {
+		return domain.ErrCategoryNotFound
+	}
+
+	return nil
+}
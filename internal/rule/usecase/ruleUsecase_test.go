@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRule(t *testing.T) {
+	ctx := context.Background()
+	meals := 4
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(mocks.RuleRepository)
+		mockCategory := new(mocks.CategoryRepository)
+		uc := NewRuleUseCase(mockRepo, mockCategory)
+		mockCategory.On("FindByID", mock.Anything, meals).Return(&domain.Category{ID: meals, Name: "Meals", Active: true}, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.PolicyRule) bool {
+			return r.Name == "Meal per diem" && r.Active
+		})).Return(nil).Once()
+
+		result, err := uc.CreateRule(ctx, &domain.PolicyRule{Name: "Meal per diem", Type: domain.RulePerDiemCap, CategoryID: &meals, Threshold: 300000, Action: domain.RuleActionWarn})
+		require.NoError(t, err)
+		require.True(t, result.Active)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		mockRepo := new(mocks.RuleRepository)
+		uc := NewRuleUseCase(mockRepo, new(mocks.CategoryRepository))
+
+		_, err := uc.CreateRule(ctx, &domain.PolicyRule{Name: "Cap", Type: domain.RuleMaxAmount, Action: domain.RuleActionBlock})
+		require.ErrorIs(t, err, domain.ErrInvalidRule)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		mockRepo := new(mocks.RuleRepository)
+		mockCategory := new(mocks.CategoryRepository)
+		uc := NewRuleUseCase(mockRepo, mockCategory)
+		mockCategory.On("FindByID", mock.Anything, meals).Return((*domain.Category)(nil), nil).Once()
+
+		_, err := uc.CreateRule(ctx, &domain.PolicyRule{Name: "Weekend meals", Type: domain.RuleWeekendDate, CategoryID: &meals, Action: domain.RuleActionWarn})
+		require.ErrorIs(t, err, domain.ErrCategoryNotFound)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetRule(t *testing.T) {
+	mockRepo := new(mocks.RuleRepository)
+	uc := NewRuleUseCase(mockRepo, new(mocks.CategoryRepository))
+	mockRepo.On("FindByID", mock.Anything, 9).Return((*domain.PolicyRule)(nil), nil).Once()
+
+	_, err := uc.GetRule(context.Background(), 9)
+	require.ErrorIs(t, err, domain.ErrRuleNotFound)
+}
+
+func TestDeactivateRule(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("active rule", func(t *testing.T) {
+		mockRepo := new(mocks.RuleRepository)
+		uc := NewRuleUseCase(mockRepo, new(mocks.CategoryRepository))
+		mockRepo.On("FindByID", mock.Anything, 1).Return(&domain.PolicyRule{ID: 1, Name: "Weekend", Active: true}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(r *domain.PolicyRule) bool {
+			return r.ID == 1 && !r.Active
+		})).Return(nil).Once()
+
+		require.NoError(t, uc.DeactivateRule(ctx, 1))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("already inactive", func(t *testing.T) {
+		mockRepo := new(mocks.RuleRepository)
+		uc := NewRuleUseCase(mockRepo, new(mocks.CategoryRepository))
+		mockRepo.On("FindByID", mock.Anything, 1).Return(&domain.PolicyRule{ID: 1, Name: "Weekend"}, nil).Once()
+
+		require.NoError(t, uc.DeactivateRule(ctx, 1))
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,12 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type ViolationRepository interface {
+	Replace(ctx context.Context, expenseID int, violations []*domain.RuleViolation) error
+	FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.RuleViolation, error)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/tax"
+)
+
+type TaxHandler struct {
+	taxUseCase tax.TaxUseCase
+}
+
+func NewTaxHandler(taxUseCase tax.TaxUseCase) *TaxHandler {
+	return &TaxHandler{taxUseCase: taxUseCase}
+}
+
+// GetTaxSummary reports PPN by month and rate. The optional from and to query parameters
+// are dates in YYYY-MM-DD form.
+func (h *TaxHandler) GetTaxSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	from, err := parseDate(r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, "Invalid from date", http.StatusBadRequest)
+		return
+	}
+
+	to, err := parseDate(r.URL.Query().Get("to"))
+	if err != nil {
+		http.Error(w, "Invalid to date", http.StatusBadRequest)
+		return
+	}
+
+	summary, err := h.taxUseCase.GetTaxSummary(ctx, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidPeriod):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
+
+// parseDate returns the zero time for an empty value.
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxHandlerGetTaxSummary(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.TaxUseCase)
+		h := NewTaxHandler(mockUC)
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+		summary := domain.NewTaxSummary(from, to, []*domain.TaxSummaryRow{{Month: "2026-01", TaxRatePercent: 11, ExpenseCount: 1, TaxAmountIDR: 11000}})
+		mockUC.On("GetTaxSummary", mock.Anything, from, to).Return(summary, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/reports/tax-summary?from=2026-01-01&to=2026-03-31", nil)
+		rr := httptest.NewRecorder()
+		h.GetTaxSummary(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"month":"2026-01"`)
+		require.Contains(t, rr.Body.String(), `"tax_amount_idr":11000`)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		mockUC := new(mocks.TaxUseCase)
+		h := NewTaxHandler(mockUC)
+
+		req := httptest.NewRequest(http.MethodGet, "/reports/tax-summary?from=01-2026", nil)
+		rr := httptest.NewRecorder()
+		h.GetTaxSummary(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		mockUC := new(mocks.TaxUseCase)
+		h := NewTaxHandler(mockUC)
+		mockUC.On("GetTaxSummary", mock.Anything, mock.Anything, mock.Anything).Return((*domain.TaxSummary)(nil), domain.ErrInvalidPeriod).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/reports/tax-summary?from=2026-03-01&to=2026-02-01", nil)
+		rr := httptest.NewRecorder()
+		h.GetTaxSummary(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/tax"
+	"github.com/lib/pq"
+)
+
+type taxRepository struct {
+	db *sql.DB
+}
+
+func NewTaxRepository(db *sql.DB) tax.TaxRepository {
+	return &taxRepository{db: db}
+}
+
+// SummarizeByMonthAndRate totals the expenses in the given statuses incurred between from
+// and to, inclusive, per calendar month and PPN rate.
+func (r *taxRepository) SummarizeByMonthAndRate(ctx context.Context, from, to time.Time, statuses ...domain.ExpenseStatus) ([]*domain.TaxSummaryRow, error) {
+	query := `
+		SELECT to_char(incurred_on, 'YYYY-MM') AS month, tax_rate_percent, COUNT(*),
+			COUNT(*) FILTER (WHERE efaktur_number <> ''),
+			SUM(net_amount_idr), SUM(tax_amount_idr), SUM(amount_idr)
+		FROM expenses
+		WHERE incurred_on BETWEEN $1 AND $2 AND status = ANY($3)
+		GROUP BY month, tax_rate_percent
+		ORDER BY month ASC, tax_rate_percent ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to, statusNames(statuses))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summary []*domain.TaxSummaryRow
+	for rows.Next() {
+		row := &domain.TaxSummaryRow{}
+		err := rows.Scan(
+			&row.Month,
+			&row.TaxRatePercent,
+			&row.ExpenseCount,
+			&row.EFakturCount,
+			&row.NetAmountIDR,
+			&row.TaxAmountIDR,
+			&row.TotalAmountIDR,
+		)
+		if err != nil {
+			return nil, err
+		}
+		summary = append(summary, row)
+	}
+
+	return summary, rows.Err()
+}
+
+// SummarizeByAllocation totals the same expenses per cost center and project. Expenses
+// without allocations are totalled under an empty cost center code.
+func (r *taxRepository) SummarizeByAllocation(ctx context.Context, from, to time.Time, statuses ...domain.ExpenseStatus) ([]*domain.AllocationTotal, error) {
+	query := `
+		SELECT COALESCE(a.cost_center_code, ''), COALESCE(a.project_code, ''),
+			COUNT(DISTINCT e.id), SUM(COALESCE(a.amount_idr, e.amount_idr))
+		FROM expenses e
+		LEFT JOIN expense_allocations a ON a.expense_id = e.id
+		WHERE e.incurred_on BETWEEN $1 AND $2 AND e.status = ANY($3)
+		GROUP BY 1, 2
+		ORDER BY 1 ASC, 2 ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to, statusNames(statuses))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []*domain.AllocationTotal
+	for rows.Next() {
+		total := &domain.AllocationTotal{}
+		err := rows.Scan(
+			&total.CostCenterCode,
+			&total.ProjectCode,
+			&total.ExpenseCount,
+			&total.AmountIDR,
+		)
+		if err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, rows.Err()
+}
+
+func statusNames(statuses []domain.ExpenseStatus) interface{} {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return pq.Array(names)
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxRepositorySummarizeByMonthAndRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &taxRepository{db: db}
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`
+		SELECT to_char(incurred_on, 'YYYY-MM') AS month, tax_rate_percent, COUNT(*),
+			COUNT(*) FILTER (WHERE efaktur_number <> ''),
+			SUM(net_amount_idr), SUM(tax_amount_idr), SUM(amount_idr)
+		FROM expenses
+		WHERE incurred_on BETWEEN $1 AND $2 AND status = ANY($3)
+		GROUP BY month, tax_rate_percent
+		ORDER BY month ASC, tax_rate_percent ASC
+	`)
+	mock.ExpectQuery(query).WithArgs(from, to, pq.Array([]string{"approved", "completed"})).
+		WillReturnRows(sqlmock.NewRows([]string{"month", "tax_rate_percent", "count", "efaktur_count", "net", "tax", "total"}).
+			AddRow("2026-01", 0, 2, 0, 80000, 0, 80000).
+			AddRow("2026-01", 11, 3, 2, 300000, 33000, 333000))
+
+	rows, findErr := repo.SummarizeByMonthAndRate(context.Background(), from, to, domain.ExpenseStatusApproved, domain.ExpenseStatusCompleted)
+	require.NoError(t, findErr)
+	require.Len(t, rows, 2)
+	require.Equal(t, &domain.TaxSummaryRow{Month: "2026-01", TaxRatePercent: 11, ExpenseCount: 3, EFakturCount: 2, NetAmountIDR: 300000, TaxAmountIDR: 33000, TotalAmountIDR: 333000}, rows[1])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaxRepositorySummarizeByAllocation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &taxRepository{db: db}
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`
+		SELECT COALESCE(a.cost_center_code, ''), COALESCE(a.project_code, ''),
+			COUNT(DISTINCT e.id), SUM(COALESCE(a.amount_idr, e.amount_idr))
+		FROM expenses e
+		LEFT JOIN expense_allocations a ON a.expense_id = e.id
+		WHERE e.incurred_on BETWEEN $1 AND $2 AND e.status = ANY($3)
+		GROUP BY 1, 2
+		ORDER BY 1 ASC, 2 ASC
+	`)
+	mock.ExpectQuery(query).WithArgs(from, to, pq.Array([]string{"approved"})).
+		WillReturnRows(sqlmock.NewRows([]string{"cost_center_code", "project_code", "count", "amount"}).
+			AddRow("", "", 4, 250000).
+			AddRow("FIN", "PRJ-01", 2, 180000))
+
+	totals, findErr := repo.SummarizeByAllocation(context.Background(), from, to, domain.ExpenseStatusApproved)
+	require.NoError(t, findErr)
+	require.Equal(t, []*domain.AllocationTotal{
+		{ExpenseCount: 4, AmountIDR: 250000},
+		{CostCenterCode: "FIN", ProjectCode: "PRJ-01", ExpenseCount: 2, AmountIDR: 180000},
+	}, totals)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,13 @@
+package tax
+
+import (
+	"context"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type TaxRepository interface {
+	SummarizeByMonthAndRate(ctx context.Context, from, to time.Time, statuses ...domain.ExpenseStatus) ([]*domain.TaxSummaryRow, error)
+	SummarizeByAllocation(ctx context.Context, from, to time.Time, statuses ...domain.ExpenseStatus) ([]*domain.AllocationTotal, error)
+}
@@ -0,0 +1,12 @@
+package tax
+
+import (
+	"context"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type TaxUseCase interface {
+	GetTaxSummary(ctx context.Context, from, to time.Time) (*domain.TaxSummary, error)
+}
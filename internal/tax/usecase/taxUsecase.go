@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/tax"
+)
+
+// claimedStatuses are the expenses the company has accepted and pays or has paid, which
+// is what input VAT can be claimed on.
+var claimedStatuses = []domain.ExpenseStatus{
+	domain.ExpenseStatusApproved,
+	domain.ExpenseStatusAutoApproved,
+	domain.ExpenseStatusProcessing,
+	domain.ExpenseStatusRetryScheduled,
+	domain.ExpenseStatusDeadLetter,
+	domain.ExpenseStatusCompleted,
+}
+
+type taxUseCase struct {
+	taxRepo tax.TaxRepository
+}
+
+func NewTaxUseCase(taxRepo tax.TaxRepository) tax.TaxUseCase {
+	return &taxUseCase{taxRepo: taxRepo}
+}
+
+// GetTaxSummary reports the claimed expenses incurred between from and to by month and
+// PPN rate, and by cost center and project. Without a period it covers the current year
+// to date.
+func (uc *taxUseCase) GetTaxSummary(ctx context.Context, from, to time.Time) (*domain.TaxSummary, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	to = domain.DateOf(to)
+
+	if from.IsZero() {
+		from = time.Date(to.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	from = domain.DateOf(from)
+
+	if from.After(to) {
+		return nil, domain.ErrInvalidPeriod
+	}
+
+	rows, err := uc.taxRepo.SummarizeByMonthAndRate(ctx, from, to, claimedStatuses...)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations, err := uc.taxRepo.SummarizeByAllocation(ctx, from, to, claimedStatuses...)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := domain.NewTaxSummary(from, to, rows)
+	if allocations != nil {
+		summary.Allocations = allocations
+	}
+
+	return summary, nil
+}
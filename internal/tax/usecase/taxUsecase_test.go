@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTaxSummary(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("period", func(t *testing.T) {
+		mockRepo := new(mocks.TaxRepository)
+		uc := NewTaxUseCase(mockRepo)
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("SummarizeByMonthAndRate", mock.Anything, from, to,
+			claimedStatuses[0], claimedStatuses[1], claimedStatuses[2], claimedStatuses[3], claimedStatuses[4], claimedStatuses[5]).
+			Return([]*domain.TaxSummaryRow{{Month: "2026-01", TaxRatePercent: 11, NetAmountIDR: 100000, TaxAmountIDR: 11000, TotalAmountIDR: 111000}}, nil).Once()
+		mockRepo.On("SummarizeByAllocation", mock.Anything, from, to,
+			claimedStatuses[0], claimedStatuses[1], claimedStatuses[2], claimedStatuses[3], claimedStatuses[4], claimedStatuses[5]).
+			Return([]*domain.AllocationTotal{{CostCenterCode: "FIN", ExpenseCount: 1, AmountIDR: 111000}}, nil).Once()
+
+		summary, err := uc.GetTaxSummary(ctx, from, to)
+		require.NoError(t, err)
+		require.Len(t, summary.Rows, 1)
+		require.Equal(t, int64(11000), summary.TaxAmountIDR)
+		require.Equal(t, "FIN", summary.Allocations[0].CostCenterCode)
+	})
+
+	t.Run("defaults to year to date", func(t *testing.T) {
+		mockRepo := new(mocks.TaxRepository)
+		uc := NewTaxUseCase(mockRepo)
+		today := domain.DateOf(time.Now())
+		yearStart := time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("SummarizeByMonthAndRate", mock.Anything, yearStart, today,
+			claimedStatuses[0], claimedStatuses[1], claimedStatuses[2], claimedStatuses[3], claimedStatuses[4], claimedStatuses[5]).
+			Return([]*domain.TaxSummaryRow(nil), nil).Once()
+		mockRepo.On("SummarizeByAllocation", mock.Anything, yearStart, today,
+			claimedStatuses[0], claimedStatuses[1], claimedStatuses[2], claimedStatuses[3], claimedStatuses[4], claimedStatuses[5]).
+			Return([]*domain.AllocationTotal(nil), nil).Once()
+
+		summary, err := uc.GetTaxSummary(ctx, time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Empty(t, summary.Rows)
+		require.NotNil(t, summary.Allocations)
+	})
+
+	t.Run("period ends before it starts", func(t *testing.T) {
+		mockRepo := new(mocks.TaxRepository)
+		uc := NewTaxUseCase(mockRepo)
+
+		_, err := uc.GetTaxSummary(ctx, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+		require.ErrorIs(t, err, domain.ErrInvalidPeriod)
+		mockRepo.AssertNotCalled(t, "SummarizeByMonthAndRate")
+	})
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/user"
+	"github.com/gorilla/mux"
+)
+
+type UserHandler struct {
+	userUseCase user.UserUseCase
+}
+
+func NewUserHandler(userUseCase user.UserUseCase) *UserHandler {
+	return &UserHandler{userUseCase: userUseCase}
+}
+
+// AssignManager sets the user's manager. A null manager_id removes it.
+func (h *UserHandler) AssignManager(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		ManagerID *int `json:"manager_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	u, err := h.userUseCase.AssignManager(ctx, id, req.ManagerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			http.Error(w, "User not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidManager):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(u)
+}
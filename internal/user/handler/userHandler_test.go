@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func assignRequest(id, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPut, "/users/"+id+"/manager", strings.NewReader(body))
+	return mux.SetURLVars(req, map[string]string{"id": id})
+}
+
+func TestUserHandlerAssignManager(t *testing.T) {
+	managerID := 2
+
+	t.Run("invalid id", func(t *testing.T) {
+		mockUC := new(mocks.UserUseCase)
+		h := NewUserHandler(mockUC)
+		rr := httptest.NewRecorder()
+
+		h.AssignManager(rr, assignRequest("abc", `{"manager_id":2}`))
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		mockUC := new(mocks.UserUseCase)
+		h := NewUserHandler(mockUC)
+		rr := httptest.NewRecorder()
+
+		h.AssignManager(rr, assignRequest("3", `{"manager_id":"two"}`))
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUC := new(mocks.UserUseCase)
+		h := NewUserHandler(mockUC)
+		rr := httptest.NewRecorder()
+		mockUC.On("AssignManager", mock.Anything, 3, &managerID).Return(&domain.User{ID: 3, ManagerID: &managerID}, nil).Once()
+
+		h.AssignManager(rr, assignRequest("3", `{"manager_id":2}`))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"manager_id":2`)
+	})
+
+	t.Run("clear manager", func(t *testing.T) {
+		mockUC := new(mocks.UserUseCase)
+		h := NewUserHandler(mockUC)
+		rr := httptest.NewRecorder()
+		mockUC.On("AssignManager", mock.Anything, 3, (*int)(nil)).Return(&domain.User{ID: 3}, nil).Once()
+
+		h.AssignManager(rr, assignRequest("3", `{"manager_id":null}`))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"manager_id":null`)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cases := map[error]int{
+			domain.ErrUserNotFound:   http.StatusNotFound,
+			domain.ErrInvalidManager: http.StatusBadRequest,
+			errors.New("db error"):   http.StatusInternalServerError,
+		}
+		for domainErr, status := range cases {
+			mockUC := new(mocks.UserUseCase)
+			h := NewUserHandler(mockUC)
+			rr := httptest.NewRecorder()
+			mockUC.On("AssignManager", mock.Anything, 3, &managerID).Return((*domain.User)(nil), domainErr).Once()
+
+			h.AssignManager(rr, assignRequest("3", `{"manager_id":2}`))
+			require.Equal(t, status, rr.Code)
+		}
+	})
+}
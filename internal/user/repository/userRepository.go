@@ -18,7 +18,7 @@ func NewUserRepository(db *sql.DB) user.UserRepository {
 
 func (r *userRepository) FindByID(ctx context.Context, id int) (*domain.User, error) {
 	query := `
-		SELECT id, email, name, role, password_hash, created_at
+		SELECT id, email, name, role, manager_id, password_hash, created_at
 		FROM users
 		WHERE id = $1
 	`
@@ -29,6 +29,7 @@ func (r *userRepository) FindByID(ctx context.Context, id int) (*domain.User, er
 		&user.Email,
 		&user.Name,
 		&user.Role,
+		&user.ManagerID,
 		&user.PasswordHash,
 		&user.CreatedAt,
 	)
@@ -45,7 +46,7 @@ func (r *userRepository) FindByID(ctx context.Context, id int) (*domain.User, er
 
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, name, role, password_hash, created_at
+		SELECT id, email, name, role, manager_id, password_hash, created_at
 		FROM users
 		WHERE email = $1
 	`
@@ -56,6 +57,7 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain
 		&user.Email,
 		&user.Name,
 		&user.Role,
+		&user.ManagerID,
 		&user.PasswordHash,
 		&user.CreatedAt,
 	)
@@ -69,3 +71,83 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain
 
 	return user, nil
 }
+
+// FindReportIDs returns the IDs of users who report to managerID. With indirect set, the
+// whole reporting tree below the manager is returned, not just direct reports.
+func (r *userRepository) FindReportIDs(ctx context.Context, managerID int, indirect bool) ([]int, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE manager_id = $1
+		ORDER BY id
+	`
+
+	if indirect {
+		query = `
+		WITH RECURSIVE reports AS (
+			SELECT id FROM users WHERE manager_id = $1
+			UNION
+			SELECT u.id FROM users u JOIN reports r ON u.manager_id = r.id
+		)
+		SELECT id FROM reports
+		ORDER BY id
+	`
+	}
+
+	return r.queryIDs(ctx, query, managerID)
+}
+
+func (r *userRepository) FindIDsByRole(ctx context.Context, role domain.Role) ([]int, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE role = $1
+		ORDER BY id
+	`
+
+	return r.queryIDs(ctx, query, role)
+}
+
+// UpdateManager sets or, with a nil managerID, clears the user's manager.
+func (r *userRepository) UpdateManager(ctx context.Context, userID int, managerID *int) error {
+	query := `
+		UPDATE users
+		SET manager_id = $1
+		WHERE id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, managerID, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) queryIDs(ctx context.Context, query string, args ...interface{}) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -20,15 +20,17 @@ func TestUserRepositoryFindByID(t *testing.T) {
 
 	repo := &userRepository{db: db}
 	query := regexp.QuoteMeta(`
-		SELECT id, email, name, role, password_hash, created_at
+		SELECT id, email, name, role, manager_id, password_hash, created_at
 		FROM users
 		WHERE id = $1
 	`)
 	createdAt := time.Now()
 
+	managerID := 7
+
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "email", "name", "role", "password_hash", "created_at"}).
-			AddRow(1, "user@example.com", "User", "employee", "hash", createdAt)
+		rows := sqlmock.NewRows([]string{"id", "email", "name", "role", "manager_id", "password_hash", "created_at"}).
+			AddRow(1, "user@example.com", "User", "employee", 7, "hash", createdAt)
 		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
 
 		user, findErr := repo.FindByID(context.Background(), 1)
@@ -38,6 +40,7 @@ func TestUserRepositoryFindByID(t *testing.T) {
 			Email:        "user@example.com",
 			Name:         "User",
 			Role:         domain.RoleEmployee,
+			ManagerID:    &managerID,
 			PasswordHash: "hash",
 			CreatedAt:    createdAt,
 		}, user)
@@ -70,19 +73,118 @@ func TestUserRepositoryFindByEmail(t *testing.T) {
 
 	repo := &userRepository{db: db}
 	query := regexp.QuoteMeta(`
-		SELECT id, email, name, role, password_hash, created_at
+		SELECT id, email, name, role, manager_id, password_hash, created_at
 		FROM users
 		WHERE email = $1
 	`)
 	createdAt := time.Now()
 
-	rows := sqlmock.NewRows([]string{"id", "email", "name", "role", "password_hash", "created_at"}).
-		AddRow(1, "user@example.com", "User", "employee", "hash", createdAt)
+	rows := sqlmock.NewRows([]string{"id", "email", "name", "role", "manager_id", "password_hash", "created_at"}).
+		AddRow(1, "user@example.com", "User", "employee", nil, "hash", createdAt)
 	mock.ExpectQuery(query).WithArgs("user@example.com").WillReturnRows(rows)
 
 	user, findErr := repo.FindByEmail(context.Background(), "user@example.com")
 	require.NoError(t, findErr)
 	require.NotNil(t, user)
 	require.Equal(t, "user@example.com", user.Email)
+	require.Nil(t, user.ManagerID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryFindReportIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &userRepository{db: db}
+
+	t.Run("direct reports", func(t *testing.T) {
+		query := regexp.QuoteMeta(`
+		SELECT id
+		FROM users
+		WHERE manager_id = $1
+		ORDER BY id
+	`)
+		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+
+		ids, findErr := repo.FindReportIDs(context.Background(), 1, false)
+		require.NoError(t, findErr)
+		require.Equal(t, []int{2, 3}, ids)
+	})
+
+	t.Run("whole reporting tree", func(t *testing.T) {
+		mock.ExpectQuery(`WITH RECURSIVE reports AS`).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3).AddRow(8))
+
+		ids, findErr := repo.FindReportIDs(context.Background(), 1, true)
+		require.NoError(t, findErr)
+		require.Equal(t, []int{2, 3, 8}, ids)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		expectedErr := errors.New("db error")
+		mock.ExpectQuery(`FROM users`).WithArgs(1).WillReturnError(expectedErr)
+
+		ids, findErr := repo.FindReportIDs(context.Background(), 1, false)
+		require.ErrorIs(t, findErr, expectedErr)
+		require.Nil(t, ids)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryFindIDsByRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &userRepository{db: db}
+	query := regexp.QuoteMeta(`
+		SELECT id
+		FROM users
+		WHERE role = $1
+		ORDER BY id
+	`)
+
+	mock.ExpectQuery(query).WithArgs(domain.RoleFinanceDirector).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(6))
+
+	ids, findErr := repo.FindIDsByRole(context.Background(), domain.RoleFinanceDirector)
+	require.NoError(t, findErr)
+	require.Equal(t, []int{4, 6}, ids)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryUpdateManager(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := &userRepository{db: db}
+	query := regexp.QuoteMeta(`
+		UPDATE users
+		SET manager_id = $1
+		WHERE id = $2
+	`)
+	managerID := 7
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectExec(query).WithArgs(&managerID, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.UpdateManager(context.Background(), 1, &managerID))
+	})
+
+	t.Run("clear manager", func(t *testing.T) {
+		mock.ExpectExec(query).WithArgs(nil, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.UpdateManager(context.Background(), 1, nil))
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock.ExpectExec(query).WithArgs(&managerID, 100).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		require.ErrorIs(t, repo.UpdateManager(context.Background(), 100, &managerID), domain.ErrUserNotFound)
+	})
+
 	require.NoError(t, mock.ExpectationsWereMet())
 }
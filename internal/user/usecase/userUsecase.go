@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/internal/user"
+)
+
+type userUseCase struct {
+	userRepo user.UserRepository
+}
+
+func NewUserUseCase(userRepo user.UserRepository) user.UserUseCase {
+	return &userUseCase{userRepo: userRepo}
+}
+
+// AssignManager sets the manager whose approvals cover userID's expenses, or clears it
+// when managerID is nil. The manager has to be another approver outside the user's own
+// reporting tree, so the reporting line never loops back on itself.
+func (uc *userUseCase) AssignManager(ctx context.Context, userID int, managerID *int) (*domain.User, error) {
+	u, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if u == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	if managerID != nil {
+		if *managerID == userID {
+			return nil, domain.ErrInvalidManager
+		}
+
+		manager, err := uc.userRepo.FindByID(ctx, *managerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if manager == nil || !manager.Role.IsApprover() {
+			return nil, domain.ErrInvalidManager
+		}
+
+		reportIDs, err := uc.userRepo.FindReportIDs(ctx, userID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range reportIDs {
+			if id == *managerID {
+				return nil, domain.ErrInvalidManager
+			}
+		}
+	}
+
+	err = uc.userRepo.UpdateManager(ctx, userID, managerID)
+	if err != nil {
+		return nil, err
+	}
+
+	u.ManagerID = managerID
+	return u, nil
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+	"github.com/evrintobing17/expense-management-backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignManager(t *testing.T) {
+	ctx := context.Background()
+	userID := 3
+	managerID := 2
+	employee := func() *domain.User { return &domain.User{ID: userID, Role: domain.RoleEmployee} }
+
+	t.Run("success", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		uc := NewUserUseCase(mockUser)
+		mockUser.On("FindByID", mock.Anything, userID).Return(employee(), nil).Once()
+		mockUser.On("FindByID", mock.Anything, managerID).Return(&domain.User{ID: managerID, Role: domain.RoleManager}, nil).Once()
+		mockUser.On("FindReportIDs", mock.Anything, userID, true).Return([]int{}, nil).Once()
+		mockUser.On("UpdateManager", mock.Anything, userID, &managerID).Return(nil).Once()
+
+		result, err := uc.AssignManager(ctx, userID, &managerID)
+		require.NoError(t, err)
+		require.Equal(t, &managerID, result.ManagerID)
+		mockUser.AssertExpectations(t)
+	})
+
+	t.Run("clear manager", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		uc := NewUserUseCase(mockUser)
+		mockUser.On("FindByID", mock.Anything, userID).Return(&domain.User{ID: userID, ManagerID: &managerID}, nil).Once()
+		mockUser.On("UpdateManager", mock.Anything, userID, (*int)(nil)).Return(nil).Once()
+
+		result, err := uc.AssignManager(ctx, userID, nil)
+		require.NoError(t, err)
+		require.Nil(t, result.ManagerID)
+		mockUser.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		uc := NewUserUseCase(mockUser)
+		mockUser.On("FindByID", mock.Anything, userID).Return((*domain.User)(nil), nil).Once()
+
+		_, err := uc.AssignManager(ctx, userID, &managerID)
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockUser.AssertNotCalled(t, "UpdateManager", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("self as manager", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		uc := NewUserUseCase(mockUser)
+		mockUser.On("FindByID", mock.Anything, userID).Return(employee(), nil).Once()
+
+		_, err := uc.AssignManager(ctx, userID, &userID)
+		require.ErrorIs(t, err, domain.ErrInvalidManager)
+		mockUser.AssertNotCalled(t, "UpdateManager", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("manager is not an approver", func(t *testing.T) {
+		for _, manager := range []*domain.User{nil, {ID: managerID, Role: domain.RoleEmployee}} {
+			mockUser := new(mocks.UserRepository)
+			uc := NewUserUseCase(mockUser)
+			mockUser.On("FindByID", mock.Anything, userID).Return(employee(), nil).Once()
+			mockUser.On("FindByID", mock.Anything, managerID).Return(manager, nil).Once()
+
+			_, err := uc.AssignManager(ctx, userID, &managerID)
+			require.ErrorIs(t, err, domain.ErrInvalidManager)
+			mockUser.AssertNotCalled(t, "UpdateManager", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("manager reports to the user", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		uc := NewUserUseCase(mockUser)
+		mockUser.On("FindByID", mock.Anything, userID).Return(&domain.User{ID: userID, Role: domain.RoleManager}, nil).Once()
+		mockUser.On("FindByID", mock.Anything, managerID).Return(&domain.User{ID: managerID, Role: domain.RoleManager}, nil).Once()
+		mockUser.On("FindReportIDs", mock.Anything, userID, true).Return([]int{5, managerID}, nil).Once()
+
+		_, err := uc.AssignManager(ctx, userID, &managerID)
+		require.ErrorIs(t, err, domain.ErrInvalidManager)
+		mockUser.AssertNotCalled(t, "UpdateManager", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockUser := new(mocks.UserRepository)
+		uc := NewUserUseCase(mockUser)
+		expectedErr := errors.New("db error")
+		mockUser.On("FindByID", mock.Anything, userID).Return((*domain.User)(nil), expectedErr).Once()
+
+		_, err := uc.AssignManager(ctx, userID, &managerID)
+		require.ErrorIs(t, err, expectedErr)
+	})
+}
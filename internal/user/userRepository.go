@@ -9,4 +9,7 @@ import (
 type UserRepository interface {
 	FindByID(ctx context.Context, id int) (*domain.User, error)
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	FindReportIDs(ctx context.Context, managerID int, indirect bool) ([]int, error)
+	FindIDsByRole(ctx context.Context, role domain.Role) ([]int, error)
+	UpdateManager(ctx context.Context, userID int, managerID *int) error
 }
@@ -0,0 +1,11 @@
+package user
+
+import (
+	"context"
+
+	"github.com/evrintobing17/expense-management-backend/internal/domain"
+)
+
+type UserUseCase interface {
+	AssignManager(ctx context.Context, userID int, managerID *int) (*domain.User, error)
+}
@@ -0,0 +1,242 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AdvanceRepository is an autogenerated mock type for the AdvanceRepository type
+type AdvanceRepository struct {
+	mock.Mock
+}
+
+// Balance provides a mock function with given fields: ctx, userID
+func (_m *AdvanceRepository) Balance(ctx context.Context, userID int) (*domain.AdvanceBalance, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Balance")
+	}
+
+	var r0 *domain.AdvanceBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.AdvanceBalance, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.AdvanceBalance); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AdvanceBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimForPayment provides a mock function with given fields: ctx, limit, timeout
+func (_m *AdvanceRepository) ClaimForPayment(ctx context.Context, limit int, timeout time.Duration) ([]*domain.CashAdvance, error) {
+	ret := _m.Called(ctx, limit, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimForPayment")
+	}
+
+	var r0 []*domain.CashAdvance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]*domain.CashAdvance, error)); ok {
+		return rf(ctx, limit, timeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []*domain.CashAdvance); ok {
+		r0 = rf(ctx, limit, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CashAdvance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, _a1
+func (_m *AdvanceRepository) Create(ctx context.Context, _a1 *domain.CashAdvance) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CashAdvance) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *AdvanceRepository) FindByID(ctx context.Context, id int) (*domain.CashAdvance, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.CashAdvance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.CashAdvance, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.CashAdvance); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CashAdvance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByStatus provides a mock function with given fields: ctx, statuses
+func (_m *AdvanceRepository) FindByStatus(ctx context.Context, statuses ...domain.AdvanceStatus) ([]*domain.CashAdvance, error) {
+	_va := make([]interface{}, len(statuses))
+	for _i := range statuses {
+		_va[_i] = statuses[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByStatus")
+	}
+
+	var r0 []*domain.CashAdvance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...domain.AdvanceStatus) ([]*domain.CashAdvance, error)); ok {
+		return rf(ctx, statuses...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...domain.AdvanceStatus) []*domain.CashAdvance); ok {
+		r0 = rf(ctx, statuses...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CashAdvance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...domain.AdvanceStatus) error); ok {
+		r1 = rf(ctx, statuses...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByUserID provides a mock function with given fields: ctx, userID
+func (_m *AdvanceRepository) FindByUserID(ctx context.Context, userID int) ([]*domain.CashAdvance, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUserID")
+	}
+
+	var r0 []*domain.CashAdvance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.CashAdvance, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.CashAdvance); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CashAdvance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Settle provides a mock function with given fields: ctx, _a1, expenses
+func (_m *AdvanceRepository) Settle(ctx context.Context, _a1 *domain.CashAdvance, expenses []*domain.Expense) error {
+	ret := _m.Called(ctx, _a1, expenses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Settle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CashAdvance, []*domain.Expense) error); ok {
+		r0 = rf(ctx, _a1, expenses)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, _a1, from
+func (_m *AdvanceRepository) UpdateStatus(ctx context.Context, _a1 *domain.CashAdvance, from domain.AdvanceStatus) error {
+	ret := _m.Called(ctx, _a1, from)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CashAdvance, domain.AdvanceStatus) error); ok {
+		r0 = rf(ctx, _a1, from)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAdvanceRepository creates a new instance of AdvanceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdvanceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdvanceRepository {
+	mock := &AdvanceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
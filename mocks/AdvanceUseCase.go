@@ -0,0 +1,305 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdvanceUseCase is an autogenerated mock type for the AdvanceUseCase type
+type AdvanceUseCase struct {
+	mock.Mock
+}
+
+// ApproveAdvance provides a mock function with given fields: ctx, id, approverID, notes
+func (_m *AdvanceUseCase) ApproveAdvance(ctx context.Context, id int, approverID int, notes string) error {
+	ret := _m.Called(ctx, id, approverID, notes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveAdvance")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) error); ok {
+		r0 = rf(ctx, id, approverID, notes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CancelAdvance provides a mock function with given fields: ctx, id, userID
+func (_m *AdvanceUseCase) CancelAdvance(ctx context.Context, id int, userID int) (*domain.CashAdvance, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelAdvance")
+	}
+
+	var r0 *domain.CashAdvance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*domain.CashAdvance, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *domain.CashAdvance); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CashAdvance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdvance provides a mock function with given fields: ctx, id, userID, role
+func (_m *AdvanceUseCase) GetAdvance(ctx context.Context, id int, userID int, role domain.Role) (*domain.CashAdvance, error) {
+	ret := _m.Called(ctx, id, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdvance")
+	}
+
+	var r0 *domain.CashAdvance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) (*domain.CashAdvance, error)); ok {
+		return rf(ctx, id, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) *domain.CashAdvance); ok {
+		r0 = rf(ctx, id, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CashAdvance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, domain.Role) error); ok {
+		r1 = rf(ctx, id, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalance provides a mock function with given fields: ctx, balanceUserID, userID, role
+func (_m *AdvanceUseCase) GetBalance(ctx context.Context, balanceUserID int, userID int, role domain.Role) (*domain.AdvanceBalance, error) {
+	ret := _m.Called(ctx, balanceUserID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalance")
+	}
+
+	var r0 *domain.AdvanceBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) (*domain.AdvanceBalance, error)); ok {
+		return rf(ctx, balanceUserID, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) *domain.AdvanceBalance); ok {
+		r0 = rf(ctx, balanceUserID, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AdvanceBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, domain.Role) error); ok {
+		r1 = rf(ctx, balanceUserID, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingApproval provides a mock function with given fields: ctx, approverID
+func (_m *AdvanceUseCase) GetPendingApproval(ctx context.Context, approverID int) ([]*domain.CashAdvance, error) {
+	ret := _m.Called(ctx, approverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingApproval")
+	}
+
+	var r0 []*domain.CashAdvance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.CashAdvance, error)); ok {
+		return rf(ctx, approverID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.CashAdvance); ok {
+		r0 = rf(ctx, approverID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CashAdvance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, approverID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserAdvances provides a mock function with given fields: ctx, userID
+func (_m *AdvanceUseCase) GetUserAdvances(ctx context.Context, userID int) ([]*domain.CashAdvance, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserAdvances")
+	}
+
+	var r0 []*domain.CashAdvance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.CashAdvance, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.CashAdvance); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CashAdvance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordRepayment provides a mock function with given fields: ctx, id
+func (_m *AdvanceUseCase) RecordRepayment(ctx context.Context, id int) (*domain.CashAdvance, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordRepayment")
+	}
+
+	var r0 *domain.CashAdvance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.CashAdvance, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.CashAdvance); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CashAdvance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectAdvance provides a mock function with given fields: ctx, id, approverID, notes
+func (_m *AdvanceUseCase) RejectAdvance(ctx context.Context, id int, approverID int, notes string) error {
+	ret := _m.Called(ctx, id, approverID, notes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectAdvance")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) error); ok {
+		r0 = rf(ctx, id, approverID, notes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequestAdvance provides a mock function with given fields: ctx, _a1
+func (_m *AdvanceUseCase) RequestAdvance(ctx context.Context, _a1 *domain.CashAdvance) (*domain.CashAdvance, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestAdvance")
+	}
+
+	var r0 *domain.CashAdvance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CashAdvance) (*domain.CashAdvance, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CashAdvance) *domain.CashAdvance); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CashAdvance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CashAdvance) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettleAdvance provides a mock function with given fields: ctx, id, userID
+func (_m *AdvanceUseCase) SettleAdvance(ctx context.Context, id int, userID int) (*domain.CashAdvance, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettleAdvance")
+	}
+
+	var r0 *domain.CashAdvance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*domain.CashAdvance, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *domain.CashAdvance); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CashAdvance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAdvanceUseCase creates a new instance of AdvanceUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdvanceUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdvanceUseCase {
+	mock := &AdvanceUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,48 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AllocationChecker is an autogenerated mock type for the AllocationChecker type
+type AllocationChecker struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, expense
+func (_m *AllocationChecker) Check(ctx context.Context, expense *domain.Expense) error {
+	ret := _m.Called(ctx, expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense) error); ok {
+		r0 = rf(ctx, expense)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAllocationChecker creates a new instance of AllocationChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAllocationChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AllocationChecker {
+	mock := &AllocationChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,246 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AllocationUseCase is an autogenerated mock type for the AllocationUseCase type
+type AllocationUseCase struct {
+	mock.Mock
+}
+
+// CreateCostCenter provides a mock function with given fields: ctx, costCenter
+func (_m *AllocationUseCase) CreateCostCenter(ctx context.Context, costCenter *domain.CostCenter) (*domain.CostCenter, error) {
+	ret := _m.Called(ctx, costCenter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCostCenter")
+	}
+
+	var r0 *domain.CostCenter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CostCenter) (*domain.CostCenter, error)); ok {
+		return rf(ctx, costCenter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CostCenter) *domain.CostCenter); ok {
+		r0 = rf(ctx, costCenter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CostCenter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CostCenter) error); ok {
+		r1 = rf(ctx, costCenter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateProject provides a mock function with given fields: ctx, project
+func (_m *AllocationUseCase) CreateProject(ctx context.Context, project *domain.Project) (*domain.Project, error) {
+	ret := _m.Called(ctx, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateProject")
+	}
+
+	var r0 *domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Project) (*domain.Project, error)); ok {
+		return rf(ctx, project)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Project) *domain.Project); ok {
+		r0 = rf(ctx, project)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Project) error); ok {
+		r1 = rf(ctx, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivateCostCenter provides a mock function with given fields: ctx, id
+func (_m *AllocationUseCase) DeactivateCostCenter(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateCostCenter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeactivateProject provides a mock function with given fields: ctx, id
+func (_m *AllocationUseCase) DeactivateProject(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateProject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListCostCenters provides a mock function with given fields: ctx, includeInactive
+func (_m *AllocationUseCase) ListCostCenters(ctx context.Context, includeInactive bool) ([]*domain.CostCenter, error) {
+	ret := _m.Called(ctx, includeInactive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCostCenters")
+	}
+
+	var r0 []*domain.CostCenter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]*domain.CostCenter, error)); ok {
+		return rf(ctx, includeInactive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []*domain.CostCenter); ok {
+		r0 = rf(ctx, includeInactive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CostCenter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, includeInactive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListProjects provides a mock function with given fields: ctx, includeInactive
+func (_m *AllocationUseCase) ListProjects(ctx context.Context, includeInactive bool) ([]*domain.Project, error) {
+	ret := _m.Called(ctx, includeInactive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProjects")
+	}
+
+	var r0 []*domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]*domain.Project, error)); ok {
+		return rf(ctx, includeInactive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []*domain.Project); ok {
+		r0 = rf(ctx, includeInactive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, includeInactive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCostCenter provides a mock function with given fields: ctx, costCenter
+func (_m *AllocationUseCase) UpdateCostCenter(ctx context.Context, costCenter *domain.CostCenter) (*domain.CostCenter, error) {
+	ret := _m.Called(ctx, costCenter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCostCenter")
+	}
+
+	var r0 *domain.CostCenter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CostCenter) (*domain.CostCenter, error)); ok {
+		return rf(ctx, costCenter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CostCenter) *domain.CostCenter); ok {
+		r0 = rf(ctx, costCenter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CostCenter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CostCenter) error); ok {
+		r1 = rf(ctx, costCenter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProject provides a mock function with given fields: ctx, project
+func (_m *AllocationUseCase) UpdateProject(ctx context.Context, project *domain.Project) (*domain.Project, error) {
+	ret := _m.Called(ctx, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProject")
+	}
+
+	var r0 *domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Project) (*domain.Project, error)); ok {
+		return rf(ctx, project)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Project) *domain.Project); ok {
+		r0 = rf(ctx, project)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Project) error); ok {
+		r1 = rf(ctx, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAllocationUseCase creates a new instance of AllocationUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAllocationUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AllocationUseCase {
+	mock := &AllocationUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
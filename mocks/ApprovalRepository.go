@@ -14,6 +14,41 @@ type ApprovalRepository struct {
 	mock.Mock
 }
 
+// CountRecentApprovalsByApprover provides a mock function with given fields: ctx, submitterID, approverID, excludeExpenseID, level, window
+func (_m *ApprovalRepository) CountRecentApprovalsByApprover(ctx context.Context, submitterID int, approverID int, excludeExpenseID int, level domain.ApprovalLevel, window int) (int, int, error) {
+	ret := _m.Called(ctx, submitterID, approverID, excludeExpenseID, level, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRecentApprovalsByApprover")
+	}
+
+	var r0 int
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, domain.ApprovalLevel, int) (int, int, error)); ok {
+		return rf(ctx, submitterID, approverID, excludeExpenseID, level, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, domain.ApprovalLevel, int) int); ok {
+		r0 = rf(ctx, submitterID, approverID, excludeExpenseID, level, window)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, domain.ApprovalLevel, int) int); ok {
+		r1 = rf(ctx, submitterID, approverID, excludeExpenseID, level, window)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, int, domain.ApprovalLevel, int) error); ok {
+		r2 = rf(ctx, submitterID, approverID, excludeExpenseID, level, window)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Create provides a mock function with given fields: ctx, _a1
 func (_m *ApprovalRepository) Create(ctx context.Context, _a1 *domain.Approval) error {
 	ret := _m.Called(ctx, _a1)
@@ -33,23 +68,23 @@ func (_m *ApprovalRepository) Create(ctx context.Context, _a1 *domain.Approval)
 }
 
 // FindByExpenseID provides a mock function with given fields: ctx, expenseID
-func (_m *ApprovalRepository) FindByExpenseID(ctx context.Context, expenseID int) (*domain.Approval, error) {
+func (_m *ApprovalRepository) FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.Approval, error) {
 	ret := _m.Called(ctx, expenseID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByExpenseID")
 	}
 
-	var r0 *domain.Approval
+	var r0 []*domain.Approval
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.Approval, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Approval, error)); ok {
 		return rf(ctx, expenseID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.Approval); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Approval); ok {
 		r0 = rf(ctx, expenseID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Approval)
+			r0 = ret.Get(0).([]*domain.Approval)
 		}
 	}
 
@@ -62,6 +97,36 @@ func (_m *ApprovalRepository) FindByExpenseID(ctx context.Context, expenseID int
 	return r0, r1
 }
 
+// FindByExpenseIDs provides a mock function with given fields: ctx, expenseIDs
+func (_m *ApprovalRepository) FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.Approval, error) {
+	ret := _m.Called(ctx, expenseIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByExpenseIDs")
+	}
+
+	var r0 map[int][]*domain.Approval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) (map[int][]*domain.Approval, error)); ok {
+		return rf(ctx, expenseIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) map[int][]*domain.Approval); ok {
+		r0 = rf(ctx, expenseIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int][]*domain.Approval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, expenseIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewApprovalRepository creates a new instance of ApprovalRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewApprovalRepository(t interface {
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BudgetChecker is an autogenerated mock type for the BudgetChecker type
+type BudgetChecker struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, expense
+func (_m *BudgetChecker) Check(ctx context.Context, expense *domain.Expense) ([]*domain.BudgetWarning, error) {
+	ret := _m.Called(ctx, expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 []*domain.BudgetWarning
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense) ([]*domain.BudgetWarning, error)); ok {
+		return rf(ctx, expense)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense) []*domain.BudgetWarning); ok {
+		r0 = rf(ctx, expense)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.BudgetWarning)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Expense) error); ok {
+		r1 = rf(ctx, expense)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewBudgetChecker creates a new instance of BudgetChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBudgetChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BudgetChecker {
+	mock := &BudgetChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
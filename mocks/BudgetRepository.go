@@ -0,0 +1,200 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BudgetRepository is an autogenerated mock type for the BudgetRepository type
+type BudgetRepository struct {
+	mock.Mock
+}
+
+// Consumption provides a mock function with given fields: ctx, _a1, statuses
+func (_m *BudgetRepository) Consumption(ctx context.Context, _a1 *domain.Budget, statuses ...domain.ExpenseStatus) (int, int, error) {
+	_va := make([]interface{}, len(statuses))
+	for _i := range statuses {
+		_va[_i] = statuses[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consumption")
+	}
+
+	var r0 int
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Budget, ...domain.ExpenseStatus) (int, int, error)); ok {
+		return rf(ctx, _a1, statuses...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Budget, ...domain.ExpenseStatus) int); ok {
+		r0 = rf(ctx, _a1, statuses...)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Budget, ...domain.ExpenseStatus) int); ok {
+		r1 = rf(ctx, _a1, statuses...)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *domain.Budget, ...domain.ExpenseStatus) error); ok {
+		r2 = rf(ctx, _a1, statuses...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Create provides a mock function with given fields: ctx, _a1
+func (_m *BudgetRepository) Create(ctx context.Context, _a1 *domain.Budget) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Budget) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAll provides a mock function with given fields: ctx, userID
+func (_m *BudgetRepository) FindAll(ctx context.Context, userID *int) ([]*domain.Budget, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAll")
+	}
+
+	var r0 []*domain.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *int) ([]*domain.Budget, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *int) []*domain.Budget); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *BudgetRepository) FindByID(ctx context.Context, id int) (*domain.Budget, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.Budget, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.Budget); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindCovering provides a mock function with given fields: ctx, date, userID, costCenterCodes
+func (_m *BudgetRepository) FindCovering(ctx context.Context, date time.Time, userID int, costCenterCodes []string) ([]*domain.Budget, error) {
+	ret := _m.Called(ctx, date, userID, costCenterCodes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCovering")
+	}
+
+	var r0 []*domain.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, []string) ([]*domain.Budget, error)); ok {
+		return rf(ctx, date, userID, costCenterCodes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, []string) []*domain.Budget); ok {
+		r0 = rf(ctx, date, userID, costCenterCodes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int, []string) error); ok {
+		r1 = rf(ctx, date, userID, costCenterCodes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, _a1
+func (_m *BudgetRepository) Update(ctx context.Context, _a1 *domain.Budget) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Budget) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewBudgetRepository creates a new instance of BudgetRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBudgetRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BudgetRepository {
+	mock := &BudgetRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,149 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BudgetUseCase is an autogenerated mock type for the BudgetUseCase type
+type BudgetUseCase struct {
+	mock.Mock
+}
+
+// CreateBudget provides a mock function with given fields: ctx, _a1
+func (_m *BudgetUseCase) CreateBudget(ctx context.Context, _a1 *domain.Budget) (*domain.Budget, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBudget")
+	}
+
+	var r0 *domain.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Budget) (*domain.Budget, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Budget) *domain.Budget); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Budget) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBudget provides a mock function with given fields: ctx, id, userID, role
+func (_m *BudgetUseCase) GetBudget(ctx context.Context, id int, userID int, role domain.Role) (*domain.BudgetUsage, error) {
+	ret := _m.Called(ctx, id, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBudget")
+	}
+
+	var r0 *domain.BudgetUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) (*domain.BudgetUsage, error)); ok {
+		return rf(ctx, id, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) *domain.BudgetUsage); ok {
+		r0 = rf(ctx, id, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BudgetUsage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, domain.Role) error); ok {
+		r1 = rf(ctx, id, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBudgets provides a mock function with given fields: ctx, userID, role
+func (_m *BudgetUseCase) ListBudgets(ctx context.Context, userID int, role domain.Role) ([]*domain.Budget, error) {
+	ret := _m.Called(ctx, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBudgets")
+	}
+
+	var r0 []*domain.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, domain.Role) ([]*domain.Budget, error)); ok {
+		return rf(ctx, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, domain.Role) []*domain.Budget); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, domain.Role) error); ok {
+		r1 = rf(ctx, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateBudget provides a mock function with given fields: ctx, _a1
+func (_m *BudgetUseCase) UpdateBudget(ctx context.Context, _a1 *domain.Budget) (*domain.Budget, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBudget")
+	}
+
+	var r0 *domain.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Budget) (*domain.Budget, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Budget) *domain.Budget); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Budget) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewBudgetUseCase creates a new instance of BudgetUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBudgetUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BudgetUseCase {
+	mock := &BudgetUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,126 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CategoryRepository is an autogenerated mock type for the CategoryRepository type
+type CategoryRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, _a1
+func (_m *CategoryRepository) Create(ctx context.Context, _a1 *domain.Category) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Category) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAll provides a mock function with given fields: ctx, includeInactive
+func (_m *CategoryRepository) FindAll(ctx context.Context, includeInactive bool) ([]*domain.Category, error) {
+	ret := _m.Called(ctx, includeInactive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAll")
+	}
+
+	var r0 []*domain.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]*domain.Category, error)); ok {
+		return rf(ctx, includeInactive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []*domain.Category); ok {
+		r0 = rf(ctx, includeInactive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, includeInactive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *CategoryRepository) FindByID(ctx context.Context, id int) (*domain.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.Category, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, _a1
+func (_m *CategoryRepository) Update(ctx context.Context, _a1 *domain.Category) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Category) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCategoryRepository creates a new instance of CategoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCategoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CategoryRepository {
+	mock := &CategoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,168 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CategoryUseCase is an autogenerated mock type for the CategoryUseCase type
+type CategoryUseCase struct {
+	mock.Mock
+}
+
+// CreateCategory provides a mock function with given fields: ctx, _a1
+func (_m *CategoryUseCase) CreateCategory(ctx context.Context, _a1 *domain.Category) (*domain.Category, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCategory")
+	}
+
+	var r0 *domain.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Category) (*domain.Category, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Category) *domain.Category); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Category) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivateCategory provides a mock function with given fields: ctx, id
+func (_m *CategoryUseCase) DeactivateCategory(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCategory provides a mock function with given fields: ctx, id
+func (_m *CategoryUseCase) GetCategory(ctx context.Context, id int) (*domain.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategory")
+	}
+
+	var r0 *domain.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.Category, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCategories provides a mock function with given fields: ctx, includeInactive
+func (_m *CategoryUseCase) ListCategories(ctx context.Context, includeInactive bool) ([]*domain.Category, error) {
+	ret := _m.Called(ctx, includeInactive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCategories")
+	}
+
+	var r0 []*domain.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]*domain.Category, error)); ok {
+		return rf(ctx, includeInactive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []*domain.Category); ok {
+		r0 = rf(ctx, includeInactive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, includeInactive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCategory provides a mock function with given fields: ctx, _a1
+func (_m *CategoryUseCase) UpdateCategory(ctx context.Context, _a1 *domain.Category) (*domain.Category, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCategory")
+	}
+
+	var r0 *domain.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Category) (*domain.Category, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Category) *domain.Category); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Category) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCategoryUseCase creates a new instance of CategoryUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCategoryUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CategoryUseCase {
+	mock := &CategoryUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
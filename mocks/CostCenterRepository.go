@@ -0,0 +1,156 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CostCenterRepository is an autogenerated mock type for the CostCenterRepository type
+type CostCenterRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, costCenter
+func (_m *CostCenterRepository) Create(ctx context.Context, costCenter *domain.CostCenter) error {
+	ret := _m.Called(ctx, costCenter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CostCenter) error); ok {
+		r0 = rf(ctx, costCenter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAll provides a mock function with given fields: ctx, includeInactive
+func (_m *CostCenterRepository) FindAll(ctx context.Context, includeInactive bool) ([]*domain.CostCenter, error) {
+	ret := _m.Called(ctx, includeInactive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAll")
+	}
+
+	var r0 []*domain.CostCenter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]*domain.CostCenter, error)); ok {
+		return rf(ctx, includeInactive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []*domain.CostCenter); ok {
+		r0 = rf(ctx, includeInactive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CostCenter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, includeInactive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByCode provides a mock function with given fields: ctx, code
+func (_m *CostCenterRepository) FindByCode(ctx context.Context, code string) (*domain.CostCenter, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByCode")
+	}
+
+	var r0 *domain.CostCenter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.CostCenter, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.CostCenter); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CostCenter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *CostCenterRepository) FindByID(ctx context.Context, id int) (*domain.CostCenter, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.CostCenter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.CostCenter, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.CostCenter); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CostCenter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, costCenter
+func (_m *CostCenterRepository) Update(ctx context.Context, costCenter *domain.CostCenter) error {
+	ret := _m.Called(ctx, costCenter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CostCenter) error); ok {
+		r0 = rf(ctx, costCenter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCostCenterRepository creates a new instance of CostCenterRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCostCenterRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CostCenterRepository {
+	mock := &CostCenterRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
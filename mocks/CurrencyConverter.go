@@ -0,0 +1,48 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CurrencyConverter is an autogenerated mock type for the CurrencyConverter type
+type CurrencyConverter struct {
+	mock.Mock
+}
+
+// Convert provides a mock function with given fields: ctx, expense
+func (_m *CurrencyConverter) Convert(ctx context.Context, expense *domain.Expense) error {
+	ret := _m.Called(ctx, expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Convert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense) error); ok {
+		r0 = rf(ctx, expense)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCurrencyConverter creates a new instance of CurrencyConverter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCurrencyConverter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CurrencyConverter {
+	mock := &CurrencyConverter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,206 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// DelegationRepository is an autogenerated mock type for the DelegationRepository type
+type DelegationRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, _a1
+func (_m *DelegationRepository) Create(ctx context.Context, _a1 *domain.Delegation) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Delegation) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *DelegationRepository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindActiveByDelegateID provides a mock function with given fields: ctx, delegateID, at
+func (_m *DelegationRepository) FindActiveByDelegateID(ctx context.Context, delegateID int, at time.Time) ([]*domain.Delegation, error) {
+	ret := _m.Called(ctx, delegateID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByDelegateID")
+	}
+
+	var r0 []*domain.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]*domain.Delegation, error)); ok {
+		return rf(ctx, delegateID, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) []*domain.Delegation); ok {
+		r0 = rf(ctx, delegateID, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, delegateID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindActiveByManagerID provides a mock function with given fields: ctx, managerID, at
+func (_m *DelegationRepository) FindActiveByManagerID(ctx context.Context, managerID int, at time.Time) ([]*domain.Delegation, error) {
+	ret := _m.Called(ctx, managerID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByManagerID")
+	}
+
+	var r0 []*domain.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]*domain.Delegation, error)); ok {
+		return rf(ctx, managerID, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) []*domain.Delegation); ok {
+		r0 = rf(ctx, managerID, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, managerID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *DelegationRepository) FindByID(ctx context.Context, id int) (*domain.Delegation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.Delegation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.Delegation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindCurrentByManagerID provides a mock function with given fields: ctx, managerID, at
+func (_m *DelegationRepository) FindCurrentByManagerID(ctx context.Context, managerID int, at time.Time) ([]*domain.Delegation, error) {
+	ret := _m.Called(ctx, managerID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCurrentByManagerID")
+	}
+
+	var r0 []*domain.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]*domain.Delegation, error)); ok {
+		return rf(ctx, managerID, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) []*domain.Delegation); ok {
+		r0 = rf(ctx, managerID, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, managerID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateEndsAt provides a mock function with given fields: ctx, id, endsAt
+func (_m *DelegationRepository) UpdateEndsAt(ctx context.Context, id int, endsAt time.Time) error {
+	ret := _m.Called(ctx, id, endsAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEndsAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, id, endsAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDelegationRepository creates a new instance of DelegationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationRepository {
+	mock := &DelegationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
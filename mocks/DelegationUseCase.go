@@ -0,0 +1,110 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// DelegationUseCase is an autogenerated mock type for the DelegationUseCase type
+type DelegationUseCase struct {
+	mock.Mock
+}
+
+// CreateDelegation provides a mock function with given fields: ctx, managerID, delegateID, startsAt, endsAt
+func (_m *DelegationUseCase) CreateDelegation(ctx context.Context, managerID int, delegateID int, startsAt time.Time, endsAt time.Time) (*domain.Delegation, error) {
+	ret := _m.Called(ctx, managerID, delegateID, startsAt, endsAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDelegation")
+	}
+
+	var r0 *domain.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, time.Time, time.Time) (*domain.Delegation, error)); ok {
+		return rf(ctx, managerID, delegateID, startsAt, endsAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, time.Time, time.Time) *domain.Delegation); ok {
+		r0 = rf(ctx, managerID, delegateID, startsAt, endsAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, managerID, delegateID, startsAt, endsAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDelegations provides a mock function with given fields: ctx, managerID
+func (_m *DelegationUseCase) ListDelegations(ctx context.Context, managerID int) ([]*domain.Delegation, error) {
+	ret := _m.Called(ctx, managerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDelegations")
+	}
+
+	var r0 []*domain.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Delegation, error)); ok {
+		return rf(ctx, managerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Delegation); ok {
+		r0 = rf(ctx, managerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, managerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeDelegation provides a mock function with given fields: ctx, id, managerID
+func (_m *DelegationUseCase) RevokeDelegation(ctx context.Context, id int, managerID int) error {
+	ret := _m.Called(ctx, id, managerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeDelegation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, managerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDelegationUseCase creates a new instance of DelegationUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationUseCase {
+	mock := &DelegationUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DuplicateDetector is an autogenerated mock type for the DuplicateDetector type
+type DuplicateDetector struct {
+	mock.Mock
+}
+
+// Detect provides a mock function with given fields: ctx, expense
+func (_m *DuplicateDetector) Detect(ctx context.Context, expense *domain.Expense) ([]*domain.DuplicateMatch, error) {
+	ret := _m.Called(ctx, expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Detect")
+	}
+
+	var r0 []*domain.DuplicateMatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense) ([]*domain.DuplicateMatch, error)); ok {
+		return rf(ctx, expense)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense) []*domain.DuplicateMatch); ok {
+		r0 = rf(ctx, expense)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.DuplicateMatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Expense) error); ok {
+		r1 = rf(ctx, expense)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDuplicateDetector creates a new instance of DuplicateDetector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDuplicateDetector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DuplicateDetector {
+	mock := &DuplicateDetector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
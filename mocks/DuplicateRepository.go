@@ -0,0 +1,78 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DuplicateRepository is an autogenerated mock type for the DuplicateRepository type
+type DuplicateRepository struct {
+	mock.Mock
+}
+
+// FindByExpenseIDs provides a mock function with given fields: ctx, expenseIDs
+func (_m *DuplicateRepository) FindByExpenseIDs(ctx context.Context, expenseIDs []int) (map[int][]*domain.DuplicateMatch, error) {
+	ret := _m.Called(ctx, expenseIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByExpenseIDs")
+	}
+
+	var r0 map[int][]*domain.DuplicateMatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) (map[int][]*domain.DuplicateMatch, error)); ok {
+		return rf(ctx, expenseIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) map[int][]*domain.DuplicateMatch); ok {
+		r0 = rf(ctx, expenseIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int][]*domain.DuplicateMatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, expenseIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Replace provides a mock function with given fields: ctx, expenseID, matches
+func (_m *DuplicateRepository) Replace(ctx context.Context, expenseID int, matches []*domain.DuplicateMatch) error {
+	ret := _m.Called(ctx, expenseID, matches)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []*domain.DuplicateMatch) error); ok {
+		r0 = rf(ctx, expenseID, matches)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDuplicateRepository creates a new instance of DuplicateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDuplicateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DuplicateRepository {
+	mock := &DuplicateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,108 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EscalationRepository is an autogenerated mock type for the EscalationRepository type
+type EscalationRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, _a1
+func (_m *EscalationRepository) Create(ctx context.Context, _a1 *domain.Escalation) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Escalation) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByExpenseID provides a mock function with given fields: ctx, expenseID
+func (_m *EscalationRepository) FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.Escalation, error) {
+	ret := _m.Called(ctx, expenseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByExpenseID")
+	}
+
+	var r0 []*domain.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Escalation, error)); ok {
+		return rf(ctx, expenseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Escalation); ok {
+		r0 = rf(ctx, expenseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, expenseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPendingByEscalatedToID provides a mock function with given fields: ctx, userID
+func (_m *EscalationRepository) FindPendingByEscalatedToID(ctx context.Context, userID int) ([]*domain.Escalation, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPendingByEscalatedToID")
+	}
+
+	var r0 []*domain.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Escalation, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Escalation); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewEscalationRepository creates a new instance of EscalationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEscalationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EscalationRepository {
+	mock := &EscalationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -17,6 +17,36 @@ type ExpenseRepository struct {
 	mock.Mock
 }
 
+// ClaimForPayment provides a mock function with given fields: ctx, limit, timeout
+func (_m *ExpenseRepository) ClaimForPayment(ctx context.Context, limit int, timeout time.Duration) ([]*domain.Expense, error) {
+	ret := _m.Called(ctx, limit, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimForPayment")
+	}
+
+	var r0 []*domain.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]*domain.Expense, error)); ok {
+		return rf(ctx, limit, timeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []*domain.Expense); ok {
+		r0 = rf(ctx, limit, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, _a1
 func (_m *ExpenseRepository) Create(ctx context.Context, _a1 *domain.Expense) error {
 	ret := _m.Called(ctx, _a1)
@@ -35,6 +65,36 @@ func (_m *ExpenseRepository) Create(ctx context.Context, _a1 *domain.Expense) er
 	return r0
 }
 
+// FindByAdvanceID provides a mock function with given fields: ctx, advanceID
+func (_m *ExpenseRepository) FindByAdvanceID(ctx context.Context, advanceID int) ([]*domain.Expense, error) {
+	ret := _m.Called(ctx, advanceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByAdvanceID")
+	}
+
+	var r0 []*domain.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Expense, error)); ok {
+		return rf(ctx, advanceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Expense); ok {
+		r0 = rf(ctx, advanceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, advanceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByID provides a mock function with given fields: ctx, id
 func (_m *ExpenseRepository) FindByID(ctx context.Context, id int) (*domain.Expense, error) {
 	ret := _m.Called(ctx, id)
@@ -65,6 +125,36 @@ func (_m *ExpenseRepository) FindByID(ctx context.Context, id int) (*domain.Expe
 	return r0, r1
 }
 
+// FindByReportID provides a mock function with given fields: ctx, reportID
+func (_m *ExpenseRepository) FindByReportID(ctx context.Context, reportID int) ([]*domain.Expense, error) {
+	ret := _m.Called(ctx, reportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByReportID")
+	}
+
+	var r0 []*domain.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Expense, error)); ok {
+		return rf(ctx, reportID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Expense); ok {
+		r0 = rf(ctx, reportID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, reportID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByStatus provides a mock function with given fields: ctx, statuses
 func (_m *ExpenseRepository) FindByStatus(ctx context.Context, statuses ...domain.ExpenseStatus) ([]*domain.Expense, error) {
 	_va := make([]interface{}, len(statuses))
@@ -132,6 +222,47 @@ func (_m *ExpenseRepository) FindByUserID(ctx context.Context, userID int, statu
 	return r0, r1
 }
 
+// FindIncurredBetween provides a mock function with given fields: ctx, userID, from, to, excludeID, skip
+func (_m *ExpenseRepository) FindIncurredBetween(ctx context.Context, userID int, from time.Time, to time.Time, excludeID int, skip ...domain.ExpenseStatus) ([]*domain.Expense, error) {
+	_va := make([]interface{}, len(skip))
+	for _i := range skip {
+		_va[_i] = skip[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, userID)
+	_ca = append(_ca, from)
+	_ca = append(_ca, to)
+	_ca = append(_ca, excludeID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIncurredBetween")
+	}
+
+	var r0 []*domain.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time, int, ...domain.ExpenseStatus) ([]*domain.Expense, error)); ok {
+		return rf(ctx, userID, from, to, excludeID, skip...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time, int, ...domain.ExpenseStatus) []*domain.Expense); ok {
+		r0 = rf(ctx, userID, from, to, excludeID, skip...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, time.Time, int, ...domain.ExpenseStatus) error); ok {
+		r1 = rf(ctx, userID, from, to, excludeID, skip...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindPendingApproval provides a mock function with given fields: ctx
 func (_m *ExpenseRepository) FindPendingApproval(ctx context.Context) ([]*domain.Expense, error) {
 	ret := _m.Called(ctx)
@@ -162,17 +293,105 @@ func (_m *ExpenseRepository) FindPendingApproval(ctx context.Context) ([]*domain
 	return r0, r1
 }
 
-// UpdateStatus provides a mock function with given fields: ctx, id, status, processedAt
-func (_m *ExpenseRepository) UpdateStatus(ctx context.Context, id int, status domain.ExpenseStatus, processedAt *time.Time) error {
-	ret := _m.Called(ctx, id, status, processedAt)
+// FindPendingApprovalByUserIDs provides a mock function with given fields: ctx, userIDs
+func (_m *ExpenseRepository) FindPendingApprovalByUserIDs(ctx context.Context, userIDs []int) ([]*domain.Expense, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPendingApprovalByUserIDs")
+	}
+
+	var r0 []*domain.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]*domain.Expense, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []*domain.Expense); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindSameDay provides a mock function with given fields: ctx, userID, incurredOn, excludeID, skip
+func (_m *ExpenseRepository) FindSameDay(ctx context.Context, userID int, incurredOn time.Time, excludeID int, skip ...domain.ExpenseStatus) ([]*domain.Expense, error) {
+	_va := make([]interface{}, len(skip))
+	for _i := range skip {
+		_va[_i] = skip[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, userID)
+	_ca = append(_ca, incurredOn)
+	_ca = append(_ca, excludeID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSameDay")
+	}
+
+	var r0 []*domain.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, int, ...domain.ExpenseStatus) ([]*domain.Expense, error)); ok {
+		return rf(ctx, userID, incurredOn, excludeID, skip...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, int, ...domain.ExpenseStatus) []*domain.Expense); ok {
+		r0 = rf(ctx, userID, incurredOn, excludeID, skip...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, int, ...domain.ExpenseStatus) error); ok {
+		r1 = rf(ctx, userID, incurredOn, excludeID, skip...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, _a1, from
+func (_m *ExpenseRepository) Update(ctx context.Context, _a1 *domain.Expense, from domain.ExpenseStatus) error {
+	ret := _m.Called(ctx, _a1, from)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense, domain.ExpenseStatus) error); ok {
+		r0 = rf(ctx, _a1, from)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, from, to, processedAt
+func (_m *ExpenseRepository) UpdateStatus(ctx context.Context, id int, from domain.ExpenseStatus, to domain.ExpenseStatus, processedAt *time.Time) error {
+	ret := _m.Called(ctx, id, from, to, processedAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateStatus")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, domain.ExpenseStatus, *time.Time) error); ok {
-		r0 = rf(ctx, id, status, processedAt)
+	if rf, ok := ret.Get(0).(func(context.Context, int, domain.ExpenseStatus, domain.ExpenseStatus, *time.Time) error); ok {
+		r0 = rf(ctx, id, from, to, processedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -33,9 +33,67 @@ func (_m *ExpenseUseCase) ApproveExpense(ctx context.Context, expenseID int, app
 	return r0
 }
 
-// CreateExpense provides a mock function with given fields: ctx, userID, amountIDR, description, receiptURL
-func (_m *ExpenseUseCase) CreateExpense(ctx context.Context, userID int, amountIDR int, description string, receiptURL string) (*domain.Expense, error) {
-	ret := _m.Called(ctx, userID, amountIDR, description, receiptURL)
+// CancelExpense provides a mock function with given fields: ctx, expenseID, userID
+func (_m *ExpenseUseCase) CancelExpense(ctx context.Context, expenseID int, userID int) (*domain.Expense, error) {
+	ret := _m.Called(ctx, expenseID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelExpense")
+	}
+
+	var r0 *domain.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*domain.Expense, error)); ok {
+		return rf(ctx, expenseID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *domain.Expense); ok {
+		r0 = rf(ctx, expenseID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, expenseID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckReportLine provides a mock function with given fields: ctx, _a1
+func (_m *ExpenseUseCase) CheckReportLine(ctx context.Context, _a1 *domain.Expense) (bool, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckReportLine")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense) (bool, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense) bool); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Expense) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateExpense provides a mock function with given fields: ctx, _a1, draft
+func (_m *ExpenseUseCase) CreateExpense(ctx context.Context, _a1 *domain.Expense, draft bool) (*domain.Expense, error) {
+	ret := _m.Called(ctx, _a1, draft)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateExpense")
@@ -43,19 +101,19 @@ func (_m *ExpenseUseCase) CreateExpense(ctx context.Context, userID int, amountI
 
 	var r0 *domain.Expense
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string) (*domain.Expense, error)); ok {
-		return rf(ctx, userID, amountIDR, description, receiptURL)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense, bool) (*domain.Expense, error)); ok {
+		return rf(ctx, _a1, draft)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string) *domain.Expense); ok {
-		r0 = rf(ctx, userID, amountIDR, description, receiptURL)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense, bool) *domain.Expense); ok {
+		r0 = rf(ctx, _a1, draft)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Expense)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, string) error); ok {
-		r1 = rf(ctx, userID, amountIDR, description, receiptURL)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Expense, bool) error); ok {
+		r1 = rf(ctx, _a1, draft)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -93,9 +151,39 @@ func (_m *ExpenseUseCase) GetExpenseByID(ctx context.Context, id int, userID int
 	return r0, r1
 }
 
-// GetPendingApproval provides a mock function with given fields: ctx
-func (_m *ExpenseUseCase) GetPendingApproval(ctx context.Context) ([]*domain.Expense, error) {
-	ret := _m.Called(ctx)
+// GetExpenseHistory provides a mock function with given fields: ctx, expenseID, userID, role
+func (_m *ExpenseUseCase) GetExpenseHistory(ctx context.Context, expenseID int, userID int, role domain.Role) ([]*domain.StatusHistory, error) {
+	ret := _m.Called(ctx, expenseID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpenseHistory")
+	}
+
+	var r0 []*domain.StatusHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) ([]*domain.StatusHistory, error)); ok {
+		return rf(ctx, expenseID, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) []*domain.StatusHistory); ok {
+		r0 = rf(ctx, expenseID, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.StatusHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, domain.Role) error); ok {
+		r1 = rf(ctx, expenseID, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingApproval provides a mock function with given fields: ctx, approverID
+func (_m *ExpenseUseCase) GetPendingApproval(ctx context.Context, approverID int) ([]*domain.Expense, error) {
+	ret := _m.Called(ctx, approverID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPendingApproval")
@@ -103,19 +191,19 @@ func (_m *ExpenseUseCase) GetPendingApproval(ctx context.Context) ([]*domain.Exp
 
 	var r0 []*domain.Expense
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Expense, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Expense, error)); ok {
+		return rf(ctx, approverID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Expense); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Expense); ok {
+		r0 = rf(ctx, approverID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Expense)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, approverID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -153,6 +241,24 @@ func (_m *ExpenseUseCase) GetUserExpenses(ctx context.Context, userID int, statu
 	return r0, r1
 }
 
+// RecordReportLine provides a mock function with given fields: ctx, _a1
+func (_m *ExpenseUseCase) RecordReportLine(ctx context.Context, _a1 *domain.Expense) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordReportLine")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RejectExpense provides a mock function with given fields: ctx, expenseID, approverID, notes
 func (_m *ExpenseUseCase) RejectExpense(ctx context.Context, expenseID int, approverID int, notes string) error {
 	ret := _m.Called(ctx, expenseID, approverID, notes)
@@ -171,6 +277,114 @@ func (_m *ExpenseUseCase) RejectExpense(ctx context.Context, expenseID int, appr
 	return r0
 }
 
+// RequestChanges provides a mock function with given fields: ctx, expenseID, approverID, notes
+func (_m *ExpenseUseCase) RequestChanges(ctx context.Context, expenseID int, approverID int, notes string) error {
+	ret := _m.Called(ctx, expenseID, approverID, notes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestChanges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) error); ok {
+		r0 = rf(ctx, expenseID, approverID, notes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResubmitExpense provides a mock function with given fields: ctx, expenseID, userID, changes
+func (_m *ExpenseUseCase) ResubmitExpense(ctx context.Context, expenseID int, userID int, changes domain.ExpenseChanges) (*domain.Expense, error) {
+	ret := _m.Called(ctx, expenseID, userID, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResubmitExpense")
+	}
+
+	var r0 *domain.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.ExpenseChanges) (*domain.Expense, error)); ok {
+		return rf(ctx, expenseID, userID, changes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.ExpenseChanges) *domain.Expense); ok {
+		r0 = rf(ctx, expenseID, userID, changes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, domain.ExpenseChanges) error); ok {
+		r1 = rf(ctx, expenseID, userID, changes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitExpense provides a mock function with given fields: ctx, expenseID, userID
+func (_m *ExpenseUseCase) SubmitExpense(ctx context.Context, expenseID int, userID int) (*domain.Expense, error) {
+	ret := _m.Called(ctx, expenseID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitExpense")
+	}
+
+	var r0 *domain.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*domain.Expense, error)); ok {
+		return rf(ctx, expenseID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *domain.Expense); ok {
+		r0 = rf(ctx, expenseID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, expenseID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateExpense provides a mock function with given fields: ctx, expenseID, userID, changes
+func (_m *ExpenseUseCase) UpdateExpense(ctx context.Context, expenseID int, userID int, changes domain.ExpenseChanges) (*domain.Expense, error) {
+	ret := _m.Called(ctx, expenseID, userID, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateExpense")
+	}
+
+	var r0 *domain.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.ExpenseChanges) (*domain.Expense, error)); ok {
+		return rf(ctx, expenseID, userID, changes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.ExpenseChanges) *domain.Expense); ok {
+		r0 = rf(ctx, expenseID, userID, changes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, domain.ExpenseChanges) error); ok {
+		r1 = rf(ctx, expenseID, userID, changes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewExpenseUseCase creates a new instance of ExpenseUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewExpenseUseCase(t interface {
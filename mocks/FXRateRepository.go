@@ -0,0 +1,80 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// FXRateRepository is an autogenerated mock type for the FXRateRepository type
+type FXRateRepository struct {
+	mock.Mock
+}
+
+// FindRate provides a mock function with given fields: ctx, currency, on
+func (_m *FXRateRepository) FindRate(ctx context.Context, currency string, on time.Time) (*domain.FXRate, error) {
+	ret := _m.Called(ctx, currency, on)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRate")
+	}
+
+	var r0 *domain.FXRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*domain.FXRate, error)); ok {
+		return rf(ctx, currency, on)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *domain.FXRate); ok {
+		r0 = rf(ctx, currency, on)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.FXRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, currency, on)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: ctx, rates
+func (_m *FXRateRepository) Upsert(ctx context.Context, rates []*domain.FXRate) error {
+	ret := _m.Called(ctx, rates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.FXRate) error); ok {
+		r0 = rf(ctx, rates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewFXRateRepository creates a new instance of FXRateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFXRateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FXRateRepository {
+	mock := &FXRateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HistoryRepository is an autogenerated mock type for the HistoryRepository type
+type HistoryRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, entry
+func (_m *HistoryRepository) Create(ctx context.Context, entry *domain.StatusHistory) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.StatusHistory) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByExpenseID provides a mock function with given fields: ctx, expenseID
+func (_m *HistoryRepository) FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.StatusHistory, error) {
+	ret := _m.Called(ctx, expenseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByExpenseID")
+	}
+
+	var r0 []*domain.StatusHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.StatusHistory, error)); ok {
+		return rf(ctx, expenseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.StatusHistory); ok {
+		r0 = rf(ctx, expenseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.StatusHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, expenseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewHistoryRepository creates a new instance of HistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHistoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HistoryRepository {
+	mock := &HistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
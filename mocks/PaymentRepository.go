@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PaymentRepository is an autogenerated mock type for the PaymentRepository type
+type PaymentRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, _a1
+func (_m *PaymentRepository) Create(ctx context.Context, _a1 *domain.Payment) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Payment) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindBySubject provides a mock function with given fields: ctx, kind, subjectID
+func (_m *PaymentRepository) FindBySubject(ctx context.Context, kind domain.PaymentKind, subjectID int) (*domain.Payment, error) {
+	ret := _m.Called(ctx, kind, subjectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBySubject")
+	}
+
+	var r0 *domain.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaymentKind, int) (*domain.Payment, error)); ok {
+		return rf(ctx, kind, subjectID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaymentKind, int) *domain.Payment); ok {
+		r0 = rf(ctx, kind, subjectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PaymentKind, int) error); ok {
+		r1 = rf(ctx, kind, subjectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, _a1
+func (_m *PaymentRepository) Update(ctx context.Context, _a1 *domain.Payment) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Payment) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPaymentRepository creates a new instance of PaymentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentRepository {
+	mock := &PaymentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
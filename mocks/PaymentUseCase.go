@@ -0,0 +1,119 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PaymentUseCase is an autogenerated mock type for the PaymentUseCase type
+type PaymentUseCase struct {
+	mock.Mock
+}
+
+// AbandonDeadLetter provides a mock function with given fields: ctx, kind, id, userID, reason
+func (_m *PaymentUseCase) AbandonDeadLetter(ctx context.Context, kind domain.PaymentKind, id int, userID int, reason string) (*domain.DeadLetter, error) {
+	ret := _m.Called(ctx, kind, id, userID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AbandonDeadLetter")
+	}
+
+	var r0 *domain.DeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaymentKind, int, int, string) (*domain.DeadLetter, error)); ok {
+		return rf(ctx, kind, id, userID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaymentKind, int, int, string) *domain.DeadLetter); ok {
+		r0 = rf(ctx, kind, id, userID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PaymentKind, int, int, string) error); ok {
+		r1 = rf(ctx, kind, id, userID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeadLetters provides a mock function with given fields: ctx
+func (_m *PaymentUseCase) GetDeadLetters(ctx context.Context) ([]*domain.DeadLetter, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadLetters")
+	}
+
+	var r0 []*domain.DeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.DeadLetter, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.DeadLetter); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequeueDeadLetter provides a mock function with given fields: ctx, kind, id, userID
+func (_m *PaymentUseCase) RequeueDeadLetter(ctx context.Context, kind domain.PaymentKind, id int, userID int) (*domain.DeadLetter, error) {
+	ret := _m.Called(ctx, kind, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueDeadLetter")
+	}
+
+	var r0 *domain.DeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaymentKind, int, int) (*domain.DeadLetter, error)); ok {
+		return rf(ctx, kind, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaymentKind, int, int) *domain.DeadLetter); ok {
+		r0 = rf(ctx, kind, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PaymentKind, int, int) error); ok {
+		r1 = rf(ctx, kind, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPaymentUseCase creates a new instance of PaymentUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentUseCase {
+	mock := &PaymentUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
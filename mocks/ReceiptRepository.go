@@ -0,0 +1,138 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReceiptRepository is an autogenerated mock type for the ReceiptRepository type
+type ReceiptRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, _a1
+func (_m *ReceiptRepository) Create(ctx context.Context, _a1 *domain.Receipt) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Receipt) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByExpenseID provides a mock function with given fields: ctx, expenseID
+func (_m *ReceiptRepository) FindByExpenseID(ctx context.Context, expenseID int) ([]*domain.Receipt, error) {
+	ret := _m.Called(ctx, expenseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByExpenseID")
+	}
+
+	var r0 []*domain.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Receipt, error)); ok {
+		return rf(ctx, expenseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Receipt); ok {
+		r0 = rf(ctx, expenseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, expenseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *ReceiptRepository) FindByID(ctx context.Context, id int) (*domain.Receipt, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.Receipt, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.Receipt); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindBySHA256 provides a mock function with given fields: ctx, hashes, excludeExpenseID
+func (_m *ReceiptRepository) FindBySHA256(ctx context.Context, hashes []string, excludeExpenseID int) ([]*domain.Receipt, error) {
+	ret := _m.Called(ctx, hashes, excludeExpenseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBySHA256")
+	}
+
+	var r0 []*domain.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int) ([]*domain.Receipt, error)); ok {
+		return rf(ctx, hashes, excludeExpenseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int) []*domain.Receipt); ok {
+		r0 = rf(ctx, hashes, excludeExpenseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, int) error); ok {
+		r1 = rf(ctx, hashes, excludeExpenseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReceiptRepository creates a new instance of ReceiptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptRepository {
+	mock := &ReceiptRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
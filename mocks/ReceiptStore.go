@@ -0,0 +1,76 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ReceiptStore is an autogenerated mock type for the ReceiptStore type
+type ReceiptStore struct {
+	mock.Mock
+}
+
+// Put provides a mock function with given fields: ctx, key, data, contentType
+func (_m *ReceiptStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	ret := _m.Called(ctx, key, data, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, string) error); ok {
+		r0 = rf(ctx, key, data, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SignedURL provides a mock function with given fields: ctx, key, ttl
+func (_m *ReceiptStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	ret := _m.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignedURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (string, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) string); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReceiptStore creates a new instance of ReceiptStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptStore {
+	mock := &ReceiptStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
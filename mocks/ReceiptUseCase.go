@@ -0,0 +1,122 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	io "io"
+)
+
+// ReceiptUseCase is an autogenerated mock type for the ReceiptUseCase type
+type ReceiptUseCase struct {
+	mock.Mock
+}
+
+// GetReceipt provides a mock function with given fields: ctx, receiptID, userID, role
+func (_m *ReceiptUseCase) GetReceipt(ctx context.Context, receiptID int, userID int, role domain.Role) (*domain.Receipt, error) {
+	ret := _m.Called(ctx, receiptID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceipt")
+	}
+
+	var r0 *domain.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) (*domain.Receipt, error)); ok {
+		return rf(ctx, receiptID, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) *domain.Receipt); ok {
+		r0 = rf(ctx, receiptID, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, domain.Role) error); ok {
+		r1 = rf(ctx, receiptID, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReceipts provides a mock function with given fields: ctx, expenseID, userID, role
+func (_m *ReceiptUseCase) ListReceipts(ctx context.Context, expenseID int, userID int, role domain.Role) ([]*domain.Receipt, error) {
+	ret := _m.Called(ctx, expenseID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReceipts")
+	}
+
+	var r0 []*domain.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) ([]*domain.Receipt, error)); ok {
+		return rf(ctx, expenseID, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) []*domain.Receipt); ok {
+		r0 = rf(ctx, expenseID, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, domain.Role) error); ok {
+		r1 = rf(ctx, expenseID, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadReceipt provides a mock function with given fields: ctx, expenseID, userID, fileName, file
+func (_m *ReceiptUseCase) UploadReceipt(ctx context.Context, expenseID int, userID int, fileName string, file io.Reader) (*domain.Receipt, error) {
+	ret := _m.Called(ctx, expenseID, userID, fileName, file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadReceipt")
+	}
+
+	var r0 *domain.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, io.Reader) (*domain.Receipt, error)); ok {
+		return rf(ctx, expenseID, userID, fileName, file)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, io.Reader) *domain.Receipt); ok {
+		r0 = rf(ctx, expenseID, userID, fileName, file)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, io.Reader) error); ok {
+		r1 = rf(ctx, expenseID, userID, fileName, file)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReceiptUseCase creates a new instance of ReceiptUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptUseCase {
+	mock := &ReceiptUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
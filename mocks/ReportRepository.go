@@ -0,0 +1,308 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ReportRepository is an autogenerated mock type for the ReportRepository type
+type ReportRepository struct {
+	mock.Mock
+}
+
+// AddLine provides a mock function with given fields: ctx, reportID, expenseID, userID
+func (_m *ReportRepository) AddLine(ctx context.Context, reportID int, expenseID int, userID int) error {
+	ret := _m.Called(ctx, reportID, expenseID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLine")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) error); ok {
+		r0 = rf(ctx, reportID, expenseID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimForPayment provides a mock function with given fields: ctx, limit, timeout
+func (_m *ReportRepository) ClaimForPayment(ctx context.Context, limit int, timeout time.Duration) ([]*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, limit, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimForPayment")
+	}
+
+	var r0 []*domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]*domain.ExpenseReport, error)); ok {
+		return rf(ctx, limit, timeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []*domain.ExpenseReport); ok {
+		r0 = rf(ctx, limit, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, _a1
+func (_m *ReportRepository) Create(ctx context.Context, _a1 *domain.ExpenseReport) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExpenseReport) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateApproval provides a mock function with given fields: ctx, approval
+func (_m *ReportRepository) CreateApproval(ctx context.Context, approval *domain.ReportApproval) error {
+	ret := _m.Called(ctx, approval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateApproval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ReportApproval) error); ok {
+		r0 = rf(ctx, approval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindApprovals provides a mock function with given fields: ctx, reportID
+func (_m *ReportRepository) FindApprovals(ctx context.Context, reportID int) ([]*domain.ReportApproval, error) {
+	ret := _m.Called(ctx, reportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindApprovals")
+	}
+
+	var r0 []*domain.ReportApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.ReportApproval, error)); ok {
+		return rf(ctx, reportID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.ReportApproval); ok {
+		r0 = rf(ctx, reportID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ReportApproval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, reportID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *ReportRepository) FindByID(ctx context.Context, id int) (*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.ExpenseReport, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.ExpenseReport); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByStatus provides a mock function with given fields: ctx, statuses
+func (_m *ReportRepository) FindByStatus(ctx context.Context, statuses ...domain.ExpenseStatus) ([]*domain.ExpenseReport, error) {
+	_va := make([]interface{}, len(statuses))
+	for _i := range statuses {
+		_va[_i] = statuses[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByStatus")
+	}
+
+	var r0 []*domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...domain.ExpenseStatus) ([]*domain.ExpenseReport, error)); ok {
+		return rf(ctx, statuses...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...domain.ExpenseStatus) []*domain.ExpenseReport); ok {
+		r0 = rf(ctx, statuses...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...domain.ExpenseStatus) error); ok {
+		r1 = rf(ctx, statuses...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByUserID provides a mock function with given fields: ctx, userID
+func (_m *ReportRepository) FindByUserID(ctx context.Context, userID int) ([]*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUserID")
+	}
+
+	var r0 []*domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.ExpenseReport, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.ExpenseReport); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveLine provides a mock function with given fields: ctx, reportID, expenseID
+func (_m *ReportRepository) RemoveLine(ctx context.Context, reportID int, expenseID int) error {
+	ret := _m.Called(ctx, reportID, expenseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveLine")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, reportID, expenseID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, _a1
+func (_m *ReportRepository) Update(ctx context.Context, _a1 *domain.ExpenseReport) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExpenseReport) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, _a1, from
+func (_m *ReportRepository) UpdateStatus(ctx context.Context, _a1 *domain.ExpenseReport, from domain.ExpenseStatus) ([]int, error) {
+	ret := _m.Called(ctx, _a1, from)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExpenseReport, domain.ExpenseStatus) ([]int, error)); ok {
+		return rf(ctx, _a1, from)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExpenseReport, domain.ExpenseStatus) []int); ok {
+		r0 = rf(ctx, _a1, from)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.ExpenseReport, domain.ExpenseStatus) error); ok {
+		r1 = rf(ctx, _a1, from)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReportRepository creates a new instance of ReportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReportRepository {
+	mock := &ReportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,366 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReportUseCase is an autogenerated mock type for the ReportUseCase type
+type ReportUseCase struct {
+	mock.Mock
+}
+
+// AddLine provides a mock function with given fields: ctx, reportID, expenseID, userID
+func (_m *ReportUseCase) AddLine(ctx context.Context, reportID int, expenseID int, userID int) (*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, reportID, expenseID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLine")
+	}
+
+	var r0 *domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) (*domain.ExpenseReport, error)); ok {
+		return rf(ctx, reportID, expenseID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) *domain.ExpenseReport); ok {
+		r0 = rf(ctx, reportID, expenseID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, reportID, expenseID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApproveReport provides a mock function with given fields: ctx, id, approverID, notes
+func (_m *ReportUseCase) ApproveReport(ctx context.Context, id int, approverID int, notes string) error {
+	ret := _m.Called(ctx, id, approverID, notes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) error); ok {
+		r0 = rf(ctx, id, approverID, notes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CancelReport provides a mock function with given fields: ctx, id, userID
+func (_m *ReportUseCase) CancelReport(ctx context.Context, id int, userID int) (*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelReport")
+	}
+
+	var r0 *domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*domain.ExpenseReport, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *domain.ExpenseReport); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReport provides a mock function with given fields: ctx, _a1
+func (_m *ReportUseCase) CreateReport(ctx context.Context, _a1 *domain.ExpenseReport) (*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReport")
+	}
+
+	var r0 *domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExpenseReport) (*domain.ExpenseReport, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExpenseReport) *domain.ExpenseReport); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.ExpenseReport) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingApproval provides a mock function with given fields: ctx, approverID
+func (_m *ReportUseCase) GetPendingApproval(ctx context.Context, approverID int) ([]*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, approverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingApproval")
+	}
+
+	var r0 []*domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.ExpenseReport, error)); ok {
+		return rf(ctx, approverID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.ExpenseReport); ok {
+		r0 = rf(ctx, approverID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, approverID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReport provides a mock function with given fields: ctx, id, userID, role
+func (_m *ReportUseCase) GetReport(ctx context.Context, id int, userID int, role domain.Role) (*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, id, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReport")
+	}
+
+	var r0 *domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) (*domain.ExpenseReport, error)); ok {
+		return rf(ctx, id, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, domain.Role) *domain.ExpenseReport); ok {
+		r0 = rf(ctx, id, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, domain.Role) error); ok {
+		r1 = rf(ctx, id, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserReports provides a mock function with given fields: ctx, userID
+func (_m *ReportUseCase) GetUserReports(ctx context.Context, userID int) ([]*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserReports")
+	}
+
+	var r0 []*domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.ExpenseReport, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.ExpenseReport); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectLine provides a mock function with given fields: ctx, reportID, expenseID, approverID, notes
+func (_m *ReportUseCase) RejectLine(ctx context.Context, reportID int, expenseID int, approverID int, notes string) (*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, reportID, expenseID, approverID, notes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectLine")
+	}
+
+	var r0 *domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, string) (*domain.ExpenseReport, error)); ok {
+		return rf(ctx, reportID, expenseID, approverID, notes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, string) *domain.ExpenseReport); ok {
+		r0 = rf(ctx, reportID, expenseID, approverID, notes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, string) error); ok {
+		r1 = rf(ctx, reportID, expenseID, approverID, notes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectReport provides a mock function with given fields: ctx, id, approverID, notes
+func (_m *ReportUseCase) RejectReport(ctx context.Context, id int, approverID int, notes string) error {
+	ret := _m.Called(ctx, id, approverID, notes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) error); ok {
+		r0 = rf(ctx, id, approverID, notes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveLine provides a mock function with given fields: ctx, reportID, expenseID, userID
+func (_m *ReportUseCase) RemoveLine(ctx context.Context, reportID int, expenseID int, userID int) (*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, reportID, expenseID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveLine")
+	}
+
+	var r0 *domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) (*domain.ExpenseReport, error)); ok {
+		return rf(ctx, reportID, expenseID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) *domain.ExpenseReport); ok {
+		r0 = rf(ctx, reportID, expenseID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, reportID, expenseID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitReport provides a mock function with given fields: ctx, id, userID
+func (_m *ReportUseCase) SubmitReport(ctx context.Context, id int, userID int) (*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitReport")
+	}
+
+	var r0 *domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*domain.ExpenseReport, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *domain.ExpenseReport); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateReport provides a mock function with given fields: ctx, _a1
+func (_m *ReportUseCase) UpdateReport(ctx context.Context, _a1 *domain.ExpenseReport) (*domain.ExpenseReport, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReport")
+	}
+
+	var r0 *domain.ExpenseReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExpenseReport) (*domain.ExpenseReport, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExpenseReport) *domain.ExpenseReport); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpenseReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.ExpenseReport) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReportUseCase creates a new instance of ReportUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReportUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReportUseCase {
+	mock := &ReportUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
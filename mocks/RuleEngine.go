@@ -0,0 +1,60 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RuleEngine is an autogenerated mock type for the RuleEngine type
+type RuleEngine struct {
+	mock.Mock
+}
+
+// Evaluate provides a mock function with given fields: ctx, expense
+func (_m *RuleEngine) Evaluate(ctx context.Context, expense *domain.Expense) ([]*domain.RuleViolation, error) {
+	ret := _m.Called(ctx, expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Evaluate")
+	}
+
+	var r0 []*domain.RuleViolation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense) ([]*domain.RuleViolation, error)); ok {
+		return rf(ctx, expense)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense) []*domain.RuleViolation); ok {
+		r0 = rf(ctx, expense)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RuleViolation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Expense) error); ok {
+		r1 = rf(ctx, expense)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRuleEngine creates a new instance of RuleEngine. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRuleEngine(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RuleEngine {
+	mock := &RuleEngine{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,168 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RuleUseCase is an autogenerated mock type for the RuleUseCase type
+type RuleUseCase struct {
+	mock.Mock
+}
+
+// CreateRule provides a mock function with given fields: ctx, _a1
+func (_m *RuleUseCase) CreateRule(ctx context.Context, _a1 *domain.PolicyRule) (*domain.PolicyRule, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRule")
+	}
+
+	var r0 *domain.PolicyRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.PolicyRule) (*domain.PolicyRule, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.PolicyRule) *domain.PolicyRule); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PolicyRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.PolicyRule) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivateRule provides a mock function with given fields: ctx, id
+func (_m *RuleUseCase) DeactivateRule(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRule provides a mock function with given fields: ctx, id
+func (_m *RuleUseCase) GetRule(ctx context.Context, id int) (*domain.PolicyRule, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRule")
+	}
+
+	var r0 *domain.PolicyRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.PolicyRule, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.PolicyRule); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PolicyRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRules provides a mock function with given fields: ctx, includeInactive
+func (_m *RuleUseCase) ListRules(ctx context.Context, includeInactive bool) ([]*domain.PolicyRule, error) {
+	ret := _m.Called(ctx, includeInactive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRules")
+	}
+
+	var r0 []*domain.PolicyRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]*domain.PolicyRule, error)); ok {
+		return rf(ctx, includeInactive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []*domain.PolicyRule); ok {
+		r0 = rf(ctx, includeInactive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.PolicyRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, includeInactive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRule provides a mock function with given fields: ctx, _a1
+func (_m *RuleUseCase) UpdateRule(ctx context.Context, _a1 *domain.PolicyRule) (*domain.PolicyRule, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRule")
+	}
+
+	var r0 *domain.PolicyRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.PolicyRule) (*domain.PolicyRule, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.PolicyRule) *domain.PolicyRule); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PolicyRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.PolicyRule) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRuleUseCase creates a new instance of RuleUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRuleUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RuleUseCase {
+	mock := &RuleUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
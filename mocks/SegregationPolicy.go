@@ -0,0 +1,65 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SegregationPolicy is an autogenerated mock type for the SegregationPolicy type
+type SegregationPolicy struct {
+	mock.Mock
+}
+
+// CheckApprover provides a mock function with given fields: ctx, expense, level, approverID
+func (_m *SegregationPolicy) CheckApprover(ctx context.Context, expense *domain.Expense, level domain.ApprovalLevel, approverID int) error {
+	ret := _m.Called(ctx, expense, level, approverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckApprover")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Expense, domain.ApprovalLevel, int) error); ok {
+		r0 = rf(ctx, expense, level, approverID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckReportApprover provides a mock function with given fields: ctx, report, level, approverID
+func (_m *SegregationPolicy) CheckReportApprover(ctx context.Context, report *domain.ExpenseReport, level domain.ApprovalLevel, approverID int) error {
+	ret := _m.Called(ctx, report, level, approverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckReportApprover")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExpenseReport, domain.ApprovalLevel, int) error); ok {
+		r0 = rf(ctx, report, level, approverID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSegregationPolicy creates a new instance of SegregationPolicy. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSegregationPolicy(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SegregationPolicy {
+	mock := &SegregationPolicy{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
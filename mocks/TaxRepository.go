@@ -0,0 +1,110 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TaxRepository is an autogenerated mock type for the TaxRepository type
+type TaxRepository struct {
+	mock.Mock
+}
+
+// SummarizeByAllocation provides a mock function with given fields: ctx, from, to, statuses
+func (_m *TaxRepository) SummarizeByAllocation(ctx context.Context, from time.Time, to time.Time, statuses ...domain.ExpenseStatus) ([]*domain.AllocationTotal, error) {
+	_va := make([]interface{}, len(statuses))
+	for _i := range statuses {
+		_va[_i] = statuses[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, from)
+	_ca = append(_ca, to)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeByAllocation")
+	}
+
+	var r0 []*domain.AllocationTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, ...domain.ExpenseStatus) ([]*domain.AllocationTotal, error)); ok {
+		return rf(ctx, from, to, statuses...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, ...domain.ExpenseStatus) []*domain.AllocationTotal); ok {
+		r0 = rf(ctx, from, to, statuses...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AllocationTotal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, ...domain.ExpenseStatus) error); ok {
+		r1 = rf(ctx, from, to, statuses...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SummarizeByMonthAndRate provides a mock function with given fields: ctx, from, to, statuses
+func (_m *TaxRepository) SummarizeByMonthAndRate(ctx context.Context, from time.Time, to time.Time, statuses ...domain.ExpenseStatus) ([]*domain.TaxSummaryRow, error) {
+	_va := make([]interface{}, len(statuses))
+	for _i := range statuses {
+		_va[_i] = statuses[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, from)
+	_ca = append(_ca, to)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeByMonthAndRate")
+	}
+
+	var r0 []*domain.TaxSummaryRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, ...domain.ExpenseStatus) ([]*domain.TaxSummaryRow, error)); ok {
+		return rf(ctx, from, to, statuses...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, ...domain.ExpenseStatus) []*domain.TaxSummaryRow); ok {
+		r0 = rf(ctx, from, to, statuses...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.TaxSummaryRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, ...domain.ExpenseStatus) error); ok {
+		r1 = rf(ctx, from, to, statuses...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTaxRepository creates a new instance of TaxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaxRepository {
+	mock := &TaxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
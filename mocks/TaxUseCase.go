@@ -0,0 +1,62 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TaxUseCase is an autogenerated mock type for the TaxUseCase type
+type TaxUseCase struct {
+	mock.Mock
+}
+
+// GetTaxSummary provides a mock function with given fields: ctx, from, to
+func (_m *TaxUseCase) GetTaxSummary(ctx context.Context, from time.Time, to time.Time) (*domain.TaxSummary, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaxSummary")
+	}
+
+	var r0 *domain.TaxSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (*domain.TaxSummary, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) *domain.TaxSummary); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaxSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTaxUseCase creates a new instance of TaxUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaxUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaxUseCase {
+	mock := &TaxUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -74,6 +75,84 @@ func (_m *UserRepository) FindByID(ctx context.Context, id int) (*domain.User, e
 	return r0, r1
 }
 
+// FindIDsByRole provides a mock function with given fields: ctx, role
+func (_m *UserRepository) FindIDsByRole(ctx context.Context, role domain.Role) ([]int, error) {
+	ret := _m.Called(ctx, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIDsByRole")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Role) ([]int, error)); ok {
+		return rf(ctx, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Role) []int); ok {
+		r0 = rf(ctx, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Role) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindReportIDs provides a mock function with given fields: ctx, managerID, indirect
+func (_m *UserRepository) FindReportIDs(ctx context.Context, managerID int, indirect bool) ([]int, error) {
+	ret := _m.Called(ctx, managerID, indirect)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindReportIDs")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) ([]int, error)); ok {
+		return rf(ctx, managerID, indirect)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) []int); ok {
+		r0 = rf(ctx, managerID, indirect)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, bool) error); ok {
+		r1 = rf(ctx, managerID, indirect)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateManager provides a mock function with given fields: ctx, userID, managerID
+func (_m *UserRepository) UpdateManager(ctx context.Context, userID int, managerID *int) error {
+	ret := _m.Called(ctx, userID, managerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateManager")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *int) error); ok {
+		r0 = rf(ctx, userID, managerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
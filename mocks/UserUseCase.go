@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/evrintobing17/expense-management-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserUseCase is an autogenerated mock type for the UserUseCase type
+type UserUseCase struct {
+	mock.Mock
+}
+
+// AssignManager provides a mock function with given fields: ctx, userID, managerID
+func (_m *UserUseCase) AssignManager(ctx context.Context, userID int, managerID *int) (*domain.User, error) {
+	ret := _m.Called(ctx, userID, managerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignManager")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *int) (*domain.User, error)); ok {
+		return rf(ctx, userID, managerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *int) *domain.User); ok {
+		r0 = rf(ctx, userID, managerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *int) error); ok {
+		r1 = rf(ctx, userID, managerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUserUseCase creates a new instance of UserUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserUseCase {
+	mock := &UserUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PayableWorkChannel is the channel the database notifies on when an expense, expense
+// report or cash advance is approved and can be paid.
+const PayableWorkChannel = "payable_work"
+
+// Listen subscribes to a notification channel on a connection of its own and returns a
+// channel that receives a value whenever a notification arrives. Notifications that
+// arrive while the last one has not been received yet are merged into it. The returned
+// channel is also signalled after the connection is re-established, since notifications
+// sent while it was down are lost. Listening stops when ctx is done.
+func Listen(ctx context.Context, host, port, user, password, dbname, channel string) (<-chan struct{}, error) {
+	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		host, port, user, password, dbname)
+
+	listener := pq.NewListener(connStr, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Database listener on %s: %v", channel, err)
+		}
+	})
+	if err := listener.Listen(channel); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to listen on %s: %v", channel, err)
+	}
+
+	signals := make(chan struct{}, 1)
+	go func() {
+		defer listener.Close()
+		for {
+			select {
+			case <-listener.Notify:
+				select {
+				case signals <- struct{}{}:
+				default:
+				}
+			case <-time.After(90 * time.Second):
+				// Notice a connection that died without being closed.
+				go listener.Ping()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return signals, nil
+}
@@ -139,6 +139,662 @@ func getAvailableMigrations() ([]Migration, error) {
 				DROP TABLE IF EXISTS users;
 			`,
 		},
+		{
+			Version: 2,
+			Name:    "expense_status_history",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS expense_status_history (
+					id SERIAL PRIMARY KEY,
+					expense_id INTEGER NOT NULL REFERENCES expenses(id),
+					from_status VARCHAR(20),
+					to_status VARCHAR(20) NOT NULL,
+					actor_type VARCHAR(20) NOT NULL CHECK (actor_type IN ('user', 'worker')),
+					actor_id INTEGER REFERENCES users(id),
+					reason TEXT NOT NULL DEFAULT '',
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_expense_status_history_expense_id ON expense_status_history (expense_id);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS expense_status_history;
+			`,
+		},
+		{
+			Version: 3,
+			Name:    "approval_chains",
+			UpSQL: `
+				ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+				ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('employee', 'manager', 'finance_director', 'cfo'));
+
+				ALTER TABLE approvals ADD COLUMN IF NOT EXISTS level VARCHAR(30) NOT NULL DEFAULT 'manager'
+					CHECK (level IN ('manager', 'finance_director', 'cfo'));
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_approvals_expense_level ON approvals (expense_id, level);
+
+				INSERT INTO users (email, name, role, password_hash) VALUES
+				('finance@example.com', 'Finance Director', 'finance_director', '$2a$10$6uvHhDNhqrAqHiTWXSsx/emnFYDJySUHLtya7yRKVuFJfWzEViLaK'),
+				('cfo@example.com', 'Chief Financial Officer', 'cfo', '$2a$10$6uvHhDNhqrAqHiTWXSsx/emnFYDJySUHLtya7yRKVuFJfWzEViLaK')
+				ON CONFLICT (email) DO NOTHING;
+			`,
+			DownSQL: `
+				DELETE FROM users WHERE role IN ('finance_director', 'cfo');
+				DROP INDEX IF EXISTS idx_approvals_expense_level;
+				ALTER TABLE approvals DROP COLUMN IF EXISTS level;
+				ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+				ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('employee', 'manager'));
+			`,
+		},
+		{
+			Version: 4,
+			Name:    "manager_hierarchy",
+			UpSQL: `
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS manager_id INTEGER REFERENCES users(id);
+				CREATE INDEX IF NOT EXISTS idx_users_manager_id ON users (manager_id);
+
+				UPDATE users SET manager_id = (SELECT id FROM users WHERE email = 'manager@example.com')
+				WHERE email = 'employee@example.com' AND manager_id IS NULL;
+			`,
+			DownSQL: `
+				DROP INDEX IF EXISTS idx_users_manager_id;
+				ALTER TABLE users DROP COLUMN IF EXISTS manager_id;
+			`,
+		},
+		{
+			Version: 5,
+			Name:    "approval_delegations",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS approval_delegations (
+					id SERIAL PRIMARY KEY,
+					manager_id INTEGER NOT NULL REFERENCES users(id),
+					delegate_id INTEGER NOT NULL REFERENCES users(id),
+					starts_at TIMESTAMP NOT NULL,
+					ends_at TIMESTAMP NOT NULL,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					CHECK (manager_id <> delegate_id),
+					CHECK (ends_at > starts_at)
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_approval_delegations_delegate ON approval_delegations (delegate_id, starts_at, ends_at);
+
+				ALTER TABLE approvals ADD COLUMN IF NOT EXISTS on_behalf_of_id INTEGER REFERENCES users(id);
+			`,
+			DownSQL: `
+				ALTER TABLE approvals DROP COLUMN IF EXISTS on_behalf_of_id;
+				DROP TABLE IF EXISTS approval_delegations;
+			`,
+		},
+		{
+			Version: 6,
+			Name:    "approval_sla_escalations",
+			UpSQL: `
+				ALTER TABLE expense_status_history ADD COLUMN IF NOT EXISTS event VARCHAR(20) NOT NULL DEFAULT 'status_change'
+					CHECK (event IN ('status_change', 'sla_reminder', 'sla_escalation'));
+
+				CREATE TABLE IF NOT EXISTS approval_escalations (
+					id SERIAL PRIMARY KEY,
+					expense_id INTEGER NOT NULL REFERENCES expenses(id),
+					level VARCHAR(30) NOT NULL CHECK (level IN ('manager', 'finance_director', 'cfo')),
+					escalated_to_id INTEGER NOT NULL REFERENCES users(id),
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (expense_id, level, escalated_to_id)
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_approval_escalations_escalated_to ON approval_escalations (escalated_to_id);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS approval_escalations;
+				ALTER TABLE expense_status_history DROP COLUMN IF EXISTS event;
+			`,
+		},
+		{
+			Version: 7,
+			Name:    "approval_change_requests",
+			UpSQL: `
+				ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_status_check;
+				ALTER TABLE expenses ADD CONSTRAINT expenses_status_check CHECK (status IN ('pending', 'awaiting_approval', 'changes_requested', 'approved', 'rejected', 'auto_approved', 'processing', 'completed', 'failed'));
+
+				ALTER TABLE approvals DROP CONSTRAINT IF EXISTS approvals_status_check;
+				ALTER TABLE approvals ADD CONSTRAINT approvals_status_check CHECK (status IN ('approved', 'rejected', 'changes_requested'));
+
+				ALTER TABLE approvals ADD COLUMN IF NOT EXISTS round INTEGER NOT NULL DEFAULT 1;
+				DROP INDEX IF EXISTS idx_approvals_expense_level;
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_approvals_expense_round_level ON approvals (expense_id, round, level);
+			`,
+			DownSQL: `
+				DROP INDEX IF EXISTS idx_approvals_expense_round_level;
+				DELETE FROM approvals WHERE round > 1 OR status = 'changes_requested';
+				ALTER TABLE approvals DROP COLUMN IF EXISTS round;
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_approvals_expense_level ON approvals (expense_id, level);
+
+				ALTER TABLE approvals DROP CONSTRAINT IF EXISTS approvals_status_check;
+				ALTER TABLE approvals ADD CONSTRAINT approvals_status_check CHECK (status IN ('approved', 'rejected'));
+
+				UPDATE expenses SET status = 'awaiting_approval' WHERE status = 'changes_requested';
+				ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_status_check;
+				ALTER TABLE expenses ADD CONSTRAINT expenses_status_check CHECK (status IN ('pending', 'awaiting_approval', 'approved', 'rejected', 'auto_approved', 'processing', 'completed', 'failed'));
+			`,
+		},
+		{
+			Version: 8,
+			Name:    "expense_drafts_and_cancellation",
+			UpSQL: `
+				ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_status_check;
+				ALTER TABLE expenses ADD CONSTRAINT expenses_status_check CHECK (status IN ('draft', 'pending', 'awaiting_approval', 'changes_requested', 'approved', 'rejected', 'auto_approved', 'processing', 'completed', 'failed', 'cancelled'));
+			`,
+			DownSQL: `
+				DELETE FROM expense_status_history WHERE expense_id IN (SELECT id FROM expenses WHERE status = 'draft');
+				DELETE FROM expenses WHERE status = 'draft';
+				UPDATE expenses SET status = 'rejected' WHERE status = 'cancelled';
+				ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_status_check;
+				ALTER TABLE expenses ADD CONSTRAINT expenses_status_check CHECK (status IN ('pending', 'awaiting_approval', 'changes_requested', 'approved', 'rejected', 'auto_approved', 'processing', 'completed', 'failed'));
+			`,
+		},
+		{
+			Version: 9,
+			Name:    "expense_categories",
+			UpSQL: `
+				ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+				ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('employee', 'manager', 'finance_director', 'cfo', 'admin'));
+
+				INSERT INTO users (email, name, role, password_hash) VALUES
+				('admin@example.com', 'Admin User', 'admin', '$2a$10$6uvHhDNhqrAqHiTWXSsx/emnFYDJySUHLtya7yRKVuFJfWzEViLaK')
+				ON CONFLICT (email) DO NOTHING;
+
+				CREATE TABLE IF NOT EXISTS expense_categories (
+					id SERIAL PRIMARY KEY,
+					name VARCHAR(100) NOT NULL UNIQUE,
+					min_amount_idr INTEGER NOT NULL CHECK (min_amount_idr > 0),
+					max_amount_idr INTEGER NOT NULL,
+					receipt_required BOOLEAN NOT NULL DEFAULT FALSE,
+					active BOOLEAN NOT NULL DEFAULT TRUE,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					CHECK (max_amount_idr >= min_amount_idr)
+				);
+
+				-- General keeps the old global limits so that existing expenses stay valid
+				INSERT INTO expense_categories (name, min_amount_idr, max_amount_idr, receipt_required) VALUES
+				('General', 10000, 50000000, FALSE),
+				('Travel', 10000, 50000000, TRUE),
+				('Lodging', 100000, 25000000, TRUE),
+				('Meals', 10000, 2000000, FALSE),
+				('Software', 10000, 25000000, TRUE)
+				ON CONFLICT (name) DO NOTHING;
+
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS category_id INTEGER REFERENCES expense_categories(id);
+				UPDATE expenses SET category_id = (SELECT id FROM expense_categories WHERE name = 'General') WHERE category_id IS NULL;
+				ALTER TABLE expenses ALTER COLUMN category_id SET NOT NULL;
+
+				ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_amount_idr_check;
+				ALTER TABLE expenses ADD CONSTRAINT expenses_amount_idr_check CHECK (amount_idr > 0);
+			`,
+			DownSQL: `
+				ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_amount_idr_check;
+				ALTER TABLE expenses ADD CONSTRAINT expenses_amount_idr_check CHECK (amount_idr >= 10000 AND amount_idr <= 50000000) NOT VALID;
+
+				ALTER TABLE expenses DROP COLUMN IF EXISTS category_id;
+				DROP TABLE IF EXISTS expense_categories;
+
+				DELETE FROM users WHERE role = 'admin';
+				ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+				ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('employee', 'manager', 'finance_director', 'cfo'));
+			`,
+		},
+		{
+			Version: 10,
+			Name:    "policy_rules",
+			UpSQL: `
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS merchant VARCHAR(255) NOT NULL DEFAULT '';
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS incurred_on DATE;
+				UPDATE expenses SET incurred_on = submitted_at::date WHERE incurred_on IS NULL;
+				ALTER TABLE expenses ALTER COLUMN incurred_on SET DEFAULT CURRENT_DATE;
+				ALTER TABLE expenses ALTER COLUMN incurred_on SET NOT NULL;
+				CREATE INDEX IF NOT EXISTS idx_expenses_user_incurred_on ON expenses(user_id, incurred_on);
+
+				CREATE TABLE IF NOT EXISTS policy_rules (
+					id SERIAL PRIMARY KEY,
+					name VARCHAR(100) NOT NULL,
+					rule_type VARCHAR(50) NOT NULL CHECK (rule_type IN ('max_amount', 'weekend_date', 'missing_receipt', 'duplicate_merchant_day', 'per_diem_cap')),
+					category_id INTEGER REFERENCES expense_categories(id),
+					threshold_idr INTEGER NOT NULL DEFAULT 0 CHECK (threshold_idr >= 0),
+					action VARCHAR(20) NOT NULL CHECK (action IN ('block', 'warn', 'require_approval')),
+					active BOOLEAN NOT NULL DEFAULT TRUE,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				INSERT INTO policy_rules (name, rule_type, action) VALUES
+				('Weekend expenses', 'weekend_date', 'warn'),
+				('Same merchant twice in a day', 'duplicate_merchant_day', 'require_approval');
+
+				CREATE TABLE IF NOT EXISTS expense_policy_violations (
+					id SERIAL PRIMARY KEY,
+					expense_id INTEGER NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+					rule_id INTEGER NOT NULL REFERENCES policy_rules(id),
+					rule_name VARCHAR(100) NOT NULL,
+					action VARCHAR(20) NOT NULL,
+					message TEXT NOT NULL,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_expense_policy_violations_expense_id ON expense_policy_violations(expense_id);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS expense_policy_violations;
+				DROP TABLE IF EXISTS policy_rules;
+				DROP INDEX IF EXISTS idx_expenses_user_incurred_on;
+				ALTER TABLE expenses DROP COLUMN IF EXISTS incurred_on;
+				ALTER TABLE expenses DROP COLUMN IF EXISTS merchant;
+			`,
+		},
+		{
+			Version: 11,
+			Name:    "receipts",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS receipts (
+					id SERIAL PRIMARY KEY,
+					expense_id INTEGER NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+					storage_key VARCHAR(255) NOT NULL,
+					file_name VARCHAR(255) NOT NULL,
+					content_type VARCHAR(50) NOT NULL CHECK (content_type IN ('image/jpeg', 'image/png', 'application/pdf')),
+					size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+					sha256 CHAR(64) NOT NULL,
+					uploaded_by INTEGER NOT NULL REFERENCES users(id),
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_receipts_expense_id ON receipts(expense_id);
+				CREATE INDEX IF NOT EXISTS idx_receipts_sha256 ON receipts(sha256);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS receipts;
+			`,
+		},
+		{
+			Version: 12,
+			Name:    "expense_duplicates",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS expense_duplicates (
+					id SERIAL PRIMARY KEY,
+					expense_id INTEGER NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+					duplicate_of_id INTEGER NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+					reason VARCHAR(30) NOT NULL CHECK (reason IN ('same_receipt', 'similar_expense')),
+					detail TEXT NOT NULL,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_expense_duplicates_expense_id ON expense_duplicates(expense_id);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS expense_duplicates;
+			`,
+		},
+		{
+			Version: 13,
+			Name:    "expense_fraud_flags",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS expense_fraud_flags (
+					id SERIAL PRIMARY KEY,
+					expense_id INTEGER NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+					kind VARCHAR(30) NOT NULL CHECK (kind IN ('threshold_split')),
+					related_expense_ids INTEGER[] NOT NULL DEFAULT '{}',
+					explanation TEXT NOT NULL,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_expense_fraud_flags_expense_id ON expense_fraud_flags(expense_id);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS expense_fraud_flags;
+			`,
+		},
+		{
+			Version: 14,
+			Name:    "multi_currency_expenses",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS fx_rates (
+					currency CHAR(3) NOT NULL,
+					rate_date DATE NOT NULL,
+					rate_idr NUMERIC(20,8) NOT NULL CHECK (rate_idr > 0),
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					PRIMARY KEY (currency, rate_date)
+				);
+
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS original_amount NUMERIC(15,2);
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS fx_rate NUMERIC(20,8) NOT NULL DEFAULT 1;
+				UPDATE expenses SET original_amount = amount_idr WHERE original_amount IS NULL;
+				ALTER TABLE expenses ALTER COLUMN original_amount SET NOT NULL;
+			`,
+			DownSQL: `
+				ALTER TABLE expenses DROP COLUMN IF EXISTS fx_rate;
+				ALTER TABLE expenses DROP COLUMN IF EXISTS original_amount;
+				ALTER TABLE expenses DROP COLUMN IF EXISTS currency;
+				DROP TABLE IF EXISTS fx_rates;
+			`,
+		},
+		{
+			Version: 15,
+			Name:    "expense_tax_breakdown",
+			UpSQL: `
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS net_amount_idr INTEGER;
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS tax_amount_idr INTEGER NOT NULL DEFAULT 0 CHECK (tax_amount_idr >= 0);
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS tax_rate_percent NUMERIC(5,2) NOT NULL DEFAULT 0
+					CHECK (tax_rate_percent BETWEEN 0 AND 100);
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS efaktur_number VARCHAR(25) NOT NULL DEFAULT '';
+				UPDATE expenses SET net_amount_idr = amount_idr WHERE net_amount_idr IS NULL;
+				ALTER TABLE expenses ALTER COLUMN net_amount_idr SET NOT NULL;
+				ALTER TABLE expenses ADD CONSTRAINT expenses_tax_breakdown_check CHECK (net_amount_idr + tax_amount_idr = amount_idr);
+
+				CREATE INDEX IF NOT EXISTS idx_expenses_incurred_on ON expenses (incurred_on);
+			`,
+			DownSQL: `
+				DROP INDEX IF EXISTS idx_expenses_incurred_on;
+				ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_tax_breakdown_check;
+				ALTER TABLE expenses DROP COLUMN IF EXISTS efaktur_number;
+				ALTER TABLE expenses DROP COLUMN IF EXISTS tax_rate_percent;
+				ALTER TABLE expenses DROP COLUMN IF EXISTS tax_amount_idr;
+				ALTER TABLE expenses DROP COLUMN IF EXISTS net_amount_idr;
+			`,
+		},
+		{
+			Version: 16,
+			Name:    "expense_reports",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS expense_reports (
+					id SERIAL PRIMARY KEY,
+					user_id INTEGER NOT NULL REFERENCES users(id),
+					title VARCHAR(200) NOT NULL,
+					purpose TEXT NOT NULL DEFAULT '',
+					trip_start DATE NOT NULL,
+					trip_end DATE NOT NULL CHECK (trip_end >= trip_start),
+					status VARCHAR(20) NOT NULL DEFAULT 'draft'
+						CHECK (status IN ('draft', 'awaiting_approval', 'approved', 'rejected', 'auto_approved', 'processing', 'completed', 'failed', 'cancelled')),
+					total_idr INTEGER NOT NULL DEFAULT 0,
+					requires_approval BOOLEAN NOT NULL DEFAULT FALSE,
+					auto_approved BOOLEAN NOT NULL DEFAULT FALSE,
+					submitted_at TIMESTAMP,
+					processed_at TIMESTAMP,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_expense_reports_user_id ON expense_reports (user_id);
+				CREATE INDEX IF NOT EXISTS idx_expense_reports_status ON expense_reports (status);
+
+				CREATE TABLE IF NOT EXISTS expense_report_approvals (
+					id SERIAL PRIMARY KEY,
+					report_id INTEGER NOT NULL REFERENCES expense_reports(id) ON DELETE CASCADE,
+					approver_id INTEGER NOT NULL REFERENCES users(id),
+					on_behalf_of_id INTEGER REFERENCES users(id),
+					level VARCHAR(30) NOT NULL CHECK (level IN ('manager', 'finance_director', 'cfo')),
+					status VARCHAR(20) NOT NULL CHECK (status IN ('approved', 'rejected')),
+					notes TEXT NOT NULL DEFAULT '',
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (report_id, level)
+				);
+
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS report_id INTEGER REFERENCES expense_reports(id) ON DELETE SET NULL;
+				CREATE INDEX IF NOT EXISTS idx_expenses_report_id ON expenses (report_id);
+			`,
+			DownSQL: `
+				DROP INDEX IF EXISTS idx_expenses_report_id;
+				ALTER TABLE expenses DROP COLUMN IF EXISTS report_id;
+				DROP TABLE IF EXISTS expense_report_approvals;
+				DROP TABLE IF EXISTS expense_reports;
+			`,
+		},
+		{
+			Version: 17,
+			Name:    "cost_center_allocations",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS cost_centers (
+					id SERIAL PRIMARY KEY,
+					code VARCHAR(30) NOT NULL UNIQUE,
+					name VARCHAR(100) NOT NULL,
+					active BOOLEAN NOT NULL DEFAULT TRUE,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE TABLE IF NOT EXISTS projects (
+					id SERIAL PRIMARY KEY,
+					code VARCHAR(30) NOT NULL UNIQUE,
+					name VARCHAR(100) NOT NULL,
+					client_name VARCHAR(100) NOT NULL DEFAULT '',
+					active BOOLEAN NOT NULL DEFAULT TRUE,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE TABLE IF NOT EXISTS expense_allocations (
+					id SERIAL PRIMARY KEY,
+					expense_id INTEGER NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+					cost_center_code VARCHAR(30) NOT NULL,
+					project_code VARCHAR(30) NOT NULL DEFAULT '',
+					percent NUMERIC(7,4) NOT NULL DEFAULT 0 CHECK (percent BETWEEN 0 AND 100),
+					amount_idr INTEGER NOT NULL CHECK (amount_idr >= 0),
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_expense_allocations_expense_id ON expense_allocations (expense_id);
+				CREATE INDEX IF NOT EXISTS idx_expense_allocations_codes ON expense_allocations (cost_center_code, project_code);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS expense_allocations;
+				DROP TABLE IF EXISTS projects;
+				DROP TABLE IF EXISTS cost_centers;
+			`,
+		},
+		{
+			Version: 18,
+			Name:    "budgets",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS budgets (
+					id SERIAL PRIMARY KEY,
+					scope VARCHAR(20) NOT NULL CHECK (scope IN ('cost_center', 'user')),
+					cost_center_code VARCHAR(30) NOT NULL DEFAULT '',
+					user_id INTEGER REFERENCES users(id),
+					period_start DATE NOT NULL,
+					period_end DATE NOT NULL CHECK (period_end >= period_start),
+					amount_idr BIGINT NOT NULL CHECK (amount_idr > 0),
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					CHECK ((scope = 'cost_center' AND cost_center_code <> '' AND user_id IS NULL)
+						OR (scope = 'user' AND cost_center_code = '' AND user_id IS NOT NULL))
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_budgets_cost_center_period ON budgets (cost_center_code, period_start, period_end);
+				CREATE INDEX IF NOT EXISTS idx_budgets_user_period ON budgets (user_id, period_start, period_end);
+
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS requires_finance_approval BOOLEAN NOT NULL DEFAULT FALSE;
+			`,
+			DownSQL: `
+				ALTER TABLE expenses DROP COLUMN IF EXISTS requires_finance_approval;
+				DROP TABLE IF EXISTS budgets;
+			`,
+		},
+		{
+			Version: 19,
+			Name:    "cash_advances",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS cash_advances (
+					id SERIAL PRIMARY KEY,
+					user_id INTEGER NOT NULL REFERENCES users(id),
+					purpose TEXT NOT NULL,
+					amount_idr BIGINT NOT NULL CHECK (amount_idr > 0),
+					status VARCHAR(30) NOT NULL CHECK (status IN ('requested', 'approved', 'rejected', 'cancelled', 'disbursing', 'outstanding', 'reimbursement_due', 'reimbursing', 'repayment_due', 'settled', 'failed')),
+					approver_id INTEGER REFERENCES users(id),
+					decision_notes TEXT NOT NULL DEFAULT '',
+					expenses_idr BIGINT NOT NULL DEFAULT 0,
+					reimbursement_idr BIGINT NOT NULL DEFAULT 0,
+					repayment_idr BIGINT NOT NULL DEFAULT 0,
+					decided_at TIMESTAMP,
+					paid_at TIMESTAMP,
+					settled_at TIMESTAMP,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_cash_advances_user_id ON cash_advances (user_id);
+				CREATE INDEX IF NOT EXISTS idx_cash_advances_status ON cash_advances (status);
+
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS advance_id INTEGER REFERENCES cash_advances(id);
+				CREATE INDEX IF NOT EXISTS idx_expenses_advance_id ON expenses (advance_id);
+			`,
+			DownSQL: `
+				DROP INDEX IF EXISTS idx_expenses_advance_id;
+				ALTER TABLE expenses DROP COLUMN IF EXISTS advance_id;
+				DROP TABLE IF EXISTS cash_advances;
+			`,
+		},
+		{
+			Version: 20,
+			Name:    "payments",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS payments (
+					id SERIAL PRIMARY KEY,
+					kind VARCHAR(30) NOT NULL CHECK (kind IN ('expense', 'expense_report', 'cash_advance', 'cash_advance_reimbursement')),
+					subject_id INTEGER NOT NULL,
+					external_id VARCHAR(64) NOT NULL UNIQUE,
+					amount_idr BIGINT NOT NULL,
+					status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'succeeded', 'failed')),
+					provider_id VARCHAR(100) NOT NULL DEFAULT '',
+					raw_response TEXT NOT NULL DEFAULT '',
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (kind, subject_id)
+				);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS payments;
+			`,
+		},
+		{
+			Version: 21,
+			Name:    "payment_claims",
+			UpSQL: `
+				ALTER TABLE expenses ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP;
+				ALTER TABLE expense_reports ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP;
+				ALTER TABLE cash_advances ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP;
+
+				UPDATE expenses SET claimed_at = CURRENT_TIMESTAMP WHERE status = 'processing';
+				UPDATE expense_reports SET claimed_at = CURRENT_TIMESTAMP WHERE status = 'processing';
+				UPDATE cash_advances SET claimed_at = CURRENT_TIMESTAMP WHERE status IN ('disbursing', 'reimbursing');
+
+				CREATE INDEX IF NOT EXISTS idx_expenses_payable ON expenses (submitted_at, id)
+					WHERE status IN ('approved', 'auto_approved', 'processing') AND report_id IS NULL AND advance_id IS NULL;
+			`,
+			DownSQL: `
+				DROP INDEX IF EXISTS idx_expenses_payable;
+				ALTER TABLE cash_advances DROP COLUMN IF EXISTS claimed_at;
+				ALTER TABLE expense_reports DROP COLUMN IF EXISTS claimed_at;
+				ALTER TABLE expenses DROP COLUMN IF EXISTS claimed_at;
+			`,
+		},
+		{
+			Version: 22,
+			Name:    "payment_retries",
+			UpSQL: `
+				ALTER TABLE payments ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+				ALTER TABLE payments ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP;
+				ALTER TABLE payments ADD COLUMN IF NOT EXISTS last_error TEXT NOT NULL DEFAULT '';
+
+				ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_status_check;
+				ALTER TABLE expenses ADD CONSTRAINT expenses_status_check CHECK (status IN ('draft', 'pending', 'awaiting_approval', 'changes_requested', 'approved', 'rejected', 'auto_approved', 'processing', 'retry_scheduled', 'dead_letter', 'completed', 'failed', 'cancelled'));
+
+				DROP INDEX IF EXISTS idx_expenses_payable;
+				CREATE INDEX IF NOT EXISTS idx_expenses_payable ON expenses (submitted_at, id)
+					WHERE status IN ('approved', 'auto_approved', 'processing', 'retry_scheduled') AND report_id IS NULL AND advance_id IS NULL;
+			`,
+			DownSQL: `
+				DROP INDEX IF EXISTS idx_expenses_payable;
+				CREATE INDEX IF NOT EXISTS idx_expenses_payable ON expenses (submitted_at, id)
+					WHERE status IN ('approved', 'auto_approved', 'processing') AND report_id IS NULL AND advance_id IS NULL;
+
+				UPDATE expenses SET status = 'failed' WHERE status IN ('retry_scheduled', 'dead_letter');
+				ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_status_check;
+				ALTER TABLE expenses ADD CONSTRAINT expenses_status_check CHECK (status IN ('draft', 'pending', 'awaiting_approval', 'changes_requested', 'approved', 'rejected', 'auto_approved', 'processing', 'completed', 'failed', 'cancelled'));
+
+				ALTER TABLE payments DROP COLUMN IF EXISTS last_error;
+				ALTER TABLE payments DROP COLUMN IF EXISTS next_attempt_at;
+				ALTER TABLE payments DROP COLUMN IF EXISTS attempts;
+			`,
+		},
+		{
+			Version: 23,
+			Name:    "payable_work_notifications",
+			UpSQL: `
+				CREATE OR REPLACE FUNCTION notify_payable_work() RETURNS trigger AS $$
+				BEGIN
+					IF NEW.status = ANY (TG_ARGV) THEN
+						PERFORM pg_notify('payable_work', TG_TABLE_NAME);
+					END IF;
+					RETURN NULL;
+				END;
+				$$ LANGUAGE plpgsql;
+
+				DROP TRIGGER IF EXISTS expenses_payable_work ON expenses;
+				CREATE TRIGGER expenses_payable_work AFTER INSERT OR UPDATE OF status ON expenses
+					FOR EACH ROW EXECUTE FUNCTION notify_payable_work('approved', 'auto_approved');
+
+				DROP TRIGGER IF EXISTS expense_reports_payable_work ON expense_reports;
+				CREATE TRIGGER expense_reports_payable_work AFTER INSERT OR UPDATE OF status ON expense_reports
+					FOR EACH ROW EXECUTE FUNCTION notify_payable_work('approved', 'auto_approved');
+
+				DROP TRIGGER IF EXISTS cash_advances_payable_work ON cash_advances;
+				CREATE TRIGGER cash_advances_payable_work AFTER INSERT OR UPDATE OF status ON cash_advances
+					FOR EACH ROW EXECUTE FUNCTION notify_payable_work('approved', 'reimbursement_due');
+			`,
+			DownSQL: `
+				DROP TRIGGER IF EXISTS cash_advances_payable_work ON cash_advances;
+				DROP TRIGGER IF EXISTS expense_reports_payable_work ON expense_reports;
+				DROP TRIGGER IF EXISTS expenses_payable_work ON expenses;
+				DROP FUNCTION IF EXISTS notify_payable_work();
+			`,
+		},
+		{
+			Version: 24,
+			Name:    "approval_level_history",
+			UpSQL: `
+				ALTER TABLE expense_status_history DROP CONSTRAINT IF EXISTS expense_status_history_event_check;
+				ALTER TABLE expense_status_history ADD CONSTRAINT expense_status_history_event_check CHECK (event IN ('status_change', 'level_approved', 'sla_reminder', 'sla_escalation'));
+			`,
+			DownSQL: `
+				DELETE FROM expense_status_history WHERE event = 'level_approved';
+				ALTER TABLE expense_status_history DROP CONSTRAINT IF EXISTS expense_status_history_event_check;
+				ALTER TABLE expense_status_history ADD CONSTRAINT expense_status_history_event_check CHECK (event IN ('status_change', 'sla_reminder', 'sla_escalation'));
+			`,
+		},
+		{
+			Version: 25,
+			Name:    "report_advance_payment_retries",
+			UpSQL: `
+				ALTER TABLE expense_reports DROP CONSTRAINT IF EXISTS expense_reports_status_check;
+				ALTER TABLE expense_reports ADD CONSTRAINT expense_reports_status_check CHECK (status IN ('draft', 'awaiting_approval', 'approved', 'rejected', 'auto_approved', 'processing', 'retry_scheduled', 'dead_letter', 'completed', 'failed', 'cancelled'));
+
+				ALTER TABLE cash_advances DROP CONSTRAINT IF EXISTS cash_advances_status_check;
+				ALTER TABLE cash_advances ADD CONSTRAINT cash_advances_status_check CHECK (status IN ('requested', 'approved', 'rejected', 'cancelled', 'disbursing', 'outstanding', 'reimbursement_due', 'reimbursing', 'repayment_due', 'settled', 'retry_scheduled', 'dead_letter', 'failed'));
+			`,
+			DownSQL: `
+				UPDATE cash_advances SET status = 'failed' WHERE status IN ('retry_scheduled', 'dead_letter');
+				ALTER TABLE cash_advances DROP CONSTRAINT IF EXISTS cash_advances_status_check;
+				ALTER TABLE cash_advances ADD CONSTRAINT cash_advances_status_check CHECK (status IN ('requested', 'approved', 'rejected', 'cancelled', 'disbursing', 'outstanding', 'reimbursement_due', 'reimbursing', 'repayment_due', 'settled', 'failed'));
+
+				UPDATE expenses SET status = 'failed' WHERE status IN ('retry_scheduled', 'dead_letter') AND report_id IS NOT NULL;
+				UPDATE expense_reports SET status = 'failed' WHERE status IN ('retry_scheduled', 'dead_letter');
+				ALTER TABLE expense_reports DROP CONSTRAINT IF EXISTS expense_reports_status_check;
+				ALTER TABLE expense_reports ADD CONSTRAINT expense_reports_status_check CHECK (status IN ('draft', 'awaiting_approval', 'approved', 'rejected', 'auto_approved', 'processing', 'completed', 'failed', 'cancelled'));
+			`,
+		},
+		{
+			Version: 26,
+			Name:    "report_finance_approval",
+			UpSQL: `
+				ALTER TABLE expense_reports ADD COLUMN IF NOT EXISTS requires_finance_approval BOOLEAN NOT NULL DEFAULT FALSE;
+			`,
+			DownSQL: `
+				ALTER TABLE expense_reports DROP COLUMN IF EXISTS requires_finance_approval;
+			`,
+		},
 	}
 
 	// Sort migrations by version
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txKey struct{}
+
+// Querier is satisfied by both *sql.DB and *sql.Tx.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Transactor runs a unit of work across repositories in one transaction.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return WithinTx(ctx, t.db, fn)
+}
+
+// WithinTx runs fn in a transaction carried by its context and commits it when fn
+// succeeds. A context that already carries a transaction joins it.
+func WithinTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Conn returns the transaction carried by ctx, or db outside of one.
+func Conn(ctx context.Context, db *sql.DB) Querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithinTx(t *testing.T) {
+	t.Run("commits when the work succeeds", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec("UPDATE expenses").WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec("INSERT INTO approvals").WillReturnResult(sqlmock.NewResult(1, 1))
+		sqlMock.ExpectCommit()
+
+		err = NewTransactor(db).WithinTx(context.Background(), func(ctx context.Context) error {
+			if _, err := Conn(ctx, db).ExecContext(ctx, "UPDATE expenses SET status = 'approved'"); err != nil {
+				return err
+			}
+			// A nested unit of work joins the outer transaction.
+			return WithinTx(ctx, db, func(ctx context.Context) error {
+				_, err := Conn(ctx, db).ExecContext(ctx, "INSERT INTO approvals DEFAULT VALUES")
+				return err
+			})
+		})
+		require.NoError(t, err)
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back when the work fails", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		failed := errors.New("insert failed")
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		err = NewTransactor(db).WithinTx(context.Background(), func(ctx context.Context) error {
+			return failed
+		})
+		require.ErrorIs(t, err, failed)
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
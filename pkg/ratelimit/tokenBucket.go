@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenBucket limits how often an action runs. The bucket holds up to burst tokens and
+// refills at rate tokens per second; every call to Wait takes one, waiting for it to be
+// refilled when the bucket is empty. Callers are served in the order they arrive.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucket returns a full bucket allowing rate calls per second on average and up
+// to burst calls at once.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a token is available or ctx is done, in which case the token is put
+// back and ctx's error returned.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait before using it. The
+// bucket goes into debt when it is empty, so later callers queue behind earlier ones.
+func (b *TokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
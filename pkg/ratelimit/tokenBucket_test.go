@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	b := NewTokenBucket(2, 2)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	require.Zero(t, b.reserve())
+	require.Zero(t, b.reserve())
+	require.Equal(t, 500*time.Millisecond, b.reserve())
+	require.Equal(t, time.Second, b.reserve())
+
+	// A second and a half later the debt is paid off and one token has been refilled.
+	now = now.Add(1500 * time.Millisecond)
+	require.Zero(t, b.reserve())
+	require.Equal(t, 500*time.Millisecond, b.reserve())
+
+	// An idle bucket refills up to its burst and no further.
+	now = now.Add(time.Minute)
+	require.Zero(t, b.reserve())
+	require.Zero(t, b.reserve())
+	require.Equal(t, 500*time.Millisecond, b.reserve())
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	b := NewTokenBucket(1, 1)
+	require.NoError(t, b.Wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, b.Wait(ctx), context.Canceled)
+
+	// The cancelled caller's token went back to the bucket.
+	require.InDelta(t, 0, b.tokens, 0.1)
+}